@@ -0,0 +1,33 @@
+package main
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/RianNegreiros/go-graphql-api/internal/user"
+)
+
+// runAccountDeletionJob anonymizes the accounts past their deletion grace
+// period every interval, until ctx is done.
+func runAccountDeletionJob(ctx context.Context, accountService user.AccountService, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		n, err := accountService.DeleteScheduledAccounts(ctx)
+		if err != nil {
+			log.Printf("error deleting scheduled accounts: %v", err)
+		}
+
+		if n > 0 {
+			log.Printf("deleted %d scheduled accounts", n)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -4,15 +4,26 @@ import (
 	"context"
 	"log"
 	"net/http"
+	"os"
+	"strings"
 	"time"
 
 	"github.com/99designs/gqlgen/graphql/handler"
+	"github.com/99designs/gqlgen/graphql/handler/extension"
+	"github.com/99designs/gqlgen/graphql/handler/lru"
+	"github.com/99designs/gqlgen/graphql/handler/transport"
 	"github.com/99designs/gqlgen/graphql/playground"
 	"github.com/RianNegreiros/go-graphql-api/config"
 	"github.com/RianNegreiros/go-graphql-api/graph"
 	"github.com/RianNegreiros/go-graphql-api/internal/domain"
 	"github.com/RianNegreiros/go-graphql-api/internal/jwt"
+	"github.com/RianNegreiros/go-graphql-api/internal/loginlimit"
+	"github.com/RianNegreiros/go-graphql-api/internal/mailer"
+	"github.com/RianNegreiros/go-graphql-api/internal/password"
+	"github.com/RianNegreiros/go-graphql-api/internal/post"
 	"github.com/RianNegreiros/go-graphql-api/internal/postgres"
+	"github.com/RianNegreiros/go-graphql-api/internal/pubsub"
+	"github.com/RianNegreiros/go-graphql-api/internal/user"
 	"github.com/go-chi/chi"
 	"github.com/go-chi/chi/middleware"
 )
@@ -24,47 +35,182 @@ func main() {
 
 	conf := config.New()
 
+	if err := conf.Password.Validate(); err != nil {
+		log.Fatal(err)
+	}
+
 	db := postgres.New(ctx, conf)
 
 	if err := db.Migrate(); err != nil {
 		log.Fatal(err)
 	}
 
+	post.EditWindow = conf.Post.EditWindow
+	post.RequireVerifiedEmail = conf.Post.RequireVerifiedEmail
+	user.PasswordResetURL = conf.App.URL + "/reset-password"
+	user.EmailVerificationURL = conf.App.URL + "/verify-email"
+	user.EmailChangeURL = conf.App.URL + "/confirm-email-change"
+	user.LoginLinkURL = conf.App.URL + "/login-link"
+	user.SocialLoginURL = conf.App.URL + "/social-login"
+	user.OAuthConsentURL = conf.App.URL + "/oauth/authorize"
+	user.TotpIssuer = conf.JWT.Issuer
+	user.AccountDeletionGracePeriod = conf.Account.DeletionGracePeriod
+	loginlimit.AccountPolicy.LockoutAttempts = conf.Login.MaxAttempts
+	loginlimit.AccountPolicy.LockoutDuration = conf.Login.LockoutDuration
+	password.DefaultParams.Memory = uint32(conf.Password.Memory)
+	password.DefaultParams.Iterations = uint32(conf.Password.Iterations)
+	password.DefaultParams.Parallelism = uint8(conf.Password.Parallelism)
+	user.PasskeyRelyingParty.ID = conf.WebAuthn.RPID
+	user.PasskeyRelyingParty.Name = conf.WebAuthn.RPName
+	user.PasskeyRelyingParty.Origins = []string{conf.App.URL}
+
+	if len(conf.WebAuthn.Origins) > 0 {
+		user.PasskeyRelyingParty.Origins = conf.WebAuthn.Origins
+	}
+
 	router := chi.NewRouter()
 
 	router.Use(middleware.Logger)
 	router.Use(middleware.RequestID)
 	router.Use(middleware.Recoverer)
 	router.Use(middleware.RedirectSlashes)
-	router.Use(middleware.Timeout(time.Second * 60))
+	router.Use(timeoutMiddleware(time.Second * 60))
+
+	if conf.App.TrustProxy {
+		router.Use(middleware.RealIP)
+	}
 
 	userRepo := postgres.NewUserRepo(db)
 	postRepo := postgres.NewPostRepo(db)
+	refreshTokenRepo := postgres.NewRefreshTokenRepo(db)
+	passwordResetRepo := postgres.NewPasswordResetRepo(db)
 
-	authTokenService := jwt.NewTokenService(conf)
-	authService := domain.NewAuthService(userRepo, authTokenService)
-	postService := domain.NewPostService(postRepo)
+	authTokenService, err := jwt.NewTokenService(conf)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	passwordHasher := password.New(password.DefaultParams)
+	mail := newMailer(conf)
+	accountService := domain.NewAccountService(userRepo, refreshTokenRepo, passwordResetRepo, authTokenService, mail, passwordHasher)
+	loginGuard := loginlimit.New(newLoginAttemptStore(conf, db))
+	twoFactorService := domain.NewTwoFactorService(userRepo, postgres.NewTwoFactorRepo(db), loginGuard)
+	passkeyService := domain.NewPasskeyService(userRepo, postgres.NewPasskeyRepo(db))
+	socialLoginService := domain.NewSocialLoginService(userRepo, postgres.NewIdentityRepo(db), passwordHasher, newIdentityProviders(ctx, conf))
+	authService := domain.NewAuthService(userRepo, authTokenService, refreshTokenRepo, accountService, loginGuard, twoFactorService, passwordHasher, passkeyService, postgres.NewLoginLinkRepo(db), mail, socialLoginService)
+	postService := domain.NewPostService(postRepo, userRepo, newPubSub(ctx, conf, db))
 	userService := domain.NewUserService(userRepo)
+	personalAccessTokenService := domain.NewPersonalAccessTokenService(postgres.NewPersonalAccessTokenRepo(db))
+	oauthService := domain.NewOAuthService(postgres.NewOAuthRepo(db), authTokenService)
+	dataExportService := domain.NewDataExportService(userRepo, postRepo, refreshTokenRepo)
 
+	go runAccountDeletionJob(ctx, accountService, conf.Account.DeletionJobInterval)
+
+	router.Use(userAgentMiddleware)
+	router.Use(clientIPMiddleware)
+	router.Use(authMiddleware(authTokenService, refreshTokenRepo, personalAccessTokenService, oauthService))
 	router.Use(graph.DataloaderMiddleware(
 		&graph.Repos{
 			UserRepo: userRepo,
+			PostRepo: postRepo,
 		},
 	))
-
-	router.Use(authMiddleware(authTokenService))
 	router.Handle("/", playground.Handler("Graphql playground", "/query"))
-	router.Handle("/query", handler.NewDefaultServer(
+	router.Method(http.MethodGet, jwt.JWKSPath, authTokenService.JWKSHandler())
+	secureCookies := strings.HasPrefix(conf.OIDC.CallbackURL, "https://")
+
+	router.Get("/auth/{provider}", socialLoginHandler(socialLoginService, secureCookies))
+	router.Get("/auth/{provider}/callback", socialLoginCallbackHandler(authService, secureCookies))
+	router.Get("/oauth/authorize", oauthAuthorizeHandler())
+	router.Post("/oauth/token", oauthTokenHandler(oauthService))
+	router.Post("/oauth/revoke", oauthRevokeHandler(oauthService))
+	router.Post("/oauth/introspect", oauthIntrospectHandler(oauthService))
+
+	srv := handler.New(
 		graph.NewExecutableSchema(
 			graph.Config{
 				Resolvers: &graph.Resolver{
-					AuthService: authService,
-					PostService: postService,
-					UserService: userService,
+					AuthService:                authService,
+					AccountService:             accountService,
+					TwoFactorService:           twoFactorService,
+					PersonalAccessTokenService: personalAccessTokenService,
+					PasskeyService:             passkeyService,
+					OAuthService:               oauthService,
+					DataExportService:          dataExportService,
+					PostService:                postService,
+					UserService:                userService,
 				},
+				Directives: graph.NewDirectives(),
 			},
 		),
-	))
+	)
+
+	srv.AddTransport(transport.Websocket{
+		KeepAlivePingInterval: 10 * time.Second,
+		InitFunc:              websocketInitFunc(authTokenService, refreshTokenRepo),
+	})
+	srv.AddTransport(transport.Options{})
+	srv.AddTransport(transport.GET{})
+	srv.AddTransport(transport.POST{})
+	srv.AddTransport(transport.MultipartForm{})
+
+	srv.SetQueryCache(lru.New(1000))
+
+	srv.AroundOperations(graph.SubscriptionDataloaders)
+
+	srv.Use(extension.Introspection{})
+	srv.Use(extension.AutomaticPersistedQuery{
+		Cache: lru.New(100),
+	})
+
+	router.Handle("/query", srv)
 
 	log.Fatal(http.ListenAndServe(":8080", router))
 }
+
+func newPubSub(ctx context.Context, conf *config.Config, db *postgres.DB) pubsub.PubSub {
+	switch conf.PubSub.Backend {
+	case "postgres":
+		ps := postgres.NewPubSub(db)
+
+		go ps.Listen(ctx)
+
+		return ps
+	case "memory":
+		return pubsub.NewMemory()
+	default:
+		log.Fatalf("unknown pubsub backend: %s", conf.PubSub.Backend)
+		return nil
+	}
+}
+
+func newLoginAttemptStore(conf *config.Config, db *postgres.DB) loginlimit.Store {
+	switch conf.Login.Store {
+	case "postgres":
+		return postgres.NewLoginAttemptStore(db)
+	case "memory":
+		return loginlimit.NewMemory()
+	default:
+		log.Fatalf("unknown login limit store: %s", conf.Login.Store)
+		return nil
+	}
+}
+
+func newMailer(conf *config.Config) mailer.Mailer {
+	switch conf.Mail.Driver {
+	case "smtp":
+		return mailer.NewSMTP(conf.Mail.SMTPHost, conf.Mail.SMTPPort, conf.Mail.SMTPUsername, conf.Mail.SMTPPassword, conf.Mail.From)
+	case "log":
+		return mailer.NewLog(os.Stdout, conf.Mail.From)
+	case "file":
+		f, err := os.OpenFile(conf.Mail.File, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		return mailer.NewLog(f, conf.Mail.From)
+	default:
+		log.Fatalf("unknown mail driver: %s", conf.Mail.Driver)
+		return nil
+	}
+}
@@ -1,25 +1,169 @@
 package main
 
 import (
-	"github.com/RianNegreiros/go-graphql-api/internal/transport"
-	"github.com/RianNegreiros/go-graphql-api/internal/user"
+	"context"
+	"errors"
+	"net"
 	"net/http"
+	"strings"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql/handler/transport"
+	"github.com/go-chi/chi/middleware"
+
+	"github.com/RianNegreiros/go-graphql-api/internal/jwt"
+	ctxtransport "github.com/RianNegreiros/go-graphql-api/internal/transport"
+	"github.com/RianNegreiros/go-graphql-api/internal/user"
+	"github.com/RianNegreiros/go-graphql-api/internal/uuid"
 )
 
-func authMiddleware(authTokenService user.AuthTokenService) func(handler http.Handler) http.Handler {
+// authMiddleware authenticates requests with a JWT access token, a personal
+// access token or an access token issued to an OAuth client in the
+// Authorization header.
+func authMiddleware(authTokenService user.AuthTokenService, refreshTokenRepo jwt.RefreshTokenRepo, personalAccessTokens user.PersonalAccessTokenService, oauth user.OAuthService) func(handler http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			ctx := r.Context()
 
+			if bearer := bearerToken(r); strings.HasPrefix(bearer, user.PersonalAccessTokenPrefix) {
+				pat, err := personalAccessTokens.Authenticate(ctx, bearer)
+				if err != nil {
+					next.ServeHTTP(w, r)
+					return
+				}
+
+				next.ServeHTTP(w, r.WithContext(putPersonalAccessTokenIntoContext(ctx, pat)))
+				return
+			}
+
 			token, err := authTokenService.ParseTokenFromRequest(ctx, r)
 			if err != nil {
+				if grant, err := oauth.Authenticate(ctx, bearerToken(r)); err == nil {
+					r = r.WithContext(putOAuthGrantIntoContext(ctx, grant))
+				}
+
 				next.ServeHTTP(w, r)
 				return
 			}
 
-			ctx = transport.PutUserIDIntoContext(ctx, token.Sub)
+			if !isSessionActive(ctx, refreshTokenRepo, token) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(putAuthTokenIntoContext(ctx, token)))
+		})
+	}
+}
+
+// timeoutMiddleware cancels requests that run longer than timeout. Websocket
+// upgrades are left alone, as subscriptions run on the context of the upgrade
+// request for as long as the connection stays open.
+func timeoutMiddleware(timeout time.Duration) func(handler http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		withTimeout := middleware.Timeout(timeout)(next)
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if strings.EqualFold(r.Header.Get("Upgrade"), "websocket") {
+				next.ServeHTTP(w, r)
+				return
+			}
 
-			next.ServeHTTP(w, r.WithContext(ctx))
+			withTimeout.ServeHTTP(w, r)
 		})
 	}
 }
+
+var errInvalidConnectionToken = errors.New("invalid authorization token")
+
+// websocketInitFunc authenticates subscriptions from the connection-init
+// payload, since browsers can't set headers on websocket upgrades. A missing
+// token leaves the connection anonymous; an invalid one refuses it.
+func websocketInitFunc(authTokenService user.AuthTokenService, refreshTokenRepo jwt.RefreshTokenRepo) transport.WebsocketInitFunc {
+	return func(ctx context.Context, initPayload transport.InitPayload) (context.Context, error) {
+		header := initPayload.Authorization()
+		if header == "" {
+			return ctx, nil
+		}
+
+		token, err := authTokenService.ParseToken(ctx, strings.TrimPrefix(header, "Bearer "))
+		if err != nil {
+			return nil, errInvalidConnectionToken
+		}
+
+		if !isSessionActive(ctx, refreshTokenRepo, token) {
+			return nil, errInvalidConnectionToken
+		}
+
+		return putAuthTokenIntoContext(ctx, token), nil
+	}
+}
+
+// isSessionActive reports whether the session the token was issued for is
+// still active. Access tokens outlive a logout by up to their lifetime.
+func isSessionActive(ctx context.Context, refreshTokenRepo jwt.RefreshTokenRepo, token user.AuthToken) bool {
+	if !uuid.Validate(token.SessionID) {
+		return false
+	}
+
+	session, err := refreshTokenRepo.GetActiveByFamilyID(ctx, token.SessionID)
+
+	return err == nil && session.UserID == token.Sub
+}
+
+func putAuthTokenIntoContext(ctx context.Context, token user.AuthToken) context.Context {
+	ctx = ctxtransport.PutUserIDIntoContext(ctx, token.Sub)
+	ctx = ctxtransport.PutSessionIDIntoContext(ctx, token.SessionID)
+	ctx = ctxtransport.PutRoleIntoContext(ctx, token.Role)
+
+	return ctx
+}
+
+// putPersonalAccessTokenIntoContext authenticates as the token's user, limited
+// to its scopes. There is no session, so session-only operations are refused.
+func putPersonalAccessTokenIntoContext(ctx context.Context, pat user.PersonalAccessToken) context.Context {
+	ctx = ctxtransport.PutUserIDIntoContext(ctx, pat.UserID)
+	ctx = ctxtransport.PutScopesIntoContext(ctx, pat.Scopes)
+
+	return ctx
+}
+
+// putOAuthGrantIntoContext authenticates as the user who authorized the
+// client, limited to the scopes they consented to.
+func putOAuthGrantIntoContext(ctx context.Context, grant user.OAuthGrant) context.Context {
+	ctx = ctxtransport.PutUserIDIntoContext(ctx, grant.UserID)
+	ctx = ctxtransport.PutScopesIntoContext(ctx, grant.Scopes)
+
+	return ctx
+}
+
+func bearerToken(r *http.Request) string {
+	header := r.Header.Get("Authorization")
+
+	if len(header) < len("Bearer ") || !strings.EqualFold(header[:len("Bearer ")], "Bearer ") {
+		return ""
+	}
+
+	return strings.TrimSpace(header[len("Bearer "):])
+}
+
+func userAgentMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := ctxtransport.PutUserAgentIntoContext(r.Context(), r.UserAgent())
+
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+func clientIPMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ip, _, err := net.SplitHostPort(r.RemoteAddr)
+		if err != nil {
+			ip = r.RemoteAddr
+		}
+
+		ctx := ctxtransport.PutClientIPIntoContext(r.Context(), ip)
+
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
@@ -0,0 +1,165 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"net/url"
+
+	"github.com/RianNegreiros/go-graphql-api/internal/user"
+)
+
+// oauthAuthorizeHandler sends the user to the consent page of the client app,
+// which checks the request with the oauthAuthorization query and answers it
+// with the authorizeOAuthClient mutation.
+func oauthAuthorizeHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, user.OAuthConsentURL+"?"+r.URL.RawQuery, http.StatusFound)
+	}
+}
+
+// oauthTokenHandler is the token endpoint of RFC 6749, exchanging
+// authorization codes and refresh tokens for access tokens.
+func oauthTokenHandler(oauth user.OAuthService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		client, ok := oauthClientCredentials(w, r)
+		if !ok {
+			return
+		}
+
+		res, err := oauth.Token(r.Context(), user.OAuthTokenInput{
+			OAuthClientCredentials: client,
+			GrantType:              r.PostForm.Get("grant_type"),
+			Code:                   r.PostForm.Get("code"),
+			RedirectURI:            r.PostForm.Get("redirect_uri"),
+			CodeVerifier:           r.PostForm.Get("code_verifier"),
+			RefreshToken:           r.PostForm.Get("refresh_token"),
+		})
+		if err != nil {
+			oauthError(w, err)
+			return
+		}
+
+		writeOAuthJSON(w, http.StatusOK, map[string]interface{}{
+			"access_token":  res.AccessToken,
+			"token_type":    "Bearer",
+			"expires_in":    int(res.ExpiresIn.Seconds()),
+			"refresh_token": res.RefreshToken,
+			"scope":         user.FormatScopes(res.Scopes),
+		})
+	}
+}
+
+// oauthRevokeHandler is the revocation endpoint of RFC 7009.
+func oauthRevokeHandler(oauth user.OAuthService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		client, ok := oauthClientCredentials(w, r)
+		if !ok {
+			return
+		}
+
+		if err := oauth.Revoke(r.Context(), client, r.PostForm.Get("token")); err != nil {
+			oauthError(w, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+// oauthIntrospectHandler is the introspection endpoint of RFC 7662.
+func oauthIntrospectHandler(oauth user.OAuthService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		client, ok := oauthClientCredentials(w, r)
+		if !ok {
+			return
+		}
+
+		in, err := oauth.Introspect(r.Context(), client, r.PostForm.Get("token"))
+		if err != nil {
+			oauthError(w, err)
+			return
+		}
+
+		if !in.Active {
+			writeOAuthJSON(w, http.StatusOK, map[string]interface{}{"active": false})
+			return
+		}
+
+		writeOAuthJSON(w, http.StatusOK, map[string]interface{}{
+			"active":     true,
+			"token_type": in.TokenType,
+			"client_id":  in.ClientID,
+			"sub":        in.UserID,
+			"scope":      user.FormatScopes(in.Scopes),
+			"exp":        in.ExpiredAt.Unix(),
+		})
+	}
+}
+
+// oauthClientCredentials reads the client credentials from HTTP basic auth,
+// or else from the form as RFC 6749 also allows.
+func oauthClientCredentials(w http.ResponseWriter, r *http.Request) (user.OAuthClientCredentials, bool) {
+	if err := r.ParseForm(); err != nil {
+		writeOAuthError(w, http.StatusBadRequest, "invalid_request", "invalid form")
+		return user.OAuthClientCredentials{}, false
+	}
+
+	id, secret, ok := r.BasicAuth()
+	if !ok {
+		return user.OAuthClientCredentials{
+			ClientID:     r.PostForm.Get("client_id"),
+			ClientSecret: r.PostForm.Get("client_secret"),
+		}, true
+	}
+
+	// RFC 6749 has clients form encode their credentials before basic auth.
+	id, idErr := url.QueryUnescape(id)
+	secret, secretErr := url.QueryUnescape(secret)
+
+	if idErr != nil || secretErr != nil {
+		writeOAuthError(w, http.StatusUnauthorized, "invalid_client", "invalid client credentials")
+		return user.OAuthClientCredentials{}, false
+	}
+
+	return user.OAuthClientCredentials{ClientID: id, ClientSecret: secret}, true
+}
+
+// oauthError answers with the error codes of RFC 6749, only letting errors
+// meant for clients through.
+func oauthError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, user.ErrInvalidOAuthClient):
+		w.Header().Set("WWW-Authenticate", `Basic realm="oauth"`)
+		writeOAuthError(w, http.StatusUnauthorized, "invalid_client", err.Error())
+	case errors.Is(err, user.ErrInvalidOAuthGrant):
+		writeOAuthError(w, http.StatusBadRequest, "invalid_grant", err.Error())
+	case errors.Is(err, user.ErrUnsupportedGrantType):
+		writeOAuthError(w, http.StatusBadRequest, "unsupported_grant_type", err.Error())
+	case errors.Is(err, user.ErrInvalidOAuthScope):
+		writeOAuthError(w, http.StatusBadRequest, "invalid_scope", err.Error())
+	case errors.Is(err, user.ErrValidation):
+		writeOAuthError(w, http.StatusBadRequest, "invalid_request", err.Error())
+	default:
+		log.Printf("error handling oauth request: %v", err)
+		writeOAuthError(w, http.StatusInternalServerError, "server_error", http.StatusText(http.StatusInternalServerError))
+	}
+}
+
+func writeOAuthError(w http.ResponseWriter, status int, code string, description string) {
+	writeOAuthJSON(w, status, map[string]interface{}{
+		"error":             code,
+		"error_description": description,
+	})
+}
+
+func writeOAuthJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Printf("error writing oauth response: %v", err)
+	}
+}
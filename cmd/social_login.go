@@ -0,0 +1,157 @@
+package main
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi"
+
+	"github.com/RianNegreiros/go-graphql-api/config"
+	"github.com/RianNegreiros/go-graphql-api/internal/oidc"
+	"github.com/RianNegreiros/go-graphql-api/internal/randtoken"
+	"github.com/RianNegreiros/go-graphql-api/internal/user"
+)
+
+// newIdentityProviders discovers the configured OpenID Connect providers. A
+// provider which can't be reached at startup is a configuration error.
+func newIdentityProviders(ctx context.Context, conf *config.Config) map[string]user.IdentityProvider {
+	providers := map[string]user.IdentityProvider{}
+
+	for _, p := range conf.OIDC.Providers {
+		provider, err := oidc.Discover(ctx, oidc.Config{
+			Issuer:       p.Issuer,
+			ClientID:     p.ClientID,
+			ClientSecret: p.ClientSecret,
+			RedirectURL:  strings.TrimSuffix(conf.OIDC.CallbackURL, "/") + "/auth/" + p.Name + "/callback",
+			Scopes:       p.Scopes,
+		}, nil)
+		if err != nil {
+			log.Fatalf("error setting up identity provider %s: %v", p.Name, err)
+		}
+
+		providers[p.Name] = provider
+	}
+
+	return providers
+}
+
+// socialLoginStateCookie ties a social login to the browser which started it.
+// Without it, anyone could start a login with their own provider account and
+// have a victim follow the callback URL, logging the victim in as them.
+const socialLoginStateCookie = "social_login_state"
+
+// socialLoginHandler sends the user to the provider in the URL to log in.
+func socialLoginHandler(socialLogin user.SocialLoginService, secureCookies bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		provider := chi.URLParam(r, "provider")
+
+		authURL, err := socialLogin.AuthURL(r.Context(), provider)
+		if err != nil {
+			switch {
+			case errors.Is(err, user.ErrUnknownProvider):
+				http.NotFound(w, r)
+			default:
+				log.Printf("error starting social login: %v", err)
+				http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			}
+
+			return
+		}
+
+		u, err := url.Parse(authURL)
+		if err != nil {
+			log.Printf("error starting social login: %v", err)
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+
+		http.SetCookie(w, socialLoginCookie(provider, randtoken.Hash(u.Query().Get("state")), user.SocialLoginStateLifeTime, secureCookies))
+		http.Redirect(w, r, authURL, http.StatusFound)
+	}
+}
+
+// socialLoginCallbackHandler is where providers send users back to. It logs
+// them in and hands the outcome to the client app in the fragment of
+// user.SocialLoginURL: either the tokens, a two-factor challenge or an error.
+// Only the browser holding the cookie set when the login started gets in.
+func socialLoginCallbackHandler(authService user.AuthService, secureCookies bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		provider := chi.URLParam(r, "provider")
+
+		cookie, err := r.Cookie(socialLoginStateCookie)
+		http.SetCookie(w, socialLoginCookie(provider, "", -time.Second, secureCookies))
+
+		if e := q.Get("error"); e != "" {
+			redirectSocialLogin(w, r, url.Values{"error": {e}})
+			return
+		}
+
+		if err != nil || subtle.ConstantTimeCompare([]byte(cookie.Value), []byte(randtoken.Hash(q.Get("state")))) != 1 {
+			redirectSocialLogin(w, r, url.Values{"error": {socialLoginError(user.ErrInvalidSocialLogin)}})
+			return
+		}
+
+		res, err := authService.LoginWithProvider(r.Context(), user.SocialLoginInput{
+			Provider: provider,
+			Code:     q.Get("code"),
+			State:    q.Get("state"),
+		})
+		if err != nil {
+			redirectSocialLogin(w, r, url.Values{"error": {socialLoginError(err)}})
+			return
+		}
+
+		if res.Challenge != nil {
+			redirectSocialLogin(w, r, url.Values{
+				"two_factor_token": {res.Challenge.Token},
+				"expired_at":       {res.Challenge.ExpiredAt.UTC().Format(time.RFC3339)},
+			})
+			return
+		}
+
+		redirectSocialLogin(w, r, url.Values{
+			"access_token":  {res.AccessToken},
+			"refresh_token": {res.RefreshToken},
+		})
+	}
+}
+
+// socialLoginCookie holds the hash of the state, scoped to the routes of the
+// provider. A negative maxAge deletes it. Lax still sends it along with the
+// redirect back from the provider.
+func socialLoginCookie(provider, value string, maxAge time.Duration, secure bool) *http.Cookie {
+	return &http.Cookie{
+		Name:     socialLoginStateCookie,
+		Value:    value,
+		Path:     "/auth/" + provider,
+		MaxAge:   int(maxAge.Seconds()),
+		Secure:   secure,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	}
+}
+
+// socialLoginError only lets errors meant for users through.
+func socialLoginError(err error) string {
+	if errors.Is(err, user.ErrValidation) || errors.Is(err, user.ErrForbidden) {
+		return err.Error()
+	}
+
+	log.Printf("error logging in with provider: %v", err)
+
+	return http.StatusText(http.StatusInternalServerError)
+}
+
+func redirectSocialLogin(w http.ResponseWriter, r *http.Request, fragment url.Values) {
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Referrer-Policy", "no-referrer")
+
+	http.Redirect(w, r, user.SocialLoginURL+"#"+fragment.Encode(), http.StatusFound)
+}
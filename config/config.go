@@ -1,8 +1,13 @@
 package config
 
 import (
+	"fmt"
+	"math"
 	"os"
 	"regexp"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -14,6 +19,109 @@ type database struct {
 type jwt struct {
 	Secret string
 	Issuer string
+	// PrivateKeyFiles are PEM files of RSA, P-256 or Ed25519 keys. The first
+	// one signs new tokens and the others are only kept to verify tokens
+	// issued before a rotation. Without any, tokens are signed with Secret.
+	PrivateKeyFiles []string
+}
+
+type post struct {
+	EditWindow           time.Duration
+	RequireVerifiedEmail bool
+}
+
+type pubSub struct {
+	// Backend is either "memory" or "postgres". Postgres shares events
+	// between every API instance connected to the same database.
+	Backend string
+}
+
+type app struct {
+	// URL is the public address of the client app, used to build links in
+	// emails.
+	URL string
+	// TrustProxy takes the client IP from the X-Forwarded-For and X-Real-IP
+	// headers. Only enable it behind a proxy which sets them.
+	TrustProxy bool
+}
+
+type login struct {
+	// Store is either "memory" or "postgres". Postgres shares failed login
+	// attempts between every API instance connected to the same database.
+	Store           string
+	MaxAttempts     int
+	LockoutDuration time.Duration
+}
+
+type account struct {
+	DeletionGracePeriod time.Duration
+	// DeletionJobInterval is how often accounts past their grace period are
+	// looked for and anonymized.
+	DeletionJobInterval time.Duration
+}
+
+// password holds the argon2id parameters for new hashes. Raising them
+// upgrades existing hashes as their users log in. They are read as ints so
+// that Validate sees values which don't fit argon2id's types.
+type password struct {
+	// Memory is in KiB.
+	Memory      int
+	Iterations  int
+	Parallelism int
+}
+
+// Validate checks the parameters fit argon2id, which panics on zero
+// iterations or parallelism.
+func (p password) Validate() error {
+	switch {
+	case p.Memory < 8 || int64(p.Memory) > math.MaxUint32:
+		return fmt.Errorf("PASSWORD_ARGON2_MEMORY must be between 8 and %d, got %d", uint32(math.MaxUint32), p.Memory)
+	case p.Iterations < 1 || int64(p.Iterations) > math.MaxUint32:
+		return fmt.Errorf("PASSWORD_ARGON2_ITERATIONS must be between 1 and %d, got %d", uint32(math.MaxUint32), p.Iterations)
+	case p.Parallelism < 1 || p.Parallelism > math.MaxUint8:
+		return fmt.Errorf("PASSWORD_ARGON2_PARALLELISM must be between 1 and %d, got %d", math.MaxUint8, p.Parallelism)
+	}
+
+	return nil
+}
+
+// webAuthn identifies the API to passkey authenticators. RPID must be the
+// domain of the client app, or a parent of it.
+type webAuthn struct {
+	RPID   string
+	RPName string
+	// Origins are the addresses of the pages allowed to use passkeys.
+	// Without any, only the client app is.
+	Origins []string
+}
+
+// oidcProvider is an OpenID Connect provider users can log in with.
+type oidcProvider struct {
+	// Name identifies the provider in the login routes, e.g. "google".
+	Name         string
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	Scopes       []string
+}
+
+type oidc struct {
+	// CallbackURL is the public address of this API. Providers send users
+	// back to CallbackURL + "/auth/{name}/callback".
+	CallbackURL string
+	Providers   []oidcProvider
+}
+
+type mail struct {
+	// Driver is "smtp", "log" to print messages to stdout or "file" to
+	// append them to File.
+	Driver       string
+	From         string
+	File         string
+	SMTPHost     string
+	SMTPPort     string
+	SMTPUsername string
+	SMTPPassword string
 }
 
 type env struct {
@@ -23,6 +131,15 @@ type env struct {
 type Config struct {
 	Database database
 	JWT      jwt
+	Post     post
+	PubSub   pubSub
+	App      app
+	Mail     mail
+	Login    login
+	Account  account
+	Password password
+	WebAuthn webAuthn
+	OIDC     oidc
 	Env      env
 }
 
@@ -43,11 +160,129 @@ func New() *Config {
 			URL: os.Getenv("DATABASE_URL"),
 		},
 		JWT: jwt{
-			Secret: os.Getenv("JWT_SECRET"),
-			Issuer: os.Getenv("DOMAIN"),
+			Secret:          os.Getenv("JWT_SECRET"),
+			Issuer:          os.Getenv("DOMAIN"),
+			PrivateKeyFiles: getList("JWT_PRIVATE_KEYS"),
+		},
+		Post: post{
+			EditWindow:           getDuration("POST_EDIT_WINDOW", 0),
+			RequireVerifiedEmail: getBool("POST_REQUIRE_VERIFIED_EMAIL", false),
+		},
+		PubSub: pubSub{
+			Backend: getString("PUBSUB_BACKEND", "memory"),
+		},
+		App: app{
+			URL:        getString("APP_URL", "http://localhost:8080"),
+			TrustProxy: getBool("TRUST_PROXY", false),
+		},
+		Mail: mail{
+			Driver:       getString("MAIL_DRIVER", "log"),
+			From:         getString("MAIL_FROM", "no-reply@localhost"),
+			File:         getString("MAIL_FILE", "mail.log"),
+			SMTPHost:     os.Getenv("SMTP_HOST"),
+			SMTPPort:     getString("SMTP_PORT", "587"),
+			SMTPUsername: os.Getenv("SMTP_USERNAME"),
+			SMTPPassword: os.Getenv("SMTP_PASSWORD"),
+		},
+		Login: login{
+			Store:           getString("LOGIN_LIMIT_STORE", "memory"),
+			MaxAttempts:     getInt("LOGIN_MAX_ATTEMPTS", 10),
+			LockoutDuration: getDuration("LOGIN_LOCKOUT_DURATION", 15*time.Minute),
+		},
+		Account: account{
+			DeletionGracePeriod: getDuration("ACCOUNT_DELETION_GRACE_PERIOD", 30*24*time.Hour),
+			DeletionJobInterval: getDuration("ACCOUNT_DELETION_JOB_INTERVAL", time.Hour),
+		},
+		Password: password{
+			Memory:      getInt("PASSWORD_ARGON2_MEMORY", 19*1024),
+			Iterations:  getInt("PASSWORD_ARGON2_ITERATIONS", 2),
+			Parallelism: getInt("PASSWORD_ARGON2_PARALLELISM", 1),
+		},
+		WebAuthn: webAuthn{
+			RPID:    getString("WEBAUTHN_RP_ID", "localhost"),
+			RPName:  getString("WEBAUTHN_RP_NAME", "go-graphql-api"),
+			Origins: getList("WEBAUTHN_ORIGINS"),
+		},
+		OIDC: oidc{
+			CallbackURL: getString("OIDC_CALLBACK_URL", "http://localhost:8080"),
+			Providers:   getOIDCProviders(),
 		},
 		Env: env{
 			BuildEnv: os.Getenv("BUILD_ENV"),
 		},
 	}
 }
+
+// getOIDCProviders reads the providers named in OIDC_PROVIDERS from their
+// OIDC_<NAME>_* variables.
+func getOIDCProviders() []oidcProvider {
+	var providers []oidcProvider
+
+	for _, name := range getList("OIDC_PROVIDERS") {
+		prefix := "OIDC_" + strings.ToUpper(name) + "_"
+
+		scopes := getList(prefix + "SCOPES")
+		if len(scopes) == 0 {
+			scopes = []string{"email", "profile"}
+		}
+
+		providers = append(providers, oidcProvider{
+			Name:         strings.ToLower(name),
+			Issuer:       os.Getenv(prefix + "ISSUER"),
+			ClientID:     os.Getenv(prefix + "CLIENT_ID"),
+			ClientSecret: os.Getenv(prefix + "CLIENT_SECRET"),
+			Scopes:       scopes,
+		})
+	}
+
+	return providers
+}
+
+func getDuration(key string, fallback time.Duration) time.Duration {
+	d, err := time.ParseDuration(os.Getenv(key))
+	if err != nil {
+		return fallback
+	}
+
+	return d
+}
+
+func getBool(key string, fallback bool) bool {
+	b, err := strconv.ParseBool(os.Getenv(key))
+	if err != nil {
+		return fallback
+	}
+
+	return b
+}
+
+func getInt(key string, fallback int) int {
+	i, err := strconv.Atoi(os.Getenv(key))
+	if err != nil {
+		return fallback
+	}
+
+	return i
+}
+
+// getList splits a comma separated value, dropping empty items.
+func getList(key string) []string {
+	var list []string
+
+	for _, v := range strings.Split(os.Getenv(key), ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			list = append(list, v)
+		}
+	}
+
+	return list
+}
+
+func getString(key string, fallback string) string {
+	v := os.Getenv(key)
+	if v == "" {
+		return fallback
+	}
+
+	return v
+}
@@ -0,0 +1,44 @@
+package graph
+
+import (
+	"context"
+
+	"github.com/RianNegreiros/go-graphql-api/internal/user"
+)
+
+func (m *mutationResolver) RequestPasswordReset(ctx context.Context, email string) (bool, error) {
+	if err := m.AccountService.RequestPasswordReset(ctx, email); err != nil {
+		return false, buildError(ctx, err)
+	}
+
+	return true, nil
+}
+
+func (m *mutationResolver) ResetPassword(ctx context.Context, input ResetPasswordInput) (bool, error) {
+	if err := m.AccountService.ResetPassword(ctx, user.ResetPasswordInput{
+		Token:           input.Token,
+		Password:        input.Password,
+		ConfirmPassword: input.ConfirmPassword,
+	}); err != nil {
+		return false, buildError(ctx, err)
+	}
+
+	return true, nil
+}
+
+func (m *mutationResolver) VerifyEmail(ctx context.Context, token string) (*User, error) {
+	u, err := m.AccountService.VerifyEmail(ctx, token)
+	if err != nil {
+		return nil, buildError(ctx, err)
+	}
+
+	return mapUser(u), nil
+}
+
+func (m *mutationResolver) ResendVerification(ctx context.Context) (bool, error) {
+	if err := m.AccountService.ResendVerification(ctx); err != nil {
+		return false, buildError(ctx, err)
+	}
+
+	return true, nil
+}
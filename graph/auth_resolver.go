@@ -3,15 +3,15 @@ package graph
 import (
 	"context"
 	"errors"
-	"fmt"
 
 	"github.com/RianNegreiros/go-graphql-api/internal/user"
 )
 
 func mapAuthResponse(a user.AuthResponse) *AuthResponse {
 	return &AuthResponse{
-		AccessToken: a.AccessToken,
-		User:        mapUser(a.User),
+		AccessToken:  a.AccessToken,
+		RefreshToken: a.RefreshToken,
+		User:         mapUser(a.User),
 	}
 }
 
@@ -23,9 +23,6 @@ func (m *mutationResolver) Register(ctx context.Context, input RegisterInput) (*
 		ConfirmPassword: input.ConfirmPassword,
 	})
 
-	fmt.Println(res)
-	fmt.Println(input)
-
 	if err != nil {
 		switch {
 		case errors.Is(err, user.ErrValidation) ||
@@ -40,7 +37,18 @@ func (m *mutationResolver) Register(ctx context.Context, input RegisterInput) (*
 	return mapAuthResponse(res), nil
 }
 
-func (m *mutationResolver) Login(ctx context.Context, input LoginInput) (*AuthResponse, error) {
+func mapLoginResponse(l user.LoginResponse) LoginResult {
+	if l.Challenge != nil {
+		return &TwoFactorChallenge{
+			Token:     l.Challenge.Token,
+			ExpiredAt: l.Challenge.ExpiredAt,
+		}
+	}
+
+	return mapAuthResponse(l.AuthResponse)
+}
+
+func (m *mutationResolver) Login(ctx context.Context, input LoginInput) (LoginResult, error) {
 	res, err := m.AuthService.Login(ctx, user.LoginInput{
 		Email:    input.Email,
 		Password: input.Password,
@@ -51,6 +59,37 @@ func (m *mutationResolver) Login(ctx context.Context, input LoginInput) (*AuthRe
 		case errors.Is(err, user.ErrValidation) ||
 			errors.Is(err, user.ErrInvalidCredentials):
 			return nil, buildBadRequestError(ctx, err)
+		default:
+			return nil, buildError(ctx, err)
+		}
+	}
+
+	return mapLoginResponse(res), nil
+}
+
+func (m *mutationResolver) RequestLoginLink(ctx context.Context, email string) (bool, error) {
+	if err := m.AuthService.RequestLoginLink(ctx, email); err != nil {
+		return false, buildError(ctx, err)
+	}
+
+	return true, nil
+}
+
+func (m *mutationResolver) ConsumeLoginLink(ctx context.Context, token string) (*AuthResponse, error) {
+	res, err := m.AuthService.ConsumeLoginLink(ctx, token)
+	if err != nil {
+		return nil, buildError(ctx, err)
+	}
+
+	return mapAuthResponse(res), nil
+}
+
+func (m *mutationResolver) RefreshToken(ctx context.Context, token string) (*AuthResponse, error) {
+	res, err := m.AuthService.RefreshToken(ctx, token)
+	if err != nil {
+		switch {
+		case errors.Is(err, user.ErrInvalidToken):
+			return nil, buildUnauthenticatedError(ctx, err)
 		default:
 			return nil, err
 		}
@@ -58,3 +97,63 @@ func (m *mutationResolver) Login(ctx context.Context, input LoginInput) (*AuthRe
 
 	return mapAuthResponse(res), nil
 }
+
+func (m *mutationResolver) ChangePassword(ctx context.Context, input ChangePasswordInput) (bool, error) {
+	if err := m.AuthService.ChangePassword(ctx, user.ChangePasswordInput{
+		CurrentPassword: input.CurrentPassword,
+		Password:        input.Password,
+		ConfirmPassword: input.ConfirmPassword,
+	}); err != nil {
+		return false, buildError(ctx, err)
+	}
+
+	return true, nil
+}
+
+func (m *mutationResolver) ChangeEmail(ctx context.Context, input ChangeEmailInput) (bool, error) {
+	if err := m.AuthService.ChangeEmail(ctx, user.ChangeEmailInput{
+		Email:    input.Email,
+		Password: input.Password,
+	}); err != nil {
+		switch {
+		case errors.Is(err, user.ErrEmailTaken):
+			return false, buildBadRequestError(ctx, err)
+		default:
+			return false, buildError(ctx, err)
+		}
+	}
+
+	return true, nil
+}
+
+func (m *mutationResolver) ConfirmEmailChange(ctx context.Context, token string) (*User, error) {
+	u, err := m.AuthService.ConfirmEmailChange(ctx, token)
+	if err != nil {
+		switch {
+		case errors.Is(err, user.ErrEmailTaken):
+			return nil, buildBadRequestError(ctx, err)
+		default:
+			return nil, buildError(ctx, err)
+		}
+	}
+
+	return mapUser(u), nil
+}
+
+func (m *mutationResolver) DeleteAccount(ctx context.Context, password string) (*User, error) {
+	u, err := m.AuthService.DeleteAccount(ctx, password)
+	if err != nil {
+		return nil, buildError(ctx, err)
+	}
+
+	return mapUser(u), nil
+}
+
+func (m *mutationResolver) CancelAccountDeletion(ctx context.Context) (*User, error) {
+	u, err := m.AuthService.CancelAccountDeletion(ctx)
+	if err != nil {
+		return nil, buildError(ctx, err)
+	}
+
+	return mapUser(u), nil
+}
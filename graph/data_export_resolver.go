@@ -0,0 +1,27 @@
+package graph
+
+import (
+	"context"
+	"encoding/base64"
+	"strings"
+
+	"github.com/RianNegreiros/go-graphql-api/internal/dataexport"
+)
+
+func (q *queryResolver) ExportMyData(ctx context.Context, format *DataExportFormat) (*DataExport, error) {
+	f := dataexport.FormatJSON
+	if format != nil {
+		f = dataexport.Format(strings.ToLower(format.String()))
+	}
+
+	archive, err := q.DataExportService.Export(ctx, f)
+	if err != nil {
+		return nil, buildError(ctx, err)
+	}
+
+	return &DataExport{
+		Filename:    archive.Filename,
+		ContentType: archive.ContentType,
+		Data:        base64.StdEncoding.EncodeToString(archive.Data),
+	}, nil
+}
@@ -1,4 +1,13 @@
 //go:generate go run github.com/vektah/dataloaden UserLoader string *go-graphql-api/graph.User
+//go:generate go run github.com/vektah/dataloaden PostLoader string *github.com/RianNegreiros/go-graphql-api/graph.Post
+//go:generate go run github.com/vektah/dataloaden ReplyCountLoader string int
+//go:generate go run github.com/vektah/dataloaden PostConnectionLoader string *github.com/RianNegreiros/go-graphql-api/graph.PostConnection
+//go:generate go run github.com/vektah/dataloaden PostRevisionsLoader string []*github.com/RianNegreiros/go-graphql-api/graph.PostRevision
+//go:generate go run github.com/vektah/dataloaden LikeCountLoader string int
+//go:generate go run github.com/vektah/dataloaden PostLikedLoader string bool
+//go:generate go run github.com/vektah/dataloaden FollowCountLoader string int
+//go:generate go run github.com/vektah/dataloaden UserFollowedLoader string bool
+//go:generate go run github.com/vektah/dataloaden UserConnectionLoader string *github.com/RianNegreiros/go-graphql-api/graph.UserConnection
 
 package graph
 
@@ -6,55 +15,68 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/RianNegreiros/go-graphql-api/internal/pagination"
+	"github.com/RianNegreiros/go-graphql-api/internal/post"
+	"github.com/RianNegreiros/go-graphql-api/internal/transport"
 	"github.com/RianNegreiros/go-graphql-api/internal/user"
+	"github.com/vektah/gqlparser/v2/ast"
 )
 
 const loadersKey = "dataloaders"
 
 type Loaders struct {
-	UserByID UserLoader
+	UserByID           UserLoader
+	PostByID           PostLoader
+	ReplyCountByPostID ReplyCountLoader
+	RevisionsByPostID  PostRevisionsLoader
+	LikeCountByPostID  LikeCountLoader
+
+	// ViewerHasLikedByPostID needs the authenticated user, so this middleware
+	// must run after authMiddleware.
+	ViewerHasLikedByPostID PostLikedLoader
+
+	FollowerCountByUserID     FollowCountLoader
+	FollowingCountByUserID    FollowCountLoader
+	ViewerIsFollowingByUserID UserFollowedLoader
+
+	ctx   context.Context
+	repos *Repos
+
+	mu                sync.Mutex
+	repliesByPostID   map[string]*PostConnectionLoader
+	followersByUserID map[string]*UserConnectionLoader
+	followingByUserID map[string]*UserConnectionLoader
 }
 
 type Repos struct {
 	UserRepo user.UserRepo
+	PostRepo post.PostRepo
+}
+
+// loadersRef holds the loaders of a request. Subscriptions resolve every
+// event with the context they started with, so they swap in new loaders for
+// each event rather than caching for the lifetime of the connection.
+type loadersRef struct {
+	repos   *Repos
+	loaders atomic.Pointer[Loaders]
+}
+
+func newLoadersRef(ctx context.Context, repos *Repos) *loadersRef {
+	ref := &loadersRef{repos: repos}
+	ref.loaders.Store(newLoaders(ctx, repos))
+
+	return ref
 }
 
 func DataloaderMiddleware(repos *Repos) func(handler http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			ctx := context.WithValue(r.Context(), loadersKey, &Loaders{
-				UserByID: UserLoader{
-					wait:     1 * time.Millisecond,
-					maxBatch: 100,
-					fetch: func(ids []string) ([]*User, []error) {
-						users, err := repos.UserRepo.GetByIds(r.Context(), ids)
-						if err != nil {
-							return nil, []error{err}
-						}
-
-						userByID := map[string]*User{}
-
-						for _, u := range users {
-							userByID[u.ID] = mapUser(u)
-						}
-
-						result := make([]*User, len(ids))
-
-						for i, id := range ids {
-							user, ok := userByID[id]
-							if !ok {
-								return nil, []error{fmt.Errorf("user with id: %s is missing", id)}
-							}
-
-							result[i] = user
-						}
-
-						return result, nil
-					},
-				},
-			})
+			ctx := context.WithValue(r.Context(), loadersKey, newLoadersRef(r.Context(), repos))
 
 			r = r.WithContext(ctx)
 
@@ -63,6 +85,332 @@ func DataloaderMiddleware(repos *Repos) func(handler http.Handler) http.Handler
 	}
 }
 
+// SubscriptionDataloaders gives each subscription loaders of its own, built
+// from the context of the operation so that they see the user authenticated
+// by the websocket init payload.
+func SubscriptionDataloaders(ctx context.Context, next graphql.OperationHandler) graphql.ResponseHandler {
+	if graphql.GetOperationContext(ctx).Operation.Operation == ast.Subscription {
+		if ref, ok := ctx.Value(loadersKey).(*loadersRef); ok {
+			ctx = context.WithValue(ctx, loadersKey, newLoadersRef(ctx, ref.repos))
+		}
+	}
+
+	return next(ctx)
+}
+
+// renewDataloaders drops the loaders cached for the previous event of a
+// subscription.
+func renewDataloaders(ctx context.Context) {
+	if ref, ok := ctx.Value(loadersKey).(*loadersRef); ok {
+		ref.loaders.Store(newLoaders(ctx, ref.repos))
+	}
+}
+
 func DataloaderFor(ctx context.Context) *Loaders {
-	return ctx.Value(loadersKey).(*Loaders)
+	return ctx.Value(loadersKey).(*loadersRef).loaders.Load()
+}
+
+func newLoaders(ctx context.Context, repos *Repos) *Loaders {
+	return &Loaders{
+		UserByID: UserLoader{
+			wait:     1 * time.Millisecond,
+			maxBatch: 100,
+			fetch: func(ids []string) ([]*User, []error) {
+				users, err := repos.UserRepo.GetByIds(ctx, ids)
+				if err != nil {
+					return nil, []error{err}
+				}
+
+				userByID := map[string]*User{}
+
+				for _, u := range users {
+					userByID[u.ID] = mapUser(u)
+				}
+
+				result := make([]*User, len(ids))
+
+				for i, id := range ids {
+					user, ok := userByID[id]
+					if !ok {
+						return nil, []error{fmt.Errorf("user with id: %s is missing", id)}
+					}
+
+					result[i] = user
+				}
+
+				return result, nil
+			},
+		},
+		PostByID: PostLoader{
+			wait:     1 * time.Millisecond,
+			maxBatch: 100,
+			fetch: func(ids []string) ([]*Post, []error) {
+				posts, err := repos.PostRepo.GetByIds(ctx, ids)
+				if err != nil {
+					return nil, []error{err}
+				}
+
+				postByID := map[string]*Post{}
+
+				for _, p := range posts {
+					postByID[p.ID] = mapPost(p)
+				}
+
+				result := make([]*Post, len(ids))
+
+				for i, id := range ids {
+					post, ok := postByID[id]
+					if !ok {
+						return nil, []error{fmt.Errorf("post with id: %s is missing", id)}
+					}
+
+					result[i] = post
+				}
+
+				return result, nil
+			},
+		},
+		ReplyCountByPostID: ReplyCountLoader{
+			wait:     1 * time.Millisecond,
+			maxBatch: 100,
+			fetch: func(ids []string) ([]int, []error) {
+				counts, err := repos.PostRepo.CountReplies(ctx, ids)
+				if err != nil {
+					return nil, []error{err}
+				}
+
+				result := make([]int, len(ids))
+
+				for i, id := range ids {
+					result[i] = counts[id]
+				}
+
+				return result, nil
+			},
+		},
+		RevisionsByPostID: PostRevisionsLoader{
+			wait:     1 * time.Millisecond,
+			maxBatch: 100,
+			fetch: func(ids []string) ([][]*PostRevision, []error) {
+				revisions, err := repos.PostRepo.GetRevisions(ctx, ids)
+				if err != nil {
+					return nil, []error{err}
+				}
+
+				result := make([][]*PostRevision, len(ids))
+
+				for i, id := range ids {
+					result[i] = mapRevisions(revisions[id])
+				}
+
+				return result, nil
+			},
+		},
+		LikeCountByPostID: LikeCountLoader{
+			wait:     1 * time.Millisecond,
+			maxBatch: 100,
+			fetch: func(ids []string) ([]int, []error) {
+				counts, err := repos.PostRepo.CountLikes(ctx, ids)
+				if err != nil {
+					return nil, []error{err}
+				}
+
+				result := make([]int, len(ids))
+
+				for i, id := range ids {
+					result[i] = counts[id]
+				}
+
+				return result, nil
+			},
+		},
+		ViewerHasLikedByPostID: PostLikedLoader{
+			wait:     1 * time.Millisecond,
+			maxBatch: 100,
+			fetch: func(ids []string) ([]bool, []error) {
+				result := make([]bool, len(ids))
+
+				viewerID, err := transport.GetUserIDFromContext(ctx)
+				if err != nil {
+					return result, nil
+				}
+
+				liked, err := repos.PostRepo.GetLikedByUser(ctx, viewerID, ids)
+				if err != nil {
+					return nil, []error{err}
+				}
+
+				for i, id := range ids {
+					result[i] = liked[id]
+				}
+
+				return result, nil
+			},
+		},
+		FollowerCountByUserID: FollowCountLoader{
+			wait:     1 * time.Millisecond,
+			maxBatch: 100,
+			fetch: func(ids []string) ([]int, []error) {
+				counts, err := repos.UserRepo.CountFollowers(ctx, ids)
+				if err != nil {
+					return nil, []error{err}
+				}
+
+				result := make([]int, len(ids))
+
+				for i, id := range ids {
+					result[i] = counts[id]
+				}
+
+				return result, nil
+			},
+		},
+		FollowingCountByUserID: FollowCountLoader{
+			wait:     1 * time.Millisecond,
+			maxBatch: 100,
+			fetch: func(ids []string) ([]int, []error) {
+				counts, err := repos.UserRepo.CountFollowing(ctx, ids)
+				if err != nil {
+					return nil, []error{err}
+				}
+
+				result := make([]int, len(ids))
+
+				for i, id := range ids {
+					result[i] = counts[id]
+				}
+
+				return result, nil
+			},
+		},
+		ViewerIsFollowingByUserID: UserFollowedLoader{
+			wait:     1 * time.Millisecond,
+			maxBatch: 100,
+			fetch: func(ids []string) ([]bool, []error) {
+				result := make([]bool, len(ids))
+
+				viewerID, err := transport.GetUserIDFromContext(ctx)
+				if err != nil {
+					return result, nil
+				}
+
+				followed, err := repos.UserRepo.GetFollowedBy(ctx, viewerID, ids)
+				if err != nil {
+					return nil, []error{err}
+				}
+
+				for i, id := range ids {
+					result[i] = followed[id]
+				}
+
+				return result, nil
+			},
+		},
+		ctx:               ctx,
+		repos:             repos,
+		repliesByPostID:   map[string]*PostConnectionLoader{},
+		followersByUserID: map[string]*UserConnectionLoader{},
+		followingByUserID: map[string]*UserConnectionLoader{},
+	}
+}
+
+// argsKey identifies a set of page arguments, so that loaders can be kept per
+// set of arguments.
+func argsKey(args pagination.Args) string {
+	key := fmt.Sprintf("%d:%t", args.Limit, args.Backward)
+	if args.After != nil {
+		key += ":a" + args.After.Encode()
+	}
+	if args.Before != nil {
+		key += ":b" + args.Before.Encode()
+	}
+
+	return key
+}
+
+// RepliesByPostID returns the loader for reply pages requested with args.
+// Sibling posts in a query share the same field arguments, so keeping one
+// loader per set of arguments lets their replies load in a single batch.
+func (l *Loaders) RepliesByPostID(args pagination.Args) *PostConnectionLoader {
+	key := argsKey(args)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if loader, ok := l.repliesByPostID[key]; ok {
+		return loader
+	}
+
+	loader := &PostConnectionLoader{
+		wait:     1 * time.Millisecond,
+		maxBatch: 100,
+		fetch: func(ids []string) ([]*PostConnection, []error) {
+			pages, err := l.repos.PostRepo.GetReplies(l.ctx, ids, args)
+			if err != nil {
+				return nil, []error{err}
+			}
+
+			result := make([]*PostConnection, len(ids))
+
+			for i, id := range ids {
+				result[i] = mapPostConnection(pages[id])
+			}
+
+			return result, nil
+		},
+	}
+
+	l.repliesByPostID[key] = loader
+
+	return loader
+}
+
+// FollowersByUserID returns the loader for follower pages requested with
+// args, kept per set of arguments like RepliesByPostID.
+func (l *Loaders) FollowersByUserID(args pagination.Args) *UserConnectionLoader {
+	return l.followLoader(l.followersByUserID, args, l.repos.UserRepo.GetFollowers)
+}
+
+// FollowingByUserID returns the loader for followed user pages requested
+// with args, kept per set of arguments like RepliesByPostID.
+func (l *Loaders) FollowingByUserID(args pagination.Args) *UserConnectionLoader {
+	return l.followLoader(l.followingByUserID, args, l.repos.UserRepo.GetFollowing)
+}
+
+func (l *Loaders) followLoader(
+	loaders map[string]*UserConnectionLoader,
+	args pagination.Args,
+	get func(ctx context.Context, userIDs []string, args pagination.Args) (map[string]pagination.Page[user.UserModel], error),
+) *UserConnectionLoader {
+	key := argsKey(args)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if loader, ok := loaders[key]; ok {
+		return loader
+	}
+
+	loader := &UserConnectionLoader{
+		wait:     1 * time.Millisecond,
+		maxBatch: 100,
+		fetch: func(ids []string) ([]*UserConnection, []error) {
+			pages, err := get(l.ctx, ids, args)
+			if err != nil {
+				return nil, []error{err}
+			}
+
+			result := make([]*UserConnection, len(ids))
+
+			for i, id := range ids {
+				result[i] = mapUserConnection(pages[id])
+			}
+
+			return result, nil
+		},
+	}
+
+	loaders[key] = loader
+
+	return loader
 }
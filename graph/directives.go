@@ -0,0 +1,85 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/RianNegreiros/go-graphql-api/internal/transport"
+	"github.com/RianNegreiros/go-graphql-api/internal/user"
+)
+
+// owned is implemented by models whose fields can be restricted with @owner.
+type owned interface {
+	OwnerID() string
+}
+
+func (u *User) OwnerID() string {
+	return u.ID
+}
+
+func (p *Post) OwnerID() string {
+	return p.UserID
+}
+
+func NewDirectives() DirectiveRoot {
+	return DirectiveRoot{
+		Auth:    authDirective,
+		Owner:   ownerDirective,
+		HasRole: hasRoleDirective,
+		Scope:   scopeDirective,
+	}
+}
+
+func authDirective(ctx context.Context, obj interface{}, next graphql.Resolver) (interface{}, error) {
+	if _, err := transport.GetUserIDFromContext(ctx); err != nil {
+		return nil, buildError(ctx, user.ErrUnauthenticated)
+	}
+
+	return next(ctx)
+}
+
+func ownerDirective(ctx context.Context, obj interface{}, next graphql.Resolver) (interface{}, error) {
+	currentUserID, err := transport.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, buildError(ctx, user.ErrUnauthenticated)
+	}
+
+	o, ok := obj.(owned)
+	if !ok || o.OwnerID() != currentUserID {
+		return nil, buildError(ctx, user.ErrForbidden)
+	}
+
+	return next(ctx)
+}
+
+func hasRoleDirective(ctx context.Context, obj interface{}, next graphql.Resolver, role Role) (interface{}, error) {
+	if _, err := transport.GetUserIDFromContext(ctx); err != nil {
+		return nil, buildError(ctx, user.ErrUnauthenticated)
+	}
+
+	if !transport.GetRoleFromContext(ctx).Satisfies(mapRole(role)) {
+		return nil, buildError(ctx, user.ErrForbidden)
+	}
+
+	return next(ctx)
+}
+
+// scopeDirective refuses personal access tokens and OAuth access tokens which
+// weren't granted scope. The services check their scopes too.
+func scopeDirective(ctx context.Context, obj interface{}, next graphql.Resolver, scope Scope) (interface{}, error) {
+	scopes, ok := transport.GetScopesFromContext(ctx)
+	if !ok {
+		return next(ctx)
+	}
+
+	required := mapScope(scope)
+
+	for _, s := range scopes {
+		if s == required {
+			return next(ctx)
+		}
+	}
+
+	return nil, buildError(ctx, fmt.Errorf("%w: %s", user.ErrInsufficientScope, required))
+}
@@ -3,29 +3,205 @@
 package graph
 
 import (
+	"fmt"
+	"io"
+	"strconv"
 	"time"
 )
 
+type LoginResult interface {
+	IsLoginResult()
+}
+
 type AuthResponse struct {
-	AccessToken string `json:"accessToken"`
-	User        *User  `json:"user"`
+	AccessToken  string `json:"accessToken"`
+	RefreshToken string `json:"refreshToken"`
+	User         *User  `json:"user"`
+}
+
+func (AuthResponse) IsLoginResult() {}
+
+type ChangeEmailInput struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+type ChangePasswordInput struct {
+	CurrentPassword string `json:"currentPassword"`
+	Password        string `json:"password"`
+	ConfirmPassword string `json:"confirmPassword"`
+}
+
+type CreateOAuthClientInput struct {
+	Name         string   `json:"name"`
+	RedirectUris []string `json:"redirectUris"`
+	Scopes       []Scope  `json:"scopes"`
+	// Confidential clients get a secret, apps which can't keep one shouldn't be.
+	Confidential bool `json:"confidential"`
+}
+
+type CreatePersonalAccessTokenInput struct {
+	Name      string     `json:"name"`
+	Scopes    []Scope    `json:"scopes"`
+	ExpiredAt *time.Time `json:"expiredAt"`
 }
 
 type CreatePostInput struct {
 	Body string `json:"body"`
 }
 
+type CreatedOAuthClient struct {
+	// Only shown once, store it somewhere safe. Null for public clients.
+	Secret *string      `json:"secret"`
+	Client *OAuthClient `json:"client"`
+}
+
+type CreatedPersonalAccessToken struct {
+	// Only shown once, store it somewhere safe.
+	Token               string               `json:"token"`
+	PersonalAccessToken *PersonalAccessToken `json:"personalAccessToken"`
+}
+
+type DataExport struct {
+	Filename    string `json:"filename"`
+	ContentType string `json:"contentType"`
+	// The archive, base64 encoded.
+	Data string `json:"data"`
+}
+
+// The response of navigator.credentials.create, binary values base64url encoded.
+type FinishPasskeyRegistrationInput struct {
+	Name              string `json:"name"`
+	ClientDataJSON    string `json:"clientDataJSON"`
+	AttestationObject string `json:"attestationObject"`
+}
+
 type LoginInput struct {
 	Email    string `json:"email"`
 	Password string `json:"password"`
 }
 
+// An authorization request to show the user before they consent.
+type OAuthAuthorization struct {
+	Client      *OAuthClient `json:"client"`
+	RedirectURI string       `json:"redirectUri"`
+	Scopes      []Scope      `json:"scopes"`
+	// True when the user already granted every scope.
+	Consented bool `json:"consented"`
+}
+
+// The query parameters of the /oauth/authorize request.
+type OAuthAuthorizationInput struct {
+	ResponseType        string  `json:"responseType"`
+	ClientID            string  `json:"clientId"`
+	RedirectURI         *string `json:"redirectUri"`
+	Scope               *string `json:"scope"`
+	State               *string `json:"state"`
+	CodeChallenge       string  `json:"codeChallenge"`
+	CodeChallengeMethod string  `json:"codeChallengeMethod"`
+}
+
+type OAuthClient struct {
+	ID           string    `json:"id"`
+	Name         string    `json:"name"`
+	RedirectUris []string  `json:"redirectUris"`
+	Scopes       []Scope   `json:"scopes"`
+	Confidential bool      `json:"confidential"`
+	CreatedAt    time.Time `json:"createdAt"`
+}
+
+type OAuthConsent struct {
+	ClientID   string    `json:"clientId"`
+	ClientName string    `json:"clientName"`
+	Scopes     []Scope   `json:"scopes"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
+type PageInfo struct {
+	HasNextPage     bool    `json:"hasNextPage"`
+	HasPreviousPage bool    `json:"hasPreviousPage"`
+	StartCursor     *string `json:"startCursor"`
+	EndCursor       *string `json:"endCursor"`
+}
+
+type Passkey struct {
+	ID         string     `json:"id"`
+	Name       string     `json:"name"`
+	LastUsedAt *time.Time `json:"lastUsedAt"`
+	CreatedAt  time.Time  `json:"createdAt"`
+}
+
+// Options for navigator.credentials.create, binary values are base64url encoded.
+type PasskeyCreationOptions struct {
+	Challenge       string `json:"challenge"`
+	RpID            string `json:"rpId"`
+	RpName          string `json:"rpName"`
+	UserID          string `json:"userId"`
+	UserName        string `json:"userName"`
+	UserDisplayName string `json:"userDisplayName"`
+	// COSE algorithm identifiers, most preferred first.
+	Algorithms           []int    `json:"algorithms"`
+	ExcludeCredentialIds []string `json:"excludeCredentialIds"`
+	// In milliseconds.
+	Timeout int `json:"timeout"`
+}
+
+// The response of navigator.credentials.get, binary values base64url encoded.
+type PasskeyLoginInput struct {
+	CredentialID      string  `json:"credentialId"`
+	ClientDataJSON    string  `json:"clientDataJSON"`
+	AuthenticatorData string  `json:"authenticatorData"`
+	Signature         string  `json:"signature"`
+	UserHandle        *string `json:"userHandle"`
+}
+
+// Options for navigator.credentials.get, binary values are base64url encoded.
+type PasskeyRequestOptions struct {
+	Challenge string `json:"challenge"`
+	RpID      string `json:"rpId"`
+	// In milliseconds.
+	Timeout int `json:"timeout"`
+}
+
+type PersonalAccessToken struct {
+	ID         string     `json:"id"`
+	Name       string     `json:"name"`
+	Scopes     []Scope    `json:"scopes"`
+	LastUsedAt *time.Time `json:"lastUsedAt"`
+	ExpiredAt  *time.Time `json:"expiredAt"`
+	CreatedAt  time.Time  `json:"createdAt"`
+}
+
 type Post struct {
+	ID             string          `json:"id"`
+	Body           string          `json:"body"`
+	Username       string          `json:"username"`
+	User           *User           `json:"user"`
+	UserID         string          `json:"userID"`
+	ParentID       *string         `json:"parentID"`
+	Parent         *Post           `json:"parent"`
+	Replies        *PostConnection `json:"replies"`
+	ReplyCount     int             `json:"replyCount"`
+	Revisions      []*PostRevision `json:"revisions"`
+	LikeCount      int             `json:"likeCount"`
+	ViewerHasLiked bool            `json:"viewerHasLiked"`
+	CreatedAt      time.Time       `json:"createdAt"`
+	UpdatedAt      time.Time       `json:"updatedAt"`
+}
+
+type PostConnection struct {
+	Edges    []*PostEdge `json:"edges"`
+	PageInfo *PageInfo   `json:"pageInfo"`
+}
+
+type PostEdge struct {
+	Cursor string `json:"cursor"`
+	Node   *Post  `json:"node"`
+}
+
+type PostRevision struct {
 	ID        string    `json:"id"`
 	Body      string    `json:"body"`
-	Username  string    `json:"username"`
-	User      *User     `json:"user"`
-	UserID    string    `json:"userID"`
 	CreatedAt time.Time `json:"createdAt"`
 }
 
@@ -36,10 +212,212 @@ type RegisterInput struct {
 	ConfirmPassword string `json:"confirmPassword"`
 }
 
+type ResetPasswordInput struct {
+	Token           string `json:"token"`
+	Password        string `json:"password"`
+	ConfirmPassword string `json:"confirmPassword"`
+}
+
+type Session struct {
+	ID         string    `json:"id"`
+	Name       string    `json:"name"`
+	Current    bool      `json:"current"`
+	LastUsedAt time.Time `json:"lastUsedAt"`
+	ExpiredAt  time.Time `json:"expiredAt"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
+type TotpSetup struct {
+	Secret string `json:"secret"`
+	URI    string `json:"uri"`
+}
+
+type TwoFactorChallenge struct {
+	Token     string    `json:"token"`
+	ExpiredAt time.Time `json:"expiredAt"`
+}
+
+func (TwoFactorChallenge) IsLoginResult() {}
+
+type TwoFactorStatus struct {
+	Enabled                bool `json:"enabled"`
+	RecoveryCodesRemaining int  `json:"recoveryCodesRemaining"`
+}
+
+type UpdatePostInput struct {
+	Body string `json:"body"`
+}
+
+type UpdateProfileInput struct {
+	DisplayName *string `json:"displayName"`
+	Bio         *string `json:"bio"`
+	Location    *string `json:"location"`
+	Website     *string `json:"website"`
+	AvatarURL   *string `json:"avatarUrl"`
+}
+
 type User struct {
-	ID        string    `json:"id"`
-	Username  string    `json:"username"`
-	Email     string    `json:"email"`
-	Password  string    `json:"password"`
-	CreatedAt time.Time `json:"createdAt"`
+	ID              string     `json:"id"`
+	Username        string     `json:"username"`
+	Email           *string    `json:"email"`
+	EmailVerifiedAt *time.Time `json:"emailVerifiedAt"`
+	// When the account will be deleted, if its owner asked for it.
+	DeletionScheduledAt *time.Time      `json:"deletionScheduledAt"`
+	DisplayName         string          `json:"displayName"`
+	Bio                 string          `json:"bio"`
+	Location            string          `json:"location"`
+	Website             string          `json:"website"`
+	AvatarURL           string          `json:"avatarUrl"`
+	Role                Role            `json:"role"`
+	Followers           *UserConnection `json:"followers"`
+	Following           *UserConnection `json:"following"`
+	FollowerCount       int             `json:"followerCount"`
+	FollowingCount      int             `json:"followingCount"`
+	ViewerIsFollowing   bool            `json:"viewerIsFollowing"`
+	CreatedAt           time.Time       `json:"createdAt"`
+}
+
+type UserConnection struct {
+	Edges    []*UserEdge `json:"edges"`
+	PageInfo *PageInfo   `json:"pageInfo"`
+}
+
+type UserEdge struct {
+	Cursor string `json:"cursor"`
+	Node   *User  `json:"node"`
+}
+
+type VerifyTwoFactorInput struct {
+	Token string `json:"token"`
+	Code  string `json:"code"`
+}
+
+type DataExportFormat string
+
+const (
+	DataExportFormatJSON DataExportFormat = "JSON"
+	DataExportFormatZip  DataExportFormat = "ZIP"
+)
+
+var AllDataExportFormat = []DataExportFormat{
+	DataExportFormatJSON,
+	DataExportFormatZip,
+}
+
+func (e DataExportFormat) IsValid() bool {
+	switch e {
+	case DataExportFormatJSON, DataExportFormatZip:
+		return true
+	}
+	return false
+}
+
+func (e DataExportFormat) String() string {
+	return string(e)
+}
+
+func (e *DataExportFormat) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = DataExportFormat(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid DataExportFormat", str)
+	}
+	return nil
+}
+
+func (e DataExportFormat) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type Role string
+
+const (
+	RoleUser      Role = "USER"
+	RoleModerator Role = "MODERATOR"
+	RoleAdmin     Role = "ADMIN"
+)
+
+var AllRole = []Role{
+	RoleUser,
+	RoleModerator,
+	RoleAdmin,
+}
+
+func (e Role) IsValid() bool {
+	switch e {
+	case RoleUser, RoleModerator, RoleAdmin:
+		return true
+	}
+	return false
+}
+
+func (e Role) String() string {
+	return string(e)
+}
+
+func (e *Role) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = Role(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid Role", str)
+	}
+	return nil
+}
+
+func (e Role) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// What a personal access token or an OAuth client may do. Sessions may do everything.
+type Scope string
+
+const (
+	ScopePostsRead  Scope = "POSTS_READ"
+	ScopePostsWrite Scope = "POSTS_WRITE"
+	ScopeUsersRead  Scope = "USERS_READ"
+	ScopeUsersWrite Scope = "USERS_WRITE"
+)
+
+var AllScope = []Scope{
+	ScopePostsRead,
+	ScopePostsWrite,
+	ScopeUsersRead,
+	ScopeUsersWrite,
+}
+
+func (e Scope) IsValid() bool {
+	switch e {
+	case ScopePostsRead, ScopePostsWrite, ScopeUsersRead, ScopeUsersWrite:
+		return true
+	}
+	return false
+}
+
+func (e Scope) String() string {
+	return string(e)
+}
+
+func (e *Scope) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = Scope(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid Scope", str)
+	}
+	return nil
+}
+
+func (e Scope) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
 }
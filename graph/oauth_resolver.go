@@ -0,0 +1,153 @@
+package graph
+
+import (
+	"context"
+
+	"github.com/RianNegreiros/go-graphql-api/internal/user"
+)
+
+func mapUserScopes(scopes []user.Scope) []Scope {
+	ss := make([]Scope, len(scopes))
+
+	for i, s := range scopes {
+		ss[i] = mapUserScope(s)
+	}
+
+	return ss
+}
+
+func mapOAuthClient(c user.OAuthClient) *OAuthClient {
+	return &OAuthClient{
+		ID:           c.ID,
+		Name:         c.Name,
+		RedirectUris: c.RedirectURIs,
+		Scopes:       mapUserScopes(c.Scopes),
+		Confidential: c.IsConfidential(),
+		CreatedAt:    c.CreatedAt,
+	}
+}
+
+func mapOAuthAuthorizationInput(input OAuthAuthorizationInput) user.OAuthAuthorizationInput {
+	in := user.OAuthAuthorizationInput{
+		ResponseType:        input.ResponseType,
+		ClientID:            input.ClientID,
+		CodeChallenge:       input.CodeChallenge,
+		CodeChallengeMethod: input.CodeChallengeMethod,
+	}
+
+	if input.RedirectURI != nil {
+		in.RedirectURI = *input.RedirectURI
+	}
+
+	if input.Scope != nil {
+		in.Scope = *input.Scope
+	}
+
+	if input.State != nil {
+		in.State = *input.State
+	}
+
+	return in
+}
+
+func (q *queryResolver) OauthClients(ctx context.Context) ([]*OAuthClient, error) {
+	clients, err := q.OAuthService.Clients(ctx)
+	if err != nil {
+		return nil, buildError(ctx, err)
+	}
+
+	cs := make([]*OAuthClient, len(clients))
+
+	for i, c := range clients {
+		cs[i] = mapOAuthClient(c)
+	}
+
+	return cs, nil
+}
+
+func (q *queryResolver) OauthAuthorization(ctx context.Context, input OAuthAuthorizationInput) (*OAuthAuthorization, error) {
+	a, err := q.OAuthService.Authorization(ctx, mapOAuthAuthorizationInput(input))
+	if err != nil {
+		return nil, buildError(ctx, err)
+	}
+
+	return &OAuthAuthorization{
+		Client:      mapOAuthClient(a.Client),
+		RedirectURI: a.RedirectURI,
+		Scopes:      mapUserScopes(a.Scopes),
+		Consented:   a.Consented,
+	}, nil
+}
+
+func (q *queryResolver) OauthConsents(ctx context.Context) ([]*OAuthConsent, error) {
+	consents, err := q.OAuthService.Consents(ctx)
+	if err != nil {
+		return nil, buildError(ctx, err)
+	}
+
+	cs := make([]*OAuthConsent, len(consents))
+
+	for i, c := range consents {
+		cs[i] = &OAuthConsent{
+			ClientID:   c.ClientID,
+			ClientName: c.ClientName,
+			Scopes:     mapUserScopes(c.Scopes),
+			CreatedAt:  c.CreatedAt,
+		}
+	}
+
+	return cs, nil
+}
+
+func (m *mutationResolver) CreateOAuthClient(ctx context.Context, input CreateOAuthClientInput) (*CreatedOAuthClient, error) {
+	scopes := make([]user.Scope, len(input.Scopes))
+
+	for i, s := range input.Scopes {
+		scopes[i] = mapScope(s)
+	}
+
+	created, err := m.OAuthService.CreateClient(ctx, user.CreateOAuthClientInput{
+		Name:         input.Name,
+		RedirectURIs: input.RedirectUris,
+		Scopes:       scopes,
+		Confidential: input.Confidential,
+	})
+	if err != nil {
+		return nil, buildError(ctx, err)
+	}
+
+	res := &CreatedOAuthClient{
+		Client: mapOAuthClient(created.OAuthClient),
+	}
+
+	if created.Secret != "" {
+		res.Secret = &created.Secret
+	}
+
+	return res, nil
+}
+
+func (m *mutationResolver) DeleteOAuthClient(ctx context.Context, id string) (bool, error) {
+	if err := m.OAuthService.DeleteClient(ctx, id); err != nil {
+		return false, buildError(ctx, err)
+	}
+
+	return true, nil
+}
+
+func (m *mutationResolver) AuthorizeOAuthClient(ctx context.Context, input OAuthAuthorizationInput) (string, error) {
+	redirectURL, err := m.OAuthService.Authorize(ctx, mapOAuthAuthorizationInput(input))
+	if err != nil {
+		return "", buildError(ctx, err)
+	}
+
+	return redirectURL, nil
+}
+
+func (m *mutationResolver) RevokeOAuthConsent(ctx context.Context, clientID string) (bool, error) {
+	if err := m.OAuthService.RevokeConsent(ctx, clientID); err != nil {
+		return false, buildError(ctx, err)
+	}
+
+	return true, nil
+}
@@ -0,0 +1,22 @@
+package graph
+
+import (
+	"github.com/RianNegreiros/go-graphql-api/internal/pagination"
+)
+
+func mapPageInfo[T any](page pagination.Page[T]) *PageInfo {
+	pageInfo := &PageInfo{
+		HasNextPage:     page.HasNextPage,
+		HasPreviousPage: page.HasPreviousPage,
+	}
+
+	if len(page.Edges) > 0 {
+		startCursor := page.Edges[0].Cursor.Encode()
+		endCursor := page.Edges[len(page.Edges)-1].Cursor.Encode()
+
+		pageInfo.StartCursor = &startCursor
+		pageInfo.EndCursor = &endCursor
+	}
+
+	return pageInfo
+}
@@ -0,0 +1,110 @@
+package graph
+
+import (
+	"context"
+	"errors"
+
+	"github.com/RianNegreiros/go-graphql-api/internal/user"
+)
+
+func mapPasskey(p user.Passkey) *Passkey {
+	return &Passkey{
+		ID:         p.ID,
+		Name:       p.Name,
+		LastUsedAt: p.LastUsedAt,
+		CreatedAt:  p.CreatedAt,
+	}
+}
+
+func (q *queryResolver) Passkeys(ctx context.Context) ([]*Passkey, error) {
+	passkeys, err := q.PasskeyService.List(ctx)
+	if err != nil {
+		return nil, buildError(ctx, err)
+	}
+
+	ps := make([]*Passkey, len(passkeys))
+
+	for i, p := range passkeys {
+		ps[i] = mapPasskey(p)
+	}
+
+	return ps, nil
+}
+
+func (m *mutationResolver) BeginPasskeyRegistration(ctx context.Context) (*PasskeyCreationOptions, error) {
+	opts, err := m.PasskeyService.BeginRegistration(ctx)
+	if err != nil {
+		return nil, buildError(ctx, err)
+	}
+
+	return &PasskeyCreationOptions{
+		Challenge:            opts.Challenge,
+		RpID:                 opts.RPID,
+		RpName:               opts.RPName,
+		UserID:               opts.UserID,
+		UserName:             opts.UserName,
+		UserDisplayName:      opts.UserDisplayName,
+		Algorithms:           opts.Algorithms,
+		ExcludeCredentialIds: opts.ExcludeCredentialIDs,
+		Timeout:              int(opts.Timeout.Milliseconds()),
+	}, nil
+}
+
+func (m *mutationResolver) FinishPasskeyRegistration(ctx context.Context, input FinishPasskeyRegistrationInput) (*Passkey, error) {
+	p, err := m.PasskeyService.FinishRegistration(ctx, user.FinishPasskeyRegistrationInput{
+		Name:              input.Name,
+		ClientDataJSON:    input.ClientDataJSON,
+		AttestationObject: input.AttestationObject,
+	})
+	if err != nil {
+		return nil, buildError(ctx, err)
+	}
+
+	return mapPasskey(p), nil
+}
+
+func (m *mutationResolver) DeletePasskey(ctx context.Context, id string) (bool, error) {
+	if err := m.PasskeyService.Delete(ctx, id); err != nil {
+		return false, buildError(ctx, err)
+	}
+
+	return true, nil
+}
+
+func (m *mutationResolver) BeginPasskeyLogin(ctx context.Context) (*PasskeyRequestOptions, error) {
+	opts, err := m.PasskeyService.BeginLogin(ctx)
+	if err != nil {
+		return nil, buildError(ctx, err)
+	}
+
+	return &PasskeyRequestOptions{
+		Challenge: opts.Challenge,
+		RpID:      opts.RPID,
+		Timeout:   int(opts.Timeout.Milliseconds()),
+	}, nil
+}
+
+func (m *mutationResolver) LoginWithPasskey(ctx context.Context, input PasskeyLoginInput) (*AuthResponse, error) {
+	in := user.PasskeyLoginInput{
+		CredentialID:      input.CredentialID,
+		ClientDataJSON:    input.ClientDataJSON,
+		AuthenticatorData: input.AuthenticatorData,
+		Signature:         input.Signature,
+	}
+
+	if input.UserHandle != nil {
+		in.UserHandle = *input.UserHandle
+	}
+
+	res, err := m.AuthService.LoginWithPasskey(ctx, in)
+	if err != nil {
+		switch {
+		case errors.Is(err, user.ErrValidation):
+			return nil, buildBadRequestError(ctx, err)
+		default:
+			return nil, buildError(ctx, err)
+		}
+	}
+
+	return mapAuthResponse(res), nil
+}
@@ -0,0 +1,72 @@
+package graph
+
+import (
+	"context"
+	"strings"
+
+	"github.com/RianNegreiros/go-graphql-api/internal/user"
+)
+
+func mapScope(scope Scope) user.Scope {
+	return user.Scope(strings.Replace(strings.ToLower(scope.String()), "_", ":", 1))
+}
+
+func mapUserScope(scope user.Scope) Scope {
+	return Scope(strings.Replace(strings.ToUpper(string(scope)), ":", "_", 1))
+}
+
+func mapPersonalAccessToken(t user.PersonalAccessToken) *PersonalAccessToken {
+	return &PersonalAccessToken{
+		ID:         t.ID,
+		Name:       t.Name,
+		Scopes:     mapUserScopes(t.Scopes),
+		LastUsedAt: t.LastUsedAt,
+		ExpiredAt:  t.ExpiredAt,
+		CreatedAt:  t.CreatedAt,
+	}
+}
+
+func (q *queryResolver) PersonalAccessTokens(ctx context.Context) ([]*PersonalAccessToken, error) {
+	tokens, err := q.PersonalAccessTokenService.List(ctx)
+	if err != nil {
+		return nil, buildError(ctx, err)
+	}
+
+	ts := make([]*PersonalAccessToken, len(tokens))
+
+	for i, t := range tokens {
+		ts[i] = mapPersonalAccessToken(t)
+	}
+
+	return ts, nil
+}
+
+func (m *mutationResolver) CreatePersonalAccessToken(ctx context.Context, input CreatePersonalAccessTokenInput) (*CreatedPersonalAccessToken, error) {
+	scopes := make([]user.Scope, len(input.Scopes))
+
+	for i, s := range input.Scopes {
+		scopes[i] = mapScope(s)
+	}
+
+	created, err := m.PersonalAccessTokenService.Create(ctx, user.CreatePersonalAccessTokenInput{
+		Name:      input.Name,
+		Scopes:    scopes,
+		ExpiredAt: input.ExpiredAt,
+	})
+	if err != nil {
+		return nil, buildError(ctx, err)
+	}
+
+	return &CreatedPersonalAccessToken{
+		Token:               created.Token,
+		PersonalAccessToken: mapPersonalAccessToken(created.PersonalAccessToken),
+	}, nil
+}
+
+func (m *mutationResolver) RevokePersonalAccessToken(ctx context.Context, id string) (bool, error) {
+	if err := m.PersonalAccessTokenService.Revoke(ctx, id); err != nil {
+		return false, buildError(ctx, err)
+	}
+
+	return true, nil
+}
@@ -3,7 +3,9 @@ package graph
 import (
 	"context"
 
+	"github.com/RianNegreiros/go-graphql-api/internal/pagination"
 	"github.com/RianNegreiros/go-graphql-api/internal/post"
+	"github.com/RianNegreiros/go-graphql-api/internal/transport"
 )
 
 func mapPost(t post.Post) *Post {
@@ -11,10 +13,30 @@ func mapPost(t post.Post) *Post {
 		ID:        t.ID,
 		Body:      t.Body,
 		UserID:    t.UserID,
+		ParentID:  t.ParentID,
 		CreatedAt: t.CreatedAt,
+		UpdatedAt: t.UpdatedAt,
 	}
 }
 
+func mapRevision(r post.Revision) *PostRevision {
+	return &PostRevision{
+		ID:        r.ID,
+		Body:      r.Body,
+		CreatedAt: r.CreatedAt,
+	}
+}
+
+func mapRevisions(revisions []post.Revision) []*PostRevision {
+	rr := make([]*PostRevision, len(revisions))
+
+	for i, r := range revisions {
+		rr[i] = mapRevision(r)
+	}
+
+	return rr
+}
+
 func mapPosts(posts []post.Post) []*Post {
 	tt := make([]*Post, len(posts))
 
@@ -25,13 +47,62 @@ func mapPosts(posts []post.Post) []*Post {
 	return tt
 }
 
+func mapPostConnection(page pagination.Page[post.Post]) *PostConnection {
+	edges := make([]*PostEdge, len(page.Edges))
+
+	for i, e := range page.Edges {
+		edges[i] = &PostEdge{
+			Cursor: e.Cursor.Encode(),
+			Node:   mapPost(e.Node),
+		}
+	}
+
+	return &PostConnection{
+		Edges:    edges,
+		PageInfo: mapPageInfo(page),
+	}
+}
+
+// Posts only returns the first page of postsConnection, the newest posts.
 func (q *queryResolver) Posts(ctx context.Context) ([]*Post, error) {
-	posts, err := q.PostService.All(ctx)
+	page, err := q.PostService.Paginate(ctx, pagination.Input{})
 	if err != nil {
-		return nil, err
+		return nil, buildError(ctx, err)
 	}
 
-	return mapPosts(posts), nil
+	posts := make([]*Post, len(page.Edges))
+
+	for i, e := range page.Edges {
+		posts[i] = mapPost(e.Node)
+	}
+
+	return posts, nil
+}
+
+func (q *queryResolver) PostsConnection(ctx context.Context, first *int, after *string, last *int, before *string) (*PostConnection, error) {
+	page, err := q.PostService.Paginate(ctx, pagination.Input{
+		First:  first,
+		After:  after,
+		Last:   last,
+		Before: before,
+	})
+	if err != nil {
+		return nil, buildError(ctx, err)
+	}
+
+	return mapPostConnection(page), nil
+}
+
+func (q *queryResolver) HomeTimeline(ctx context.Context, first *int, after *string) (*PostConnection, error) {
+	page, err := q.PostService.HomeTimeline(ctx, pagination.Input{
+		First: first,
+		After: after,
+	})
+	if err != nil {
+		return nil, buildError(ctx, err)
+	}
+
+	return mapPostConnection(page), nil
 }
 
 func (m *mutationResolver) CreatePost(ctx context.Context, input CreatePostInput) (*Post, error) {
@@ -49,6 +120,59 @@ func (t *postResolver) User(ctx context.Context, obj *Post) (*User, error) {
 	return DataloaderFor(ctx).UserByID.Load(obj.UserID)
 }
 
+func (t *postResolver) Parent(ctx context.Context, obj *Post) (*Post, error) {
+	if obj.ParentID == nil {
+		return nil, nil
+	}
+
+	return DataloaderFor(ctx).PostByID.Load(*obj.ParentID)
+}
+
+func (t *postResolver) Replies(ctx context.Context, obj *Post, first *int, after *string) (*PostConnection, error) {
+	args, err := pagination.Input{
+		First: first,
+		After: after,
+	}.Args()
+	if err != nil {
+		return nil, buildError(ctx, err)
+	}
+
+	return DataloaderFor(ctx).RepliesByPostID(args).Load(obj.ID)
+}
+
+func (t *postResolver) ReplyCount(ctx context.Context, obj *Post) (int, error) {
+	return DataloaderFor(ctx).ReplyCountByPostID.Load(obj.ID)
+}
+
+func (q *queryResolver) Thread(ctx context.Context, rootID string, depth *int) ([]*Post, error) {
+	d := post.DefaultThreadDepth
+	if depth != nil {
+		d = *depth
+	}
+
+	posts, err := q.PostService.Thread(ctx, rootID, d)
+	if err != nil {
+		return nil, buildError(ctx, err)
+	}
+
+	return mapPosts(posts), nil
+}
+
+func (t *postResolver) Revisions(ctx context.Context, obj *Post) ([]*PostRevision, error) {
+	return DataloaderFor(ctx).RevisionsByPostID.Load(obj.ID)
+}
+
+func (m *mutationResolver) UpdatePost(ctx context.Context, id string, input UpdatePostInput) (*Post, error) {
+	p, err := m.PostService.Update(ctx, id, post.UpdatePostInput{
+		Body: input.Body,
+	})
+	if err != nil {
+		return nil, buildError(ctx, err)
+	}
+
+	return mapPost(p), nil
+}
+
 func (m *mutationResolver) DeletePost(ctx context.Context, id string) (bool, error) {
 	if err := m.PostService.Delete(ctx, id); err != nil {
 		return false, buildError(ctx, err)
@@ -57,13 +181,72 @@ func (m *mutationResolver) DeletePost(ctx context.Context, id string) (bool, err
 	return true, nil
 }
 
-func (m *mutationResolver) CreateReply(ctx context.Context, parentID string, input CreatePostInput) (*Post, error) {
+func (m *mutationResolver) RemovePost(ctx context.Context, id string, reason string) (bool, error) {
+	if err := m.PostService.Remove(ctx, id, post.RemovePostInput{
+		Reason: reason,
+	}); err != nil {
+		return false, buildError(ctx, err)
+	}
+
+	return true, nil
+}
+
+func (m *mutationResolver) CreateReply(ctx context.Context, parentID string, input *CreatePostInput) (*Post, error) {
+	var body string
+	if input != nil {
+		body = input.Body
+	}
+
 	p, err := m.PostService.CreateReply(ctx, parentID, post.CreatePostInput{
-		Body: input.Body,
+		Body: body,
+	})
+	if err != nil {
+		return nil, buildError(ctx, err)
+	}
+
+	return mapPost(p), nil
+}
+
+func (t *postResolver) LikeCount(ctx context.Context, obj *Post) (int, error) {
+	return DataloaderFor(ctx).LikeCountByPostID.Load(obj.ID)
+}
+
+func (t *postResolver) ViewerHasLiked(ctx context.Context, obj *Post) (bool, error) {
+	if _, err := transport.GetUserIDFromContext(ctx); err != nil {
+		return false, nil
+	}
+
+	return DataloaderFor(ctx).ViewerHasLikedByPostID.Load(obj.ID)
+}
+
+func (q *queryResolver) LikedPosts(ctx context.Context, userID string, first *int, after *string, last *int, before *string) (*PostConnection, error) {
+	page, err := q.PostService.LikedPosts(ctx, userID, pagination.Input{
+		First:  first,
+		After:  after,
+		Last:   last,
+		Before: before,
 	})
 	if err != nil {
 		return nil, buildError(ctx, err)
 	}
 
+	return mapPostConnection(page), nil
+}
+
+func (m *mutationResolver) LikePost(ctx context.Context, id string) (*Post, error) {
+	p, err := m.PostService.Like(ctx, id)
+	if err != nil {
+		return nil, buildError(ctx, err)
+	}
+
+	return mapPost(p), nil
+}
+
+func (m *mutationResolver) UnlikePost(ctx context.Context, id string) (*Post, error) {
+	p, err := m.PostService.Unlike(ctx, id)
+	if err != nil {
+		return nil, buildError(ctx, err)
+	}
+
 	return mapPost(p), nil
 }
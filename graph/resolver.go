@@ -6,6 +6,8 @@ import (
 	"net/http"
 
 	"github.com/99designs/gqlgen/graphql"
+	"github.com/RianNegreiros/go-graphql-api/internal/dataexport"
+	"github.com/RianNegreiros/go-graphql-api/internal/pagination"
 	"github.com/RianNegreiros/go-graphql-api/internal/post"
 	"github.com/RianNegreiros/go-graphql-api/internal/user"
 	"github.com/vektah/gqlparser/v2/gqlerror"
@@ -14,9 +16,15 @@ import (
 //go:generate go run github.com/99designs/gqlgen
 
 type Resolver struct {
-	AuthService user.AuthService
-	PostService post.PostService
-	UserService user.UserService
+	AuthService                user.AuthService
+	AccountService             user.AccountService
+	TwoFactorService           user.TwoFactorService
+	PersonalAccessTokenService user.PersonalAccessTokenService
+	PasskeyService             user.PasskeyService
+	OAuthService               user.OAuthService
+	DataExportService          dataexport.Service
+	PostService                post.PostService
+	UserService                user.UserService
 }
 
 type queryResolver struct {
@@ -35,6 +43,14 @@ func (r *Resolver) Mutation() MutationResolver {
 	return &mutationResolver{r}
 }
 
+type subscriptionResolver struct {
+	*Resolver
+}
+
+func (r *Resolver) Subscription() SubscriptionResolver {
+	return &subscriptionResolver{r}
+}
+
 type postResolver struct {
 	*Resolver
 }
@@ -43,6 +59,14 @@ func (r *Resolver) Post() PostResolver {
 	return &postResolver{r}
 }
 
+type userResolver struct {
+	*Resolver
+}
+
+func (r *Resolver) User() UserResolver {
+	return &userResolver{r}
+}
+
 func buildBadRequestError(ctx context.Context, err error) error {
 	return &gqlerror.Error{
 		Message: err.Error(),
@@ -83,13 +107,29 @@ func buildNotFoundError(ctx context.Context, err error) error {
 	}
 }
 
+func buildTooManyRequestsError(ctx context.Context, err *user.LoginLockedError) error {
+	return &gqlerror.Error{
+		Message: err.Error(),
+		Path:    graphql.GetPath(ctx),
+		Extensions: map[string]interface{}{
+			"code":       http.StatusTooManyRequests,
+			"retryAfter": err.RetrySeconds(),
+		},
+	}
+}
+
 func buildError(ctx context.Context, err error) error {
+	var locked *user.LoginLockedError
+
 	switch {
+	case errors.As(err, &locked):
+		return buildTooManyRequestsError(ctx, locked)
 	case errors.Is(err, user.ErrForbidden):
 		return buildForbiddenError(ctx, err)
 	case errors.Is(err, user.ErrUnauthenticated):
 		return buildUnauthenticatedError(ctx, err)
-	case errors.Is(err, user.ErrValidation):
+	case errors.Is(err, user.ErrValidation) ||
+		errors.Is(err, pagination.ErrInvalidArgs):
 		return buildBadRequestError(ctx, err)
 	case errors.Is(err, user.ErrNotFound):
 		return buildNotFoundError(ctx, err)
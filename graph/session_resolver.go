@@ -0,0 +1,61 @@
+package graph
+
+import (
+	"context"
+
+	"github.com/RianNegreiros/go-graphql-api/internal/user"
+)
+
+func mapSession(s user.Session) *Session {
+	return &Session{
+		ID:         s.ID,
+		Name:       s.Name,
+		Current:    s.Current,
+		LastUsedAt: s.LastUsedAt,
+		ExpiredAt:  s.ExpiredAt,
+		CreatedAt:  s.CreatedAt,
+	}
+}
+
+func mapSessions(sessions []user.Session) []*Session {
+	ss := make([]*Session, len(sessions))
+
+	for i, s := range sessions {
+		ss[i] = mapSession(s)
+	}
+
+	return ss
+}
+
+func (q *queryResolver) MySessions(ctx context.Context) ([]*Session, error) {
+	sessions, err := q.AuthService.Sessions(ctx)
+	if err != nil {
+		return nil, buildError(ctx, err)
+	}
+
+	return mapSessions(sessions), nil
+}
+
+func (m *mutationResolver) Logout(ctx context.Context) (bool, error) {
+	if err := m.AuthService.Logout(ctx); err != nil {
+		return false, buildError(ctx, err)
+	}
+
+	return true, nil
+}
+
+func (m *mutationResolver) RevokeSession(ctx context.Context, id string) (bool, error) {
+	if err := m.AuthService.RevokeSession(ctx, id); err != nil {
+		return false, buildError(ctx, err)
+	}
+
+	return true, nil
+}
+
+func (m *mutationResolver) RevokeAllSessions(ctx context.Context) (bool, error) {
+	if err := m.AuthService.RevokeAllSessions(ctx); err != nil {
+		return false, buildError(ctx, err)
+	}
+
+	return true, nil
+}
@@ -0,0 +1,54 @@
+package graph
+
+import (
+	"context"
+
+	"github.com/RianNegreiros/go-graphql-api/internal/post"
+)
+
+func mapPostChannel(ctx context.Context, posts <-chan post.Post) <-chan *Post {
+	ch := make(chan *Post)
+
+	go func() {
+		defer close(ch)
+
+		for p := range posts {
+			renewDataloaders(ctx)
+
+			select {
+			case ch <- mapPost(p):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return ch
+}
+
+func (s *subscriptionResolver) PostCreated(ctx context.Context) (<-chan *Post, error) {
+	posts, err := s.PostService.PostCreated(ctx)
+	if err != nil {
+		return nil, buildError(ctx, err)
+	}
+
+	return mapPostChannel(ctx, posts), nil
+}
+
+func (s *subscriptionResolver) PostDeleted(ctx context.Context) (<-chan *Post, error) {
+	posts, err := s.PostService.PostDeleted(ctx)
+	if err != nil {
+		return nil, buildError(ctx, err)
+	}
+
+	return mapPostChannel(ctx, posts), nil
+}
+
+func (s *subscriptionResolver) ReplyAdded(ctx context.Context, parentID string) (<-chan *Post, error) {
+	posts, err := s.PostService.ReplyAdded(ctx, parentID)
+	if err != nil {
+		return nil, buildError(ctx, err)
+	}
+
+	return mapPostChannel(ctx, posts), nil
+}
@@ -0,0 +1,69 @@
+package graph
+
+import (
+	"context"
+
+	"github.com/RianNegreiros/go-graphql-api/internal/user"
+)
+
+func (m *mutationResolver) VerifyTwoFactor(ctx context.Context, input VerifyTwoFactorInput) (*AuthResponse, error) {
+	res, err := m.AuthService.VerifyTwoFactor(ctx, user.VerifyTwoFactorInput{
+		Token: input.Token,
+		Code:  input.Code,
+	})
+	if err != nil {
+		return nil, buildError(ctx, err)
+	}
+
+	return mapAuthResponse(res), nil
+}
+
+func (m *mutationResolver) EnableTotp(ctx context.Context) (*TotpSetup, error) {
+	setup, err := m.TwoFactorService.EnableTotp(ctx)
+	if err != nil {
+		return nil, buildError(ctx, err)
+	}
+
+	return &TotpSetup{
+		Secret: setup.Secret,
+		URI:    setup.URI,
+	}, nil
+}
+
+func (m *mutationResolver) ConfirmTotp(ctx context.Context, code string) ([]string, error) {
+	codes, err := m.TwoFactorService.ConfirmTotp(ctx, code)
+	if err != nil {
+		return nil, buildError(ctx, err)
+	}
+
+	return codes, nil
+}
+
+func (m *mutationResolver) DisableTotp(ctx context.Context, code string) (bool, error) {
+	if err := m.TwoFactorService.DisableTotp(ctx, code); err != nil {
+		return false, buildError(ctx, err)
+	}
+
+	return true, nil
+}
+
+func (m *mutationResolver) RegenerateRecoveryCodes(ctx context.Context, code string) ([]string, error) {
+	codes, err := m.TwoFactorService.RegenerateRecoveryCodes(ctx, code)
+	if err != nil {
+		return nil, buildError(ctx, err)
+	}
+
+	return codes, nil
+}
+
+func (q *queryResolver) TwoFactorStatus(ctx context.Context) (*TwoFactorStatus, error) {
+	status, err := q.TwoFactorService.Status(ctx)
+	if err != nil {
+		return nil, buildError(ctx, err)
+	}
+
+	return &TwoFactorStatus{
+		Enabled:                status.Enabled,
+		RecoveryCodesRemaining: status.RecoveryCodesRemaining,
+	}, nil
+}
@@ -2,16 +2,56 @@ package graph
 
 import (
 	"context"
+	"fmt"
+	"strings"
+
+	"github.com/RianNegreiros/go-graphql-api/internal/pagination"
 	"github.com/RianNegreiros/go-graphql-api/internal/transport"
 	"github.com/RianNegreiros/go-graphql-api/internal/user"
 )
 
 func mapUser(user user.UserModel) *User {
 	return &User{
-		ID:        user.ID,
-		Email:     user.Email,
-		Username:  user.Username,
-		CreatedAt: user.CreatedAt,
+		ID:                  user.ID,
+		Email:               &user.Email,
+		Username:            user.Username,
+		DisplayName:         user.DisplayName,
+		Bio:                 user.Bio,
+		Location:            user.Location,
+		Website:             user.Website,
+		AvatarURL:           user.AvatarURL,
+		Role:                mapUserRole(user.Role),
+		EmailVerifiedAt:     user.EmailVerifiedAt,
+		CreatedAt:           user.CreatedAt,
+		DeletionScheduledAt: user.DeletionScheduledAt,
+	}
+}
+
+func mapRole(role Role) user.Role {
+	return user.Role(strings.ToLower(role.String()))
+}
+
+func mapUserRole(role user.Role) Role {
+	if role == "" {
+		return RoleUser
+	}
+
+	return Role(strings.ToUpper(string(role)))
+}
+
+func mapUserConnection(page pagination.Page[user.UserModel]) *UserConnection {
+	edges := make([]*UserEdge, len(page.Edges))
+
+	for i, e := range page.Edges {
+		edges[i] = &UserEdge{
+			Cursor: e.Cursor.Encode(),
+			Node:   mapUser(e.Node),
+		}
+	}
+
+	return &UserConnection{
+		Edges:    edges,
+		PageInfo: mapPageInfo(page),
 	}
 }
 
@@ -21,7 +61,122 @@ func (r *queryResolver) Me(ctx context.Context) (*User, error) {
 		return nil, user.ErrUnauthenticated
 	}
 
-	return mapUser(user.UserModel{
-		ID: userID,
-	}), nil
+	return DataloaderFor(ctx).UserByID.Load(userID)
+}
+
+func (r *queryResolver) User(ctx context.Context, id *string, username *string) (*User, error) {
+	var (
+		u   user.UserModel
+		err error
+	)
+
+	switch {
+	case id != nil && username == nil:
+		u, err = r.UserService.GetByID(ctx, *id)
+	case username != nil && id == nil:
+		u, err = r.UserService.GetByUsername(ctx, *username)
+	default:
+		err = fmt.Errorf("%w: exactly one of id or username is required", user.ErrValidation)
+	}
+
+	if err != nil {
+		return nil, buildError(ctx, err)
+	}
+
+	return mapUser(u), nil
+}
+
+func (m *mutationResolver) UpdateProfile(ctx context.Context, input UpdateProfileInput) (*User, error) {
+	u, err := m.UserService.UpdateProfile(ctx, user.UpdateProfileInput{
+		DisplayName: input.DisplayName,
+		Bio:         input.Bio,
+		Location:    input.Location,
+		Website:     input.Website,
+		AvatarURL:   input.AvatarURL,
+	})
+	if err != nil {
+		return nil, buildError(ctx, err)
+	}
+
+	return mapUser(u), nil
+}
+
+func (u *userResolver) Followers(ctx context.Context, obj *User, first *int, after *string, last *int, before *string) (*UserConnection, error) {
+	args, err := pagination.Input{
+		First:  first,
+		After:  after,
+		Last:   last,
+		Before: before,
+	}.Args()
+	if err != nil {
+		return nil, buildError(ctx, err)
+	}
+
+	return DataloaderFor(ctx).FollowersByUserID(args).Load(obj.ID)
+}
+
+func (u *userResolver) Following(ctx context.Context, obj *User, first *int, after *string, last *int, before *string) (*UserConnection, error) {
+	args, err := pagination.Input{
+		First:  first,
+		After:  after,
+		Last:   last,
+		Before: before,
+	}.Args()
+	if err != nil {
+		return nil, buildError(ctx, err)
+	}
+
+	return DataloaderFor(ctx).FollowingByUserID(args).Load(obj.ID)
+}
+
+func (u *userResolver) FollowerCount(ctx context.Context, obj *User) (int, error) {
+	return DataloaderFor(ctx).FollowerCountByUserID.Load(obj.ID)
+}
+
+func (u *userResolver) FollowingCount(ctx context.Context, obj *User) (int, error) {
+	return DataloaderFor(ctx).FollowingCountByUserID.Load(obj.ID)
+}
+
+func (u *userResolver) ViewerIsFollowing(ctx context.Context, obj *User) (bool, error) {
+	if _, err := transport.GetUserIDFromContext(ctx); err != nil {
+		return false, nil
+	}
+
+	return DataloaderFor(ctx).ViewerIsFollowingByUserID.Load(obj.ID)
+}
+
+func (m *mutationResolver) FollowUser(ctx context.Context, userID string) (*User, error) {
+	u, err := m.UserService.Follow(ctx, userID)
+	if err != nil {
+		return nil, buildError(ctx, err)
+	}
+
+	return mapUser(u), nil
+}
+
+func (m *mutationResolver) UnfollowUser(ctx context.Context, userID string) (*User, error) {
+	u, err := m.UserService.Unfollow(ctx, userID)
+	if err != nil {
+		return nil, buildError(ctx, err)
+	}
+
+	return mapUser(u), nil
+}
+
+func (m *mutationResolver) GrantRole(ctx context.Context, userID string, role Role) (*User, error) {
+	u, err := m.UserService.GrantRole(ctx, userID, mapRole(role))
+	if err != nil {
+		return nil, buildError(ctx, err)
+	}
+
+	return mapUser(u), nil
+}
+
+func (m *mutationResolver) RevokeRole(ctx context.Context, userID string, role Role) (*User, error) {
+	u, err := m.UserService.RevokeRole(ctx, userID, mapRole(role))
+	if err != nil {
+		return nil, buildError(ctx, err)
+	}
+
+	return mapUser(u), nil
 }
@@ -0,0 +1,193 @@
+// Package dataexport builds the archive users download to get a copy of
+// everything stored about them.
+package dataexport
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/RianNegreiros/go-graphql-api/internal/post"
+	"github.com/RianNegreiros/go-graphql-api/internal/user"
+)
+
+var ErrUnsupportedFormat = fmt.Errorf("%w: unsupported export format", user.ErrValidation)
+
+type Format string
+
+const (
+	// FormatJSON is a single JSON document.
+	FormatJSON Format = "json"
+	// FormatZIP is a ZIP archive with a JSON file per kind of data.
+	FormatZIP Format = "zip"
+)
+
+type Service interface {
+	// Export gathers the data of the current user in format.
+	Export(ctx context.Context, format Format) (Archive, error)
+}
+
+type Archive struct {
+	Filename    string
+	ContentType string
+	Data        []byte
+}
+
+type Export struct {
+	ExportedAt time.Time `json:"exportedAt"`
+	Profile    Profile   `json:"profile"`
+	Posts      []Post    `json:"posts"`
+	Replies    []Post    `json:"replies"`
+	Sessions   []Session `json:"sessions"`
+}
+
+type Profile struct {
+	ID                  string     `json:"id"`
+	Username            string     `json:"username"`
+	Email               string     `json:"email"`
+	EmailVerifiedAt     *time.Time `json:"emailVerifiedAt"`
+	DisplayName         string     `json:"displayName"`
+	Bio                 string     `json:"bio"`
+	Location            string     `json:"location"`
+	Website             string     `json:"website"`
+	AvatarURL           string     `json:"avatarUrl"`
+	Role                user.Role  `json:"role"`
+	DeletionScheduledAt *time.Time `json:"deletionScheduledAt"`
+	CreatedAt           time.Time  `json:"createdAt"`
+}
+
+type Post struct {
+	ID        string    `json:"id"`
+	ParentID  *string   `json:"parentId,omitempty"`
+	Body      string    `json:"body"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+type Session struct {
+	ID         string    `json:"id"`
+	Name       string    `json:"name"`
+	LastUsedAt time.Time `json:"lastUsedAt"`
+	ExpiredAt  time.Time `json:"expiredAt"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
+// New splits the posts of u between top level posts and replies.
+func New(u user.UserModel, posts []post.Post, sessions []user.Session, now time.Time) Export {
+	e := Export{
+		ExportedAt: now.UTC(),
+		Profile: Profile{
+			ID:                  u.ID,
+			Username:            u.Username,
+			Email:               u.Email,
+			EmailVerifiedAt:     u.EmailVerifiedAt,
+			DisplayName:         u.DisplayName,
+			Bio:                 u.Bio,
+			Location:            u.Location,
+			Website:             u.Website,
+			AvatarURL:           u.AvatarURL,
+			Role:                u.Role,
+			DeletionScheduledAt: u.DeletionScheduledAt,
+			CreatedAt:           u.CreatedAt,
+		},
+		Posts:    []Post{},
+		Replies:  []Post{},
+		Sessions: make([]Session, len(sessions)),
+	}
+
+	for _, p := range posts {
+		ep := Post{
+			ID:        p.ID,
+			ParentID:  p.ParentID,
+			Body:      p.Body,
+			CreatedAt: p.CreatedAt,
+			UpdatedAt: p.UpdatedAt,
+		}
+
+		if p.ParentID == nil {
+			e.Posts = append(e.Posts, ep)
+		} else {
+			e.Replies = append(e.Replies, ep)
+		}
+	}
+
+	for i, s := range sessions {
+		e.Sessions[i] = Session{
+			ID:         s.ID,
+			Name:       s.Name,
+			LastUsedAt: s.LastUsedAt,
+			ExpiredAt:  s.ExpiredAt,
+			CreatedAt:  s.CreatedAt,
+		}
+	}
+
+	return e
+}
+
+func (e Export) Archive(format Format) (Archive, error) {
+	name := fmt.Sprintf("%s-%s", e.Profile.Username, e.ExportedAt.Format("20060102"))
+
+	switch format {
+	case FormatJSON:
+		data, err := json.MarshalIndent(e, "", "  ")
+		if err != nil {
+			return Archive{}, fmt.Errorf("error encoding export: %w", err)
+		}
+
+		return Archive{Filename: name + ".json", ContentType: "application/json", Data: data}, nil
+	case FormatZIP:
+		data, err := e.zip()
+		if err != nil {
+			return Archive{}, err
+		}
+
+		return Archive{Filename: name + ".zip", ContentType: "application/zip", Data: data}, nil
+	default:
+		return Archive{}, ErrUnsupportedFormat
+	}
+}
+
+func (e Export) zip() ([]byte, error) {
+	files := []struct {
+		name string
+		v    interface{}
+	}{
+		{"profile.json", e.Profile},
+		{"posts.json", e.Posts},
+		{"replies.json", e.Replies},
+		{"sessions.json", e.Sessions},
+	}
+
+	var buf bytes.Buffer
+
+	zw := zip.NewWriter(&buf)
+
+	for _, f := range files {
+		w, err := zw.CreateHeader(&zip.FileHeader{
+			Name:     f.name,
+			Method:   zip.Deflate,
+			Modified: e.ExportedAt,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error creating %s: %w", f.name, err)
+		}
+
+		data, err := json.MarshalIndent(f.v, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("error encoding %s: %w", f.name, err)
+		}
+
+		if _, err := w.Write(data); err != nil {
+			return nil, fmt.Errorf("error writing %s: %w", f.name, err)
+		}
+	}
+
+	if err := zw.Close(); err != nil {
+		return nil, fmt.Errorf("error closing archive: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
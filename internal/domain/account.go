@@ -0,0 +1,233 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/RianNegreiros/go-graphql-api/internal/jwt"
+	"github.com/RianNegreiros/go-graphql-api/internal/mailer"
+	"github.com/RianNegreiros/go-graphql-api/internal/randtoken"
+	"github.com/RianNegreiros/go-graphql-api/internal/transport"
+	"github.com/RianNegreiros/go-graphql-api/internal/user"
+)
+
+type AccountService struct {
+	UserRepo          user.UserRepo
+	RefreshTokenRepo  jwt.RefreshTokenRepo
+	PasswordResetRepo user.PasswordResetRepo
+	AuthTokenService  user.AuthTokenService
+	Mailer            mailer.Mailer
+	PasswordHasher    user.PasswordHasher
+}
+
+func NewAccountService(ur user.UserRepo, rr jwt.RefreshTokenRepo, pr user.PasswordResetRepo, ts user.AuthTokenService, m mailer.Mailer, ph user.PasswordHasher) *AccountService {
+	return &AccountService{
+		UserRepo:          ur,
+		RefreshTokenRepo:  rr,
+		PasswordResetRepo: pr,
+		AuthTokenService:  ts,
+		Mailer:            m,
+		PasswordHasher:    ph,
+	}
+}
+
+// RequestPasswordReset emails a reset link to the account with email. It
+// succeeds for unknown emails too, so it can't be used to find accounts.
+func (as *AccountService) RequestPasswordReset(ctx context.Context, email string) error {
+	email = strings.ToLower(strings.TrimSpace(email))
+
+	u, err := as.UserRepo.GetByEmail(ctx, email)
+	if err != nil {
+		switch {
+		case errors.Is(err, user.ErrNotFound):
+			return nil
+		default:
+			return err
+		}
+	}
+
+	// Past this point the account exists, so failures are logged rather
+	// than returned.
+	token, hash, err := randtoken.Generate()
+	if err != nil {
+		log.Printf("error generating password reset token: %v", err)
+		return nil
+	}
+
+	if _, err := as.PasswordResetRepo.Create(ctx, u.ID, hash); err != nil {
+		log.Printf("error creating password reset token: %v", err)
+		return nil
+	}
+
+	link := user.PasswordResetURL + "?token=" + url.QueryEscape(token)
+
+	sendInBackground(ctx, as.Mailer, mailer.Message{
+		To:      u.Email,
+		Subject: "Reset your password",
+		Body: fmt.Sprintf("Hi %s,\r\n\r\nFollow this link to choose a new password:\r\n\r\n%s\r\n\r\n"+
+			"The link expires in %s. If you didn't ask to reset your password, you can ignore this email.\r\n",
+			u.Username, link, user.PasswordResetTokenLifeTime),
+	})
+
+	return nil
+}
+
+// sendInBackground sends msg without making the caller wait for it, so that
+// neither the time a request takes nor a failure to send tells whether an
+// email went out. Failures are logged.
+func sendInBackground(ctx context.Context, m mailer.Mailer, msg mailer.Message) {
+	ctx = context.WithoutCancel(ctx)
+
+	go func() {
+		if err := m.Send(ctx, msg); err != nil {
+			log.Printf("error sending %q email: %v", msg.Subject, err)
+		}
+	}()
+}
+
+// ResetPassword sets a new password using a reset token and signs the user
+// out of every session.
+func (as *AccountService) ResetPassword(ctx context.Context, input user.ResetPasswordInput) error {
+	input.Sanitize()
+
+	if err := input.Validate(); err != nil {
+		return err
+	}
+
+	password, err := as.PasswordHasher.Hash(input.Password)
+	if err != nil {
+		return err
+	}
+
+	if _, err := as.PasswordResetRepo.Reset(ctx, randtoken.Hash(input.Token), password); err != nil {
+		switch {
+		case errors.Is(err, user.ErrNotFound):
+			return user.ErrInvalidResetToken
+		default:
+			return err
+		}
+	}
+
+	return nil
+}
+
+// SendVerificationEmail emails u a link proving they own their address.
+func (as *AccountService) SendVerificationEmail(ctx context.Context, u user.UserModel) error {
+	token, err := as.AuthTokenService.CreateEmailVerificationToken(ctx, u)
+	if err != nil {
+		return user.ErrGenerateToken
+	}
+
+	link := user.EmailVerificationURL + "?token=" + url.QueryEscape(token)
+
+	return as.Mailer.Send(ctx, mailer.Message{
+		To:      u.Email,
+		Subject: "Verify your email",
+		Body: fmt.Sprintf("Hi %s,\r\n\r\nFollow this link to verify your email address:\r\n\r\n%s\r\n\r\n"+
+			"The link expires in %s.\r\n",
+			u.Username, link, jwt.EmailVerificationTokenLifeTime),
+	})
+}
+
+// SendEmailChangeConfirmation emails newEmail a link confirming u wants to
+// switch to it.
+func (as *AccountService) SendEmailChangeConfirmation(ctx context.Context, u user.UserModel, newEmail string) error {
+	token, err := as.AuthTokenService.CreateEmailChangeToken(ctx, u, newEmail)
+	if err != nil {
+		return user.ErrGenerateToken
+	}
+
+	link := user.EmailChangeURL + "?token=" + url.QueryEscape(token)
+
+	return as.Mailer.Send(ctx, mailer.Message{
+		To:      newEmail,
+		Subject: "Confirm your new email",
+		Body: fmt.Sprintf("Hi %s,\r\n\r\nFollow this link to use this address for your account:\r\n\r\n%s\r\n\r\n"+
+			"The link expires in %s. If you didn't ask to change your email, you can ignore this email.\r\n",
+			u.Username, link, jwt.EmailVerificationTokenLifeTime),
+	})
+}
+
+// VerifyEmail marks the email of the user the token was issued for as
+// verified. Tokens issued before the user changed their email are rejected.
+func (as *AccountService) VerifyEmail(ctx context.Context, token string) (user.UserModel, error) {
+	t, err := as.AuthTokenService.ParseEmailVerificationToken(ctx, strings.TrimSpace(token))
+	if err != nil {
+		return user.UserModel{}, user.ErrInvalidVerificationToken
+	}
+
+	u, err := as.UserRepo.GetByID(ctx, t.Sub)
+	if err != nil {
+		switch {
+		case errors.Is(err, user.ErrNotFound):
+			return user.UserModel{}, user.ErrInvalidVerificationToken
+		default:
+			return user.UserModel{}, err
+		}
+	}
+
+	if u.Email != t.Email {
+		return user.UserModel{}, user.ErrInvalidVerificationToken
+	}
+
+	if u.IsEmailVerified() {
+		return u, nil
+	}
+
+	return as.UserRepo.MarkEmailVerified(ctx, u.ID)
+}
+
+func (as *AccountService) ResendVerification(ctx context.Context) error {
+	currentUserID, err := transport.GetUserIDFromContext(ctx)
+	if err != nil {
+		return user.ErrUnauthenticated
+	}
+
+	if err := requireSession(ctx); err != nil {
+		return err
+	}
+
+	u, err := as.UserRepo.GetByID(ctx, currentUserID)
+	if err != nil {
+		return err
+	}
+
+	if u.IsEmailVerified() {
+		return user.ErrEmailAlreadyVerified
+	}
+
+	return as.SendVerificationEmail(ctx, u)
+}
+
+// DeleteScheduledAccounts anonymizes the accounts whose grace period is over
+// and returns how many were. It's run periodically by a background job; an
+// account that fails is left for the next run without holding up the others.
+func (as *AccountService) DeleteScheduledAccounts(ctx context.Context) (int, error) {
+	ids, err := as.UserRepo.GetDueForDeletion(ctx, time.Now())
+	if err != nil {
+		return 0, err
+	}
+
+	var (
+		n    int
+		errs []error
+	)
+
+	for _, id := range ids {
+		if err := as.UserRepo.Anonymize(ctx, id); err != nil {
+			log.Printf("error deleting account %s: %v", id, err)
+			errs = append(errs, fmt.Errorf("account %s: %w", id, err))
+
+			continue
+		}
+
+		n++
+	}
+
+	return n, errors.Join(errs...)
+}
@@ -4,22 +4,48 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log"
+	"net/url"
+	"strings"
+	"time"
 
+	"github.com/RianNegreiros/go-graphql-api/internal/jwt"
+	"github.com/RianNegreiros/go-graphql-api/internal/mailer"
+	"github.com/RianNegreiros/go-graphql-api/internal/randtoken"
+	"github.com/RianNegreiros/go-graphql-api/internal/transport"
 	"github.com/RianNegreiros/go-graphql-api/internal/user"
-	"golang.org/x/crypto/bcrypt"
+	"github.com/RianNegreiros/go-graphql-api/internal/uuid"
 )
 
-var passwordCost = bcrypt.DefaultCost
+var sessionNameMaxLength = 255
 
 type AuthService struct {
 	AuthTokenService user.AuthTokenService
 	UserRepo         user.UserRepo
+	RefreshTokenRepo jwt.RefreshTokenRepo
+	EmailVerifier    user.EmailVerifier
+	LoginGuard       user.LoginGuard
+	TwoFactor        user.TwoFactorVerifier
+	PasswordHasher   user.PasswordHasher
+	Passkeys         user.PasskeyVerifier
+	LoginLinkRepo    user.LoginLinkRepo
+	Mailer           mailer.Mailer
+	Identities       user.IdentityVerifier
 }
 
-func NewAuthService(ur user.UserRepo, service user.AuthTokenService) *AuthService {
+func NewAuthService(ur user.UserRepo, service user.AuthTokenService, rr jwt.RefreshTokenRepo, ev user.EmailVerifier, lg user.LoginGuard, tf user.TwoFactorVerifier, ph user.PasswordHasher, pk user.PasskeyVerifier, lr user.LoginLinkRepo, m mailer.Mailer, iv user.IdentityVerifier) *AuthService {
 	return &AuthService{
 		UserRepo:         ur,
 		AuthTokenService: service,
+		RefreshTokenRepo: rr,
+		EmailVerifier:    ev,
+		LoginGuard:       lg,
+		TwoFactor:        tf,
+		PasswordHasher:   ph,
+		Passkeys:         pk,
+		LoginLinkRepo:    lr,
+		Mailer:           m,
+		Identities:       iv,
 	}
 }
 
@@ -43,57 +69,682 @@ func (as *AuthService) Register(ctx context.Context, input user.RegisterInput) (
 		Username: input.Username,
 	}
 
-	hashPassword, err := bcrypt.GenerateFromPassword([]byte(input.Password), passwordCost)
+	password, err := as.PasswordHasher.Hash(input.Password)
 	if err != nil {
-		return user.AuthResponse{}, fmt.Errorf("error hashing password: %v", err)
+		return user.AuthResponse{}, err
 	}
 
-	u.Password = string(hashPassword)
+	u.Password = password
 
 	u, err = as.UserRepo.Create(ctx, u)
 	if err != nil {
 		return user.AuthResponse{}, fmt.Errorf("error creating user: %v", err)
 	}
 
-	accessToken, err := as.AuthTokenService.CreateAccessToken(ctx, u)
-	if err != nil {
-		return user.AuthResponse{}, user.ErrGenerateToken
+	// The account exists at this point, so a mail failure shouldn't fail the
+	// registration; the user can ask for the email again.
+	if err := as.EmailVerifier.SendVerificationEmail(ctx, u); err != nil {
+		log.Printf("error sending verification email: %v", err)
 	}
 
-	return user.AuthResponse{
-		AccessToken: accessToken,
-		User:        u,
-	}, nil
+	return as.createAuthResponse(ctx, u)
 }
 
-func (as *AuthService) Login(ctx context.Context, input user.LoginInput) (user.AuthResponse, error) {
+func (as *AuthService) Login(ctx context.Context, input user.LoginInput) (user.LoginResponse, error) {
 	input.Sanitize()
 
 	if err := input.Validate(); err != nil {
+		return user.LoginResponse{}, err
+	}
+
+	u, err := as.checkPassword(ctx, input.Email, input.Password)
+	if err != nil {
+		switch {
+		case errors.Is(err, user.ErrInvalidPassword):
+			return user.LoginResponse{}, user.ErrInvalidCredentials
+		default:
+			return user.LoginResponse{}, err
+		}
+	}
+
+	// The plain password is only known here, so this is the one chance to
+	// move the hash to the current algorithm. Failing to do so is no reason
+	// to refuse the login; it's tried again on the next one.
+	if as.PasswordHasher.NeedsRehash(u.Password) {
+		if err := as.rehashPassword(ctx, u, input.Password); err != nil {
+			log.Printf("error rehashing password: %v", err)
+		}
+	}
+
+	enabled, err := as.TwoFactor.IsEnabled(ctx, u.ID)
+	if err != nil {
+		return user.LoginResponse{}, err
+	}
+
+	// The failures are only forgotten once the second step passed too, or
+	// logging in again would reset the budget for guessing codes. The right
+	// password still doesn't count as one.
+	if enabled {
+		if err := as.LoginGuard.Release(ctx, u.Email, transport.GetClientIPFromContext(ctx)); err != nil {
+			return user.LoginResponse{}, err
+		}
+
+		return as.createTwoFactorChallenge(ctx, u)
+	}
+
+	if err := as.LoginGuard.Succeed(ctx, u.Email, transport.GetClientIPFromContext(ctx)); err != nil {
+		return user.LoginResponse{}, err
+	}
+
+	res, err := as.createAuthResponse(ctx, u)
+	if err != nil {
+		return user.LoginResponse{}, err
+	}
+
+	return user.LoginResponse{AuthResponse: res}, nil
+}
+
+func (as *AuthService) createTwoFactorChallenge(ctx context.Context, u user.UserModel) (user.LoginResponse, error) {
+	token, err := as.AuthTokenService.CreateTwoFactorChallengeToken(ctx, u)
+	if err != nil {
+		return user.LoginResponse{}, user.ErrGenerateToken
+	}
+
+	return user.LoginResponse{
+		Challenge: &user.TwoFactorChallenge{
+			Token:     token,
+			ExpiredAt: jwt.Now().Add(jwt.TwoFactorChallengeLifeTime),
+		},
+	}, nil
+}
+
+// VerifyTwoFactor is the second step of a login for users with two-factor
+// authentication enabled, trading the challenge from Login and a code for
+// tokens.
+func (as *AuthService) VerifyTwoFactor(ctx context.Context, input user.VerifyTwoFactorInput) (user.AuthResponse, error) {
+	t, err := as.AuthTokenService.ParseTwoFactorChallengeToken(ctx, strings.TrimSpace(input.Token))
+	if err != nil {
+		return user.AuthResponse{}, user.ErrInvalidChallenge
+	}
+
+	u, err := as.UserRepo.GetByID(ctx, t.Sub)
+	if err != nil {
+		switch {
+		case errors.Is(err, user.ErrNotFound):
+			return user.AuthResponse{}, user.ErrInvalidChallenge
+		default:
+			return user.AuthResponse{}, err
+		}
+	}
+
+	if err := as.TwoFactor.Verify(ctx, u.ID, input.Code); err != nil {
+		return user.AuthResponse{}, err
+	}
+
+	return as.createAuthResponse(ctx, u)
+}
+
+// LoginWithPasskey signs in the user whose passkey answered a challenge from
+// PasskeyService.BeginLogin. Passkeys verify the user on the authenticator,
+// so no second factor is asked for.
+func (as *AuthService) LoginWithPasskey(ctx context.Context, input user.PasskeyLoginInput) (user.AuthResponse, error) {
+	userID, err := as.Passkeys.VerifyLogin(ctx, input)
+	if err != nil {
 		return user.AuthResponse{}, err
 	}
 
-	u, err := as.UserRepo.GetByEmail(ctx, input.Email)
+	u, err := as.UserRepo.GetByID(ctx, userID)
 	if err != nil {
 		switch {
 		case errors.Is(err, user.ErrNotFound):
-			return user.AuthResponse{}, user.ErrInvalidCredentials
+			return user.AuthResponse{}, user.ErrInvalidPasskey
 		default:
 			return user.AuthResponse{}, err
 		}
 	}
 
-	if err := bcrypt.CompareHashAndPassword([]byte(u.Password), []byte(input.Password)); err != nil {
-		return user.AuthResponse{}, user.ErrInvalidCredentials
+	return as.createAuthResponse(ctx, u)
+}
+
+// RequestLoginLink is throttled with the LoginGuard like a password attempt,
+// so it can't be used to flood an inbox. The attempt is only taken back once
+// the link is used.
+func (as *AuthService) RequestLoginLink(ctx context.Context, email string) error {
+	email = strings.ToLower(strings.TrimSpace(email))
+	ip := transport.GetClientIPFromContext(ctx)
+
+	// A locked account or client can't get around it with a link. Asking for
+	// one isn't a password guess though, so the attempt is taken back.
+	if err := as.LoginGuard.Allow(ctx, email, ip); err != nil {
+		return err
+	}
+
+	if err := as.LoginGuard.Release(ctx, email, ip); err != nil {
+		return err
 	}
 
-	accessToken, err := as.AuthTokenService.CreateAccessToken(ctx, u)
+	u, err := as.UserRepo.GetByEmail(ctx, email)
+	if err != nil {
+		switch {
+		case errors.Is(err, user.ErrNotFound):
+			return nil
+		default:
+			return err
+		}
+	}
+
+	// Past this point the account exists, so failures are logged rather
+	// than returned.
+	token, hash, err := randtoken.Generate()
+	if err != nil {
+		log.Printf("error generating login link token: %v", err)
+		return nil
+	}
+
+	if _, err := as.LoginLinkRepo.Create(ctx, u.ID, hash); err != nil {
+		log.Printf("error creating login link token: %v", err)
+		return nil
+	}
+
+	link := user.LoginLinkURL + "?token=" + url.QueryEscape(token)
+
+	sendInBackground(ctx, as.Mailer, mailer.Message{
+		To:      u.Email,
+		Subject: "Your login link",
+		Body: fmt.Sprintf("Hi %s,\r\n\r\nFollow this link to log in:\r\n\r\n%s\r\n\r\n"+
+			"The link expires in %s and works once. If you didn't ask to log in, you can ignore this email.\r\n",
+			u.Username, link, user.LoginLinkTokenLifeTime),
+	})
+
+	return nil
+}
+
+// ConsumeLoginLink trades a token from RequestLoginLink for tokens. Opening
+// the link proves the user owns their email, so it gets verified too. A link
+// is only one factor, so users with two-factor authentication are refused.
+func (as *AuthService) ConsumeLoginLink(ctx context.Context, token string) (user.AuthResponse, error) {
+	t, err := as.LoginLinkRepo.Consume(ctx, randtoken.Hash(strings.TrimSpace(token)))
+	if err != nil {
+		switch {
+		case errors.Is(err, user.ErrNotFound):
+			return user.AuthResponse{}, user.ErrInvalidLoginLink
+		default:
+			return user.AuthResponse{}, err
+		}
+	}
+
+	u, err := as.UserRepo.GetByID(ctx, t.UserID)
+	if err != nil {
+		switch {
+		case errors.Is(err, user.ErrNotFound):
+			return user.AuthResponse{}, user.ErrInvalidLoginLink
+		default:
+			return user.AuthResponse{}, err
+		}
+	}
+
+	enabled, err := as.TwoFactor.IsEnabled(ctx, u.ID)
+	if err != nil {
+		return user.AuthResponse{}, err
+	}
+
+	if enabled {
+		return user.AuthResponse{}, user.ErrLoginLinkTwoFactor
+	}
+
+	if !u.IsEmailVerified() {
+		if u, err = as.UserRepo.MarkEmailVerified(ctx, u.ID); err != nil {
+			return user.AuthResponse{}, err
+		}
+	}
+
+	if err := as.LoginGuard.Succeed(ctx, u.Email, transport.GetClientIPFromContext(ctx)); err != nil {
+		return user.AuthResponse{}, err
+	}
+
+	return as.createAuthResponse(ctx, u)
+}
+
+// LoginWithProvider signs in the user of the identity the provider sent back
+// to the callback. The provider only stands in for the password, so users
+// with two-factor authentication still get a challenge.
+func (as *AuthService) LoginWithProvider(ctx context.Context, input user.SocialLoginInput) (user.LoginResponse, error) {
+	userID, err := as.Identities.VerifyLogin(ctx, input)
+	if err != nil {
+		return user.LoginResponse{}, err
+	}
+
+	u, err := as.UserRepo.GetByID(ctx, userID)
+	if err != nil {
+		switch {
+		case errors.Is(err, user.ErrNotFound):
+			return user.LoginResponse{}, user.ErrInvalidSocialLogin
+		default:
+			return user.LoginResponse{}, err
+		}
+	}
+
+	enabled, err := as.TwoFactor.IsEnabled(ctx, u.ID)
+	if err != nil {
+		return user.LoginResponse{}, err
+	}
+
+	if enabled {
+		return as.createTwoFactorChallenge(ctx, u)
+	}
+
+	res, err := as.createAuthResponse(ctx, u)
+	if err != nil {
+		return user.LoginResponse{}, err
+	}
+
+	return user.LoginResponse{AuthResponse: res}, nil
+}
+
+// checkPassword loads the user with email and checks password against their
+// hash. The attempt is reserved with the LoginGuard first, which refuses to
+// check at all while the account or client IP is locked, and stays counted
+// as a failure unless the password matches. Unknown emails count as failures
+// too, so locking doesn't reveal which accounts exist. Callers report the
+// success once the user is fully authenticated.
+func (as *AuthService) checkPassword(ctx context.Context, email, password string) (user.UserModel, error) {
+	ip := transport.GetClientIPFromContext(ctx)
+
+	if err := as.LoginGuard.Allow(ctx, email, ip); err != nil {
+		return user.UserModel{}, err
+	}
+
+	u, err := as.UserRepo.GetByEmail(ctx, email)
+	if err != nil && !errors.Is(err, user.ErrNotFound) {
+		if err := as.LoginGuard.Release(ctx, email, ip); err != nil {
+			log.Printf("error releasing login attempt: %v", err)
+		}
+
+		return user.UserModel{}, err
+	}
+
+	match := false
+
+	// A hash which can't be read counts as a wrong password, the user can
+	// still get in by resetting it.
+	if err == nil {
+		match, err = as.PasswordHasher.Verify(u.Password, password)
+		if err != nil {
+			log.Printf("error verifying password of user %s: %v", u.ID, err)
+		}
+	}
+
+	if !match {
+		return user.UserModel{}, user.ErrInvalidPassword
+	}
+
+	return u, nil
+}
+
+func (as *AuthService) rehashPassword(ctx context.Context, u user.UserModel, password string) error {
+	hash, err := as.PasswordHasher.Hash(password)
+	if err != nil {
+		return err
+	}
+
+	return as.UserRepo.UpdatePassword(ctx, u.ID, hash)
+}
+
+func (as *AuthService) RefreshToken(ctx context.Context, token string) (user.AuthResponse, error) {
+	authToken, err := as.AuthTokenService.ParseToken(ctx, token)
+	if err != nil {
+		return user.AuthResponse{}, user.ErrInvalidToken
+	}
+
+	if !uuid.Validate(authToken.ID) {
+		return user.AuthResponse{}, user.ErrInvalidToken
+	}
+
+	rt, err := as.RefreshTokenRepo.GetByID(ctx, authToken.ID)
+	if err != nil {
+		switch {
+		case errors.Is(err, user.ErrNotFound):
+			return user.AuthResponse{}, user.ErrInvalidToken
+		default:
+			return user.AuthResponse{}, err
+		}
+	}
+
+	if rt.UserID != authToken.Sub {
+		return user.AuthResponse{}, user.ErrInvalidToken
+	}
+
+	if rt.IsRevoked() {
+		return user.AuthResponse{}, as.revokeRefreshTokenFamily(ctx, rt)
+	}
+
+	if rt.IsExpired() {
+		return user.AuthResponse{}, user.ErrInvalidToken
+	}
+
+	u, err := as.UserRepo.GetByID(ctx, rt.UserID)
+	if err != nil {
+		switch {
+		case errors.Is(err, user.ErrNotFound):
+			return user.AuthResponse{}, user.ErrInvalidToken
+		default:
+			return user.AuthResponse{}, err
+		}
+	}
+
+	next, err := as.RefreshTokenRepo.Rotate(ctx, rt.ID, jwt.CreateRefreshTokenParams{
+		Sub:      rt.UserID,
+		Name:     rt.Name,
+		FamilyID: rt.FamilyID,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, jwt.ErrRefreshTokenRevoked):
+			return user.AuthResponse{}, as.revokeRefreshTokenFamily(ctx, rt)
+		default:
+			return user.AuthResponse{}, err
+		}
+	}
+
+	return as.signAuthResponse(ctx, u, next)
+}
+
+// revokeRefreshTokenFamily is called when an already rotated refresh token is
+// presented again. The token was most likely stolen, so every token issued
+// from the same login is revoked.
+func (as *AuthService) revokeRefreshTokenFamily(ctx context.Context, rt jwt.RefreshToken) error {
+	if err := as.RefreshTokenRepo.RevokeFamily(ctx, rt.FamilyID); err != nil {
+		return err
+	}
+
+	return user.ErrInvalidToken
+}
+
+func (as *AuthService) createAuthResponse(ctx context.Context, u user.UserModel) (user.AuthResponse, error) {
+	rt, err := as.RefreshTokenRepo.Create(ctx, jwt.CreateRefreshTokenParams{
+		Sub:  u.ID,
+		Name: sessionName(ctx),
+	})
+	if err != nil {
+		return user.AuthResponse{}, fmt.Errorf("error creating refresh token: %v", err)
+	}
+
+	return as.signAuthResponse(ctx, u, rt)
+}
+
+func (as *AuthService) signAuthResponse(ctx context.Context, u user.UserModel, rt jwt.RefreshToken) (user.AuthResponse, error) {
+	accessToken, err := as.AuthTokenService.CreateAccessToken(ctx, u, rt.FamilyID)
+	if err != nil {
+		return user.AuthResponse{}, user.ErrGenerateToken
+	}
+
+	refreshToken, err := as.AuthTokenService.CreateRefreshToken(ctx, u, rt.ID)
 	if err != nil {
 		return user.AuthResponse{}, user.ErrGenerateToken
 	}
 
 	return user.AuthResponse{
-		AccessToken: accessToken,
-		User:        u,
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		User:         u,
 	}, nil
 }
+
+func sessionName(ctx context.Context) string {
+	name := transport.GetUserAgentFromContext(ctx)
+
+	if len(name) > sessionNameMaxLength {
+		name = name[:sessionNameMaxLength]
+	}
+
+	return strings.ToValidUTF8(name, "")
+}
+
+func (as *AuthService) Sessions(ctx context.Context) ([]user.Session, error) {
+	currentUserID, err := transport.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, user.ErrUnauthenticated
+	}
+
+	if err := requireSession(ctx); err != nil {
+		return nil, err
+	}
+
+	currentSessionID, _ := transport.GetSessionIDFromContext(ctx)
+
+	tokens, err := as.RefreshTokenRepo.GetActiveByUserID(ctx, currentUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	sessions := make([]user.Session, len(tokens))
+
+	for i, rt := range tokens {
+		sessions[i] = user.Session{
+			ID:         rt.FamilyID,
+			Name:       rt.Name,
+			Current:    rt.FamilyID == currentSessionID,
+			LastUsedAt: rt.LastUsedAt,
+			ExpiredAt:  rt.ExpiredAt,
+			CreatedAt:  rt.CreatedAt,
+		}
+	}
+
+	return sessions, nil
+}
+
+func (as *AuthService) Logout(ctx context.Context) error {
+	if _, err := transport.GetUserIDFromContext(ctx); err != nil {
+		return user.ErrUnauthenticated
+	}
+
+	currentSessionID, err := transport.GetSessionIDFromContext(ctx)
+	if err != nil {
+		return user.ErrUnauthenticated
+	}
+
+	return as.RefreshTokenRepo.RevokeFamily(ctx, currentSessionID)
+}
+
+func (as *AuthService) RevokeSession(ctx context.Context, id string) error {
+	currentUserID, err := transport.GetUserIDFromContext(ctx)
+	if err != nil {
+		return user.ErrUnauthenticated
+	}
+
+	if err := requireSession(ctx); err != nil {
+		return err
+	}
+
+	if !uuid.Validate(id) {
+		return uuid.ErrInvalidUUID
+	}
+
+	rt, err := as.RefreshTokenRepo.GetActiveByFamilyID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if rt.UserID != currentUserID {
+		return user.ErrNotFound
+	}
+
+	return as.RefreshTokenRepo.RevokeFamily(ctx, id)
+}
+
+func (as *AuthService) RevokeAllSessions(ctx context.Context) error {
+	currentUserID, err := transport.GetUserIDFromContext(ctx)
+	if err != nil {
+		return user.ErrUnauthenticated
+	}
+
+	if err := requireSession(ctx); err != nil {
+		return err
+	}
+
+	return as.RefreshTokenRepo.RevokeAllByUserID(ctx, currentUserID)
+}
+
+// ChangePassword sets a new password after checking the current one, and
+// signs the user out of every other session.
+func (as *AuthService) ChangePassword(ctx context.Context, input user.ChangePasswordInput) error {
+	input.Sanitize()
+
+	if err := input.Validate(); err != nil {
+		return err
+	}
+
+	u, err := as.reauthenticate(ctx, input.CurrentPassword)
+	if err != nil {
+		return err
+	}
+
+	password, err := as.PasswordHasher.Hash(input.Password)
+	if err != nil {
+		return err
+	}
+
+	if err := as.UserRepo.UpdatePassword(ctx, u.ID, password); err != nil {
+		return err
+	}
+
+	return as.revokeOtherSessions(ctx, u.ID)
+}
+
+// ChangeEmail mails a confirmation link to the new email after checking the
+// password. The email is only switched once the link is followed, see
+// ConfirmEmailChange.
+func (as *AuthService) ChangeEmail(ctx context.Context, input user.ChangeEmailInput) error {
+	input.Sanitize()
+
+	if err := input.Validate(); err != nil {
+		return err
+	}
+
+	u, err := as.reauthenticate(ctx, input.Password)
+	if err != nil {
+		return err
+	}
+
+	if input.Email == u.Email {
+		return user.ErrSameEmail
+	}
+
+	if _, err := as.UserRepo.GetByEmail(ctx, input.Email); !errors.Is(err, user.ErrNotFound) {
+		return user.ErrEmailTaken
+	}
+
+	if err := as.EmailVerifier.SendEmailChangeConfirmation(ctx, u, input.Email); err != nil {
+		return err
+	}
+
+	return as.revokeOtherSessions(ctx, u.ID)
+}
+
+// ConfirmEmailChange switches the email of the user the token was issued
+// for. Tokens issued before a later email change are rejected.
+func (as *AuthService) ConfirmEmailChange(ctx context.Context, token string) (user.UserModel, error) {
+	t, err := as.AuthTokenService.ParseEmailChangeToken(ctx, strings.TrimSpace(token))
+	if err != nil {
+		return user.UserModel{}, user.ErrInvalidEmailChange
+	}
+
+	u, err := as.UserRepo.GetByID(ctx, t.Sub)
+	if err != nil {
+		switch {
+		case errors.Is(err, user.ErrNotFound):
+			return user.UserModel{}, user.ErrInvalidEmailChange
+		default:
+			return user.UserModel{}, err
+		}
+	}
+
+	if u.Email != t.Email {
+		return user.UserModel{}, user.ErrInvalidEmailChange
+	}
+
+	// The address may have been registered since the link was sent.
+	if _, err := as.UserRepo.GetByEmail(ctx, t.NewEmail); !errors.Is(err, user.ErrNotFound) {
+		return user.UserModel{}, user.ErrEmailTaken
+	}
+
+	return as.UserRepo.UpdateEmail(ctx, u.ID, t.NewEmail)
+}
+
+// DeleteAccount schedules the deletion of the account after checking the
+// password, revoking every credential of the user. Until then, the user can
+// log back in and cancel it.
+func (as *AuthService) DeleteAccount(ctx context.Context, password string) (user.UserModel, error) {
+	u, err := as.reauthenticate(ctx, password)
+	if err != nil {
+		return user.UserModel{}, err
+	}
+
+	if u.DeletionScheduledAt != nil {
+		return user.UserModel{}, user.ErrDeletionScheduled
+	}
+
+	at := time.Now().Add(user.AccountDeletionGracePeriod)
+
+	return as.UserRepo.ScheduleDeletion(ctx, u.ID, &at)
+}
+
+func (as *AuthService) CancelAccountDeletion(ctx context.Context) (user.UserModel, error) {
+	currentUserID, err := transport.GetUserIDFromContext(ctx)
+	if err != nil {
+		return user.UserModel{}, user.ErrUnauthenticated
+	}
+
+	if err := requireSession(ctx); err != nil {
+		return user.UserModel{}, err
+	}
+
+	u, err := as.UserRepo.GetByID(ctx, currentUserID)
+	if err != nil {
+		return user.UserModel{}, err
+	}
+
+	if u.DeletionScheduledAt == nil {
+		return user.UserModel{}, user.ErrDeletionNotScheduled
+	}
+
+	return as.UserRepo.ScheduleDeletion(ctx, u.ID, nil)
+}
+
+// reauthenticate loads the current user and checks password against their
+// hash, so sensitive changes can't be made from a hijacked session alone.
+// It's throttled like Login, so it can't be used to guess the password.
+func (as *AuthService) reauthenticate(ctx context.Context, password string) (user.UserModel, error) {
+	currentUserID, err := transport.GetUserIDFromContext(ctx)
+	if err != nil {
+		return user.UserModel{}, user.ErrUnauthenticated
+	}
+
+	if err := requireSession(ctx); err != nil {
+		return user.UserModel{}, err
+	}
+
+	u, err := as.UserRepo.GetByID(ctx, currentUserID)
+	if err != nil {
+		return user.UserModel{}, err
+	}
+
+	if _, err := as.checkPassword(ctx, u.Email, password); err != nil {
+		return user.UserModel{}, err
+	}
+
+	if err := as.LoginGuard.Succeed(ctx, u.Email, transport.GetClientIPFromContext(ctx)); err != nil {
+		return user.UserModel{}, err
+	}
+
+	return u, nil
+}
+
+func (as *AuthService) revokeOtherSessions(ctx context.Context, userID string) error {
+	currentSessionID, err := transport.GetSessionIDFromContext(ctx)
+	if err != nil {
+		return as.RefreshTokenRepo.RevokeAllByUserID(ctx, userID)
+	}
+
+	return as.RefreshTokenRepo.RevokeAllByUserIDExcept(ctx, userID, currentSessionID)
+}
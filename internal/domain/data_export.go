@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/RianNegreiros/go-graphql-api/internal/dataexport"
+	"github.com/RianNegreiros/go-graphql-api/internal/jwt"
+	"github.com/RianNegreiros/go-graphql-api/internal/post"
+	"github.com/RianNegreiros/go-graphql-api/internal/transport"
+	"github.com/RianNegreiros/go-graphql-api/internal/user"
+)
+
+type DataExportService struct {
+	UserRepo         user.UserRepo
+	PostRepo         post.PostRepo
+	RefreshTokenRepo jwt.RefreshTokenRepo
+}
+
+func NewDataExportService(ur user.UserRepo, pr post.PostRepo, rr jwt.RefreshTokenRepo) *DataExportService {
+	return &DataExportService{
+		UserRepo:         ur,
+		PostRepo:         pr,
+		RefreshTokenRepo: rr,
+	}
+}
+
+// Export gathers the profile, posts, replies and sessions of the current
+// user. It's too broad for scoped access tokens.
+func (des *DataExportService) Export(ctx context.Context, format dataexport.Format) (dataexport.Archive, error) {
+	currentUserID, err := transport.GetUserIDFromContext(ctx)
+	if err != nil {
+		return dataexport.Archive{}, user.ErrUnauthenticated
+	}
+
+	if err := requireSession(ctx); err != nil {
+		return dataexport.Archive{}, err
+	}
+
+	if format != dataexport.FormatJSON && format != dataexport.FormatZIP {
+		return dataexport.Archive{}, dataexport.ErrUnsupportedFormat
+	}
+
+	u, err := des.UserRepo.GetByID(ctx, currentUserID)
+	if err != nil {
+		return dataexport.Archive{}, err
+	}
+
+	posts, err := des.PostRepo.GetAllByUserID(ctx, u.ID)
+	if err != nil {
+		return dataexport.Archive{}, err
+	}
+
+	tokens, err := des.RefreshTokenRepo.GetActiveByUserID(ctx, u.ID)
+	if err != nil {
+		return dataexport.Archive{}, err
+	}
+
+	sessions := make([]user.Session, len(tokens))
+
+	for i, rt := range tokens {
+		sessions[i] = user.Session{
+			ID:         rt.FamilyID,
+			Name:       rt.Name,
+			LastUsedAt: rt.LastUsedAt,
+			ExpiredAt:  rt.ExpiredAt,
+			CreatedAt:  rt.CreatedAt,
+		}
+	}
+
+	return dataexport.New(u, posts, sessions, time.Now()).Archive(format)
+}
@@ -0,0 +1,546 @@
+package domain
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/RianNegreiros/go-graphql-api/internal/jwt"
+	"github.com/RianNegreiros/go-graphql-api/internal/oidc"
+	"github.com/RianNegreiros/go-graphql-api/internal/randtoken"
+	"github.com/RianNegreiros/go-graphql-api/internal/transport"
+	"github.com/RianNegreiros/go-graphql-api/internal/user"
+	"github.com/RianNegreiros/go-graphql-api/internal/uuid"
+)
+
+const (
+	grantTypeAuthorizationCode = "authorization_code"
+	grantTypeRefreshToken      = "refresh_token"
+)
+
+type OAuthService struct {
+	OAuthRepo        user.OAuthRepo
+	AuthTokenService user.AuthTokenService
+}
+
+func NewOAuthService(or user.OAuthRepo, ts user.AuthTokenService) *OAuthService {
+	return &OAuthService{
+		OAuthRepo:        or,
+		AuthTokenService: ts,
+	}
+}
+
+func (oas *OAuthService) CreateClient(ctx context.Context, input user.CreateOAuthClientInput) (user.CreatedOAuthClient, error) {
+	currentUserID, err := transport.GetUserIDFromContext(ctx)
+	if err != nil {
+		return user.CreatedOAuthClient{}, user.ErrUnauthenticated
+	}
+
+	if err := requireSession(ctx); err != nil {
+		return user.CreatedOAuthClient{}, err
+	}
+
+	input.Sanitize()
+
+	if err := input.Validate(); err != nil {
+		return user.CreatedOAuthClient{}, err
+	}
+
+	c := user.OAuthClient{
+		UserID:       currentUserID,
+		Name:         input.Name,
+		RedirectURIs: input.RedirectURIs,
+		Scopes:       input.Scopes,
+	}
+
+	var secret string
+
+	if input.Confidential {
+		var hash string
+
+		if secret, hash, err = randtoken.Generate(); err != nil {
+			return user.CreatedOAuthClient{}, err
+		}
+
+		c.SecretHash = &hash
+	}
+
+	c, err = oas.OAuthRepo.CreateClient(ctx, c)
+	if err != nil {
+		return user.CreatedOAuthClient{}, err
+	}
+
+	return user.CreatedOAuthClient{
+		OAuthClient: c,
+		Secret:      secret,
+	}, nil
+}
+
+func (oas *OAuthService) Clients(ctx context.Context) ([]user.OAuthClient, error) {
+	currentUserID, err := transport.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, user.ErrUnauthenticated
+	}
+
+	if err := requireSession(ctx); err != nil {
+		return nil, err
+	}
+
+	return oas.OAuthRepo.GetClientsByUserID(ctx, currentUserID)
+}
+
+func (oas *OAuthService) DeleteClient(ctx context.Context, id string) error {
+	currentUserID, err := transport.GetUserIDFromContext(ctx)
+	if err != nil {
+		return user.ErrUnauthenticated
+	}
+
+	if err := requireSession(ctx); err != nil {
+		return err
+	}
+
+	if !uuid.Validate(id) {
+		return uuid.ErrInvalidUUID
+	}
+
+	return oas.OAuthRepo.DeleteClient(ctx, id, currentUserID)
+}
+
+func (oas *OAuthService) Authorization(ctx context.Context, input user.OAuthAuthorizationInput) (user.OAuthAuthorization, error) {
+	currentUserID, err := transport.GetUserIDFromContext(ctx)
+	if err != nil {
+		return user.OAuthAuthorization{}, user.ErrUnauthenticated
+	}
+
+	if err := requireSession(ctx); err != nil {
+		return user.OAuthAuthorization{}, err
+	}
+
+	a, err := oas.checkAuthorization(ctx, input)
+	if err != nil {
+		return user.OAuthAuthorization{}, err
+	}
+
+	consent, err := oas.OAuthRepo.GetConsent(ctx, currentUserID, a.Client.ID)
+	if err != nil {
+		switch {
+		case errors.Is(err, user.ErrNotFound):
+			return a, nil
+		default:
+			return user.OAuthAuthorization{}, err
+		}
+	}
+
+	a.Consented = consent.Covers(a.Scopes)
+
+	return a, nil
+}
+
+func (oas *OAuthService) Authorize(ctx context.Context, input user.OAuthAuthorizationInput) (string, error) {
+	currentUserID, err := transport.GetUserIDFromContext(ctx)
+	if err != nil {
+		return "", user.ErrUnauthenticated
+	}
+
+	if err := requireSession(ctx); err != nil {
+		return "", err
+	}
+
+	a, err := oas.checkAuthorization(ctx, input)
+	if err != nil {
+		return "", err
+	}
+
+	if _, err := oas.OAuthRepo.SaveConsent(ctx, user.OAuthConsent{
+		UserID:   currentUserID,
+		ClientID: a.Client.ID,
+		Scopes:   a.Scopes,
+	}); err != nil {
+		return "", err
+	}
+
+	code, hash, err := randtoken.Generate()
+	if err != nil {
+		return "", err
+	}
+
+	if err := oas.OAuthRepo.CreateCode(ctx, user.OAuthAuthorizationCode{
+		CodeHash:            hash,
+		ClientID:            a.Client.ID,
+		UserID:              currentUserID,
+		RedirectURI:         a.RedirectURI,
+		RedirectURIRequired: input.RedirectURI != "",
+		Scopes:              a.Scopes,
+		CodeChallenge:       input.CodeChallenge,
+		ExpiredAt:           time.Now().Add(user.OAuthCodeLifeTime),
+	}); err != nil {
+		return "", err
+	}
+
+	redirect, err := url.Parse(a.RedirectURI)
+	if err != nil {
+		return "", user.ErrInvalidRedirectURI
+	}
+
+	q := redirect.Query()
+	q.Set("code", code)
+
+	if input.State != "" {
+		q.Set("state", input.State)
+	}
+
+	redirect.RawQuery = q.Encode()
+
+	return redirect.String(), nil
+}
+
+// checkAuthorization validates the request against the registered client.
+// The redirect URI is checked before anything else can be reported through
+// it; a client with a single one may leave it out.
+func (oas *OAuthService) checkAuthorization(ctx context.Context, input user.OAuthAuthorizationInput) (user.OAuthAuthorization, error) {
+	c, err := oas.getClient(ctx, input.ClientID)
+	if err != nil {
+		return user.OAuthAuthorization{}, err
+	}
+
+	redirectURI := input.RedirectURI
+	if redirectURI == "" && len(c.RedirectURIs) == 1 {
+		redirectURI = c.RedirectURIs[0]
+	}
+
+	if !c.HasRedirectURI(redirectURI) {
+		return user.OAuthAuthorization{}, user.ErrInvalidRedirectURI
+	}
+
+	if input.ResponseType != "code" {
+		return user.OAuthAuthorization{}, fmt.Errorf("%w: response type must be code", user.ErrValidation)
+	}
+
+	if input.CodeChallengeMethod != "S256" {
+		return user.OAuthAuthorization{}, fmt.Errorf("%w: a S256 code challenge is required", user.ErrValidation)
+	}
+
+	if len(input.CodeChallenge) < 43 || len(input.CodeChallenge) > 128 {
+		return user.OAuthAuthorization{}, fmt.Errorf("%w: invalid code challenge", user.ErrValidation)
+	}
+
+	scopes := user.ParseScopes(input.Scope)
+	if len(scopes) == 0 {
+		scopes = c.Scopes
+	}
+
+	for _, s := range scopes {
+		if !s.IsValid() || !c.HasScope(s) {
+			return user.OAuthAuthorization{}, fmt.Errorf("%w: %s", user.ErrInvalidOAuthScope, s)
+		}
+	}
+
+	return user.OAuthAuthorization{
+		Client:      c,
+		RedirectURI: redirectURI,
+		Scopes:      scopes,
+	}, nil
+}
+
+func (oas *OAuthService) Consents(ctx context.Context) ([]user.OAuthConsent, error) {
+	currentUserID, err := transport.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, user.ErrUnauthenticated
+	}
+
+	if err := requireSession(ctx); err != nil {
+		return nil, err
+	}
+
+	return oas.OAuthRepo.GetConsentsByUserID(ctx, currentUserID)
+}
+
+func (oas *OAuthService) RevokeConsent(ctx context.Context, clientID string) error {
+	currentUserID, err := transport.GetUserIDFromContext(ctx)
+	if err != nil {
+		return user.ErrUnauthenticated
+	}
+
+	if err := requireSession(ctx); err != nil {
+		return err
+	}
+
+	if !uuid.Validate(clientID) {
+		return uuid.ErrInvalidUUID
+	}
+
+	return oas.OAuthRepo.DeleteConsent(ctx, currentUserID, clientID)
+}
+
+// Token redeems an authorization code or a refresh token. Refresh tokens are
+// rotated, each one works once.
+func (oas *OAuthService) Token(ctx context.Context, input user.OAuthTokenInput) (user.OAuthTokenResponse, error) {
+	c, err := oas.authenticateClient(ctx, input.OAuthClientCredentials)
+	if err != nil {
+		return user.OAuthTokenResponse{}, err
+	}
+
+	refreshToken, refreshTokenHash, err := newRefreshToken()
+	if err != nil {
+		return user.OAuthTokenResponse{}, err
+	}
+
+	var g user.OAuthGrant
+
+	switch input.GrantType {
+	case grantTypeAuthorizationCode:
+		g, err = oas.redeemCode(ctx, c, input, refreshTokenHash)
+	case grantTypeRefreshToken:
+		g, err = oas.rotateRefreshToken(ctx, c, input.RefreshToken, refreshTokenHash)
+	default:
+		err = user.ErrUnsupportedGrantType
+	}
+
+	if err != nil {
+		return user.OAuthTokenResponse{}, err
+	}
+
+	accessToken, err := oas.AuthTokenService.CreateOAuthAccessToken(ctx, g)
+	if err != nil {
+		return user.OAuthTokenResponse{}, user.ErrGenerateToken
+	}
+
+	return user.OAuthTokenResponse{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		ExpiresIn:    jwt.AccessTokenLifeTime,
+		Scopes:       g.Scopes,
+	}, nil
+}
+
+// redeemCode checks the code was issued to the client for the same redirect
+// URI, and that the client knows the PKCE verifier of its challenge. The
+// redirect URI may only be left out if the authorization request did too.
+func (oas *OAuthService) redeemCode(ctx context.Context, c user.OAuthClient, input user.OAuthTokenInput, refreshTokenHash string) (user.OAuthGrant, error) {
+	if len(input.CodeVerifier) < 43 || len(input.CodeVerifier) > 128 {
+		return user.OAuthGrant{}, fmt.Errorf("%w: invalid code verifier", user.ErrValidation)
+	}
+
+	code, err := oas.OAuthRepo.ConsumeCode(ctx, randtoken.Hash(input.Code))
+	if err != nil {
+		switch {
+		case errors.Is(err, user.ErrNotFound):
+			return user.OAuthGrant{}, user.ErrInvalidOAuthGrant
+		default:
+			return user.OAuthGrant{}, err
+		}
+	}
+
+	if code.ClientID != c.ID {
+		return user.OAuthGrant{}, user.ErrInvalidOAuthGrant
+	}
+
+	if (code.RedirectURIRequired || input.RedirectURI != "") && input.RedirectURI != code.RedirectURI {
+		return user.OAuthGrant{}, user.ErrInvalidOAuthGrant
+	}
+
+	if subtle.ConstantTimeCompare([]byte(oidc.CodeChallenge(input.CodeVerifier)), []byte(code.CodeChallenge)) != 1 {
+		return user.OAuthGrant{}, user.ErrInvalidOAuthGrant
+	}
+
+	return oas.OAuthRepo.CreateGrant(ctx, user.OAuthGrant{
+		ClientID:         c.ID,
+		UserID:           code.UserID,
+		Scopes:           code.Scopes,
+		RefreshTokenHash: refreshTokenHash,
+		ExpiredAt:        time.Now().Add(user.OAuthRefreshTokenLifeTime),
+	})
+}
+
+func (oas *OAuthService) rotateRefreshToken(ctx context.Context, c user.OAuthClient, refreshToken string, newRefreshTokenHash string) (user.OAuthGrant, error) {
+	hash := randtoken.Hash(refreshToken)
+
+	g, err := oas.OAuthRepo.GetActiveGrantByRefreshTokenHash(ctx, hash)
+	if err != nil {
+		switch {
+		case errors.Is(err, user.ErrNotFound):
+			return user.OAuthGrant{}, user.ErrInvalidOAuthGrant
+		default:
+			return user.OAuthGrant{}, err
+		}
+	}
+
+	if g.ClientID != c.ID {
+		return user.OAuthGrant{}, user.ErrInvalidOAuthGrant
+	}
+
+	g, err = oas.OAuthRepo.RotateGrant(ctx, hash, newRefreshTokenHash)
+	if err != nil {
+		switch {
+		case errors.Is(err, user.ErrNotFound):
+			return user.OAuthGrant{}, user.ErrInvalidOAuthGrant
+		default:
+			return user.OAuthGrant{}, err
+		}
+	}
+
+	return g, nil
+}
+
+func (oas *OAuthService) Revoke(ctx context.Context, client user.OAuthClientCredentials, token string) error {
+	c, err := oas.authenticateClient(ctx, client)
+	if err != nil {
+		return err
+	}
+
+	g, _, err := oas.grantOf(ctx, token)
+	if err != nil {
+		switch {
+		case errors.Is(err, user.ErrNotFound):
+			return nil
+		default:
+			return err
+		}
+	}
+
+	if g.ClientID != c.ID {
+		return nil
+	}
+
+	return oas.OAuthRepo.RevokeGrant(ctx, g.ID)
+}
+
+// Introspect only describes tokens issued to the client asking, any other
+// token is reported inactive.
+func (oas *OAuthService) Introspect(ctx context.Context, client user.OAuthClientCredentials, token string) (user.OAuthIntrospection, error) {
+	c, err := oas.authenticateClient(ctx, client)
+	if err != nil {
+		return user.OAuthIntrospection{}, err
+	}
+
+	g, accessToken, err := oas.grantOf(ctx, token)
+	if err != nil {
+		switch {
+		case errors.Is(err, user.ErrNotFound):
+			return user.OAuthIntrospection{}, nil
+		default:
+			return user.OAuthIntrospection{}, err
+		}
+	}
+
+	if g.ClientID != c.ID {
+		return user.OAuthIntrospection{}, nil
+	}
+
+	if accessToken != nil {
+		return user.OAuthIntrospection{
+			Active:    true,
+			TokenType: "access_token",
+			ClientID:  g.ClientID,
+			UserID:    g.UserID,
+			Scopes:    accessToken.Scopes,
+			ExpiredAt: accessToken.ExpiredAt,
+		}, nil
+	}
+
+	return user.OAuthIntrospection{
+		Active:    true,
+		TokenType: "refresh_token",
+		ClientID:  g.ClientID,
+		UserID:    g.UserID,
+		Scopes:    g.Scopes,
+		ExpiredAt: g.ExpiredAt,
+	}, nil
+}
+
+func (oas *OAuthService) Authenticate(ctx context.Context, token string) (user.OAuthGrant, error) {
+	g, accessToken, err := oas.grantOf(ctx, token)
+	if err != nil || accessToken == nil {
+		switch {
+		case err == nil, errors.Is(err, user.ErrNotFound):
+			return user.OAuthGrant{}, user.ErrInvalidOAuthToken
+		default:
+			return user.OAuthGrant{}, err
+		}
+	}
+
+	return g, nil
+}
+
+// grantOf returns the active grant of an access or refresh token, and the
+// parsed access token if it is one. It returns ErrNotFound for unknown,
+// expired or revoked tokens.
+func (oas *OAuthService) grantOf(ctx context.Context, token string) (user.OAuthGrant, *user.OAuthAccessToken, error) {
+	if strings.HasPrefix(token, user.OAuthRefreshTokenPrefix) {
+		g, err := oas.OAuthRepo.GetActiveGrantByRefreshTokenHash(ctx, randtoken.Hash(token))
+
+		return g, nil, err
+	}
+
+	t, err := oas.AuthTokenService.ParseOAuthAccessToken(ctx, token)
+	if err != nil || !uuid.Validate(t.GrantID) {
+		return user.OAuthGrant{}, nil, user.ErrNotFound
+	}
+
+	g, err := oas.OAuthRepo.GetActiveGrant(ctx, t.GrantID)
+	if err != nil {
+		return user.OAuthGrant{}, nil, err
+	}
+
+	if g.UserID != t.Sub || g.ClientID != t.ClientID {
+		return user.OAuthGrant{}, nil, user.ErrNotFound
+	}
+
+	return g, &t, nil
+}
+
+// authenticateClient checks the secret of confidential clients. Public ones
+// only identify themselves, PKCE stands in for the secret.
+func (oas *OAuthService) authenticateClient(ctx context.Context, credentials user.OAuthClientCredentials) (user.OAuthClient, error) {
+	c, err := oas.getClient(ctx, credentials.ClientID)
+	if err != nil {
+		return user.OAuthClient{}, err
+	}
+
+	if !c.IsConfidential() {
+		if credentials.ClientSecret != "" {
+			return user.OAuthClient{}, user.ErrInvalidOAuthClient
+		}
+
+		return c, nil
+	}
+
+	if subtle.ConstantTimeCompare([]byte(randtoken.Hash(credentials.ClientSecret)), []byte(*c.SecretHash)) != 1 {
+		return user.OAuthClient{}, user.ErrInvalidOAuthClient
+	}
+
+	return c, nil
+}
+
+func (oas *OAuthService) getClient(ctx context.Context, id string) (user.OAuthClient, error) {
+	if !uuid.Validate(id) {
+		return user.OAuthClient{}, user.ErrInvalidOAuthClient
+	}
+
+	c, err := oas.OAuthRepo.GetClientByID(ctx, id)
+	if err != nil {
+		switch {
+		case errors.Is(err, user.ErrNotFound):
+			return user.OAuthClient{}, user.ErrInvalidOAuthClient
+		default:
+			return user.OAuthClient{}, err
+		}
+	}
+
+	return c, nil
+}
+
+func newRefreshToken() (token string, hash string, err error) {
+	token, _, err = randtoken.Generate()
+	if err != nil {
+		return "", "", err
+	}
+
+	token = user.OAuthRefreshTokenPrefix + token
+
+	return token, randtoken.Hash(token), nil
+}
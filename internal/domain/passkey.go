@@ -0,0 +1,262 @@
+package domain
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"time"
+
+	"github.com/RianNegreiros/go-graphql-api/internal/randtoken"
+	"github.com/RianNegreiros/go-graphql-api/internal/transport"
+	"github.com/RianNegreiros/go-graphql-api/internal/user"
+	"github.com/RianNegreiros/go-graphql-api/internal/uuid"
+	"github.com/RianNegreiros/go-graphql-api/internal/webauthn"
+)
+
+type PasskeyService struct {
+	UserRepo    user.UserRepo
+	PasskeyRepo user.PasskeyRepo
+}
+
+func NewPasskeyService(ur user.UserRepo, pr user.PasskeyRepo) *PasskeyService {
+	return &PasskeyService{
+		UserRepo:    ur,
+		PasskeyRepo: pr,
+	}
+}
+
+func (ps *PasskeyService) BeginRegistration(ctx context.Context) (user.PasskeyCreationOptions, error) {
+	currentUserID, err := transport.GetUserIDFromContext(ctx)
+	if err != nil {
+		return user.PasskeyCreationOptions{}, user.ErrUnauthenticated
+	}
+
+	if err := requireSession(ctx); err != nil {
+		return user.PasskeyCreationOptions{}, err
+	}
+
+	u, err := ps.UserRepo.GetByID(ctx, currentUserID)
+	if err != nil {
+		return user.PasskeyCreationOptions{}, err
+	}
+
+	passkeys, err := ps.PasskeyRepo.GetByUserID(ctx, u.ID)
+	if err != nil {
+		return user.PasskeyCreationOptions{}, err
+	}
+
+	challenge, err := ps.createChallenge(ctx, &u.ID, user.PasskeyRegistration)
+	if err != nil {
+		return user.PasskeyCreationOptions{}, err
+	}
+
+	// Listing the passkeys the user already has keeps authenticators from
+	// registering a second one for the same account.
+	exclude := make([]string, len(passkeys))
+	for i, p := range passkeys {
+		exclude[i] = base64.RawURLEncoding.EncodeToString(p.CredentialID)
+	}
+
+	return user.PasskeyCreationOptions{
+		Challenge:            challenge,
+		RPID:                 user.PasskeyRelyingParty.ID,
+		RPName:               user.PasskeyRelyingParty.Name,
+		UserID:               base64.RawURLEncoding.EncodeToString([]byte(u.ID)),
+		UserName:             u.Email,
+		UserDisplayName:      u.Username,
+		Algorithms:           webauthn.Algorithms,
+		ExcludeCredentialIDs: exclude,
+		Timeout:              user.PasskeyChallengeLifeTime,
+	}, nil
+}
+
+func (ps *PasskeyService) FinishRegistration(ctx context.Context, input user.FinishPasskeyRegistrationInput) (user.Passkey, error) {
+	currentUserID, err := transport.GetUserIDFromContext(ctx)
+	if err != nil {
+		return user.Passkey{}, user.ErrUnauthenticated
+	}
+
+	if err := requireSession(ctx); err != nil {
+		return user.Passkey{}, err
+	}
+
+	input.Sanitize()
+
+	if err := input.Validate(); err != nil {
+		return user.Passkey{}, err
+	}
+
+	clientDataJSON, err := base64.RawURLEncoding.DecodeString(input.ClientDataJSON)
+	if err != nil {
+		return user.Passkey{}, user.ErrInvalidPasskey
+	}
+
+	attestationObject, err := base64.RawURLEncoding.DecodeString(input.AttestationObject)
+	if err != nil {
+		return user.Passkey{}, user.ErrInvalidPasskey
+	}
+
+	clientData, credential, err := user.PasskeyRelyingParty.VerifyRegistration(clientDataJSON, attestationObject)
+	if err != nil {
+		return user.Passkey{}, user.ErrInvalidPasskey
+	}
+
+	if _, err := ps.consumeChallenge(ctx, clientData.Challenge, user.PasskeyRegistration, currentUserID); err != nil {
+		return user.Passkey{}, err
+	}
+
+	if _, err := ps.PasskeyRepo.GetByCredentialID(ctx, credential.ID); err == nil {
+		return user.Passkey{}, user.ErrPasskeyTaken
+	} else if !errors.Is(err, user.ErrNotFound) {
+		return user.Passkey{}, err
+	}
+
+	return ps.PasskeyRepo.Create(ctx, user.Passkey{
+		UserID:       currentUserID,
+		Name:         input.Name,
+		CredentialID: credential.ID,
+		PublicKey:    credential.PublicKey,
+		SignCount:    int64(credential.SignCount),
+	})
+}
+
+func (ps *PasskeyService) BeginLogin(ctx context.Context) (user.PasskeyRequestOptions, error) {
+	challenge, err := ps.createChallenge(ctx, nil, user.PasskeyLogin)
+	if err != nil {
+		return user.PasskeyRequestOptions{}, err
+	}
+
+	return user.PasskeyRequestOptions{
+		Challenge: challenge,
+		RPID:      user.PasskeyRelyingParty.ID,
+		Timeout:   user.PasskeyChallengeLifeTime,
+	}, nil
+}
+
+// VerifyLogin checks the assertion against the stored public key, burns the
+// challenge and records the new signature counter. A counter that didn't
+// increase means the credential may have been cloned, so the login fails.
+func (ps *PasskeyService) VerifyLogin(ctx context.Context, input user.PasskeyLoginInput) (string, error) {
+	credentialID, err := base64.RawURLEncoding.DecodeString(input.CredentialID)
+	if err != nil {
+		return "", user.ErrInvalidPasskey
+	}
+
+	clientDataJSON, err := base64.RawURLEncoding.DecodeString(input.ClientDataJSON)
+	if err != nil {
+		return "", user.ErrInvalidPasskey
+	}
+
+	authenticatorData, err := base64.RawURLEncoding.DecodeString(input.AuthenticatorData)
+	if err != nil {
+		return "", user.ErrInvalidPasskey
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(input.Signature)
+	if err != nil {
+		return "", user.ErrInvalidPasskey
+	}
+
+	userHandle, err := base64.RawURLEncoding.DecodeString(input.UserHandle)
+	if err != nil {
+		return "", user.ErrInvalidPasskey
+	}
+
+	p, err := ps.PasskeyRepo.GetByCredentialID(ctx, credentialID)
+	if err != nil {
+		switch {
+		case errors.Is(err, user.ErrNotFound):
+			return "", user.ErrInvalidPasskey
+		default:
+			return "", err
+		}
+	}
+
+	if len(userHandle) > 0 && !bytes.Equal(userHandle, []byte(p.UserID)) {
+		return "", user.ErrInvalidPasskey
+	}
+
+	clientData, authData, err := user.PasskeyRelyingParty.VerifyAssertion(p.PublicKey, clientDataJSON, authenticatorData, signature)
+	if err != nil {
+		return "", user.ErrInvalidPasskey
+	}
+
+	if _, err := ps.consumeChallenge(ctx, clientData.Challenge, user.PasskeyLogin, ""); err != nil {
+		return "", err
+	}
+
+	if err := ps.PasskeyRepo.UpdateSignCount(ctx, p.ID, int64(authData.SignCount)); err != nil {
+		return "", err
+	}
+
+	return p.UserID, nil
+}
+
+func (ps *PasskeyService) List(ctx context.Context) ([]user.Passkey, error) {
+	currentUserID, err := transport.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, user.ErrUnauthenticated
+	}
+
+	if err := requireSession(ctx); err != nil {
+		return nil, err
+	}
+
+	return ps.PasskeyRepo.GetByUserID(ctx, currentUserID)
+}
+
+func (ps *PasskeyService) Delete(ctx context.Context, id string) error {
+	currentUserID, err := transport.GetUserIDFromContext(ctx)
+	if err != nil {
+		return user.ErrUnauthenticated
+	}
+
+	if err := requireSession(ctx); err != nil {
+		return err
+	}
+
+	if !uuid.Validate(id) {
+		return uuid.ErrInvalidUUID
+	}
+
+	return ps.PasskeyRepo.Delete(ctx, id, currentUserID)
+}
+
+func (ps *PasskeyService) createChallenge(ctx context.Context, userID *string, ceremony user.PasskeyCeremony) (string, error) {
+	challenge, _, err := randtoken.Generate()
+	if err != nil {
+		return "", err
+	}
+
+	if err := ps.PasskeyRepo.CreateChallenge(ctx, user.PasskeyChallenge{
+		Challenge: challenge,
+		UserID:    userID,
+		Ceremony:  ceremony,
+		ExpiredAt: time.Now().Add(user.PasskeyChallengeLifeTime),
+	}); err != nil {
+		return "", err
+	}
+
+	return challenge, nil
+}
+
+// consumeChallenge burns challenge. Registration challenges must also have
+// been issued to userID.
+func (ps *PasskeyService) consumeChallenge(ctx context.Context, challenge string, ceremony user.PasskeyCeremony, userID string) (user.PasskeyChallenge, error) {
+	c, err := ps.PasskeyRepo.ConsumeChallenge(ctx, challenge, ceremony)
+	if err != nil {
+		switch {
+		case errors.Is(err, user.ErrNotFound):
+			return user.PasskeyChallenge{}, user.ErrInvalidPasskey
+		default:
+			return user.PasskeyChallenge{}, err
+		}
+	}
+
+	if userID != "" && (c.UserID == nil || *c.UserID != userID) {
+		return user.PasskeyChallenge{}, user.ErrInvalidPasskey
+	}
+
+	return c, nil
+}
@@ -0,0 +1,70 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/RianNegreiros/go-graphql-api/internal/transport"
+	"github.com/RianNegreiros/go-graphql-api/internal/user"
+)
+
+// currentUser returns the authenticated user as carried by the access token:
+// only the ID and role are set.
+func currentUser(ctx context.Context) (user.UserModel, error) {
+	currentUserID, err := transport.GetUserIDFromContext(ctx)
+	if err != nil {
+		return user.UserModel{}, user.ErrUnauthenticated
+	}
+
+	return user.UserModel{
+		ID:   currentUserID,
+		Role: transport.GetRoleFromContext(ctx),
+	}, nil
+}
+
+// authorize returns the authenticated user if their role is granted p. Role
+// permissions are only exercised from a session, never with a personal access
+// token.
+func authorize(ctx context.Context, p user.Permission) (user.UserModel, error) {
+	u, err := currentUser(ctx)
+	if err != nil {
+		return user.UserModel{}, err
+	}
+
+	if err := requireSession(ctx); err != nil {
+		return user.UserModel{}, err
+	}
+
+	if !u.Role.Can(p) {
+		return user.UserModel{}, user.ErrForbidden
+	}
+
+	return u, nil
+}
+
+// requireScope refuses requests made with a personal access token or OAuth
+// access token which wasn't granted scope. Sessions are granted every scope.
+func requireScope(ctx context.Context, scope user.Scope) error {
+	scopes, ok := transport.GetScopesFromContext(ctx)
+	if !ok {
+		return nil
+	}
+
+	for _, s := range scopes {
+		if s == scope {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: %s", user.ErrInsufficientScope, scope)
+}
+
+// requireSession refuses requests made with a personal access token or OAuth
+// access token, for account management no scope grants.
+func requireSession(ctx context.Context) error {
+	if _, ok := transport.GetScopesFromContext(ctx); ok {
+		return user.ErrSessionRequired
+	}
+
+	return nil
+}
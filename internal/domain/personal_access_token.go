@@ -0,0 +1,112 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/RianNegreiros/go-graphql-api/internal/randtoken"
+	"github.com/RianNegreiros/go-graphql-api/internal/transport"
+	"github.com/RianNegreiros/go-graphql-api/internal/user"
+	"github.com/RianNegreiros/go-graphql-api/internal/uuid"
+)
+
+type PersonalAccessTokenService struct {
+	PersonalAccessTokenRepo user.PersonalAccessTokenRepo
+}
+
+func NewPersonalAccessTokenService(pr user.PersonalAccessTokenRepo) *PersonalAccessTokenService {
+	return &PersonalAccessTokenService{
+		PersonalAccessTokenRepo: pr,
+	}
+}
+
+// Create issues a token for the current user. Only its hash is stored, the
+// token itself can't be shown again.
+func (ps *PersonalAccessTokenService) Create(ctx context.Context, input user.CreatePersonalAccessTokenInput) (user.CreatedPersonalAccessToken, error) {
+	currentUserID, err := transport.GetUserIDFromContext(ctx)
+	if err != nil {
+		return user.CreatedPersonalAccessToken{}, user.ErrUnauthenticated
+	}
+
+	if err := requireSession(ctx); err != nil {
+		return user.CreatedPersonalAccessToken{}, err
+	}
+
+	input.Sanitize()
+
+	if err := input.Validate(); err != nil {
+		return user.CreatedPersonalAccessToken{}, err
+	}
+
+	token, _, err := randtoken.Generate()
+	if err != nil {
+		return user.CreatedPersonalAccessToken{}, err
+	}
+
+	token = user.PersonalAccessTokenPrefix + token
+
+	t, err := ps.PersonalAccessTokenRepo.Create(ctx, user.PersonalAccessToken{
+		UserID:    currentUserID,
+		Name:      input.Name,
+		TokenHash: randtoken.Hash(token),
+		Scopes:    input.Scopes,
+		ExpiredAt: input.ExpiredAt,
+	})
+	if err != nil {
+		return user.CreatedPersonalAccessToken{}, err
+	}
+
+	return user.CreatedPersonalAccessToken{
+		PersonalAccessToken: t,
+		Token:               token,
+	}, nil
+}
+
+func (ps *PersonalAccessTokenService) List(ctx context.Context) ([]user.PersonalAccessToken, error) {
+	currentUserID, err := transport.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, user.ErrUnauthenticated
+	}
+
+	if err := requireSession(ctx); err != nil {
+		return nil, err
+	}
+
+	return ps.PersonalAccessTokenRepo.GetActiveByUserID(ctx, currentUserID)
+}
+
+func (ps *PersonalAccessTokenService) Revoke(ctx context.Context, id string) error {
+	currentUserID, err := transport.GetUserIDFromContext(ctx)
+	if err != nil {
+		return user.ErrUnauthenticated
+	}
+
+	if err := requireSession(ctx); err != nil {
+		return err
+	}
+
+	if !uuid.Validate(id) {
+		return uuid.ErrInvalidUUID
+	}
+
+	return ps.PersonalAccessTokenRepo.Revoke(ctx, id, currentUserID)
+}
+
+func (ps *PersonalAccessTokenService) Authenticate(ctx context.Context, token string) (user.PersonalAccessToken, error) {
+	if !strings.HasPrefix(token, user.PersonalAccessTokenPrefix) {
+		return user.PersonalAccessToken{}, user.ErrInvalidAccessToken
+	}
+
+	t, err := ps.PersonalAccessTokenRepo.Use(ctx, randtoken.Hash(token))
+	if err != nil {
+		switch {
+		case errors.Is(err, user.ErrNotFound):
+			return user.PersonalAccessToken{}, user.ErrInvalidAccessToken
+		default:
+			return user.PersonalAccessToken{}, err
+		}
+	}
+
+	return t, nil
+}
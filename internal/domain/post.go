@@ -2,8 +2,14 @@ package domain
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
 
+	"github.com/RianNegreiros/go-graphql-api/internal/pagination"
 	"github.com/RianNegreiros/go-graphql-api/internal/post"
+	"github.com/RianNegreiros/go-graphql-api/internal/pubsub"
 	"github.com/RianNegreiros/go-graphql-api/internal/transport"
 	"github.com/RianNegreiros/go-graphql-api/internal/user"
 	"github.com/RianNegreiros/go-graphql-api/internal/uuid"
@@ -11,16 +17,43 @@ import (
 
 type PostService struct {
 	PostRepo post.PostRepo
+	UserRepo user.UserRepo
+	PubSub   pubsub.PubSub
 }
 
-func NewPostService(tr post.PostRepo) *PostService {
+func NewPostService(tr post.PostRepo, ur user.UserRepo, ps pubsub.PubSub) *PostService {
 	return &PostService{
 		PostRepo: tr,
+		UserRepo: ur,
+		PubSub:   ps,
 	}
 }
 
-func (ts *PostService) All(ctx context.Context) ([]post.Post, error) {
-	return ts.PostRepo.All(ctx)
+func (ts *PostService) Paginate(ctx context.Context, input pagination.Input) (pagination.Page[post.Post], error) {
+	args, err := input.Args()
+	if err != nil {
+		return pagination.Page[post.Post]{}, err
+	}
+
+	return ts.PostRepo.Paginate(ctx, args)
+}
+
+func (ts *PostService) HomeTimeline(ctx context.Context, input pagination.Input) (pagination.Page[post.Post], error) {
+	currentUserID, err := transport.GetUserIDFromContext(ctx)
+	if err != nil {
+		return pagination.Page[post.Post]{}, user.ErrUnauthenticated
+	}
+
+	if err := requireScope(ctx, user.ScopePostsRead); err != nil {
+		return pagination.Page[post.Post]{}, err
+	}
+
+	args, err := input.Args()
+	if err != nil {
+		return pagination.Page[post.Post]{}, err
+	}
+
+	return ts.PostRepo.GetHomeTimeline(ctx, currentUserID, args)
 }
 
 func (ts *PostService) Create(ctx context.Context, input post.CreatePostInput) (post.Post, error) {
@@ -29,12 +62,20 @@ func (ts *PostService) Create(ctx context.Context, input post.CreatePostInput) (
 		return post.Post{}, user.ErrUnauthenticated
 	}
 
+	if err := requireScope(ctx, user.ScopePostsWrite); err != nil {
+		return post.Post{}, err
+	}
+
 	input.Sanitize()
 
 	if err := input.Validate(); err != nil {
 		return post.Post{}, err
 	}
 
+	if err := ts.checkCanPublish(ctx, currentUserID); err != nil {
+		return post.Post{}, err
+	}
+
 	p, err := ts.PostRepo.Create(ctx, post.Post{
 		Body:   input.Body,
 		UserID: currentUserID,
@@ -43,6 +84,8 @@ func (ts *PostService) Create(ctx context.Context, input post.CreatePostInput) (
 		return post.Post{}, err
 	}
 
+	ts.publish(ctx, post.TopicPostCreated, p)
+
 	return p, nil
 }
 
@@ -60,20 +103,106 @@ func (ts *PostService) Delete(ctx context.Context, id string) error {
 		return user.ErrUnauthenticated
 	}
 
+	if err := requireScope(ctx, user.ScopePostsWrite); err != nil {
+		return err
+	}
+
 	if !uuid.Validate(id) {
 		return uuid.ErrInvalidUUID
 	}
 
-	post, err := ts.PostRepo.GetByID(ctx, id)
+	p, err := ts.PostRepo.GetByID(ctx, id)
 	if err != nil {
 		return err
 	}
 
-	if !post.CanDelete(user.UserModel{ID: currentUserID}) {
+	if !p.CanDelete(user.UserModel{ID: currentUserID}) {
 		return user.ErrForbidden
 	}
 
-	return ts.PostRepo.Delete(ctx, id)
+	if err := ts.PostRepo.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	ts.publish(ctx, post.TopicPostDeleted, p)
+
+	return nil
+}
+
+func (ts *PostService) Remove(ctx context.Context, id string, input post.RemovePostInput) error {
+	moderator, err := authorize(ctx, user.PermissionDeleteAnyPost)
+	if err != nil {
+		return err
+	}
+
+	input.Sanitize()
+
+	if err := input.Validate(); err != nil {
+		return err
+	}
+
+	if !uuid.Validate(id) {
+		return uuid.ErrInvalidUUID
+	}
+
+	p, err := ts.PostRepo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := ts.PostRepo.Remove(ctx, post.Removal{
+		PostID:    p.ID,
+		RemovedBy: moderator.ID,
+		Reason:    input.Reason,
+	}); err != nil {
+		return err
+	}
+
+	ts.publish(ctx, post.TopicPostDeleted, p)
+
+	return nil
+}
+
+func (ts *PostService) Update(ctx context.Context, id string, input post.UpdatePostInput) (post.Post, error) {
+	currentUserID, err := transport.GetUserIDFromContext(ctx)
+	if err != nil {
+		return post.Post{}, user.ErrUnauthenticated
+	}
+
+	if err := requireScope(ctx, user.ScopePostsWrite); err != nil {
+		return post.Post{}, err
+	}
+
+	input.Sanitize()
+
+	if err := input.Validate(); err != nil {
+		return post.Post{}, err
+	}
+
+	if !uuid.Validate(id) {
+		return post.Post{}, uuid.ErrInvalidUUID
+	}
+
+	p, err := ts.PostRepo.GetByID(ctx, id)
+	if err != nil {
+		return post.Post{}, err
+	}
+
+	if !p.CanEdit(user.UserModel{ID: currentUserID}) {
+		return post.Post{}, user.ErrForbidden
+	}
+
+	if !p.InEditWindow(time.Now()) {
+		return post.Post{}, post.ErrEditWindowExpired
+	}
+
+	if p.Body == input.Body {
+		return p, nil
+	}
+
+	p.Body = input.Body
+
+	return ts.PostRepo.Update(ctx, p)
 }
 
 func (ts *PostService) CreateReply(ctx context.Context, parentID string, input post.CreatePostInput) (post.Post, error) {
@@ -82,6 +211,10 @@ func (ts *PostService) CreateReply(ctx context.Context, parentID string, input p
 		return post.Post{}, user.ErrUnauthenticated
 	}
 
+	if err := requireScope(ctx, user.ScopePostsWrite); err != nil {
+		return post.Post{}, err
+	}
+
 	input.Sanitize()
 
 	if err := input.Validate(); err != nil {
@@ -96,6 +229,10 @@ func (ts *PostService) CreateReply(ctx context.Context, parentID string, input p
 		return post.Post{}, user.ErrNotFound
 	}
 
+	if err := ts.checkCanPublish(ctx, currentUserID); err != nil {
+		return post.Post{}, err
+	}
+
 	p, err := ts.PostRepo.Create(ctx, post.Post{
 		Body:     input.Body,
 		UserID:   currentUserID,
@@ -105,5 +242,175 @@ func (ts *PostService) CreateReply(ctx context.Context, parentID string, input p
 		return post.Post{}, err
 	}
 
+	ts.publish(ctx, post.TopicReplyAdded(parentID), p)
+
 	return p, nil
 }
+
+func (ts *PostService) Thread(ctx context.Context, rootID string, depth int) ([]post.Post, error) {
+	if !uuid.Validate(rootID) {
+		return nil, uuid.ErrInvalidUUID
+	}
+
+	if depth < 0 || depth > post.MaxThreadDepth {
+		return nil, fmt.Errorf("%w: depth must be between 0 and %d", user.ErrValidation, post.MaxThreadDepth)
+	}
+
+	posts, err := ts.PostRepo.GetThread(ctx, rootID, depth)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(posts) == 0 {
+		return nil, user.ErrNotFound
+	}
+
+	return posts, nil
+}
+
+func (ts *PostService) Like(ctx context.Context, id string) (post.Post, error) {
+	currentUserID, err := transport.GetUserIDFromContext(ctx)
+	if err != nil {
+		return post.Post{}, user.ErrUnauthenticated
+	}
+
+	if err := requireScope(ctx, user.ScopePostsWrite); err != nil {
+		return post.Post{}, err
+	}
+
+	if !uuid.Validate(id) {
+		return post.Post{}, uuid.ErrInvalidUUID
+	}
+
+	p, err := ts.PostRepo.GetByID(ctx, id)
+	if err != nil {
+		return post.Post{}, err
+	}
+
+	if err := ts.PostRepo.Like(ctx, currentUserID, p.ID); err != nil {
+		return post.Post{}, err
+	}
+
+	return p, nil
+}
+
+func (ts *PostService) Unlike(ctx context.Context, id string) (post.Post, error) {
+	currentUserID, err := transport.GetUserIDFromContext(ctx)
+	if err != nil {
+		return post.Post{}, user.ErrUnauthenticated
+	}
+
+	if err := requireScope(ctx, user.ScopePostsWrite); err != nil {
+		return post.Post{}, err
+	}
+
+	if !uuid.Validate(id) {
+		return post.Post{}, uuid.ErrInvalidUUID
+	}
+
+	p, err := ts.PostRepo.GetByID(ctx, id)
+	if err != nil {
+		return post.Post{}, err
+	}
+
+	if err := ts.PostRepo.Unlike(ctx, currentUserID, p.ID); err != nil {
+		return post.Post{}, err
+	}
+
+	return p, nil
+}
+
+func (ts *PostService) LikedPosts(ctx context.Context, userID string, input pagination.Input) (pagination.Page[post.Post], error) {
+	if !uuid.Validate(userID) {
+		return pagination.Page[post.Post]{}, uuid.ErrInvalidUUID
+	}
+
+	args, err := input.Args()
+	if err != nil {
+		return pagination.Page[post.Post]{}, err
+	}
+
+	return ts.PostRepo.GetLikedPosts(ctx, userID, args)
+}
+
+func (ts *PostService) PostCreated(ctx context.Context) (<-chan post.Post, error) {
+	return ts.subscribe(ctx, post.TopicPostCreated)
+}
+
+func (ts *PostService) PostDeleted(ctx context.Context) (<-chan post.Post, error) {
+	return ts.subscribe(ctx, post.TopicPostDeleted)
+}
+
+func (ts *PostService) ReplyAdded(ctx context.Context, parentID string) (<-chan post.Post, error) {
+	if !uuid.Validate(parentID) {
+		return nil, uuid.ErrInvalidUUID
+	}
+
+	return ts.subscribe(ctx, post.TopicReplyAdded(parentID))
+}
+
+// checkCanPublish enforces post.RequireVerifiedEmail.
+func (ts *PostService) checkCanPublish(ctx context.Context, userID string) error {
+	if !post.RequireVerifiedEmail {
+		return nil
+	}
+
+	u, err := ts.UserRepo.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if !u.IsEmailVerified() {
+		return user.ErrEmailNotVerified
+	}
+
+	return nil
+}
+
+// publish notifies subscribers of a change that is already committed, so a
+// failure is only logged instead of failing the mutation.
+func (ts *PostService) publish(ctx context.Context, topic string, p post.Post) {
+	payload, err := json.Marshal(p)
+	if err != nil {
+		log.Printf("error encoding %s event: %v", topic, err)
+		return
+	}
+
+	if err := ts.PubSub.Publish(ctx, topic, payload); err != nil {
+		log.Printf("error publishing %s event: %v", topic, err)
+	}
+}
+
+func (ts *PostService) subscribe(ctx context.Context, topic string) (<-chan post.Post, error) {
+	if _, err := transport.GetUserIDFromContext(ctx); err != nil {
+		return nil, user.ErrUnauthenticated
+	}
+
+	messages, err := ts.PubSub.Subscribe(ctx, topic)
+	if err != nil {
+		return nil, err
+	}
+
+	posts := make(chan post.Post)
+
+	go func() {
+		defer close(posts)
+
+		for payload := range messages {
+			var p post.Post
+
+			if err := json.Unmarshal(payload, &p); err != nil {
+				log.Printf("error decoding %s event: %v", topic, err)
+				continue
+			}
+
+			select {
+			case posts <- p:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return posts, nil
+}
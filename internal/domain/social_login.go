@@ -0,0 +1,217 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/RianNegreiros/go-graphql-api/internal/oidc"
+	"github.com/RianNegreiros/go-graphql-api/internal/randtoken"
+	"github.com/RianNegreiros/go-graphql-api/internal/user"
+)
+
+var socialUsernameMaxLength = 30
+
+type SocialLoginService struct {
+	UserRepo       user.UserRepo
+	IdentityRepo   user.IdentityRepo
+	PasswordHasher user.PasswordHasher
+	Providers      map[string]user.IdentityProvider
+}
+
+func NewSocialLoginService(ur user.UserRepo, ir user.IdentityRepo, ph user.PasswordHasher, providers map[string]user.IdentityProvider) *SocialLoginService {
+	return &SocialLoginService{
+		UserRepo:       ur,
+		IdentityRepo:   ir,
+		PasswordHasher: ph,
+		Providers:      providers,
+	}
+}
+
+func (ss *SocialLoginService) AuthURL(ctx context.Context, provider string) (string, error) {
+	p, ok := ss.Providers[provider]
+	if !ok {
+		return "", user.ErrUnknownProvider
+	}
+
+	state, _, err := randtoken.Generate()
+	if err != nil {
+		return "", err
+	}
+
+	nonce, _, err := randtoken.Generate()
+	if err != nil {
+		return "", err
+	}
+
+	verifier, _, err := randtoken.Generate()
+	if err != nil {
+		return "", err
+	}
+
+	if err := ss.IdentityRepo.CreateState(ctx, user.SocialLoginState{
+		State:        state,
+		Provider:     provider,
+		Nonce:        nonce,
+		CodeVerifier: verifier,
+		ExpiredAt:    time.Now().Add(user.SocialLoginStateLifeTime),
+	}); err != nil {
+		return "", err
+	}
+
+	return p.AuthCodeURL(state, nonce, verifier), nil
+}
+
+// VerifyLogin burns the state, exchanges the code and finds the user of the
+// identity. Accounts are only linked by email when both the provider and we
+// have verified it, or anyone able to register that email at some provider
+// would take the account over.
+func (ss *SocialLoginService) VerifyLogin(ctx context.Context, input user.SocialLoginInput) (string, error) {
+	p, ok := ss.Providers[input.Provider]
+	if !ok {
+		return "", user.ErrUnknownProvider
+	}
+
+	s, err := ss.IdentityRepo.ConsumeState(ctx, input.State, input.Provider)
+	if err != nil {
+		switch {
+		case errors.Is(err, user.ErrNotFound):
+			return "", user.ErrInvalidSocialLogin
+		default:
+			return "", err
+		}
+	}
+
+	claims, err := p.Exchange(ctx, input.Code, s.CodeVerifier, s.Nonce)
+	if err != nil {
+		log.Printf("error logging in with %s: %v", input.Provider, err)
+		return "", user.ErrInvalidSocialLogin
+	}
+
+	i, err := ss.IdentityRepo.GetByProviderSubject(ctx, input.Provider, claims.Subject)
+	if err == nil {
+		return i.UserID, nil
+	}
+
+	if !errors.Is(err, user.ErrNotFound) {
+		return "", err
+	}
+
+	email := strings.ToLower(strings.TrimSpace(claims.Email))
+
+	if email == "" || !claims.EmailVerified {
+		return "", user.ErrProviderEmailUnverified
+	}
+
+	u, err := ss.UserRepo.GetByEmail(ctx, email)
+	if err != nil {
+		switch {
+		case errors.Is(err, user.ErrNotFound):
+			if u, err = ss.createUser(ctx, email, claims); err != nil {
+				return "", err
+			}
+		default:
+			return "", err
+		}
+	} else if !u.IsEmailVerified() {
+		return "", user.ErrIdentityEmailTaken
+	}
+
+	if _, err := ss.IdentityRepo.Create(ctx, user.Identity{
+		UserID:   u.ID,
+		Provider: input.Provider,
+		Subject:  claims.Subject,
+		Email:    email,
+	}); err != nil {
+		return "", err
+	}
+
+	return u.ID, nil
+}
+
+// createUser registers the user of a new identity. They get a random
+// password nobody knows; one can be set with a password reset.
+func (ss *SocialLoginService) createUser(ctx context.Context, email string, claims oidc.Claims) (user.UserModel, error) {
+	username, err := ss.username(ctx, email, claims)
+	if err != nil {
+		return user.UserModel{}, err
+	}
+
+	secret, _, err := randtoken.Generate()
+	if err != nil {
+		return user.UserModel{}, err
+	}
+
+	password, err := ss.PasswordHasher.Hash(secret)
+	if err != nil {
+		return user.UserModel{}, err
+	}
+
+	u, err := ss.UserRepo.Create(ctx, user.UserModel{
+		Email:    email,
+		Username: username,
+		Password: password,
+	})
+	if err != nil {
+		return user.UserModel{}, fmt.Errorf("error creating user: %v", err)
+	}
+
+	return ss.UserRepo.MarkEmailVerified(ctx, u.ID)
+}
+
+// username picks a free username from the provider's preferred one, or the
+// local part of the email, adding a random suffix when it's taken.
+func (ss *SocialLoginService) username(ctx context.Context, email string, claims oidc.Claims) (string, error) {
+	base := sanitizeUsername(claims.PreferredUsername)
+	if len(base) < user.UsernameMinLength {
+		local, _, _ := strings.Cut(email, "@")
+		base = sanitizeUsername(local)
+	}
+
+	if len(base) < user.UsernameMinLength {
+		base = "user"
+	}
+
+	candidate := base
+
+	for i := 0; i < 5; i++ {
+		if _, err := ss.UserRepo.GetByUsername(ctx, candidate); err != nil {
+			switch {
+			case errors.Is(err, user.ErrNotFound):
+				return candidate, nil
+			default:
+				return "", err
+			}
+		}
+
+		suffix, _, err := randtoken.Generate()
+		if err != nil {
+			return "", err
+		}
+
+		candidate = base + "_" + strings.ToLower(suffix[:6])
+	}
+
+	return "", user.ErrUsernameTaken
+}
+
+func sanitizeUsername(s string) string {
+	var b strings.Builder
+
+	for _, r := range s {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '_', r == '.', r == '-':
+			b.WriteRune(r)
+		}
+	}
+
+	username := b.String()
+	if len(username) > socialUsernameMaxLength {
+		username = username[:socialUsernameMaxLength]
+	}
+
+	return username
+}
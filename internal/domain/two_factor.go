@@ -0,0 +1,295 @@
+package domain
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/RianNegreiros/go-graphql-api/internal/randtoken"
+	"github.com/RianNegreiros/go-graphql-api/internal/totp"
+	"github.com/RianNegreiros/go-graphql-api/internal/transport"
+	"github.com/RianNegreiros/go-graphql-api/internal/user"
+)
+
+const recoveryCodeAlphabet = "abcdefghijklmnopqrstuvwxyz234567"
+
+type TwoFactorService struct {
+	UserRepo      user.UserRepo
+	TwoFactorRepo user.TwoFactorRepo
+	LoginGuard    user.LoginGuard
+}
+
+func NewTwoFactorService(ur user.UserRepo, tr user.TwoFactorRepo, lg user.LoginGuard) *TwoFactorService {
+	return &TwoFactorService{
+		UserRepo:      ur,
+		TwoFactorRepo: tr,
+		LoginGuard:    lg,
+	}
+}
+
+func (ts *TwoFactorService) EnableTotp(ctx context.Context) (user.TotpSetup, error) {
+	currentUserID, err := transport.GetUserIDFromContext(ctx)
+	if err != nil {
+		return user.TotpSetup{}, user.ErrUnauthenticated
+	}
+
+	if err := requireSession(ctx); err != nil {
+		return user.TotpSetup{}, err
+	}
+
+	t, err := ts.TwoFactorRepo.GetTotp(ctx, currentUserID)
+	if err != nil && !errors.Is(err, user.ErrNotFound) {
+		return user.TotpSetup{}, err
+	}
+
+	if t.IsEnabled() {
+		return user.TotpSetup{}, user.ErrTotpAlreadyEnabled
+	}
+
+	u, err := ts.UserRepo.GetByID(ctx, currentUserID)
+	if err != nil {
+		return user.TotpSetup{}, err
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return user.TotpSetup{}, err
+	}
+
+	if _, err := ts.TwoFactorRepo.SaveTotp(ctx, u.ID, secret); err != nil {
+		return user.TotpSetup{}, err
+	}
+
+	return user.TotpSetup{
+		Secret: secret,
+		URI:    totp.URI(user.TotpIssuer, u.Email, secret),
+	}, nil
+}
+
+func (ts *TwoFactorService) ConfirmTotp(ctx context.Context, code string) ([]string, error) {
+	currentUserID, err := transport.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, user.ErrUnauthenticated
+	}
+
+	if err := requireSession(ctx); err != nil {
+		return nil, err
+	}
+
+	t, err := ts.TwoFactorRepo.GetTotp(ctx, currentUserID)
+	if err != nil {
+		switch {
+		case errors.Is(err, user.ErrNotFound):
+			return nil, user.ErrTotpNotSetUp
+		default:
+			return nil, err
+		}
+	}
+
+	if t.IsEnabled() {
+		return nil, user.ErrTotpAlreadyEnabled
+	}
+
+	var step int64
+
+	err = ts.guard(ctx, currentUserID, func() error {
+		s, ok := totp.Verify(t.Secret, normalizeCode(code), time.Now())
+		if !ok {
+			return user.ErrInvalidTwoFactor
+		}
+
+		step = s
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	codes, hashes, err := generateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ts.TwoFactorRepo.ConfirmTotp(ctx, currentUserID, step, hashes); err != nil {
+		return nil, err
+	}
+
+	return codes, nil
+}
+
+func (ts *TwoFactorService) DisableTotp(ctx context.Context, code string) error {
+	currentUserID, err := transport.GetUserIDFromContext(ctx)
+	if err != nil {
+		return user.ErrUnauthenticated
+	}
+
+	if err := requireSession(ctx); err != nil {
+		return err
+	}
+
+	if err := ts.Verify(ctx, currentUserID, code); err != nil {
+		return err
+	}
+
+	return ts.TwoFactorRepo.DeleteTotp(ctx, currentUserID)
+}
+
+func (ts *TwoFactorService) RegenerateRecoveryCodes(ctx context.Context, code string) ([]string, error) {
+	currentUserID, err := transport.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, user.ErrUnauthenticated
+	}
+
+	if err := requireSession(ctx); err != nil {
+		return nil, err
+	}
+
+	if err := ts.Verify(ctx, currentUserID, code); err != nil {
+		return nil, err
+	}
+
+	codes, hashes, err := generateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ts.TwoFactorRepo.ReplaceRecoveryCodes(ctx, currentUserID, hashes); err != nil {
+		return nil, err
+	}
+
+	return codes, nil
+}
+
+func (ts *TwoFactorService) Status(ctx context.Context) (user.TwoFactorStatus, error) {
+	currentUserID, err := transport.GetUserIDFromContext(ctx)
+	if err != nil {
+		return user.TwoFactorStatus{}, user.ErrUnauthenticated
+	}
+
+	enabled, err := ts.IsEnabled(ctx, currentUserID)
+	if err != nil || !enabled {
+		return user.TwoFactorStatus{}, err
+	}
+
+	remaining, err := ts.TwoFactorRepo.CountRecoveryCodes(ctx, currentUserID)
+	if err != nil {
+		return user.TwoFactorStatus{}, err
+	}
+
+	return user.TwoFactorStatus{
+		Enabled:                true,
+		RecoveryCodesRemaining: remaining,
+	}, nil
+}
+
+func (ts *TwoFactorService) IsEnabled(ctx context.Context, userID string) (bool, error) {
+	t, err := ts.TwoFactorRepo.GetTotp(ctx, userID)
+	if err != nil {
+		switch {
+		case errors.Is(err, user.ErrNotFound):
+			return false, nil
+		default:
+			return false, err
+		}
+	}
+
+	return t.IsEnabled(), nil
+}
+
+func (ts *TwoFactorService) Verify(ctx context.Context, userID string, code string) error {
+	t, err := ts.TwoFactorRepo.GetTotp(ctx, userID)
+	if err != nil && !errors.Is(err, user.ErrNotFound) {
+		return err
+	}
+
+	if !t.IsEnabled() {
+		return user.ErrTotpNotEnabled
+	}
+
+	return ts.guard(ctx, userID, func() error {
+		code := normalizeCode(code)
+
+		if step, ok := totp.Verify(t.Secret, code, time.Now()); ok {
+			return ts.TwoFactorRepo.UseTotpStep(ctx, userID, step)
+		}
+
+		if err := ts.TwoFactorRepo.UseRecoveryCode(ctx, userID, randtoken.Hash(code)); err != nil {
+			switch {
+			case errors.Is(err, user.ErrNotFound):
+				return user.ErrInvalidTwoFactor
+			default:
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// guard throttles check with the LoginGuard of the user's account, so codes
+// can't be guessed any faster than passwords.
+func (ts *TwoFactorService) guard(ctx context.Context, userID string, check func() error) error {
+	u, err := ts.UserRepo.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	ip := transport.GetClientIPFromContext(ctx)
+
+	if err := ts.LoginGuard.Allow(ctx, u.Email, ip); err != nil {
+		return err
+	}
+
+	// Only wrong codes count as failures.
+	if err := check(); err != nil {
+		if !errors.Is(err, user.ErrInvalidTwoFactor) {
+			if err := ts.LoginGuard.Release(ctx, u.Email, ip); err != nil {
+				return err
+			}
+		}
+
+		return err
+	}
+
+	return ts.LoginGuard.Succeed(ctx, u.Email, ip)
+}
+
+// normalizeCode lets users type codes with spaces, dashes or capitals.
+func normalizeCode(code string) string {
+	code = strings.ToLower(code)
+
+	return strings.Map(func(r rune) rune {
+		if r == ' ' || r == '-' {
+			return -1
+		}
+
+		return r
+	}, code)
+}
+
+// generateRecoveryCodes returns user.RecoveryCodeCount codes formatted as
+// xxxxx-xxxxx, and the hashes to store.
+func generateRecoveryCodes() (codes []string, hashes []string, err error) {
+	for i := 0; i < user.RecoveryCodeCount; i++ {
+		b := make([]byte, 10)
+
+		if _, err := rand.Read(b); err != nil {
+			return nil, nil, fmt.Errorf("error generating recovery code: %v", err)
+		}
+
+		for j := range b {
+			b[j] = recoveryCodeAlphabet[int(b[j])%len(recoveryCodeAlphabet)]
+		}
+
+		code := string(b[:5]) + "-" + string(b[5:])
+
+		codes = append(codes, code)
+		hashes = append(hashes, randtoken.Hash(normalizeCode(code)))
+	}
+
+	return codes, hashes, nil
+}
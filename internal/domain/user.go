@@ -3,6 +3,8 @@ package domain
 import (
 	"context"
 
+	"github.com/RianNegreiros/go-graphql-api/internal/pagination"
+	"github.com/RianNegreiros/go-graphql-api/internal/transport"
 	"github.com/RianNegreiros/go-graphql-api/internal/user"
 	"github.com/RianNegreiros/go-graphql-api/internal/uuid"
 )
@@ -17,10 +19,193 @@ func NewUserService(ur user.UserRepo) *UserService {
 	}
 }
 
+// GetByID doesn't find deleted accounts, their row is only kept for the
+// posts others replied to.
 func (u *UserService) GetByID(ctx context.Context, id string) (user.UserModel, error) {
 	if !uuid.Validate(id) {
 		return user.UserModel{}, uuid.ErrInvalidUUID
 	}
 
-	return u.UserRepo.GetByID(ctx, id)
+	return existingUser(u.UserRepo.GetByID(ctx, id))
+}
+
+func (u *UserService) GetByUsername(ctx context.Context, username string) (user.UserModel, error) {
+	return existingUser(u.UserRepo.GetByUsername(ctx, username))
+}
+
+func (u *UserService) UpdateProfile(ctx context.Context, input user.UpdateProfileInput) (user.UserModel, error) {
+	currentUserID, err := transport.GetUserIDFromContext(ctx)
+	if err != nil {
+		return user.UserModel{}, user.ErrUnauthenticated
+	}
+
+	if err := requireScope(ctx, user.ScopeUsersWrite); err != nil {
+		return user.UserModel{}, err
+	}
+
+	input.Sanitize()
+
+	if err := input.Validate(); err != nil {
+		return user.UserModel{}, err
+	}
+
+	currentUser, err := u.UserRepo.GetByID(ctx, currentUserID)
+	if err != nil {
+		return user.UserModel{}, err
+	}
+
+	return u.UserRepo.UpdateProfile(ctx, input.Apply(currentUser))
+}
+
+func (u *UserService) GrantRole(ctx context.Context, userID string, role user.Role) (user.UserModel, error) {
+	if err := u.authorizeRoleChange(ctx, userID, role); err != nil {
+		return user.UserModel{}, err
+	}
+
+	return u.UserRepo.UpdateRole(ctx, userID, role)
+}
+
+// RevokeRole demotes the user back to user.RoleUser if they currently have
+// role; revoking a role the user doesn't have is a no-op.
+func (u *UserService) RevokeRole(ctx context.Context, userID string, role user.Role) (user.UserModel, error) {
+	if err := u.authorizeRoleChange(ctx, userID, role); err != nil {
+		return user.UserModel{}, err
+	}
+
+	target, err := u.UserRepo.GetByID(ctx, userID)
+	if err != nil {
+		return user.UserModel{}, err
+	}
+
+	if target.Role != role || role == user.RoleUser {
+		return target, nil
+	}
+
+	return u.UserRepo.UpdateRole(ctx, userID, user.RoleUser)
+}
+
+func (u *UserService) authorizeRoleChange(ctx context.Context, userID string, role user.Role) error {
+	admin, err := authorize(ctx, user.PermissionManageRoles)
+	if err != nil {
+		return err
+	}
+
+	if !uuid.Validate(userID) {
+		return uuid.ErrInvalidUUID
+	}
+
+	if !role.IsValid() {
+		return user.ErrInvalidRole
+	}
+
+	if userID == admin.ID {
+		return user.ErrCannotChangeSelf
+	}
+
+	return nil
+}
+
+func (u *UserService) Follow(ctx context.Context, userID string) (user.UserModel, error) {
+	currentUserID, err := transport.GetUserIDFromContext(ctx)
+	if err != nil {
+		return user.UserModel{}, user.ErrUnauthenticated
+	}
+
+	if err := requireScope(ctx, user.ScopeUsersWrite); err != nil {
+		return user.UserModel{}, err
+	}
+
+	if !uuid.Validate(userID) {
+		return user.UserModel{}, uuid.ErrInvalidUUID
+	}
+
+	if userID == currentUserID {
+		return user.UserModel{}, user.ErrCannotFollowSelf
+	}
+
+	followee, err := existingUser(u.UserRepo.GetByID(ctx, userID))
+	if err != nil {
+		return user.UserModel{}, err
+	}
+
+	if err := u.UserRepo.Follow(ctx, currentUserID, followee.ID); err != nil {
+		return user.UserModel{}, err
+	}
+
+	return followee, nil
+}
+
+func (u *UserService) Unfollow(ctx context.Context, userID string) (user.UserModel, error) {
+	currentUserID, err := transport.GetUserIDFromContext(ctx)
+	if err != nil {
+		return user.UserModel{}, user.ErrUnauthenticated
+	}
+
+	if err := requireScope(ctx, user.ScopeUsersWrite); err != nil {
+		return user.UserModel{}, err
+	}
+
+	if !uuid.Validate(userID) {
+		return user.UserModel{}, uuid.ErrInvalidUUID
+	}
+
+	followee, err := u.UserRepo.GetByID(ctx, userID)
+	if err != nil {
+		return user.UserModel{}, err
+	}
+
+	if err := u.UserRepo.Unfollow(ctx, currentUserID, followee.ID); err != nil {
+		return user.UserModel{}, err
+	}
+
+	return followee, nil
+}
+
+func (u *UserService) Followers(ctx context.Context, userID string, input pagination.Input) (pagination.Page[user.UserModel], error) {
+	if !uuid.Validate(userID) {
+		return pagination.Page[user.UserModel]{}, uuid.ErrInvalidUUID
+	}
+
+	args, err := input.Args()
+	if err != nil {
+		return pagination.Page[user.UserModel]{}, err
+	}
+
+	pages, err := u.UserRepo.GetFollowers(ctx, []string{userID}, args)
+	if err != nil {
+		return pagination.Page[user.UserModel]{}, err
+	}
+
+	return pages[userID], nil
+}
+
+func (u *UserService) Following(ctx context.Context, userID string, input pagination.Input) (pagination.Page[user.UserModel], error) {
+	if !uuid.Validate(userID) {
+		return pagination.Page[user.UserModel]{}, uuid.ErrInvalidUUID
+	}
+
+	args, err := input.Args()
+	if err != nil {
+		return pagination.Page[user.UserModel]{}, err
+	}
+
+	pages, err := u.UserRepo.GetFollowing(ctx, []string{userID}, args)
+	if err != nil {
+		return pagination.Page[user.UserModel]{}, err
+	}
+
+	return pages[userID], nil
+}
+
+// existingUser reports deleted accounts as not found.
+func existingUser(u user.UserModel, err error) (user.UserModel, error) {
+	if err != nil {
+		return user.UserModel{}, err
+	}
+
+	if u.IsDeleted() {
+		return user.UserModel{}, user.ErrNotFound
+	}
+
+	return u, nil
 }
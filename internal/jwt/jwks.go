@@ -0,0 +1,25 @@
+package jwt
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// JWKSPath is where other services fetch the keys to verify tokens with.
+const JWKSPath = "/.well-known/jwks.json"
+
+// JWKSHandler serves the public keys as a JSON Web Key Set. Keys are cached
+// for a few minutes, so a new key should be published before it signs tokens.
+func (s *TokenService) JWKSHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := json.Marshal(s.Keys.PublicSet())
+		if err != nil {
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "public, max-age=300")
+		w.Write(body)
+	})
+}
@@ -9,21 +9,43 @@ import (
 	"github.com/RianNegreiros/go-graphql-api/config"
 	"github.com/RianNegreiros/go-graphql-api/internal/user"
 	_ "github.com/lestrrat-go/jwx"
-	"github.com/lestrrat-go/jwx/jwa"
 	jwtGo "github.com/lestrrat-go/jwx/jwt"
 )
 
-var (
-	signatureType = jwa.HS256
-	Now           = time.Now
+const (
+	SessionIDKey = "sid"
+	RoleKey      = "role"
+	EmailKey     = "email"
+	NewEmailKey  = "new_email"
+	ClientIDKey  = "client_id"
+	ScopeKey     = "scope"
+	GrantIDKey   = "gid"
+
+	// PurposeKey marks tokens that are not access or refresh tokens, so they
+	// can't be used to authenticate.
+	PurposeKey = "purpose"
+)
+
+const (
+	purposeEmailVerification = "email_verification"
+	purposeEmailChange       = "email_change"
+	purposeTwoFactor         = "two_factor"
 )
 
+var Now = time.Now
+
 type TokenService struct {
 	Conf *config.Config
+	Keys *Keys
 }
 
-func NewTokenService(conf *config.Config) *TokenService {
-	return &TokenService{Conf: conf}
+func NewTokenService(conf *config.Config) (*TokenService, error) {
+	keys, err := LoadKeys(conf)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TokenService{Conf: conf, Keys: keys}, nil
 }
 
 func (s *TokenService) ParseTokenFromRequest(ctx context.Context, r *http.Request) (user.AuthToken, error) {
@@ -31,9 +53,10 @@ func (s *TokenService) ParseTokenFromRequest(ctx context.Context, r *http.Reques
 		r,
 		jwtGo.WithValidate(true),
 		jwtGo.WithIssuer(s.Conf.JWT.Issuer),
-		jwtGo.WithVerify(signatureType, []byte(s.Conf.JWT.Secret)),
+		jwtGo.WithKeySet(s.Keys.verify),
+		jwtGo.UseDefaultKey(true),
 	)
-	if err != nil {
+	if err != nil || hasPurpose(token) || isOAuthToken(token) {
 		return user.AuthToken{}, user.ErrInvalidToken
 	}
 
@@ -41,20 +64,45 @@ func (s *TokenService) ParseTokenFromRequest(ctx context.Context, r *http.Reques
 }
 
 func buildToken(token jwtGo.Token) user.AuthToken {
+	var sessionID, role string
+
+	if v, ok := token.Get(SessionIDKey); ok {
+		sessionID, _ = v.(string)
+	}
+
+	if v, ok := token.Get(RoleKey); ok {
+		role, _ = v.(string)
+	}
+
 	return user.AuthToken{
-		ID:  token.JwtID(),
-		Sub: token.Subject(),
+		ID:        token.JwtID(),
+		Sub:       token.Subject(),
+		SessionID: sessionID,
+		Role:      user.Role(role),
 	}
 }
 
+func hasPurpose(token jwtGo.Token) bool {
+	_, ok := token.Get(PurposeKey)
+	return ok
+}
+
+// isOAuthToken reports whether the token was issued to an OAuth client. Those
+// are limited to their scopes, so they must never pass for a session.
+func isOAuthToken(token jwtGo.Token) bool {
+	_, ok := token.Get(ClientIDKey)
+	return ok
+}
+
 func (s *TokenService) ParseToken(ctx context.Context, payload string) (user.AuthToken, error) {
 	token, err := jwtGo.Parse(
 		[]byte(payload),
 		jwtGo.WithValidate(true),
 		jwtGo.WithIssuer(s.Conf.JWT.Issuer),
-		jwtGo.WithVerify(signatureType, []byte(s.Conf.JWT.Secret)),
+		jwtGo.WithKeySet(s.Keys.verify),
+		jwtGo.UseDefaultKey(true),
 	)
-	if err != nil {
+	if err != nil || hasPurpose(token) || isOAuthToken(token) {
 		return user.AuthToken{}, user.ErrInvalidToken
 	}
 
@@ -72,22 +120,179 @@ func (s *TokenService) CreateRefreshToken(ctx context.Context, user user.UserMod
 		return "", fmt.Errorf("failed to set jwt id: %w", err)
 	}
 
-	token, err := jwtGo.Sign(t, signatureType, []byte(s.Conf.JWT.Secret))
+	return s.sign(t)
+}
+
+func (s *TokenService) CreateAccessToken(ctx context.Context, user user.UserModel, sessionID string) (string, error) {
+	t := jwtGo.New()
+
+	if err := setDefaultToken(t, user, AccessTokenLifeTime, s.Conf); err != nil {
+		return "", err
+	}
+
+	if err := t.Set(SessionIDKey, sessionID); err != nil {
+		return "", fmt.Errorf("failed to set jwt session id: %w", err)
+	}
+
+	return s.sign(t)
+}
+
+// CreateEmailVerificationToken signs a token proving the user controls their
+// current email address.
+func (s *TokenService) CreateEmailVerificationToken(ctx context.Context, user user.UserModel) (string, error) {
+	return s.createPurposeToken(user, purposeEmailVerification, EmailVerificationTokenLifeTime, map[string]string{
+		EmailKey: user.Email,
+	})
+}
+
+func (s *TokenService) ParseEmailVerificationToken(ctx context.Context, payload string) (user.EmailVerificationToken, error) {
+	token, err := s.parsePurposeToken(payload, purposeEmailVerification)
 	if err != nil {
-		return "", fmt.Errorf("failed to sign jwt: %w", err)
+		return user.EmailVerificationToken{}, err
 	}
 
-	return string(token), nil
+	return user.EmailVerificationToken{
+		Sub:   token.Subject(),
+		Email: getString(token, EmailKey),
+	}, nil
+}
+
+// CreateEmailChangeToken signs a token proving the user controls newEmail.
+// It carries the current email too, so it can't be redeemed once the email
+// has changed again.
+func (s *TokenService) CreateEmailChangeToken(ctx context.Context, user user.UserModel, newEmail string) (string, error) {
+	return s.createPurposeToken(user, purposeEmailChange, EmailVerificationTokenLifeTime, map[string]string{
+		EmailKey:    user.Email,
+		NewEmailKey: newEmail,
+	})
+}
+
+func (s *TokenService) ParseEmailChangeToken(ctx context.Context, payload string) (user.EmailChangeToken, error) {
+	token, err := s.parsePurposeToken(payload, purposeEmailChange)
+	if err != nil {
+		return user.EmailChangeToken{}, err
+	}
+
+	return user.EmailChangeToken{
+		Sub:      token.Subject(),
+		Email:    getString(token, EmailKey),
+		NewEmail: getString(token, NewEmailKey),
+	}, nil
+}
+
+// CreateTwoFactorChallengeToken signs a token proving the user passed the
+// password step of a login.
+func (s *TokenService) CreateTwoFactorChallengeToken(ctx context.Context, user user.UserModel) (string, error) {
+	return s.createPurposeToken(user, purposeTwoFactor, TwoFactorChallengeLifeTime, nil)
+}
+
+func (s *TokenService) ParseTwoFactorChallengeToken(ctx context.Context, payload string) (user.TwoFactorChallengeToken, error) {
+	token, err := s.parsePurposeToken(payload, purposeTwoFactor)
+	if err != nil {
+		return user.TwoFactorChallengeToken{}, err
+	}
+
+	return user.TwoFactorChallengeToken{
+		Sub: token.Subject(),
+	}, nil
 }
 
-func (s *TokenService) CreateAccessToken(ctx context.Context, user user.UserModel) (string, error) {
+// CreateOAuthAccessToken signs an access token for the grant a user gave an
+// OAuth client.
+func (s *TokenService) CreateOAuthAccessToken(ctx context.Context, grant user.OAuthGrant) (string, error) {
 	t := jwtGo.New()
 
-	if err := setDefaultToken(t, user, AccessTokenLifeTime, s.Conf); err != nil {
+	if err := setDefaultToken(t, user.UserModel{ID: grant.UserID}, AccessTokenLifeTime, s.Conf); err != nil {
+		return "", err
+	}
+
+	claims := map[string]string{
+		ClientIDKey: grant.ClientID,
+		GrantIDKey:  grant.ID,
+		ScopeKey:    user.FormatScopes(grant.Scopes),
+	}
+
+	for k, v := range claims {
+		if err := t.Set(k, v); err != nil {
+			return "", fmt.Errorf("failed to set jwt %s: %w", k, err)
+		}
+	}
+
+	return s.sign(t)
+}
+
+func (s *TokenService) ParseOAuthAccessToken(ctx context.Context, payload string) (user.OAuthAccessToken, error) {
+	token, err := jwtGo.Parse(
+		[]byte(payload),
+		jwtGo.WithValidate(true),
+		jwtGo.WithIssuer(s.Conf.JWT.Issuer),
+		jwtGo.WithKeySet(s.Keys.verify),
+		jwtGo.UseDefaultKey(true),
+	)
+	if err != nil || hasPurpose(token) || !isOAuthToken(token) {
+		return user.OAuthAccessToken{}, user.ErrInvalidOAuthToken
+	}
+
+	t := user.OAuthAccessToken{
+		Sub:       token.Subject(),
+		ClientID:  getString(token, ClientIDKey),
+		GrantID:   getString(token, GrantIDKey),
+		Scopes:    user.ParseScopes(getString(token, ScopeKey)),
+		ExpiredAt: token.Expiration(),
+	}
+
+	if t.ClientID == "" || t.GrantID == "" {
+		return user.OAuthAccessToken{}, user.ErrInvalidOAuthToken
+	}
+
+	return t, nil
+}
+
+func (s *TokenService) createPurposeToken(user user.UserModel, purpose string, lifetime time.Duration, claims map[string]string) (string, error) {
+	t := jwtGo.New()
+
+	if err := setDefaultToken(t, user, lifetime, s.Conf); err != nil {
 		return "", err
 	}
 
-	token, err := jwtGo.Sign(t, signatureType, []byte(s.Conf.JWT.Secret))
+	for k, v := range claims {
+		if err := t.Set(k, v); err != nil {
+			return "", fmt.Errorf("failed to set jwt %s: %w", k, err)
+		}
+	}
+
+	if err := t.Set(PurposeKey, purpose); err != nil {
+		return "", fmt.Errorf("failed to set jwt purpose: %w", err)
+	}
+
+	return s.sign(t)
+}
+
+func (s *TokenService) parsePurposeToken(payload string, purpose string) (jwtGo.Token, error) {
+	token, err := jwtGo.Parse(
+		[]byte(payload),
+		jwtGo.WithValidate(true),
+		jwtGo.WithIssuer(s.Conf.JWT.Issuer),
+		jwtGo.WithKeySet(s.Keys.verify),
+		jwtGo.UseDefaultKey(true),
+		jwtGo.WithClaimValue(PurposeKey, purpose),
+	)
+	if err != nil {
+		return nil, user.ErrInvalidToken
+	}
+
+	return token, nil
+}
+
+func getString(token jwtGo.Token, key string) string {
+	v, _ := token.Get(key)
+	s, _ := v.(string)
+
+	return s
+}
+
+func (s *TokenService) sign(t jwtGo.Token) (string, error) {
+	token, err := jwtGo.Sign(t, s.Keys.Algorithm(), s.Keys.signing)
 	if err != nil {
 		return "", fmt.Errorf("failed to sign jwt: %w", err)
 	}
@@ -112,5 +317,11 @@ func setDefaultToken(t jwtGo.Token, user user.UserModel, lifetime time.Duration,
 		return fmt.Errorf("failed to set jwt expiration: %w", err)
 	}
 
+	if user.Role != "" {
+		if err := t.Set(RoleKey, string(user.Role)); err != nil {
+			return fmt.Errorf("failed to set jwt role: %w", err)
+		}
+	}
+
 	return nil
 }
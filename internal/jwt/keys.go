@@ -0,0 +1,176 @@
+package jwt
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/RianNegreiros/go-graphql-api/config"
+	"github.com/lestrrat-go/jwx/jwa"
+	"github.com/lestrrat-go/jwx/jwk"
+)
+
+var ErrNoSigningKey = errors.New("no jwt signing key configured")
+
+// Keys holds the key tokens are signed with and every key they are verified
+// with. Keys are identified by their kid, so tokens signed with a previous key
+// stay valid while it is kept around for rotation.
+type Keys struct {
+	signing jwk.Key
+	verify  jwk.Set
+	public  jwk.Set
+}
+
+// LoadKeys reads the PEM files in conf.JWT.PrivateKeyFiles, the first one
+// signing new tokens. Without any, tokens are signed with HS256 and
+// conf.JWT.Secret, and there are no public keys to publish.
+func LoadKeys(conf *config.Config) (*Keys, error) {
+	if len(conf.JWT.PrivateKeyFiles) == 0 {
+		if conf.JWT.Secret == "" {
+			return nil, ErrNoSigningKey
+		}
+
+		key, err := newKey([]byte(conf.JWT.Secret), jwa.HS256)
+		if err != nil {
+			return nil, err
+		}
+
+		return newKeys([]jwk.Key{key})
+	}
+
+	keys := make([]jwk.Key, len(conf.JWT.PrivateKeyFiles))
+
+	for i, path := range conf.JWT.PrivateKeyFiles {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("error reading jwt key: %w", err)
+		}
+
+		key, err := ParsePrivateKey(data)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing jwt key %s: %w", path, err)
+		}
+
+		keys[i] = key
+	}
+
+	return newKeys(keys)
+}
+
+// ParsePrivateKey parses a PEM encoded RSA, P-256 ECDSA or Ed25519 private
+// key, choosing RS256, ES256 or EdDSA for it.
+func ParsePrivateKey(data []byte) (jwk.Key, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM data found")
+	}
+
+	var (
+		raw interface{}
+		err error
+	)
+
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		raw, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		raw, err = x509.ParseECPrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		raw, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("unsupported PEM block %q", block.Type)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	var alg jwa.SignatureAlgorithm
+
+	switch k := raw.(type) {
+	case *rsa.PrivateKey:
+		alg = jwa.RS256
+	case *ecdsa.PrivateKey:
+		if k.Curve != elliptic.P256() {
+			return nil, fmt.Errorf("unsupported curve %s, only P-256 is", k.Curve.Params().Name)
+		}
+
+		alg = jwa.ES256
+	case ed25519.PrivateKey:
+		alg = jwa.EdDSA
+	default:
+		return nil, fmt.Errorf("unsupported key type %T", raw)
+	}
+
+	return newKey(raw, alg)
+}
+
+func newKey(raw interface{}, alg jwa.SignatureAlgorithm) (jwk.Key, error) {
+	key, err := jwk.New(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := key.Set(jwk.AlgorithmKey, alg); err != nil {
+		return nil, err
+	}
+
+	if err := jwk.AssignKeyID(key); err != nil {
+		return nil, err
+	}
+
+	return key, nil
+}
+
+func newKeys(keys []jwk.Key) (*Keys, error) {
+	verify := jwk.NewSet()
+	public := jwk.NewSet()
+
+	for _, key := range keys {
+		// A shared secret verifies itself and is never published.
+		if key.KeyType() == jwa.OctetSeq {
+			verify.Add(key)
+			continue
+		}
+
+		pub, err := jwk.PublicKeyOf(key)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := pub.Set(jwk.KeyUsageKey, jwk.ForSignature); err != nil {
+			return nil, err
+		}
+
+		verify.Add(pub)
+		public.Add(pub)
+	}
+
+	return &Keys{
+		signing: keys[0],
+		verify:  verify,
+		public:  public,
+	}, nil
+}
+
+// Algorithm is the algorithm new tokens are signed with.
+func (k *Keys) Algorithm() jwa.SignatureAlgorithm {
+	return jwa.SignatureAlgorithm(k.signing.Algorithm())
+}
+
+// KeyID is the kid of the key new tokens are signed with.
+func (k *Keys) KeyID() string {
+	return k.signing.KeyID()
+}
+
+// PublicSet returns the public keys tokens can be verified with, empty when
+// signing with a shared secret.
+func (k *Keys) PublicSet() jwk.Set {
+	return k.public
+}
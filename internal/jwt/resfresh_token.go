@@ -2,29 +2,55 @@ package jwt
 
 import (
 	"context"
+	"errors"
 	"time"
 )
 
 var (
 	AccessTokenLifeTime  = time.Minute * 15
 	RefreshTokenLifeTime = time.Hour * 24 * 7
+
+	EmailVerificationTokenLifeTime = time.Hour * 24
+	TwoFactorChallengeLifeTime     = time.Minute * 5
+)
+
+var (
+	ErrRefreshTokenRevoked = errors.New("refresh token revoked")
 )
 
 type RefreshToken struct {
 	ID         string
 	Name       string
 	UserID     string
+	FamilyID   string
 	LastUsedAt time.Time
 	ExpiredAt  time.Time
+	RevokedAt  *time.Time
 	CreatedAt  time.Time
 }
 
+func (rt RefreshToken) IsRevoked() bool {
+	return rt.RevokedAt != nil
+}
+
+func (rt RefreshToken) IsExpired() bool {
+	return Now().After(rt.ExpiredAt)
+}
+
 type CreateRefreshTokenParams struct {
-	Sub  string
-	Name string
+	Sub      string
+	Name     string
+	FamilyID string
 }
 
 type RefreshTokenRepo interface {
 	Create(ctx context.Context, params CreateRefreshTokenParams) (RefreshToken, error)
 	GetByID(ctx context.Context, id string) (RefreshToken, error)
+	Rotate(ctx context.Context, id string, params CreateRefreshTokenParams) (RefreshToken, error)
+	RevokeFamily(ctx context.Context, familyID string) error
+	GetActiveByFamilyID(ctx context.Context, familyID string) (RefreshToken, error)
+	GetActiveByUserID(ctx context.Context, userID string) ([]RefreshToken, error)
+	RevokeAllByUserID(ctx context.Context, userID string) error
+	// RevokeAllByUserIDExcept revokes every session of the user but familyID.
+	RevokeAllByUserIDExcept(ctx context.Context, userID string, familyID string) error
 }
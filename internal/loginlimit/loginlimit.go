@@ -0,0 +1,174 @@
+package loginlimit
+
+import (
+	"context"
+	"time"
+
+	"github.com/RianNegreiros/go-graphql-api/internal/user"
+)
+
+var Now = time.Now
+
+// Policy decides how long a key has to wait after a number of consecutive
+// failures. The first FreeAttempts failures cost nothing, then the delay
+// doubles from BaseDelay up to MaxDelay, and from LockoutAttempts on the key
+// is locked for LockoutDuration.
+type Policy struct {
+	FreeAttempts    int
+	BaseDelay       time.Duration
+	MaxDelay        time.Duration
+	LockoutAttempts int
+	LockoutDuration time.Duration
+	// Window is how long failures are remembered after the last one.
+	Window time.Duration
+}
+
+var (
+	AccountPolicy = Policy{
+		FreeAttempts:    3,
+		BaseDelay:       time.Second,
+		MaxDelay:        time.Minute,
+		LockoutAttempts: 10,
+		LockoutDuration: 15 * time.Minute,
+		Window:          time.Hour,
+	}
+
+	// IPPolicy is looser than AccountPolicy, since many users may share an
+	// address behind a NAT.
+	IPPolicy = Policy{
+		FreeAttempts:    20,
+		BaseDelay:       time.Second,
+		MaxDelay:        time.Minute,
+		LockoutAttempts: 100,
+		LockoutDuration: time.Hour,
+		Window:          time.Hour,
+	}
+)
+
+func (p Policy) Delay(failures int) time.Duration {
+	switch {
+	case failures >= p.LockoutAttempts:
+		return p.LockoutDuration
+	case failures < p.FreeAttempts:
+		return 0
+	}
+
+	delay := p.BaseDelay
+	for i := p.FreeAttempts; i < failures && delay < p.MaxDelay; i++ {
+		delay *= 2
+	}
+
+	return min(delay, p.MaxDelay)
+}
+
+type Attempts struct {
+	Failures      int
+	LastFailureAt time.Time
+}
+
+type Store interface {
+	// Reserve records an attempt on key as a failure up front, unless check
+	// refuses it given the failures recorded so far. Checking and recording
+	// happen atomically, so parallel attempts can't all pass the check before
+	// any of them is recorded. Failures are forgotten once the last one is
+	// more than window ago.
+	Reserve(ctx context.Context, key string, window time.Duration, check func(Attempts) error) error
+	// Release takes back an attempt reserved on key.
+	Release(ctx context.Context, key string) error
+	Reset(ctx context.Context, key string) error
+}
+
+// Limiter is a user.LoginGuard keeping its counters in a Store, so they can be
+// shared between API instances.
+type Limiter struct {
+	Store   Store
+	Account Policy
+	IP      Policy
+}
+
+func New(store Store) *Limiter {
+	return &Limiter{
+		Store:   store,
+		Account: AccountPolicy,
+		IP:      IPPolicy,
+	}
+}
+
+// Allow reserves an attempt on the account and the IP, which counts as a
+// failure until Release or Succeed takes it back.
+func (l *Limiter) Allow(ctx context.Context, email, ip string) error {
+	keys := l.keys(email, ip)
+
+	for i, k := range keys {
+		policy := k.policy
+
+		err := l.Store.Reserve(ctx, k.name, policy.Window, func(a Attempts) error {
+			if a.Failures == 0 {
+				return nil
+			}
+
+			if wait := a.LastFailureAt.Add(policy.Delay(a.Failures)).Sub(Now()); wait > 0 {
+				return &user.LoginLockedError{RetryAfter: wait}
+			}
+
+			return nil
+		})
+		if err != nil {
+			for _, reserved := range keys[:i] {
+				if err := l.Store.Release(ctx, reserved.name); err != nil {
+					return err
+				}
+			}
+
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (l *Limiter) Release(ctx context.Context, email, ip string) error {
+	for _, k := range l.keys(email, ip) {
+		if err := l.Store.Release(ctx, k.name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Succeed takes back the attempt and forgets the failures of the account.
+// Those of the IP are kept, so logging into an own account doesn't reset the
+// budget for guessing others.
+func (l *Limiter) Succeed(ctx context.Context, email, ip string) error {
+	if ip != "" {
+		if err := l.Store.Release(ctx, ipKey(ip)); err != nil {
+			return err
+		}
+	}
+
+	return l.Store.Reset(ctx, accountKey(email))
+}
+
+type key struct {
+	name   string
+	policy Policy
+}
+
+func (l *Limiter) keys(email, ip string) []key {
+	keys := []key{{name: accountKey(email), policy: l.Account}}
+
+	if ip != "" {
+		keys = append(keys, key{name: ipKey(ip), policy: l.IP})
+	}
+
+	return keys
+}
+
+func accountKey(email string) string {
+	return "account:" + email
+}
+
+func ipKey(ip string) string {
+	return "ip:" + ip
+}
@@ -0,0 +1,98 @@
+package loginlimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// sweepInterval is how often Memory drops the keys whose window has passed.
+var sweepInterval = time.Minute
+
+// Memory is an in-process Store. Counters aren't shared between API
+// instances, so each one allows its own budget of attempts.
+type Memory struct {
+	mu       sync.Mutex
+	attempts map[string]memoryAttempts
+	sweptAt  time.Time
+}
+
+type memoryAttempts struct {
+	Attempts
+	expiredAt time.Time
+}
+
+func NewMemory() *Memory {
+	return &Memory{
+		attempts: map[string]memoryAttempts{},
+	}
+}
+
+func (m *Memory) Reserve(ctx context.Context, key string, window time.Duration, check func(Attempts) error) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := Now()
+
+	m.sweep(now)
+
+	a := m.attempts[key]
+	if !now.Before(a.expiredAt) {
+		a = memoryAttempts{}
+	}
+
+	if err := check(a.Attempts); err != nil {
+		return err
+	}
+
+	a.Failures++
+	a.LastFailureAt = now
+	a.expiredAt = now.Add(window)
+
+	m.attempts[key] = a
+
+	return nil
+}
+
+func (m *Memory) Release(ctx context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	a, ok := m.attempts[key]
+	if !ok {
+		return nil
+	}
+
+	if a.Failures <= 1 {
+		delete(m.attempts, key)
+		return nil
+	}
+
+	a.Failures--
+	m.attempts[key] = a
+
+	return nil
+}
+
+func (m *Memory) Reset(ctx context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.attempts, key)
+
+	return nil
+}
+
+func (m *Memory) sweep(now time.Time) {
+	if now.Sub(m.sweptAt) < sweepInterval {
+		return
+	}
+
+	for k, a := range m.attempts {
+		if !now.Before(a.expiredAt) {
+			delete(m.attempts, k)
+		}
+	}
+
+	m.sweptAt = now
+}
@@ -0,0 +1,97 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/smtp"
+	"strings"
+	"sync"
+	"time"
+)
+
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+type Mailer interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// SMTP sends messages through an SMTP server, authenticating with PLAIN auth
+// when a username is set.
+type SMTP struct {
+	Addr string
+	From string
+	Auth smtp.Auth
+}
+
+func NewSMTP(host string, port string, username string, password string, from string) *SMTP {
+	var auth smtp.Auth
+	if username != "" {
+		auth = smtp.PlainAuth("", username, password, host)
+	}
+
+	return &SMTP{
+		Addr: host + ":" + port,
+		From: from,
+		Auth: auth,
+	}
+}
+
+func (s *SMTP) Send(ctx context.Context, msg Message) error {
+	if err := smtp.SendMail(s.Addr, s.Auth, s.From, []string{msg.To}, format(s.From, msg)); err != nil {
+		return fmt.Errorf("error sending mail: %v", err)
+	}
+
+	return nil
+}
+
+// Log writes messages to a writer instead of sending them, for local
+// development. Pointing it at a file keeps every message around.
+type Log struct {
+	From string
+
+	mu     sync.Mutex
+	writer io.Writer
+}
+
+func NewLog(w io.Writer, from string) *Log {
+	return &Log{
+		From:   from,
+		writer: w,
+	}
+}
+
+func (l *Log) Send(ctx context.Context, msg Message) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if _, err := fmt.Fprintf(l.writer, "%s\r\n", format(l.From, msg)); err != nil {
+		return fmt.Errorf("error writing mail: %v", err)
+	}
+
+	return nil
+}
+
+func format(from string, msg Message) []byte {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "From: %s\r\n", headerValue(from))
+	fmt.Fprintf(&b, "To: %s\r\n", headerValue(msg.To))
+	fmt.Fprintf(&b, "Subject: %s\r\n", headerValue(msg.Subject))
+	fmt.Fprintf(&b, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(msg.Body)
+
+	return []byte(b.String())
+}
+
+// headerValue strips line breaks so values can't inject extra headers.
+func headerValue(v string) string {
+	return strings.NewReplacer("\r", "", "\n", "").Replace(v)
+}
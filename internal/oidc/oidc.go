@@ -0,0 +1,387 @@
+// Package oidc is an OpenID Connect relying party for the authorization code
+// flow with PKCE (https://openid.net/specs/openid-connect-core-1_0.html).
+package oidc
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lestrrat-go/jwx/jwa"
+	"github.com/lestrrat-go/jwx/jwk"
+	"github.com/lestrrat-go/jwx/jws"
+	"github.com/lestrrat-go/jwx/jwt"
+)
+
+var (
+	ErrDiscovery      = errors.New("oidc discovery failed")
+	ErrExchange       = errors.New("oidc code exchange failed")
+	ErrInvalidIDToken = errors.New("invalid id token")
+)
+
+// Algorithms are the ID token signature algorithms we accept. Symmetric ones
+// are left out on purpose: the client secret isn't meant to sign anything.
+var Algorithms = []jwa.SignatureAlgorithm{
+	jwa.RS256, jwa.RS384, jwa.RS512,
+	jwa.PS256, jwa.PS384, jwa.PS512,
+	jwa.ES256, jwa.ES384, jwa.ES512,
+	jwa.EdDSA,
+}
+
+// Leeway is the clock skew tolerated with the provider.
+var Leeway = time.Minute
+
+var Now = time.Now
+
+const discoveryPath = "/.well-known/openid-configuration"
+
+// Config is what we're given when registering with a provider.
+type Config struct {
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	// RedirectURL is our callback, the provider sends users back to it.
+	RedirectURL string
+	// Scopes are requested along with "openid".
+	Scopes []string
+}
+
+// Metadata is the part of the provider's discovery document we use.
+type Metadata struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// Claims are the identity claims of a verified ID token.
+type Claims struct {
+	Subject           string
+	Email             string
+	EmailVerified     bool
+	Name              string
+	PreferredUsername string
+}
+
+type Provider struct {
+	Config     Config
+	Metadata   Metadata
+	HTTPClient *http.Client
+
+	mu   sync.Mutex
+	keys jwk.Set
+}
+
+// Discover fetches the provider's metadata and signing keys.
+func Discover(ctx context.Context, config Config, client *http.Client) (*Provider, error) {
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+
+	p := &Provider{Config: config, HTTPClient: client}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(config.Issuer, "/")+discoveryPath, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrDiscovery, err)
+	}
+
+	if err := p.do(req, &p.Metadata); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrDiscovery, err)
+	}
+
+	// The issuer must match exactly, or a provider could pass itself off as
+	// another one.
+	if p.Metadata.Issuer != config.Issuer {
+		return nil, fmt.Errorf("%w: issuer %q, %q expected", ErrDiscovery, p.Metadata.Issuer, config.Issuer)
+	}
+
+	if p.Metadata.AuthorizationEndpoint == "" || p.Metadata.TokenEndpoint == "" || p.Metadata.JWKSURI == "" {
+		return nil, fmt.Errorf("%w: missing endpoints", ErrDiscovery)
+	}
+
+	if _, err := p.refreshKeys(ctx); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrDiscovery, err)
+	}
+
+	return p, nil
+}
+
+// AuthCodeURL is where to send the user to log in. state and nonce are
+// checked on the way back, and codeVerifier proves we started the flow when
+// exchanging the code.
+func (p *Provider) AuthCodeURL(state, nonce, codeVerifier string) string {
+	u, err := url.Parse(p.Metadata.AuthorizationEndpoint)
+	if err != nil {
+		return ""
+	}
+
+	q := u.Query()
+	q.Set("response_type", "code")
+	q.Set("client_id", p.Config.ClientID)
+	q.Set("redirect_uri", p.Config.RedirectURL)
+	q.Set("scope", strings.Join(p.scopes(), " "))
+	q.Set("state", state)
+	q.Set("nonce", nonce)
+	q.Set("code_challenge", CodeChallenge(codeVerifier))
+	q.Set("code_challenge_method", "S256")
+
+	u.RawQuery = q.Encode()
+
+	return u.String()
+}
+
+func (p *Provider) scopes() []string {
+	scopes := []string{"openid"}
+
+	for _, s := range p.Config.Scopes {
+		if s != "openid" {
+			scopes = append(scopes, s)
+		}
+	}
+
+	return scopes
+}
+
+// CodeChallenge derives the S256 PKCE challenge of verifier.
+func CodeChallenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// Exchange trades the code the provider sent back for an ID token, and
+// returns its claims once verified. nonce is the one passed to AuthCodeURL.
+func (p *Provider) Exchange(ctx context.Context, code, codeVerifier, nonce string) (Claims, error) {
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {p.Config.RedirectURL},
+		"code_verifier": {codeVerifier},
+		"client_id":     {p.Config.ClientID},
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.Metadata.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return Claims{}, fmt.Errorf("%w: %v", ErrExchange, err)
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.SetBasicAuth(url.QueryEscape(p.Config.ClientID), url.QueryEscape(p.Config.ClientSecret))
+
+	var res struct {
+		IDToken string `json:"id_token"`
+	}
+
+	if err := p.do(req, &res); err != nil {
+		return Claims{}, fmt.Errorf("%w: %v", ErrExchange, err)
+	}
+
+	if res.IDToken == "" {
+		return Claims{}, fmt.Errorf("%w: no id token", ErrExchange)
+	}
+
+	return p.VerifyIDToken(ctx, res.IDToken, nonce)
+}
+
+// VerifyIDToken checks the signature and claims of raw, an ID token issued
+// to us for nonce.
+func (p *Provider) VerifyIDToken(ctx context.Context, raw string, nonce string) (Claims, error) {
+	msg, err := jws.ParseString(raw)
+	if err != nil || len(msg.Signatures()) != 1 {
+		return Claims{}, ErrInvalidIDToken
+	}
+
+	headers := msg.Signatures()[0].ProtectedHeaders()
+
+	alg := headers.Algorithm()
+	if !acceptable(alg) {
+		return Claims{}, fmt.Errorf("%w: algorithm %q not accepted", ErrInvalidIDToken, alg)
+	}
+
+	key, err := p.key(ctx, headers.KeyID())
+	if err != nil {
+		return Claims{}, err
+	}
+
+	if ka := key.Algorithm(); ka != "" && ka != alg.String() {
+		return Claims{}, fmt.Errorf("%w: key %q is for %s", ErrInvalidIDToken, headers.KeyID(), ka)
+	}
+
+	var rawKey interface{}
+	if err := key.Raw(&rawKey); err != nil {
+		return Claims{}, fmt.Errorf("%w: %v", ErrInvalidIDToken, err)
+	}
+
+	payload, err := jws.Verify([]byte(raw), alg, rawKey)
+	if err != nil {
+		return Claims{}, fmt.Errorf("%w: bad signature", ErrInvalidIDToken)
+	}
+
+	token := jwt.New()
+	if err := json.Unmarshal(payload, token); err != nil {
+		return Claims{}, fmt.Errorf("%w: %v", ErrInvalidIDToken, err)
+	}
+
+	if token.Expiration().IsZero() || token.IssuedAt().IsZero() || token.Subject() == "" {
+		return Claims{}, fmt.Errorf("%w: missing required claims", ErrInvalidIDToken)
+	}
+
+	if err := jwt.Validate(token,
+		jwt.WithIssuer(p.Metadata.Issuer),
+		jwt.WithAudience(p.Config.ClientID),
+		jwt.WithAcceptableSkew(Leeway),
+		jwt.WithClock(jwt.ClockFunc(Now)),
+	); err != nil {
+		return Claims{}, fmt.Errorf("%w: %v", ErrInvalidIDToken, err)
+	}
+
+	if len(token.Audience()) > 1 && stringClaim(token, "azp") != p.Config.ClientID {
+		return Claims{}, fmt.Errorf("%w: azp not satisfied", ErrInvalidIDToken)
+	}
+
+	if stringClaim(token, "nonce") != nonce || nonce == "" {
+		return Claims{}, fmt.Errorf("%w: nonce not satisfied", ErrInvalidIDToken)
+	}
+
+	return Claims{
+		Subject:           token.Subject(),
+		Email:             stringClaim(token, "email"),
+		EmailVerified:     boolClaim(token, "email_verified"),
+		Name:              stringClaim(token, "name"),
+		PreferredUsername: stringClaim(token, "preferred_username"),
+	}, nil
+}
+
+func acceptable(alg jwa.SignatureAlgorithm) bool {
+	for _, a := range Algorithms {
+		if a == alg {
+			return true
+		}
+	}
+
+	return false
+}
+
+// key returns the signing key with kid. Providers rotate their keys, so an
+// unknown kid refreshes the set once before giving up.
+func (p *Provider) key(ctx context.Context, kid string) (jwk.Key, error) {
+	p.mu.Lock()
+	keys := p.keys
+	p.mu.Unlock()
+
+	if key, ok := lookupKey(keys, kid); ok {
+		return key, nil
+	}
+
+	keys, err := p.refreshKeys(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidIDToken, err)
+	}
+
+	if key, ok := lookupKey(keys, kid); ok {
+		return key, nil
+	}
+
+	return nil, fmt.Errorf("%w: unknown key %q", ErrInvalidIDToken, kid)
+}
+
+// lookupKey finds the key with kid, or the only key when the token doesn't
+// name one.
+func lookupKey(keys jwk.Set, kid string) (jwk.Key, bool) {
+	if keys == nil {
+		return nil, false
+	}
+
+	if kid == "" {
+		if keys.Len() != 1 {
+			return nil, false
+		}
+
+		return keys.Get(0)
+	}
+
+	return keys.LookupKeyID(kid)
+}
+
+func (p *Provider) refreshKeys(ctx context.Context) (jwk.Set, error) {
+	keys, err := jwk.Fetch(ctx, p.Metadata.JWKSURI, jwk.WithHTTPClient(p.HTTPClient))
+	if err != nil {
+		return nil, fmt.Errorf("error fetching keys: %v", err)
+	}
+
+	p.mu.Lock()
+	p.keys = keys
+	p.mu.Unlock()
+
+	return keys, nil
+}
+
+// do sends req and decodes the JSON response into v. Token endpoints answer
+// errors with a JSON body too, which is decoded before failing.
+func (p *Provider) do(req *http.Request, v interface{}) error {
+	req.Header.Set("Accept", "application/json")
+
+	res, err := p.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(res.Body, 1<<20))
+	if err != nil {
+		return err
+	}
+
+	decodeErr := json.Unmarshal(body, v)
+
+	if res.StatusCode != http.StatusOK {
+		var oauthErr struct {
+			Error string `json:"error"`
+		}
+
+		if json.Unmarshal(body, &oauthErr) == nil && oauthErr.Error != "" {
+			return fmt.Errorf("status %d: %s", res.StatusCode, oauthErr.Error)
+		}
+
+		return fmt.Errorf("status %d", res.StatusCode)
+	}
+
+	return decodeErr
+}
+
+func stringClaim(token jwt.Token, name string) string {
+	v, ok := token.Get(name)
+	if !ok {
+		return ""
+	}
+
+	s, _ := v.(string)
+
+	return s
+}
+
+// boolClaim reads a boolean claim, which some providers send as a string.
+func boolClaim(token jwt.Token, name string) bool {
+	v, ok := token.Get(name)
+	if !ok {
+		return false
+	}
+
+	switch v := v.(type) {
+	case bool:
+		return v
+	case string:
+		return v == "true"
+	default:
+		return false
+	}
+}
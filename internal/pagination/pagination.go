@@ -0,0 +1,195 @@
+package pagination
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/RianNegreiros/go-graphql-api/internal/uuid"
+)
+
+var (
+	ErrInvalidArgs = errors.New("invalid pagination arguments")
+)
+
+var (
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+)
+
+// Cursor points at a row in a list ordered by (created_at, id). It is opaque
+// to clients, who only see its encoded form.
+type Cursor struct {
+	CreatedAt time.Time
+	ID        string
+}
+
+func (c Cursor) Encode() string {
+	raw := fmt.Sprintf("%s|%s", c.CreatedAt.UTC().Format(time.RFC3339Nano), c.ID)
+
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func DecodeCursor(value string) (Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return Cursor{}, fmt.Errorf("%w: invalid cursor", ErrInvalidArgs)
+	}
+
+	parts := strings.SplitN(string(raw), "|", 2)
+	if len(parts) != 2 || !uuid.Validate(parts[1]) {
+		return Cursor{}, fmt.Errorf("%w: invalid cursor", ErrInvalidArgs)
+	}
+
+	createdAt, err := time.Parse(time.RFC3339Nano, parts[0])
+	if err != nil {
+		return Cursor{}, fmt.Errorf("%w: invalid cursor", ErrInvalidArgs)
+	}
+
+	return Cursor{
+		CreatedAt: createdAt,
+		ID:        parts[1],
+	}, nil
+}
+
+type Input struct {
+	First  *int
+	After  *string
+	Last   *int
+	Before *string
+}
+
+// Args is the validated form of Input that repositories build their keyset
+// queries from. Backward is set when paginating with last/before.
+type Args struct {
+	Limit    int
+	After    *Cursor
+	Before   *Cursor
+	Backward bool
+}
+
+func (in Input) Args() (Args, error) {
+	if in.First != nil && in.Last != nil {
+		return Args{}, fmt.Errorf("%w: first and last cannot be used together", ErrInvalidArgs)
+	}
+
+	args := Args{
+		Limit: DefaultPageSize,
+	}
+
+	switch {
+	case in.First != nil:
+		args.Limit = *in.First
+	case in.Last != nil:
+		args.Limit = *in.Last
+		args.Backward = true
+	case in.Before != nil && in.After == nil:
+		args.Backward = true
+	}
+
+	if args.Limit < 0 {
+		return Args{}, fmt.Errorf("%w: page size cannot be negative", ErrInvalidArgs)
+	}
+
+	if args.Limit > MaxPageSize {
+		return Args{}, fmt.Errorf("%w: page size too big, (%d) at max", ErrInvalidArgs, MaxPageSize)
+	}
+
+	if in.After != nil {
+		c, err := DecodeCursor(*in.After)
+		if err != nil {
+			return Args{}, err
+		}
+
+		args.After = &c
+	}
+
+	if in.Before != nil {
+		c, err := DecodeCursor(*in.Before)
+		if err != nil {
+			return Args{}, err
+		}
+
+		args.Before = &c
+	}
+
+	return args, nil
+}
+
+type Edge[T any] struct {
+	Node   T
+	Cursor Cursor
+}
+
+type Page[T any] struct {
+	Edges           []Edge[T]
+	HasNextPage     bool
+	HasPreviousPage bool
+}
+
+// NewPage builds a page out of rows fetched with a limit of args.Limit+1, in
+// the order the keyset query returned them. The extra row only tells whether
+// there is more data past the requested page.
+func NewPage[T any](rows []T, args Args, cursor func(T) Cursor) Page[T] {
+	hasMore := len(rows) > args.Limit
+	if hasMore {
+		rows = rows[:args.Limit]
+	}
+
+	edges := make([]Edge[T], len(rows))
+
+	for i, row := range rows {
+		edges[i] = Edge[T]{
+			Node:   row,
+			Cursor: cursor(row),
+		}
+	}
+
+	if args.Backward {
+		for i, j := 0, len(edges)-1; i < j; i, j = i+1, j-1 {
+			edges[i], edges[j] = edges[j], edges[i]
+		}
+
+		return Page[T]{
+			Edges:           edges,
+			HasNextPage:     args.Before != nil,
+			HasPreviousPage: hasMore,
+		}
+	}
+
+	return Page[T]{
+		Edges:           edges,
+		HasNextPage:     hasMore,
+		HasPreviousPage: args.After != nil,
+	}
+}
+
+// MapPage converts the nodes of a page, keeping their cursors.
+func MapPage[T, U any](page Page[T], f func(T) U) Page[U] {
+	edges := make([]Edge[U], len(page.Edges))
+
+	for i, e := range page.Edges {
+		edges[i] = Edge[U]{
+			Node:   f(e.Node),
+			Cursor: e.Cursor,
+		}
+	}
+
+	return Page[U]{
+		Edges:           edges,
+		HasNextPage:     page.HasNextPage,
+		HasPreviousPage: page.HasPreviousPage,
+	}
+}
+
+func (p Page[T]) Nodes() []T {
+	nodes := make([]T, len(p.Edges))
+
+	for i, e := range p.Edges {
+		nodes[i] = e.Node
+	}
+
+	return nodes
+}
@@ -5,7 +5,9 @@ import (
 	"fmt"
 	"strings"
 	"time"
+	"unicode/utf8"
 
+	"github.com/RianNegreiros/go-graphql-api/internal/pagination"
 	"github.com/RianNegreiros/go-graphql-api/internal/user"
 )
 
@@ -14,6 +16,40 @@ var (
 	PostMaxLength = 250
 )
 
+// EditWindow is how long after creation a post can still be edited. Zero
+// means posts can be edited at any time.
+var EditWindow time.Duration
+
+var (
+	ErrEditWindowExpired = fmt.Errorf("%w: edit window has expired", user.ErrForbidden)
+)
+
+var RemovalReasonMaxLength = 500
+
+// DeletedBody replaces the body of posts whose author deleted their account,
+// when they are kept for the replies of other users.
+var DeletedBody = "[deleted]"
+
+// RequireVerifiedEmail blocks users who haven't verified their email from
+// publishing posts and replies.
+var RequireVerifiedEmail bool
+
+var (
+	DefaultThreadDepth = 5
+	MaxThreadDepth     = 20
+)
+
+const (
+	TopicPostCreated = "post_created"
+	TopicPostDeleted = "post_deleted"
+)
+
+// TopicReplyAdded is the topic replies to the post with parentID are
+// published to.
+func TopicReplyAdded(parentID string) string {
+	return "reply_added:" + parentID
+}
+
 type CreatePostInput struct {
 	Body string
 }
@@ -34,6 +70,18 @@ func (in CreatePostInput) Validate() error {
 	return nil
 }
 
+type UpdatePostInput struct {
+	Body string
+}
+
+func (in *UpdatePostInput) Sanitize() {
+	in.Body = strings.TrimSpace(in.Body)
+}
+
+func (in UpdatePostInput) Validate() error {
+	return CreatePostInput(in).Validate()
+}
+
 type Post struct {
 	ID        string
 	Body      string
@@ -47,17 +95,91 @@ func (t Post) CanDelete(user user.UserModel) bool {
 	return t.UserID == user.ID
 }
 
+func (t Post) CanEdit(user user.UserModel) bool {
+	return t.UserID == user.ID
+}
+
+func (t Post) InEditWindow(now time.Time) bool {
+	return EditWindow == 0 || now.Before(t.CreatedAt.Add(EditWindow))
+}
+
+type RemovePostInput struct {
+	Reason string
+}
+
+func (in *RemovePostInput) Sanitize() {
+	in.Reason = strings.TrimSpace(in.Reason)
+}
+
+func (in RemovePostInput) Validate() error {
+	if in.Reason == "" {
+		return fmt.Errorf("%w: reason required", user.ErrValidation)
+	}
+
+	if utf8.RuneCountInString(in.Reason) > RemovalReasonMaxLength {
+		return fmt.Errorf("%w: reason too long, (%d) characters at max", user.ErrValidation, RemovalReasonMaxLength)
+	}
+
+	return nil
+}
+
+// Removal records a post deleted by a moderator.
+type Removal struct {
+	PostID    string
+	RemovedBy string
+	Reason    string
+}
+
+type Revision struct {
+	ID        string
+	PostID    string
+	Body      string
+	CreatedAt time.Time
+}
+
+func (t Post) Cursor() pagination.Cursor {
+	return pagination.Cursor{
+		CreatedAt: t.CreatedAt,
+		ID:        t.ID,
+	}
+}
+
 type PostService interface {
-	All(ctx context.Context) ([]Post, error)
+	Paginate(ctx context.Context, input pagination.Input) (pagination.Page[Post], error)
+	HomeTimeline(ctx context.Context, input pagination.Input) (pagination.Page[Post], error)
 	Create(ctx context.Context, input CreatePostInput) (Post, error)
 	CreateReply(ctx context.Context, parentID string, input CreatePostInput) (Post, error)
 	GetByID(ctx context.Context, id string) (Post, error)
 	Delete(ctx context.Context, id string) error
+	Remove(ctx context.Context, id string, input RemovePostInput) error
+	Update(ctx context.Context, id string, input UpdatePostInput) (Post, error)
+	Like(ctx context.Context, id string) (Post, error)
+	Unlike(ctx context.Context, id string) (Post, error)
+	LikedPosts(ctx context.Context, userID string, input pagination.Input) (pagination.Page[Post], error)
+	Thread(ctx context.Context, rootID string, depth int) ([]Post, error)
+	PostCreated(ctx context.Context) (<-chan Post, error)
+	PostDeleted(ctx context.Context) (<-chan Post, error)
+	ReplyAdded(ctx context.Context, parentID string) (<-chan Post, error)
 }
 
 type PostRepo interface {
-	All(ctx context.Context) ([]Post, error)
+	// GetAllByUserID returns the posts and replies of the user, newest first.
+	GetAllByUserID(ctx context.Context, userID string) ([]Post, error)
+	Paginate(ctx context.Context, args pagination.Args) (pagination.Page[Post], error)
+	GetHomeTimeline(ctx context.Context, userID string, args pagination.Args) (pagination.Page[Post], error)
 	Create(ctx context.Context, Post Post) (Post, error)
 	GetByID(ctx context.Context, id string) (Post, error)
+	GetByIds(ctx context.Context, ids []string) ([]Post, error)
 	Delete(ctx context.Context, id string) error
+	Remove(ctx context.Context, removal Removal) error
+	CountReplies(ctx context.Context, parentIDs []string) (map[string]int, error)
+	GetReplies(ctx context.Context, parentIDs []string, args pagination.Args) (map[string]pagination.Page[Post], error)
+	GetThread(ctx context.Context, rootID string, depth int) ([]Post, error)
+	Update(ctx context.Context, Post Post) (Post, error)
+	GetRevisions(ctx context.Context, postIDs []string) (map[string][]Revision, error)
+	Like(ctx context.Context, userID, postID string) error
+	Unlike(ctx context.Context, userID, postID string) error
+	CountLikes(ctx context.Context, postIDs []string) (map[string]int, error)
+	GetLikedByUser(ctx context.Context, userID string, postIDs []string) (map[string]bool, error)
+	GetLikedPosts(ctx context.Context, userID string, args pagination.Args) (pagination.Page[Post], error)
 }
@@ -0,0 +1,140 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/RianNegreiros/go-graphql-api/internal/pagination"
+	"github.com/RianNegreiros/go-graphql-api/internal/user"
+	"github.com/georgysavva/scany/v2/pgxscan"
+)
+
+func (ur *UserRepo) Follow(ctx context.Context, followerID, followeeID string) error {
+	query := `INSERT INTO follows (follower_id, followee_id) VALUES ($1, $2) ON CONFLICT DO NOTHING;`
+
+	if _, err := ur.DB.Pool.Exec(ctx, query, followerID, followeeID); err != nil {
+		return fmt.Errorf("error insert follow: %v", err)
+	}
+
+	return nil
+}
+
+func (ur *UserRepo) Unfollow(ctx context.Context, followerID, followeeID string) error {
+	query := `DELETE FROM follows WHERE follower_id = $1 AND followee_id = $2;`
+
+	if _, err := ur.DB.Pool.Exec(ctx, query, followerID, followeeID); err != nil {
+		return fmt.Errorf("error delete follow: %v", err)
+	}
+
+	return nil
+}
+
+func (ur *UserRepo) GetFollowers(ctx context.Context, userIDs []string, args pagination.Args) (map[string]pagination.Page[user.UserModel], error) {
+	return getFollowUsers(ctx, ur.DB.Pool, "followee_id", "follower_id", userIDs, args)
+}
+
+func (ur *UserRepo) GetFollowing(ctx context.Context, userIDs []string, args pagination.Args) (map[string]pagination.Page[user.UserModel], error) {
+	return getFollowUsers(ctx, ur.DB.Pool, "follower_id", "followee_id", userIDs, args)
+}
+
+type followedUser struct {
+	user.UserModel
+	FollowedAt time.Time
+}
+
+// getFollowUsers loads a page of the users on the other side of the follows
+// of each of userIDs in a single query, most recent follow first. Cursors
+// point at the follow.
+func getFollowUsers(ctx context.Context, q pgxscan.Querier, userColumn, otherColumn string, userIDs []string, args pagination.Args) (map[string]pagination.Page[user.UserModel], error) {
+	ks := buildKeyset(args, "f.created_at", "f."+otherColumn, 1, newestFirst)
+
+	query := fmt.Sprintf(`SELECT r.* FROM (
+			SELECT u.*, f.created_at AS followed_at, f.%s AS list_user_id,
+				ROW_NUMBER() OVER (PARTITION BY f.%s ORDER BY %s) AS row_number
+			FROM follows f JOIN users u ON u.id = f.%s
+			WHERE f.%s = ANY($1) AND %s
+		) r WHERE r.row_number <= %d ORDER BY r.list_user_id, r.row_number;`,
+		userColumn, userColumn, ks.OrderBy, otherColumn, userColumn, ks.Where, ks.Limit)
+
+	var rows []struct {
+		user.UserModel
+		FollowedAt time.Time
+		ListUserID string
+		RowNumber  int
+	}
+
+	if err := pgxscan.Select(ctx, q, &rows, query, append([]interface{}{userIDs}, ks.Args...)...); err != nil {
+		return nil, fmt.Errorf("error get follows: %+v", err)
+	}
+
+	usersByListID := map[string][]followedUser{}
+
+	for _, r := range rows {
+		usersByListID[r.ListUserID] = append(usersByListID[r.ListUserID], followedUser{r.UserModel, r.FollowedAt})
+	}
+
+	pages := make(map[string]pagination.Page[user.UserModel], len(userIDs))
+
+	for _, id := range userIDs {
+		page := pagination.NewPage(usersByListID[id], args, func(r followedUser) pagination.Cursor {
+			return pagination.Cursor{
+				CreatedAt: r.FollowedAt,
+				ID:        r.ID,
+			}
+		})
+
+		pages[id] = pagination.MapPage(page, func(r followedUser) user.UserModel {
+			return r.UserModel
+		})
+	}
+
+	return pages, nil
+}
+
+func (ur *UserRepo) CountFollowers(ctx context.Context, userIDs []string) (map[string]int, error) {
+	return countFollows(ctx, ur.DB.Pool, "followee_id", userIDs)
+}
+
+func (ur *UserRepo) CountFollowing(ctx context.Context, userIDs []string) (map[string]int, error) {
+	return countFollows(ctx, ur.DB.Pool, "follower_id", userIDs)
+}
+
+func countFollows(ctx context.Context, q pgxscan.Querier, column string, userIDs []string) (map[string]int, error) {
+	query := fmt.Sprintf(`SELECT %s AS user_id, COUNT(*) AS count FROM follows WHERE %s = ANY($1) GROUP BY %s;`, column, column, column)
+
+	var rows []struct {
+		UserID string
+		Count  int
+	}
+
+	if err := pgxscan.Select(ctx, q, &rows, query, userIDs); err != nil {
+		return nil, fmt.Errorf("error count follows: %+v", err)
+	}
+
+	counts := make(map[string]int, len(rows))
+
+	for _, r := range rows {
+		counts[r.UserID] = r.Count
+	}
+
+	return counts, nil
+}
+
+func (ur *UserRepo) GetFollowedBy(ctx context.Context, followerID string, userIDs []string) (map[string]bool, error) {
+	query := `SELECT followee_id FROM follows WHERE follower_id = $1 AND followee_id = ANY($2);`
+
+	var ids []string
+
+	if err := pgxscan.Select(ctx, ur.DB.Pool, &ids, query, followerID, userIDs); err != nil {
+		return nil, fmt.Errorf("error get followed users: %+v", err)
+	}
+
+	followed := make(map[string]bool, len(ids))
+
+	for _, id := range ids {
+		followed[id] = true
+	}
+
+	return followed, nil
+}
@@ -0,0 +1,80 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/RianNegreiros/go-graphql-api/internal/user"
+	"github.com/georgysavva/scany/v2/pgxscan"
+)
+
+type IdentityRepo struct {
+	DB *DB
+}
+
+func NewIdentityRepo(db *DB) *IdentityRepo {
+	return &IdentityRepo{
+		DB: db,
+	}
+}
+
+func (ir *IdentityRepo) Create(ctx context.Context, i user.Identity) (user.Identity, error) {
+	query := `INSERT INTO identities (user_id, provider, subject, email) VALUES ($1, $2, $3, $4) RETURNING *;`
+
+	created := user.Identity{}
+
+	if err := pgxscan.Get(ctx, ir.DB.Pool, &created, query, i.UserID, i.Provider, i.Subject, i.Email); err != nil {
+		return user.Identity{}, fmt.Errorf("error insert: %v", err)
+	}
+
+	return created, nil
+}
+
+func (ir *IdentityRepo) GetByProviderSubject(ctx context.Context, provider string, subject string) (user.Identity, error) {
+	query := `SELECT * FROM identities WHERE provider = $1 AND subject = $2 LIMIT 1;`
+
+	i := user.Identity{}
+
+	if err := pgxscan.Get(ctx, ir.DB.Pool, &i, query, provider, subject); err != nil {
+		if pgxscan.NotFound(err) {
+			return user.Identity{}, user.ErrNotFound
+		}
+
+		return user.Identity{}, fmt.Errorf("error select: %v", err)
+	}
+
+	return i, nil
+}
+
+// CreateState also clears expired states, nothing else would.
+func (ir *IdentityRepo) CreateState(ctx context.Context, s user.SocialLoginState) error {
+	if _, err := ir.DB.Pool.Exec(ctx, `DELETE FROM social_login_states WHERE expired_at <= NOW();`); err != nil {
+		return fmt.Errorf("error delete: %v", err)
+	}
+
+	query := `INSERT INTO social_login_states (state, provider, nonce, code_verifier, expired_at) VALUES ($1, $2, $3, $4, $5);`
+
+	if _, err := ir.DB.Pool.Exec(ctx, query, s.State, s.Provider, s.Nonce, s.CodeVerifier, s.ExpiredAt); err != nil {
+		return fmt.Errorf("error insert: %v", err)
+	}
+
+	return nil
+}
+
+func (ir *IdentityRepo) ConsumeState(ctx context.Context, state string, provider string) (user.SocialLoginState, error) {
+	query := `DELETE FROM social_login_states
+		WHERE state = $1 AND provider = $2 AND expired_at > NOW()
+		RETURNING *;`
+
+	s := user.SocialLoginState{}
+
+	if err := pgxscan.Get(ctx, ir.DB.Pool, &s, query, state, provider); err != nil {
+		if pgxscan.NotFound(err) {
+			return user.SocialLoginState{}, user.ErrNotFound
+		}
+
+		return user.SocialLoginState{}, fmt.Errorf("error delete: %v", err)
+	}
+
+	return s, nil
+}
@@ -0,0 +1,92 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/RianNegreiros/go-graphql-api/internal/loginlimit"
+	"github.com/georgysavva/scany/v2/pgxscan"
+)
+
+// LoginAttemptStore is a loginlimit.Store shared by every API instance using
+// the same database.
+type LoginAttemptStore struct {
+	DB *DB
+}
+
+func NewLoginAttemptStore(db *DB) *LoginAttemptStore {
+	return &LoginAttemptStore{
+		DB: db,
+	}
+}
+
+// Reserve locks the row of key until the attempt is recorded, so concurrent
+// attempts on the same key are checked one after the other.
+func (ls *LoginAttemptStore) Reserve(ctx context.Context, key string, window time.Duration, check func(loginlimit.Attempts) error) error {
+	now := loginlimit.Now()
+
+	// Keys nobody failed on lately are dead weight; drop them as we go.
+	if _, err := ls.DB.Pool.Exec(ctx, `DELETE FROM login_attempts WHERE expired_at <= $1;`, now); err != nil {
+		return fmt.Errorf("error delete expired login attempts: %v", err)
+	}
+
+	tx, err := ls.DB.Pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("error starting transaction: %v", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// The no-op update takes the row lock when the key exists already.
+	query := `INSERT INTO login_attempts (key, failures, last_failure_at, expired_at) VALUES ($1, 0, $2, $2)
+		ON CONFLICT (key) DO UPDATE SET key = EXCLUDED.key
+		RETURNING failures, last_failure_at, expired_at;`
+
+	var row struct {
+		loginlimit.Attempts
+		ExpiredAt time.Time
+	}
+
+	if err := pgxscan.Get(ctx, tx, &row, query, key, now); err != nil {
+		return fmt.Errorf("error upsert login attempts: %v", err)
+	}
+
+	a := row.Attempts
+	if !now.Before(row.ExpiredAt) {
+		a = loginlimit.Attempts{}
+	}
+
+	if err := check(a); err != nil {
+		return err
+	}
+
+	updateQuery := `UPDATE login_attempts SET failures = $2, last_failure_at = $3, expired_at = $4 WHERE key = $1;`
+
+	if _, err := tx.Exec(ctx, updateQuery, key, a.Failures+1, now, now.Add(window)); err != nil {
+		return fmt.Errorf("error update login attempts: %v", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("error commiting: %v", err)
+	}
+
+	return nil
+}
+
+func (ls *LoginAttemptStore) Release(ctx context.Context, key string) error {
+	query := `UPDATE login_attempts SET failures = failures - 1 WHERE key = $1 AND failures > 0;`
+
+	if _, err := ls.DB.Pool.Exec(ctx, query, key); err != nil {
+		return fmt.Errorf("error release login attempt: %v", err)
+	}
+
+	return nil
+}
+
+func (ls *LoginAttemptStore) Reset(ctx context.Context, key string) error {
+	if _, err := ls.DB.Pool.Exec(ctx, `DELETE FROM login_attempts WHERE key = $1;`, key); err != nil {
+		return fmt.Errorf("error delete login attempts: %v", err)
+	}
+
+	return nil
+}
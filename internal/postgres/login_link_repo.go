@@ -0,0 +1,67 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/RianNegreiros/go-graphql-api/internal/user"
+	"github.com/georgysavva/scany/v2/pgxscan"
+)
+
+type LoginLinkRepo struct {
+	DB *DB
+}
+
+func NewLoginLinkRepo(db *DB) *LoginLinkRepo {
+	return &LoginLinkRepo{
+		DB: db,
+	}
+}
+
+func (lr *LoginLinkRepo) Create(ctx context.Context, userID string, tokenHash string) (user.LoginLinkToken, error) {
+	query := `INSERT INTO login_link_tokens (user_id, token_hash, expired_at) VALUES ($1, $2, $3) RETURNING *;`
+
+	t := user.LoginLinkToken{}
+
+	expiredAt := time.Now().Add(user.LoginLinkTokenLifeTime)
+
+	if err := pgxscan.Get(ctx, lr.DB.Pool, &t, query, userID, tokenHash, expiredAt); err != nil {
+		return user.LoginLinkToken{}, fmt.Errorf("error insert: %v", err)
+	}
+
+	return t, nil
+}
+
+func (lr *LoginLinkRepo) Consume(ctx context.Context, tokenHash string) (user.LoginLinkToken, error) {
+	tx, err := lr.DB.Pool.Begin(ctx)
+	if err != nil {
+		return user.LoginLinkToken{}, fmt.Errorf("error starting transaction: %v", err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := `UPDATE login_link_tokens SET used_at = NOW()
+		WHERE token_hash = $1 AND used_at IS NULL AND expired_at > NOW() RETURNING *;`
+
+	t := user.LoginLinkToken{}
+
+	if err := pgxscan.Get(ctx, tx, &t, query, tokenHash); err != nil {
+		if pgxscan.NotFound(err) {
+			return user.LoginLinkToken{}, user.ErrNotFound
+		}
+
+		return user.LoginLinkToken{}, fmt.Errorf("error update: %v", err)
+	}
+
+	othersQuery := `UPDATE login_link_tokens SET used_at = NOW() WHERE user_id = $1 AND used_at IS NULL;`
+
+	if _, err := tx.Exec(ctx, othersQuery, t.UserID); err != nil {
+		return user.LoginLinkToken{}, fmt.Errorf("error update: %v", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return user.LoginLinkToken{}, fmt.Errorf("error commiting: %v", err)
+	}
+
+	return t, nil
+}
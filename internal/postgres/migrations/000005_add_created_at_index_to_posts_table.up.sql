@@ -0,0 +1 @@
+CREATE INDEX IF NOT EXISTS posts_created_at_id_idx ON posts (created_at DESC, id DESC);
\ No newline at end of file
@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS post_revisions (
+    id UUID PRIMARY KEY NOT NULL DEFAULT uuid_generate_v4(),
+    post_id UUID NOT NULL REFERENCES posts (id) ON DELETE CASCADE,
+    body VARCHAR(250) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS post_revisions_post_id_idx ON post_revisions (post_id, created_at DESC);
\ No newline at end of file
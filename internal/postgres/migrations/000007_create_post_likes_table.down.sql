@@ -0,0 +1 @@
+DROP TABLE IF EXISTS post_likes;
\ No newline at end of file
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS post_likes (
+    user_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    post_id UUID NOT NULL REFERENCES posts (id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, post_id)
+);
+
+CREATE INDEX IF NOT EXISTS post_likes_post_id_idx ON post_likes (post_id);
+CREATE INDEX IF NOT EXISTS post_likes_user_id_created_at_idx ON post_likes (user_id, created_at DESC, post_id DESC);
\ No newline at end of file
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS follows (
+    follower_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    followee_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (follower_id, followee_id),
+    CONSTRAINT follows_no_self_follow CHECK (follower_id <> followee_id)
+);
+
+CREATE INDEX IF NOT EXISTS follows_followee_id_created_at_idx ON follows (followee_id, created_at DESC, follower_id DESC);
+CREATE INDEX IF NOT EXISTS follows_follower_id_created_at_idx ON follows (follower_id, created_at DESC, followee_id DESC);
\ No newline at end of file
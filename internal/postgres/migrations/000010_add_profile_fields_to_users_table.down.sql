@@ -0,0 +1,6 @@
+ALTER TABLE users
+    DROP COLUMN IF EXISTS display_name,
+    DROP COLUMN IF EXISTS bio,
+    DROP COLUMN IF EXISTS location,
+    DROP COLUMN IF EXISTS website,
+    DROP COLUMN IF EXISTS avatar_url;
\ No newline at end of file
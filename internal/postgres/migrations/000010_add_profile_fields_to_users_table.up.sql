@@ -0,0 +1,6 @@
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS display_name VARCHAR(50) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS bio VARCHAR(160) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS location VARCHAR(30) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS website VARCHAR(100) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS avatar_url VARCHAR(255) NOT NULL DEFAULT '';
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS post_removals;
\ No newline at end of file
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS post_removals (
+    id UUID PRIMARY KEY NOT NULL DEFAULT uuid_generate_v4(),
+    post_id UUID NOT NULL,
+    author_id UUID REFERENCES users (id) ON DELETE SET NULL,
+    body VARCHAR(250) NOT NULL,
+    removed_by UUID REFERENCES users (id) ON DELETE SET NULL,
+    reason VARCHAR(500) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS post_removals_created_at_idx ON post_removals (created_at DESC);
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS login_link_tokens;
\ No newline at end of file
@@ -0,0 +1,9 @@
+ALTER TABLE posts
+    DROP CONSTRAINT IF EXISTS posts_user_id_fkey,
+    ADD CONSTRAINT posts_user_id_fkey FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE;
+
+DROP INDEX IF EXISTS users_deletion_scheduled_at_idx;
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS deleted_at,
+    DROP COLUMN IF EXISTS deletion_scheduled_at;
\ No newline at end of file
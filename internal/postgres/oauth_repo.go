@@ -0,0 +1,255 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/RianNegreiros/go-graphql-api/internal/user"
+	"github.com/georgysavva/scany/v2/pgxscan"
+)
+
+type OAuthRepo struct {
+	DB *DB
+}
+
+func NewOAuthRepo(db *DB) *OAuthRepo {
+	return &OAuthRepo{
+		DB: db,
+	}
+}
+
+func (or *OAuthRepo) CreateClient(ctx context.Context, c user.OAuthClient) (user.OAuthClient, error) {
+	query := `INSERT INTO oauth_clients (user_id, name, secret_hash, redirect_uris, scopes)
+		VALUES ($1, $2, $3, $4, $5) RETURNING *;`
+
+	created := user.OAuthClient{}
+
+	if err := pgxscan.Get(ctx, or.DB.Pool, &created, query, c.UserID, c.Name, c.SecretHash, c.RedirectURIs, c.Scopes); err != nil {
+		return user.OAuthClient{}, fmt.Errorf("error insert: %v", err)
+	}
+
+	return created, nil
+}
+
+func (or *OAuthRepo) GetClientByID(ctx context.Context, id string) (user.OAuthClient, error) {
+	query := `SELECT * FROM oauth_clients WHERE id = $1 LIMIT 1;`
+
+	c := user.OAuthClient{}
+
+	if err := pgxscan.Get(ctx, or.DB.Pool, &c, query, id); err != nil {
+		if pgxscan.NotFound(err) {
+			return user.OAuthClient{}, user.ErrNotFound
+		}
+
+		return user.OAuthClient{}, fmt.Errorf("error select: %v", err)
+	}
+
+	return c, nil
+}
+
+func (or *OAuthRepo) GetClientsByUserID(ctx context.Context, userID string) ([]user.OAuthClient, error) {
+	query := `SELECT * FROM oauth_clients WHERE user_id = $1 ORDER BY created_at DESC;`
+
+	var clients []user.OAuthClient
+
+	if err := pgxscan.Select(ctx, or.DB.Pool, &clients, query, userID); err != nil {
+		return nil, fmt.Errorf("error get oauth clients by user id: %+v", err)
+	}
+
+	return clients, nil
+}
+
+func (or *OAuthRepo) DeleteClient(ctx context.Context, id string, userID string) error {
+	tag, err := or.DB.Pool.Exec(ctx, `DELETE FROM oauth_clients WHERE id = $1 AND user_id = $2;`, id, userID)
+	if err != nil {
+		return fmt.Errorf("error delete: %v", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return user.ErrNotFound
+	}
+
+	return nil
+}
+
+func (or *OAuthRepo) SaveConsent(ctx context.Context, c user.OAuthConsent) (user.OAuthConsent, error) {
+	query := `INSERT INTO oauth_consents (user_id, client_id, scopes) VALUES ($1, $2, $3)
+		ON CONFLICT (user_id, client_id) DO UPDATE
+		SET scopes = ARRAY(SELECT DISTINCT unnest(oauth_consents.scopes || EXCLUDED.scopes)), updated_at = NOW()
+		RETURNING *;`
+
+	saved := user.OAuthConsent{}
+
+	if err := pgxscan.Get(ctx, or.DB.Pool, &saved, query, c.UserID, c.ClientID, c.Scopes); err != nil {
+		return user.OAuthConsent{}, fmt.Errorf("error upsert: %v", err)
+	}
+
+	return saved, nil
+}
+
+func (or *OAuthRepo) GetConsent(ctx context.Context, userID string, clientID string) (user.OAuthConsent, error) {
+	query := `SELECT oauth_consents.*, oauth_clients.name AS client_name FROM oauth_consents
+		JOIN oauth_clients ON oauth_clients.id = oauth_consents.client_id
+		WHERE oauth_consents.user_id = $1 AND oauth_consents.client_id = $2 LIMIT 1;`
+
+	c := user.OAuthConsent{}
+
+	if err := pgxscan.Get(ctx, or.DB.Pool, &c, query, userID, clientID); err != nil {
+		if pgxscan.NotFound(err) {
+			return user.OAuthConsent{}, user.ErrNotFound
+		}
+
+		return user.OAuthConsent{}, fmt.Errorf("error select: %v", err)
+	}
+
+	return c, nil
+}
+
+func (or *OAuthRepo) GetConsentsByUserID(ctx context.Context, userID string) ([]user.OAuthConsent, error) {
+	query := `SELECT oauth_consents.*, oauth_clients.name AS client_name FROM oauth_consents
+		JOIN oauth_clients ON oauth_clients.id = oauth_consents.client_id
+		WHERE oauth_consents.user_id = $1 ORDER BY oauth_consents.created_at DESC;`
+
+	var consents []user.OAuthConsent
+
+	if err := pgxscan.Select(ctx, or.DB.Pool, &consents, query, userID); err != nil {
+		return nil, fmt.Errorf("error get oauth consents by user id: %+v", err)
+	}
+
+	return consents, nil
+}
+
+func (or *OAuthRepo) DeleteConsent(ctx context.Context, userID string, clientID string) error {
+	tx, err := or.DB.Pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("error starting transaction: %v", err)
+	}
+	defer tx.Rollback(ctx)
+
+	tag, err := tx.Exec(ctx, `DELETE FROM oauth_consents WHERE user_id = $1 AND client_id = $2;`, userID, clientID)
+	if err != nil {
+		return fmt.Errorf("error delete: %v", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return user.ErrNotFound
+	}
+
+	grantsQuery := `UPDATE oauth_grants SET revoked_at = NOW()
+		WHERE user_id = $1 AND client_id = $2 AND revoked_at IS NULL;`
+
+	if _, err := tx.Exec(ctx, grantsQuery, userID, clientID); err != nil {
+		return fmt.Errorf("error update: %v", err)
+	}
+
+	codesQuery := `DELETE FROM oauth_authorization_codes WHERE user_id = $1 AND client_id = $2;`
+
+	if _, err := tx.Exec(ctx, codesQuery, userID, clientID); err != nil {
+		return fmt.Errorf("error delete: %v", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("error commiting: %v", err)
+	}
+
+	return nil
+}
+
+// CreateCode also clears expired codes, nothing else would.
+func (or *OAuthRepo) CreateCode(ctx context.Context, c user.OAuthAuthorizationCode) error {
+	if _, err := or.DB.Pool.Exec(ctx, `DELETE FROM oauth_authorization_codes WHERE expired_at <= NOW();`); err != nil {
+		return fmt.Errorf("error delete: %v", err)
+	}
+
+	query := `INSERT INTO oauth_authorization_codes (code_hash, client_id, user_id, redirect_uri, redirect_uri_required, scopes, code_challenge, expired_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8);`
+
+	if _, err := or.DB.Pool.Exec(ctx, query, c.CodeHash, c.ClientID, c.UserID, c.RedirectURI, c.RedirectURIRequired, c.Scopes, c.CodeChallenge, c.ExpiredAt); err != nil {
+		return fmt.Errorf("error insert: %v", err)
+	}
+
+	return nil
+}
+
+func (or *OAuthRepo) ConsumeCode(ctx context.Context, codeHash string) (user.OAuthAuthorizationCode, error) {
+	query := `DELETE FROM oauth_authorization_codes WHERE code_hash = $1 AND expired_at > NOW() RETURNING *;`
+
+	c := user.OAuthAuthorizationCode{}
+
+	if err := pgxscan.Get(ctx, or.DB.Pool, &c, query, codeHash); err != nil {
+		if pgxscan.NotFound(err) {
+			return user.OAuthAuthorizationCode{}, user.ErrNotFound
+		}
+
+		return user.OAuthAuthorizationCode{}, fmt.Errorf("error delete: %v", err)
+	}
+
+	return c, nil
+}
+
+func (or *OAuthRepo) CreateGrant(ctx context.Context, g user.OAuthGrant) (user.OAuthGrant, error) {
+	query := `INSERT INTO oauth_grants (client_id, user_id, scopes, refresh_token_hash, expired_at)
+		VALUES ($1, $2, $3, $4, $5) RETURNING *;`
+
+	created := user.OAuthGrant{}
+
+	if err := pgxscan.Get(ctx, or.DB.Pool, &created, query, g.ClientID, g.UserID, g.Scopes, g.RefreshTokenHash, g.ExpiredAt); err != nil {
+		return user.OAuthGrant{}, fmt.Errorf("error insert: %v", err)
+	}
+
+	return created, nil
+}
+
+func (or *OAuthRepo) GetActiveGrant(ctx context.Context, id string) (user.OAuthGrant, error) {
+	query := `SELECT * FROM oauth_grants WHERE id = $1 AND revoked_at IS NULL AND expired_at > NOW() LIMIT 1;`
+
+	return or.getGrant(ctx, query, id)
+}
+
+func (or *OAuthRepo) GetActiveGrantByRefreshTokenHash(ctx context.Context, refreshTokenHash string) (user.OAuthGrant, error) {
+	query := `SELECT * FROM oauth_grants
+		WHERE refresh_token_hash = $1 AND revoked_at IS NULL AND expired_at > NOW() LIMIT 1;`
+
+	return or.getGrant(ctx, query, refreshTokenHash)
+}
+
+func (or *OAuthRepo) RotateGrant(ctx context.Context, refreshTokenHash string, newRefreshTokenHash string) (user.OAuthGrant, error) {
+	query := `UPDATE oauth_grants SET refresh_token_hash = $2, expired_at = $3
+		WHERE refresh_token_hash = $1 AND revoked_at IS NULL AND expired_at > NOW()
+		RETURNING *;`
+
+	g := user.OAuthGrant{}
+
+	if err := pgxscan.Get(ctx, or.DB.Pool, &g, query, refreshTokenHash, newRefreshTokenHash, time.Now().Add(user.OAuthRefreshTokenLifeTime)); err != nil {
+		if pgxscan.NotFound(err) {
+			return user.OAuthGrant{}, user.ErrNotFound
+		}
+
+		return user.OAuthGrant{}, fmt.Errorf("error update: %v", err)
+	}
+
+	return g, nil
+}
+
+func (or *OAuthRepo) RevokeGrant(ctx context.Context, id string) error {
+	if _, err := or.DB.Pool.Exec(ctx, `UPDATE oauth_grants SET revoked_at = NOW() WHERE id = $1 AND revoked_at IS NULL;`, id); err != nil {
+		return fmt.Errorf("error update: %v", err)
+	}
+
+	return nil
+}
+
+func (or *OAuthRepo) getGrant(ctx context.Context, query string, arg string) (user.OAuthGrant, error) {
+	g := user.OAuthGrant{}
+
+	if err := pgxscan.Get(ctx, or.DB.Pool, &g, query, arg); err != nil {
+		if pgxscan.NotFound(err) {
+			return user.OAuthGrant{}, user.ErrNotFound
+		}
+
+		return user.OAuthGrant{}, fmt.Errorf("error select: %v", err)
+	}
+
+	return g, nil
+}
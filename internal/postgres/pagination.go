@@ -0,0 +1,68 @@
+package postgres
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/RianNegreiros/go-graphql-api/internal/pagination"
+)
+
+type sortOrder int
+
+const (
+	newestFirst sortOrder = iota
+	oldestFirst
+)
+
+// keyset holds the pieces of a keyset paginated query over rows ordered by
+// (created_at, id).
+type keyset struct {
+	Where   string
+	OrderBy string
+	Limit   int
+	Args    []interface{}
+}
+
+// buildKeyset builds the conditions over the createdAt and id columns. Query
+// placeholders are numbered starting after argOffset.
+func buildKeyset(args pagination.Args, createdAt, id string, argOffset int, order sortOrder) keyset {
+	columns := fmt.Sprintf("(%s, %s)", createdAt, id)
+
+	afterOp, beforeOp := "<", ">"
+	if order == oldestFirst {
+		afterOp, beforeOp = ">", "<"
+	}
+
+	var (
+		conds  []string
+		params []interface{}
+	)
+
+	if args.After != nil {
+		conds = append(conds, fmt.Sprintf("%s %s ($%d, $%d)", columns, afterOp, argOffset+len(params)+1, argOffset+len(params)+2))
+		params = append(params, args.After.CreatedAt, args.After.ID)
+	}
+
+	if args.Before != nil {
+		conds = append(conds, fmt.Sprintf("%s %s ($%d, $%d)", columns, beforeOp, argOffset+len(params)+1, argOffset+len(params)+2))
+		params = append(params, args.Before.CreatedAt, args.Before.ID)
+	}
+
+	// Backward pages are read in reverse and flipped by pagination.NewPage.
+	direction := "DESC"
+	if (order == oldestFirst) != args.Backward {
+		direction = "ASC"
+	}
+
+	where := "TRUE"
+	if len(conds) > 0 {
+		where = strings.Join(conds, " AND ")
+	}
+
+	return keyset{
+		Where:   where,
+		OrderBy: fmt.Sprintf("%s %s, %s %s", createdAt, direction, id, direction),
+		Limit:   args.Limit + 1,
+		Args:    params,
+	}
+}
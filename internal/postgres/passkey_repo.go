@@ -0,0 +1,124 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/RianNegreiros/go-graphql-api/internal/user"
+	"github.com/georgysavva/scany/v2/pgxscan"
+)
+
+type PasskeyRepo struct {
+	DB *DB
+}
+
+func NewPasskeyRepo(db *DB) *PasskeyRepo {
+	return &PasskeyRepo{
+		DB: db,
+	}
+}
+
+func (pr *PasskeyRepo) Create(ctx context.Context, p user.Passkey) (user.Passkey, error) {
+	query := `INSERT INTO passkeys (user_id, name, credential_id, public_key, sign_count)
+		VALUES ($1, $2, $3, $4, $5) RETURNING *;`
+
+	created := user.Passkey{}
+
+	if err := pgxscan.Get(ctx, pr.DB.Pool, &created, query, p.UserID, p.Name, p.CredentialID, p.PublicKey, p.SignCount); err != nil {
+		return user.Passkey{}, fmt.Errorf("error insert: %v", err)
+	}
+
+	return created, nil
+}
+
+func (pr *PasskeyRepo) GetByCredentialID(ctx context.Context, credentialID []byte) (user.Passkey, error) {
+	query := `SELECT * FROM passkeys WHERE credential_id = $1 LIMIT 1;`
+
+	p := user.Passkey{}
+
+	if err := pgxscan.Get(ctx, pr.DB.Pool, &p, query, credentialID); err != nil {
+		if pgxscan.NotFound(err) {
+			return user.Passkey{}, user.ErrNotFound
+		}
+
+		return user.Passkey{}, fmt.Errorf("error select: %v", err)
+	}
+
+	return p, nil
+}
+
+func (pr *PasskeyRepo) GetByUserID(ctx context.Context, userID string) ([]user.Passkey, error) {
+	query := `SELECT * FROM passkeys WHERE user_id = $1 ORDER BY created_at DESC;`
+
+	var passkeys []user.Passkey
+
+	if err := pgxscan.Select(ctx, pr.DB.Pool, &passkeys, query, userID); err != nil {
+		return nil, fmt.Errorf("error get passkeys by user id: %+v", err)
+	}
+
+	return passkeys, nil
+}
+
+func (pr *PasskeyRepo) UpdateSignCount(ctx context.Context, id string, signCount int64) error {
+	query := `UPDATE passkeys SET sign_count = $2, last_used_at = NOW()
+		WHERE id = $1 AND (sign_count < $2 OR (sign_count = 0 AND $2 = 0));`
+
+	tag, err := pr.DB.Pool.Exec(ctx, query, id, signCount)
+	if err != nil {
+		return fmt.Errorf("error update: %v", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return user.ErrPasskeyReplayed
+	}
+
+	return nil
+}
+
+func (pr *PasskeyRepo) Delete(ctx context.Context, id string, userID string) error {
+	query := `DELETE FROM passkeys WHERE id = $1 AND user_id = $2;`
+
+	tag, err := pr.DB.Pool.Exec(ctx, query, id, userID)
+	if err != nil {
+		return fmt.Errorf("error delete: %v", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return user.ErrNotFound
+	}
+
+	return nil
+}
+
+// CreateChallenge also clears expired challenges, nothing else would.
+func (pr *PasskeyRepo) CreateChallenge(ctx context.Context, c user.PasskeyChallenge) error {
+	if _, err := pr.DB.Pool.Exec(ctx, `DELETE FROM passkey_challenges WHERE expired_at <= NOW();`); err != nil {
+		return fmt.Errorf("error delete: %v", err)
+	}
+
+	query := `INSERT INTO passkey_challenges (challenge, user_id, ceremony, expired_at) VALUES ($1, $2, $3, $4);`
+
+	if _, err := pr.DB.Pool.Exec(ctx, query, c.Challenge, c.UserID, c.Ceremony, c.ExpiredAt); err != nil {
+		return fmt.Errorf("error insert: %v", err)
+	}
+
+	return nil
+}
+
+func (pr *PasskeyRepo) ConsumeChallenge(ctx context.Context, challenge string, ceremony user.PasskeyCeremony) (user.PasskeyChallenge, error) {
+	query := `DELETE FROM passkey_challenges
+		WHERE challenge = $1 AND ceremony = $2 AND expired_at > NOW()
+		RETURNING *;`
+
+	c := user.PasskeyChallenge{}
+
+	if err := pgxscan.Get(ctx, pr.DB.Pool, &c, query, challenge, ceremony); err != nil {
+		if pgxscan.NotFound(err) {
+			return user.PasskeyChallenge{}, user.ErrNotFound
+		}
+
+		return user.PasskeyChallenge{}, fmt.Errorf("error delete: %v", err)
+	}
+
+	return c, nil
+}
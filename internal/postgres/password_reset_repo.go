@@ -0,0 +1,77 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/RianNegreiros/go-graphql-api/internal/user"
+	"github.com/georgysavva/scany/v2/pgxscan"
+)
+
+type PasswordResetRepo struct {
+	DB *DB
+}
+
+func NewPasswordResetRepo(db *DB) *PasswordResetRepo {
+	return &PasswordResetRepo{
+		DB: db,
+	}
+}
+
+func (pr *PasswordResetRepo) Create(ctx context.Context, userID string, tokenHash string) (user.PasswordResetToken, error) {
+	query := `INSERT INTO password_reset_tokens (user_id, token_hash, expired_at) VALUES ($1, $2, $3) RETURNING *;`
+
+	t := user.PasswordResetToken{}
+
+	expiredAt := time.Now().Add(user.PasswordResetTokenLifeTime)
+
+	if err := pgxscan.Get(ctx, pr.DB.Pool, &t, query, userID, tokenHash, expiredAt); err != nil {
+		return user.PasswordResetToken{}, fmt.Errorf("error insert: %v", err)
+	}
+
+	return t, nil
+}
+
+func (pr *PasswordResetRepo) Reset(ctx context.Context, tokenHash string, password string) (user.PasswordResetToken, error) {
+	tx, err := pr.DB.Pool.Begin(ctx)
+	if err != nil {
+		return user.PasswordResetToken{}, fmt.Errorf("error starting transaction: %v", err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := `UPDATE password_reset_tokens SET used_at = NOW()
+		WHERE token_hash = $1 AND used_at IS NULL AND expired_at > NOW() RETURNING *;`
+
+	t := user.PasswordResetToken{}
+
+	if err := pgxscan.Get(ctx, tx, &t, query, tokenHash); err != nil {
+		if pgxscan.NotFound(err) {
+			return user.PasswordResetToken{}, user.ErrNotFound
+		}
+
+		return user.PasswordResetToken{}, fmt.Errorf("error update: %v", err)
+	}
+
+	othersQuery := `UPDATE password_reset_tokens SET used_at = NOW() WHERE user_id = $1 AND used_at IS NULL;`
+
+	if _, err := tx.Exec(ctx, othersQuery, t.UserID); err != nil {
+		return user.PasswordResetToken{}, fmt.Errorf("error update: %v", err)
+	}
+
+	if _, err := tx.Exec(ctx, `UPDATE users SET password = $1, updated_at = NOW() WHERE id = $2;`, password, t.UserID); err != nil {
+		return user.PasswordResetToken{}, fmt.Errorf("error update password: %v", err)
+	}
+
+	revokeQuery := `UPDATE refresh_tokens SET revoked_at = NOW() WHERE user_id = $1 AND revoked_at IS NULL;`
+
+	if _, err := tx.Exec(ctx, revokeQuery, t.UserID); err != nil {
+		return user.PasswordResetToken{}, fmt.Errorf("error revoke refresh tokens: %v", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return user.PasswordResetToken{}, fmt.Errorf("error commiting: %v", err)
+	}
+
+	return t, nil
+}
@@ -0,0 +1,80 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/RianNegreiros/go-graphql-api/internal/user"
+	"github.com/georgysavva/scany/v2/pgxscan"
+)
+
+type PersonalAccessTokenRepo struct {
+	DB *DB
+}
+
+func NewPersonalAccessTokenRepo(db *DB) *PersonalAccessTokenRepo {
+	return &PersonalAccessTokenRepo{
+		DB: db,
+	}
+}
+
+func (pr *PersonalAccessTokenRepo) Create(ctx context.Context, t user.PersonalAccessToken) (user.PersonalAccessToken, error) {
+	query := `INSERT INTO personal_access_tokens (user_id, name, token_hash, scopes, expired_at)
+		VALUES ($1, $2, $3, $4, $5) RETURNING *;`
+
+	created := user.PersonalAccessToken{}
+
+	if err := pgxscan.Get(ctx, pr.DB.Pool, &created, query, t.UserID, t.Name, t.TokenHash, t.Scopes, t.ExpiredAt); err != nil {
+		return user.PersonalAccessToken{}, fmt.Errorf("error insert: %v", err)
+	}
+
+	return created, nil
+}
+
+func (pr *PersonalAccessTokenRepo) GetActiveByUserID(ctx context.Context, userID string) ([]user.PersonalAccessToken, error) {
+	query := `SELECT * FROM personal_access_tokens
+		WHERE user_id = $1 AND revoked_at IS NULL AND (expired_at IS NULL OR expired_at > NOW())
+		ORDER BY created_at DESC;`
+
+	var tokens []user.PersonalAccessToken
+
+	if err := pgxscan.Select(ctx, pr.DB.Pool, &tokens, query, userID); err != nil {
+		return nil, fmt.Errorf("error get personal access tokens by user id: %+v", err)
+	}
+
+	return tokens, nil
+}
+
+func (pr *PersonalAccessTokenRepo) Revoke(ctx context.Context, id string, userID string) error {
+	query := `UPDATE personal_access_tokens SET revoked_at = NOW()
+		WHERE id = $1 AND user_id = $2 AND revoked_at IS NULL;`
+
+	tag, err := pr.DB.Pool.Exec(ctx, query, id, userID)
+	if err != nil {
+		return fmt.Errorf("error update: %v", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return user.ErrNotFound
+	}
+
+	return nil
+}
+
+func (pr *PersonalAccessTokenRepo) Use(ctx context.Context, tokenHash string) (user.PersonalAccessToken, error) {
+	query := `UPDATE personal_access_tokens SET last_used_at = NOW()
+		WHERE token_hash = $1 AND revoked_at IS NULL AND (expired_at IS NULL OR expired_at > NOW())
+		RETURNING *;`
+
+	t := user.PersonalAccessToken{}
+
+	if err := pgxscan.Get(ctx, pr.DB.Pool, &t, query, tokenHash); err != nil {
+		if pgxscan.NotFound(err) {
+			return user.PersonalAccessToken{}, user.ErrNotFound
+		}
+
+		return user.PersonalAccessToken{}, fmt.Errorf("error update: %v", err)
+	}
+
+	return t, nil
+}
@@ -0,0 +1,102 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/RianNegreiros/go-graphql-api/internal/pagination"
+	"github.com/RianNegreiros/go-graphql-api/internal/post"
+	"github.com/georgysavva/scany/v2/pgxscan"
+)
+
+func (tr *PostRepo) Like(ctx context.Context, userID, postID string) error {
+	query := `INSERT INTO post_likes (user_id, post_id) VALUES ($1, $2) ON CONFLICT DO NOTHING;`
+
+	if _, err := tr.DB.Pool.Exec(ctx, query, userID, postID); err != nil {
+		return fmt.Errorf("error insert like: %v", err)
+	}
+
+	return nil
+}
+
+func (tr *PostRepo) Unlike(ctx context.Context, userID, postID string) error {
+	query := `DELETE FROM post_likes WHERE user_id = $1 AND post_id = $2;`
+
+	if _, err := tr.DB.Pool.Exec(ctx, query, userID, postID); err != nil {
+		return fmt.Errorf("error delete like: %v", err)
+	}
+
+	return nil
+}
+
+func (tr *PostRepo) CountLikes(ctx context.Context, postIDs []string) (map[string]int, error) {
+	query := `SELECT post_id, COUNT(*) AS count FROM post_likes WHERE post_id = ANY($1) GROUP BY post_id;`
+
+	var rows []struct {
+		PostID string
+		Count  int
+	}
+
+	if err := pgxscan.Select(ctx, tr.DB.Pool, &rows, query, postIDs); err != nil {
+		return nil, fmt.Errorf("error count likes: %+v", err)
+	}
+
+	counts := make(map[string]int, len(rows))
+
+	for _, r := range rows {
+		counts[r.PostID] = r.Count
+	}
+
+	return counts, nil
+}
+
+func (tr *PostRepo) GetLikedByUser(ctx context.Context, userID string, postIDs []string) (map[string]bool, error) {
+	query := `SELECT post_id FROM post_likes WHERE user_id = $1 AND post_id = ANY($2);`
+
+	var ids []string
+
+	if err := pgxscan.Select(ctx, tr.DB.Pool, &ids, query, userID, postIDs); err != nil {
+		return nil, fmt.Errorf("error get liked posts: %+v", err)
+	}
+
+	liked := make(map[string]bool, len(ids))
+
+	for _, id := range ids {
+		liked[id] = true
+	}
+
+	return liked, nil
+}
+
+// GetLikedPosts pages through the posts a user liked, most recently liked
+// first. Cursors point at the like, not at the post.
+func (tr *PostRepo) GetLikedPosts(ctx context.Context, userID string, args pagination.Args) (pagination.Page[post.Post], error) {
+	ks := buildKeyset(args, "l.created_at", "l.post_id", 1, newestFirst)
+
+	query := fmt.Sprintf(`SELECT p.*, l.created_at AS liked_at FROM post_likes l
+		JOIN posts p ON p.id = l.post_id
+		WHERE l.user_id = $1 AND %s ORDER BY %s LIMIT %d;`, ks.Where, ks.OrderBy, ks.Limit)
+
+	type likedPost struct {
+		post.Post
+		LikedAt time.Time
+	}
+
+	var rows []likedPost
+
+	if err := pgxscan.Select(ctx, tr.DB.Pool, &rows, query, append([]interface{}{userID}, ks.Args...)...); err != nil {
+		return pagination.Page[post.Post]{}, fmt.Errorf("error get liked posts: %+v", err)
+	}
+
+	page := pagination.NewPage(rows, args, func(r likedPost) pagination.Cursor {
+		return pagination.Cursor{
+			CreatedAt: r.LikedAt,
+			ID:        r.ID,
+		}
+	})
+
+	return pagination.MapPage(page, func(r likedPost) post.Post {
+		return r.Post
+	}), nil
+}
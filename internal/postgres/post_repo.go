@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/RianNegreiros/go-graphql-api/internal/pagination"
 	"github.com/RianNegreiros/go-graphql-api/internal/post"
 	"github.com/RianNegreiros/go-graphql-api/internal/user"
 	"github.com/georgysavva/scany/v2/pgxscan"
@@ -20,20 +21,56 @@ func NewPostRepo(db *DB) *PostRepo {
 	}
 }
 
-func (tr *PostRepo) All(ctx context.Context) ([]post.Post, error) {
-	return getAllPost(ctx, tr.DB.Pool)
+func (tr *PostRepo) GetAllByUserID(ctx context.Context, userID string) ([]post.Post, error) {
+	query := `SELECT * FROM posts WHERE user_id = $1 ORDER BY created_at DESC, id DESC;`
+
+	var posts []post.Post
+
+	if err := pgxscan.Select(ctx, tr.DB.Pool, &posts, query, userID); err != nil {
+		return nil, fmt.Errorf("error get posts by user id: %+v", err)
+	}
+
+	return posts, nil
+}
+
+func (tr *PostRepo) Paginate(ctx context.Context, args pagination.Args) (pagination.Page[post.Post], error) {
+	return paginatePosts(ctx, tr.DB.Pool, args)
 }
 
-func getAllPost(ctx context.Context, q pgxscan.Querier) ([]post.Post, error) {
-	query := `SELECT * FROM posts ORDER BY created_at DESC;`
+func paginatePosts(ctx context.Context, q pgxscan.Querier, args pagination.Args) (pagination.Page[post.Post], error) {
+	ks := buildKeyset(args, "p.created_at", "p.id", 0, newestFirst)
+
+	query := fmt.Sprintf(`SELECT p.* FROM posts p WHERE %s ORDER BY %s LIMIT %d;`, ks.Where, ks.OrderBy, ks.Limit)
 
 	var posts []post.Post
 
-	if err := pgxscan.Select(ctx, q, &posts, query); err != nil {
-		return nil, fmt.Errorf("error get all posts %+v", err)
+	if err := pgxscan.Select(ctx, q, &posts, query, ks.Args...); err != nil {
+		return pagination.Page[post.Post]{}, fmt.Errorf("error paginate posts %+v", err)
 	}
 
-	return posts, nil
+	return pagination.NewPage(posts, args, post.Post.Cursor), nil
+}
+
+// GetHomeTimeline pages through the posts of userID and of the users they
+// follow, newest first.
+func (tr *PostRepo) GetHomeTimeline(ctx context.Context, userID string, args pagination.Args) (pagination.Page[post.Post], error) {
+	ks := buildKeyset(args, "p.created_at", "p.id", 1, newestFirst)
+
+	query := fmt.Sprintf(`SELECT p.* FROM posts p
+		JOIN (
+			SELECT followee_id AS user_id FROM follows WHERE follower_id = $1
+			UNION ALL
+			SELECT $1::UUID
+		) a ON a.user_id = p.user_id
+		WHERE %s ORDER BY %s LIMIT %d;`, ks.Where, ks.OrderBy, ks.Limit)
+
+	var posts []post.Post
+
+	if err := pgxscan.Select(ctx, tr.DB.Pool, &posts, query, append([]interface{}{userID}, ks.Args...)...); err != nil {
+		return pagination.Page[post.Post]{}, fmt.Errorf("error get home timeline: %+v", err)
+	}
+
+	return pagination.NewPage(posts, args, post.Post.Cursor), nil
 }
 
 func (tr *PostRepo) Create(ctx context.Context, p post.Post) (post.Post, error) {
@@ -87,6 +124,173 @@ func getPostByID(ctx context.Context, q pgxscan.Querier, id string) (post.Post,
 	return t, nil
 }
 
+func (tr *PostRepo) GetByIds(ctx context.Context, ids []string) ([]post.Post, error) {
+	return getPostsByIds(ctx, tr.DB.Pool, ids)
+}
+
+func getPostsByIds(ctx context.Context, q pgxscan.Querier, ids []string) ([]post.Post, error) {
+	query := `SELECT * FROM posts WHERE id = ANY($1);`
+
+	var posts []post.Post
+
+	if err := pgxscan.Select(ctx, q, &posts, query, ids); err != nil {
+		return nil, fmt.Errorf("error get posts by ids: %+v", err)
+	}
+
+	return posts, nil
+}
+
+func (tr *PostRepo) CountReplies(ctx context.Context, parentIDs []string) (map[string]int, error) {
+	query := `SELECT parent_id, COUNT(*) AS count FROM posts WHERE parent_id = ANY($1) GROUP BY parent_id;`
+
+	var rows []struct {
+		ParentID string
+		Count    int
+	}
+
+	if err := pgxscan.Select(ctx, tr.DB.Pool, &rows, query, parentIDs); err != nil {
+		return nil, fmt.Errorf("error count replies: %+v", err)
+	}
+
+	counts := make(map[string]int, len(rows))
+
+	for _, r := range rows {
+		counts[r.ParentID] = r.Count
+	}
+
+	return counts, nil
+}
+
+// GetReplies loads a page of replies, oldest first, for each of the parent
+// posts in a single query.
+func (tr *PostRepo) GetReplies(ctx context.Context, parentIDs []string, args pagination.Args) (map[string]pagination.Page[post.Post], error) {
+	ks := buildKeyset(args, "p.created_at", "p.id", 1, oldestFirst)
+
+	query := fmt.Sprintf(`SELECT r.* FROM (
+			SELECT p.*, ROW_NUMBER() OVER (PARTITION BY p.parent_id ORDER BY %s) AS row_number
+			FROM posts p WHERE p.parent_id = ANY($1) AND %s
+		) r WHERE r.row_number <= %d ORDER BY r.parent_id, r.row_number;`, ks.OrderBy, ks.Where, ks.Limit)
+
+	var rows []struct {
+		post.Post
+		RowNumber int
+	}
+
+	if err := pgxscan.Select(ctx, tr.DB.Pool, &rows, query, append([]interface{}{parentIDs}, ks.Args...)...); err != nil {
+		return nil, fmt.Errorf("error get replies: %+v", err)
+	}
+
+	repliesByParentID := map[string][]post.Post{}
+
+	for _, r := range rows {
+		repliesByParentID[*r.ParentID] = append(repliesByParentID[*r.ParentID], r.Post)
+	}
+
+	pages := make(map[string]pagination.Page[post.Post], len(parentIDs))
+
+	for _, id := range parentIDs {
+		pages[id] = pagination.NewPage(repliesByParentID[id], args, post.Post.Cursor)
+	}
+
+	return pages, nil
+}
+
+const threadPathStep = `(to_char(p.created_at AT TIME ZONE 'UTC', 'YYYYMMDDHH24MISSUS') || p.id::TEXT)`
+
+// GetThread loads the root post and its replies down to depth levels, in
+// depth-first order with siblings sorted oldest first. Each step of the path
+// is the fixed-width creation time of a post followed by its id, so siblings
+// created at the same time still keep their replies below them.
+func (tr *PostRepo) GetThread(ctx context.Context, rootID string, depth int) ([]post.Post, error) {
+	query := `WITH RECURSIVE thread AS (
+			SELECT p.*, 0 AS depth, ARRAY[` + threadPathStep + `] AS path
+			FROM posts p WHERE p.id = $1
+			UNION ALL
+			SELECT p.*, t.depth + 1, t.path || ` + threadPathStep + `
+			FROM posts p JOIN thread t ON p.parent_id = t.id
+			WHERE t.depth < $2
+		)
+		SELECT * FROM thread ORDER BY path COLLATE "C";`
+
+	var rows []struct {
+		post.Post
+		Depth int
+		Path  []string
+	}
+
+	if err := pgxscan.Select(ctx, tr.DB.Pool, &rows, query, rootID, depth); err != nil {
+		return nil, fmt.Errorf("error get thread: %+v", err)
+	}
+
+	posts := make([]post.Post, len(rows))
+
+	for i, r := range rows {
+		posts[i] = r.Post
+	}
+
+	return posts, nil
+}
+
+// Update keeps the current body as a revision before replacing it.
+func (tr *PostRepo) Update(ctx context.Context, p post.Post) (post.Post, error) {
+	tx, err := tr.DB.Pool.Begin(ctx)
+	if err != nil {
+		return post.Post{}, fmt.Errorf("error starting transaction: %v", err)
+	}
+	defer tx.Rollback(ctx)
+
+	p, err = updatePost(ctx, tx, p)
+	if err != nil {
+		return post.Post{}, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return post.Post{}, fmt.Errorf("error commiting: %v", err)
+	}
+
+	return p, nil
+}
+
+func updatePost(ctx context.Context, tx pgx.Tx, p post.Post) (post.Post, error) {
+	revisionQuery := `INSERT INTO post_revisions (post_id, body) SELECT id, body FROM posts WHERE id = $1;`
+
+	if _, err := tx.Exec(ctx, revisionQuery, p.ID); err != nil {
+		return post.Post{}, fmt.Errorf("error insert revision: %v", err)
+	}
+
+	query := `UPDATE posts SET body = $2, updated_at = NOW() WHERE id = $1 RETURNING *;`
+
+	t := post.Post{}
+
+	if err := pgxscan.Get(ctx, tx, &t, query, p.ID, p.Body); err != nil {
+		if pgxscan.NotFound(err) {
+			return post.Post{}, user.ErrNotFound
+		}
+
+		return post.Post{}, fmt.Errorf("error update: %v", err)
+	}
+
+	return t, nil
+}
+
+func (tr *PostRepo) GetRevisions(ctx context.Context, postIDs []string) (map[string][]post.Revision, error) {
+	query := `SELECT * FROM post_revisions WHERE post_id = ANY($1) ORDER BY created_at DESC;`
+
+	var revisions []post.Revision
+
+	if err := pgxscan.Select(ctx, tr.DB.Pool, &revisions, query, postIDs); err != nil {
+		return nil, fmt.Errorf("error get revisions: %+v", err)
+	}
+
+	revisionsByPostID := make(map[string][]post.Revision, len(postIDs))
+
+	for _, r := range revisions {
+		revisionsByPostID[r.PostID] = append(revisionsByPostID[r.PostID], r)
+	}
+
+	return revisionsByPostID, nil
+}
+
 func (tr *PostRepo) Delete(ctx context.Context, id string) error {
 	tx, err := tr.DB.Pool.Begin(ctx)
 	if err != nil {
@@ -114,3 +318,35 @@ func deletePost(ctx context.Context, tx pgx.Tx, id string) error {
 
 	return nil
 }
+
+// Remove deletes a post on behalf of a moderator, recording who removed it
+// and why.
+func (tr *PostRepo) Remove(ctx context.Context, removal post.Removal) error {
+	tx, err := tr.DB.Pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("error starting transaction: %v", err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := `INSERT INTO post_removals (post_id, author_id, body, removed_by, reason)
+		SELECT id, user_id, body, $2, $3 FROM posts WHERE id = $1;`
+
+	tag, err := tx.Exec(ctx, query, removal.PostID, removal.RemovedBy, removal.Reason)
+	if err != nil {
+		return fmt.Errorf("error insert removal: %v", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return user.ErrNotFound
+	}
+
+	if err := deletePost(ctx, tx, removal.PostID); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("error commiting: %v", err)
+	}
+
+	return nil
+}
@@ -93,7 +93,13 @@ func (db *DB) Drop() error {
 	return nil
 }
 
+// Truncate empties the database for tests. Posts go first, deleting their
+// author is restricted.
 func (db *DB) Truncate(ctx context.Context) error {
+	if _, err := db.Pool.Exec(ctx, `DELETE FROM posts;`); err != nil {
+		return fmt.Errorf("error truncating posts table: %w", err)
+	}
+
 	_, err := db.Pool.Exec(ctx, `DELETE FROM users;`)
 	if err != nil {
 		return fmt.Errorf("error truncating users table: %w", err)
@@ -0,0 +1,97 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/RianNegreiros/go-graphql-api/internal/pubsub"
+)
+
+const pubSubChannel = "pubsub_events"
+
+type pubSubMessage struct {
+	Topic   string          `json:"topic"`
+	Payload json.RawMessage `json:"payload"`
+}
+
+// PubSub shares messages between API instances through LISTEN/NOTIFY. Each
+// instance listens on a single channel and fans messages out to its local
+// subscribers.
+type PubSub struct {
+	DB    *DB
+	local *pubsub.Memory
+}
+
+func NewPubSub(db *DB) *PubSub {
+	return &PubSub{
+		DB:    db,
+		local: pubsub.NewMemory(),
+	}
+}
+
+func (ps *PubSub) Publish(ctx context.Context, topic string, payload []byte) error {
+	msg, err := json.Marshal(pubSubMessage{
+		Topic:   topic,
+		Payload: payload,
+	})
+	if err != nil {
+		return fmt.Errorf("error encoding message: %v", err)
+	}
+
+	if _, err := ps.DB.Pool.Exec(ctx, `SELECT pg_notify($1, $2);`, pubSubChannel, string(msg)); err != nil {
+		return fmt.Errorf("error notify: %v", err)
+	}
+
+	return nil
+}
+
+func (ps *PubSub) Subscribe(ctx context.Context, topic string) (<-chan []byte, error) {
+	return ps.local.Subscribe(ctx, topic)
+}
+
+// Listen forwards notifications to local subscribers until ctx is done,
+// reconnecting when the listening connection is lost.
+func (ps *PubSub) Listen(ctx context.Context) {
+	for {
+		if err := ps.listen(ctx); err != nil {
+			log.Printf("error listening to %s: %v", pubSubChannel, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(time.Second):
+		}
+	}
+}
+
+func (ps *PubSub) listen(ctx context.Context) error {
+	conn, err := ps.DB.Pool.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("error acquiring connection: %v", err)
+	}
+	defer conn.Release()
+
+	if _, err := conn.Exec(ctx, "LISTEN "+pubSubChannel); err != nil {
+		return fmt.Errorf("error listen: %v", err)
+	}
+
+	for {
+		notification, err := conn.Conn().WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+
+		var msg pubSubMessage
+
+		if err := json.Unmarshal([]byte(notification.Payload), &msg); err != nil {
+			log.Printf("error decoding %s message: %v", pubSubChannel, err)
+			continue
+		}
+
+		ps.local.Publish(ctx, msg.Topic, msg.Payload)
+	}
+}
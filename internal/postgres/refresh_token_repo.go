@@ -0,0 +1,183 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/RianNegreiros/go-graphql-api/internal/jwt"
+	"github.com/RianNegreiros/go-graphql-api/internal/user"
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/jackc/pgx/v5"
+)
+
+type RefreshTokenRepo struct {
+	DB *DB
+}
+
+func NewRefreshTokenRepo(db *DB) *RefreshTokenRepo {
+	return &RefreshTokenRepo{
+		DB: db,
+	}
+}
+
+func (rr *RefreshTokenRepo) Create(ctx context.Context, params jwt.CreateRefreshTokenParams) (jwt.RefreshToken, error) {
+	tx, err := rr.DB.Pool.Begin(ctx)
+	if err != nil {
+		return jwt.RefreshToken{}, fmt.Errorf("error starting transaction: %v", err)
+	}
+	defer tx.Rollback(ctx)
+
+	rt, err := createRefreshToken(ctx, tx, params)
+	if err != nil {
+		return jwt.RefreshToken{}, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return jwt.RefreshToken{}, fmt.Errorf("error commiting: %v", err)
+	}
+
+	return rt, nil
+}
+
+// createRefreshToken starts a new family unless params.FamilyID is set. The
+// tokens of a family keep the created_at of its first one, which is when the
+// session was opened.
+func createRefreshToken(ctx context.Context, tx pgx.Tx, params jwt.CreateRefreshTokenParams) (jwt.RefreshToken, error) {
+	query := `INSERT INTO refresh_tokens (name, user_id, family_id, expired_at, created_at)
+		VALUES ($1, $2, COALESCE(NULLIF($3, '')::UUID, uuid_generate_v4()), $4,
+			COALESCE((SELECT MIN(created_at) FROM refresh_tokens WHERE family_id = NULLIF($3, '')::UUID), NOW()))
+		RETURNING *;`
+
+	rt := jwt.RefreshToken{}
+
+	expiredAt := jwt.Now().Add(jwt.RefreshTokenLifeTime)
+
+	if err := pgxscan.Get(ctx, tx, &rt, query, params.Name, params.Sub, params.FamilyID, expiredAt); err != nil {
+		return jwt.RefreshToken{}, fmt.Errorf("error insert: %v", err)
+	}
+
+	return rt, nil
+}
+
+func (rr *RefreshTokenRepo) GetByID(ctx context.Context, id string) (jwt.RefreshToken, error) {
+	return getRefreshTokenByID(ctx, rr.DB.Pool, id)
+}
+
+func getRefreshTokenByID(ctx context.Context, q pgxscan.Querier, id string) (jwt.RefreshToken, error) {
+	query := `SELECT * FROM refresh_tokens WHERE id = $1 LIMIT 1;`
+
+	rt := jwt.RefreshToken{}
+
+	if err := pgxscan.Get(ctx, q, &rt, query, id); err != nil {
+		if pgxscan.NotFound(err) {
+			return jwt.RefreshToken{}, user.ErrNotFound
+		}
+
+		return jwt.RefreshToken{}, fmt.Errorf("error get refresh token: %+v", err)
+	}
+
+	return rt, nil
+}
+
+// Rotate marks the refresh token as used and revoked, and issues its
+// replacement in the same family. It returns jwt.ErrRefreshTokenRevoked
+// when the token was already rotated, so callers can detect reuse.
+func (rr *RefreshTokenRepo) Rotate(ctx context.Context, id string, params jwt.CreateRefreshTokenParams) (jwt.RefreshToken, error) {
+	tx, err := rr.DB.Pool.Begin(ctx)
+	if err != nil {
+		return jwt.RefreshToken{}, fmt.Errorf("error starting transaction: %v", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := revokeRefreshToken(ctx, tx, id); err != nil {
+		return jwt.RefreshToken{}, err
+	}
+
+	rt, err := createRefreshToken(ctx, tx, params)
+	if err != nil {
+		return jwt.RefreshToken{}, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return jwt.RefreshToken{}, fmt.Errorf("error commiting: %v", err)
+	}
+
+	return rt, nil
+}
+
+func revokeRefreshToken(ctx context.Context, tx pgx.Tx, id string) error {
+	query := `UPDATE refresh_tokens SET last_used_at = NOW(), revoked_at = NOW() WHERE id = $1 AND revoked_at IS NULL;`
+
+	tag, err := tx.Exec(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("error update: %v", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return jwt.ErrRefreshTokenRevoked
+	}
+
+	return nil
+}
+
+func (rr *RefreshTokenRepo) RevokeFamily(ctx context.Context, familyID string) error {
+	query := `UPDATE refresh_tokens SET revoked_at = NOW() WHERE family_id = $1 AND revoked_at IS NULL;`
+
+	if _, err := rr.DB.Pool.Exec(ctx, query, familyID); err != nil {
+		return fmt.Errorf("error revoke refresh token family: %v", err)
+	}
+
+	return nil
+}
+
+func (rr *RefreshTokenRepo) GetActiveByFamilyID(ctx context.Context, familyID string) (jwt.RefreshToken, error) {
+	query := `SELECT * FROM refresh_tokens
+		WHERE family_id = $1 AND revoked_at IS NULL AND expired_at > NOW() LIMIT 1;`
+
+	rt := jwt.RefreshToken{}
+
+	if err := pgxscan.Get(ctx, rr.DB.Pool, &rt, query, familyID); err != nil {
+		if pgxscan.NotFound(err) {
+			return jwt.RefreshToken{}, user.ErrNotFound
+		}
+
+		return jwt.RefreshToken{}, fmt.Errorf("error get refresh token: %+v", err)
+	}
+
+	return rt, nil
+}
+
+func (rr *RefreshTokenRepo) GetActiveByUserID(ctx context.Context, userID string) ([]jwt.RefreshToken, error) {
+	query := `SELECT * FROM refresh_tokens
+		WHERE user_id = $1 AND revoked_at IS NULL AND expired_at > NOW()
+		ORDER BY last_used_at DESC;`
+
+	var tokens []jwt.RefreshToken
+
+	if err := pgxscan.Select(ctx, rr.DB.Pool, &tokens, query, userID); err != nil {
+		return nil, fmt.Errorf("error get refresh tokens by user id: %+v", err)
+	}
+
+	return tokens, nil
+}
+
+func (rr *RefreshTokenRepo) RevokeAllByUserID(ctx context.Context, userID string) error {
+	query := `UPDATE refresh_tokens SET revoked_at = NOW() WHERE user_id = $1 AND revoked_at IS NULL;`
+
+	if _, err := rr.DB.Pool.Exec(ctx, query, userID); err != nil {
+		return fmt.Errorf("error revoke refresh tokens: %v", err)
+	}
+
+	return nil
+}
+
+func (rr *RefreshTokenRepo) RevokeAllByUserIDExcept(ctx context.Context, userID string, familyID string) error {
+	query := `UPDATE refresh_tokens SET revoked_at = NOW()
+		WHERE user_id = $1 AND family_id <> $2 AND revoked_at IS NULL;`
+
+	if _, err := rr.DB.Pool.Exec(ctx, query, userID, familyID); err != nil {
+		return fmt.Errorf("error revoke refresh tokens: %v", err)
+	}
+
+	return nil
+}
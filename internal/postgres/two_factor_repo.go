@@ -0,0 +1,182 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/RianNegreiros/go-graphql-api/internal/user"
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/jackc/pgx/v5"
+)
+
+type TwoFactorRepo struct {
+	DB *DB
+}
+
+func NewTwoFactorRepo(db *DB) *TwoFactorRepo {
+	return &TwoFactorRepo{
+		DB: db,
+	}
+}
+
+func (tr *TwoFactorRepo) GetTotp(ctx context.Context, userID string) (user.Totp, error) {
+	query := `SELECT * FROM user_totp WHERE user_id = $1 LIMIT 1;`
+
+	t := user.Totp{}
+
+	if err := pgxscan.Get(ctx, tr.DB.Pool, &t, query, userID); err != nil {
+		if pgxscan.NotFound(err) {
+			return user.Totp{}, user.ErrNotFound
+		}
+
+		return user.Totp{}, fmt.Errorf("error get totp: %+v", err)
+	}
+
+	return t, nil
+}
+
+func (tr *TwoFactorRepo) SaveTotp(ctx context.Context, userID string, secret string) (user.Totp, error) {
+	query := `INSERT INTO user_totp (user_id, secret) VALUES ($1, $2)
+		ON CONFLICT (user_id) DO UPDATE SET secret = $2, last_used_step = 0, created_at = NOW()
+			WHERE user_totp.confirmed_at IS NULL
+		RETURNING *;`
+
+	t := user.Totp{}
+
+	if err := pgxscan.Get(ctx, tr.DB.Pool, &t, query, userID, secret); err != nil {
+		if pgxscan.NotFound(err) {
+			return user.Totp{}, user.ErrTotpAlreadyEnabled
+		}
+
+		return user.Totp{}, fmt.Errorf("error upsert totp: %v", err)
+	}
+
+	return t, nil
+}
+
+func (tr *TwoFactorRepo) ConfirmTotp(ctx context.Context, userID string, step int64, recoveryCodeHashes []string) error {
+	tx, err := tr.DB.Pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("error starting transaction: %v", err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := `UPDATE user_totp SET confirmed_at = NOW(), last_used_step = $2
+		WHERE user_id = $1 AND confirmed_at IS NULL;`
+
+	tag, err := tx.Exec(ctx, query, userID, step)
+	if err != nil {
+		return fmt.Errorf("error update: %v", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return user.ErrTotpAlreadyEnabled
+	}
+
+	if err := replaceRecoveryCodes(ctx, tx, userID, recoveryCodeHashes); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("error commiting: %v", err)
+	}
+
+	return nil
+}
+
+func (tr *TwoFactorRepo) UseTotpStep(ctx context.Context, userID string, step int64) error {
+	query := `UPDATE user_totp SET last_used_step = $2 WHERE user_id = $1 AND last_used_step < $2;`
+
+	tag, err := tr.DB.Pool.Exec(ctx, query, userID, step)
+	if err != nil {
+		return fmt.Errorf("error update: %v", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return user.ErrInvalidTwoFactor
+	}
+
+	return nil
+}
+
+func (tr *TwoFactorRepo) DeleteTotp(ctx context.Context, userID string) error {
+	tx, err := tr.DB.Pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("error starting transaction: %v", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `DELETE FROM recovery_codes WHERE user_id = $1;`, userID); err != nil {
+		return fmt.Errorf("error delete recovery codes: %v", err)
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM user_totp WHERE user_id = $1;`, userID); err != nil {
+		return fmt.Errorf("error delete totp: %v", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("error commiting: %v", err)
+	}
+
+	return nil
+}
+
+func (tr *TwoFactorRepo) ReplaceRecoveryCodes(ctx context.Context, userID string, hashes []string) error {
+	tx, err := tr.DB.Pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("error starting transaction: %v", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := replaceRecoveryCodes(ctx, tx, userID, hashes); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("error commiting: %v", err)
+	}
+
+	return nil
+}
+
+func replaceRecoveryCodes(ctx context.Context, tx pgx.Tx, userID string, hashes []string) error {
+	if _, err := tx.Exec(ctx, `DELETE FROM recovery_codes WHERE user_id = $1;`, userID); err != nil {
+		return fmt.Errorf("error delete recovery codes: %v", err)
+	}
+
+	query := `INSERT INTO recovery_codes (user_id, code_hash) SELECT $1, unnest($2::TEXT[]);`
+
+	if _, err := tx.Exec(ctx, query, userID, hashes); err != nil {
+		return fmt.Errorf("error insert recovery codes: %v", err)
+	}
+
+	return nil
+}
+
+func (tr *TwoFactorRepo) UseRecoveryCode(ctx context.Context, userID string, hash string) error {
+	query := `UPDATE recovery_codes SET used_at = NOW()
+		WHERE user_id = $1 AND code_hash = $2 AND used_at IS NULL;`
+
+	tag, err := tr.DB.Pool.Exec(ctx, query, userID, hash)
+	if err != nil {
+		return fmt.Errorf("error update: %v", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return user.ErrNotFound
+	}
+
+	return nil
+}
+
+func (tr *TwoFactorRepo) CountRecoveryCodes(ctx context.Context, userID string) (int, error) {
+	query := `SELECT COUNT(*) FROM recovery_codes WHERE user_id = $1 AND used_at IS NULL;`
+
+	var count int
+
+	if err := tr.DB.Pool.QueryRow(ctx, query, userID).Scan(&count); err != nil {
+		return 0, fmt.Errorf("error count recovery codes: %v", err)
+	}
+
+	return count, nil
+}
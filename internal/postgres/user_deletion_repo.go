@@ -0,0 +1,175 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/RianNegreiros/go-graphql-api/internal/post"
+	"github.com/RianNegreiros/go-graphql-api/internal/user"
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/jackc/pgx/v5"
+)
+
+// personalDataTables hold nothing but data of their user_id, they're emptied
+// when the user is anonymized.
+var personalDataTables = []string{
+	"refresh_tokens",
+	"password_reset_tokens",
+	"user_totp",
+	"recovery_codes",
+	"personal_access_tokens",
+	"passkeys",
+	"passkey_challenges",
+	"login_link_tokens",
+	"identities",
+	"oauth_grants",
+	"oauth_authorization_codes",
+	"oauth_consents",
+	"oauth_clients",
+	"post_likes",
+}
+
+// credentialTables hold the tokens that sign in as their user_id, they're
+// revoked when the deletion of the account is scheduled.
+var credentialTables = []string{
+	"refresh_tokens",
+	"personal_access_tokens",
+	"oauth_grants",
+}
+
+// ScheduleDeletion revokes every credential of the user along with
+// scheduling, and drops the authorization codes not redeemed yet. Cancelling
+// gives none of them back.
+func (ur *UserRepo) ScheduleDeletion(ctx context.Context, userID string, at *time.Time) (user.UserModel, error) {
+	tx, err := ur.DB.Pool.Begin(ctx)
+	if err != nil {
+		return user.UserModel{}, fmt.Errorf("error starting transaction: %v", err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := `UPDATE users SET deletion_scheduled_at = $1, updated_at = NOW()
+		WHERE id = $2 AND deleted_at IS NULL RETURNING *;`
+
+	u := user.UserModel{}
+
+	if err := pgxscan.Get(ctx, tx, &u, query, at, userID); err != nil {
+		if pgxscan.NotFound(err) {
+			return user.UserModel{}, user.ErrNotFound
+		}
+
+		return user.UserModel{}, fmt.Errorf("error update: %v", err)
+	}
+
+	if at != nil {
+		for _, table := range credentialTables {
+			if _, err := tx.Exec(ctx, `UPDATE `+table+` SET revoked_at = NOW() WHERE user_id = $1 AND revoked_at IS NULL;`, userID); err != nil {
+				return user.UserModel{}, fmt.Errorf("error revoke %s: %v", table, err)
+			}
+		}
+
+		if _, err := tx.Exec(ctx, `DELETE FROM oauth_authorization_codes WHERE user_id = $1;`, userID); err != nil {
+			return user.UserModel{}, fmt.Errorf("error delete: %v", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return user.UserModel{}, fmt.Errorf("error commiting: %v", err)
+	}
+
+	return u, nil
+}
+
+func (ur *UserRepo) GetDueForDeletion(ctx context.Context, now time.Time) ([]string, error) {
+	query := `SELECT id FROM users WHERE deletion_scheduled_at <= $1 AND deleted_at IS NULL;`
+
+	var ids []string
+
+	if err := pgxscan.Select(ctx, ur.DB.Pool, &ids, query, now); err != nil {
+		return nil, fmt.Errorf("error get users due for deletion: %+v", err)
+	}
+
+	return ids, nil
+}
+
+// Anonymize deletes the posts of the user nobody else replied to, and blanks
+// the others so the threads below them survive. Everything else about the
+// user is deleted, only their id is kept.
+func (ur *UserRepo) Anonymize(ctx context.Context, userID string) error {
+	tx, err := ur.DB.Pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("error starting transaction: %v", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := anonymizePosts(ctx, tx, userID); err != nil {
+		return err
+	}
+
+	for _, table := range personalDataTables {
+		if _, err := tx.Exec(ctx, `DELETE FROM `+table+` WHERE user_id = $1;`, userID); err != nil {
+			return fmt.Errorf("error delete from %s: %v", table, err)
+		}
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM follows WHERE follower_id = $1 OR followee_id = $1;`, userID); err != nil {
+		return fmt.Errorf("error delete: %v", err)
+	}
+
+	removalsQuery := `UPDATE post_removals SET author_id = NULL, body = $2 WHERE author_id = $1;`
+
+	if _, err := tx.Exec(ctx, removalsQuery, userID, post.DeletedBody); err != nil {
+		return fmt.Errorf("error update: %v", err)
+	}
+
+	usersQuery := `UPDATE users SET username = 'deleted_' || REPLACE(id::text, '-', ''),
+		email = id::text || '@deleted.invalid', password = '', display_name = '', bio = '',
+		location = '', website = '', avatar_url = '', role = 'user', email_verified_at = NULL,
+		deletion_scheduled_at = NULL, deleted_at = NOW(), updated_at = NOW()
+		WHERE id = $1;`
+
+	tag, err := tx.Exec(ctx, usersQuery, userID)
+	if err != nil {
+		return fmt.Errorf("error update: %v", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return user.ErrNotFound
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("error commiting: %v", err)
+	}
+
+	return nil
+}
+
+// anonymizePosts prunes the posts of the user from the leaves up, until only
+// the ones with replies from other users are left.
+func anonymizePosts(ctx context.Context, tx pgx.Tx, userID string) error {
+	pruneQuery := `DELETE FROM posts WHERE user_id = $1
+		AND NOT EXISTS (SELECT 1 FROM posts replies WHERE replies.parent_id = posts.id);`
+
+	for {
+		tag, err := tx.Exec(ctx, pruneQuery, userID)
+		if err != nil {
+			return fmt.Errorf("error delete: %v", err)
+		}
+
+		if tag.RowsAffected() == 0 {
+			break
+		}
+	}
+
+	revisionsQuery := `DELETE FROM post_revisions WHERE post_id IN (SELECT id FROM posts WHERE user_id = $1);`
+
+	if _, err := tx.Exec(ctx, revisionsQuery, userID); err != nil {
+		return fmt.Errorf("error delete: %v", err)
+	}
+
+	if _, err := tx.Exec(ctx, `UPDATE posts SET body = $2, updated_at = NOW() WHERE user_id = $1;`, userID, post.DeletedBody); err != nil {
+		return fmt.Errorf("error update: %v", err)
+	}
+
+	return nil
+}
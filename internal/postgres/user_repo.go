@@ -98,6 +98,93 @@ func (ur *UserRepo) GetByID(ctx context.Context, id string) (user.UserModel, err
 	return u, nil
 }
 
+func (ur *UserRepo) UpdateProfile(ctx context.Context, userModel user.UserModel) (user.UserModel, error) {
+	query := `UPDATE users
+		SET display_name = $1, bio = $2, location = $3, website = $4, avatar_url = $5, updated_at = NOW()
+		WHERE id = $6 RETURNING *;`
+
+	u := user.UserModel{}
+
+	err := pgxscan.Get(ctx, ur.DB.Pool, &u, query,
+		userModel.DisplayName, userModel.Bio, userModel.Location, userModel.Website, userModel.AvatarURL, userModel.ID)
+	if err != nil {
+		if pgxscan.NotFound(err) {
+			return user.UserModel{}, user.ErrNotFound
+		}
+
+		return user.UserModel{}, fmt.Errorf("error update: %v", err)
+	}
+
+	return u, nil
+}
+
+func (ur *UserRepo) UpdateRole(ctx context.Context, userID string, role user.Role) (user.UserModel, error) {
+	query := `UPDATE users SET role = $1, updated_at = NOW() WHERE id = $2 RETURNING *;`
+
+	u := user.UserModel{}
+
+	if err := pgxscan.Get(ctx, ur.DB.Pool, &u, query, role, userID); err != nil {
+		if pgxscan.NotFound(err) {
+			return user.UserModel{}, user.ErrNotFound
+		}
+
+		return user.UserModel{}, fmt.Errorf("error update: %v", err)
+	}
+
+	return u, nil
+}
+
+func (ur *UserRepo) UpdatePassword(ctx context.Context, userID string, password string) error {
+	query := `UPDATE users SET password = $1, updated_at = NOW() WHERE id = $2;`
+
+	tag, err := ur.DB.Pool.Exec(ctx, query, password, userID)
+	if err != nil {
+		return fmt.Errorf("error update: %v", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return user.ErrNotFound
+	}
+
+	return nil
+}
+
+func (ur *UserRepo) MarkEmailVerified(ctx context.Context, userID string) (user.UserModel, error) {
+	query := `UPDATE users SET email_verified_at = COALESCE(email_verified_at, NOW()), updated_at = NOW()
+		WHERE id = $1 RETURNING *;`
+
+	u := user.UserModel{}
+
+	if err := pgxscan.Get(ctx, ur.DB.Pool, &u, query, userID); err != nil {
+		if pgxscan.NotFound(err) {
+			return user.UserModel{}, user.ErrNotFound
+		}
+
+		return user.UserModel{}, fmt.Errorf("error update: %v", err)
+	}
+
+	return u, nil
+}
+
+// UpdateEmail switches the email of the user. The new address was confirmed
+// by the user, so it's marked as verified.
+func (ur *UserRepo) UpdateEmail(ctx context.Context, userID string, email string) (user.UserModel, error) {
+	query := `UPDATE users SET email = $1, email_verified_at = NOW(), updated_at = NOW()
+		WHERE id = $2 RETURNING *;`
+
+	u := user.UserModel{}
+
+	if err := pgxscan.Get(ctx, ur.DB.Pool, &u, query, email, userID); err != nil {
+		if pgxscan.NotFound(err) {
+			return user.UserModel{}, user.ErrNotFound
+		}
+
+		return user.UserModel{}, fmt.Errorf("error update: %v", err)
+	}
+
+	return u, nil
+}
+
 func (ur *UserRepo) GetByIds(ctx context.Context, ids []string) ([]user.UserModel, error) {
 	return getUsersByIds(ctx, ur.DB.Pool, ids)
 }
@@ -0,0 +1,70 @@
+package pubsub
+
+import (
+	"context"
+	"sync"
+)
+
+// SubscriptionBufferSize is how many messages a subscriber can fall behind
+// before new messages are dropped for it.
+var SubscriptionBufferSize = 16
+
+type PubSub interface {
+	Publish(ctx context.Context, topic string, payload []byte) error
+	// Subscribe returns a channel receiving every message published to topic
+	// until ctx is done, when the channel is closed.
+	Subscribe(ctx context.Context, topic string) (<-chan []byte, error)
+}
+
+// Memory is an in-process PubSub. Messages only reach subscribers of the same
+// process.
+type Memory struct {
+	mu          sync.RWMutex
+	subscribers map[string]map[chan []byte]struct{}
+}
+
+func NewMemory() *Memory {
+	return &Memory{
+		subscribers: map[string]map[chan []byte]struct{}{},
+	}
+}
+
+func (m *Memory) Publish(ctx context.Context, topic string, payload []byte) error {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for ch := range m.subscribers[topic] {
+		select {
+		case ch <- payload:
+		default:
+		}
+	}
+
+	return nil
+}
+
+func (m *Memory) Subscribe(ctx context.Context, topic string) (<-chan []byte, error) {
+	ch := make(chan []byte, SubscriptionBufferSize)
+
+	m.mu.Lock()
+	if m.subscribers[topic] == nil {
+		m.subscribers[topic] = map[chan []byte]struct{}{}
+	}
+	m.subscribers[topic][ch] = struct{}{}
+	m.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+
+		m.mu.Lock()
+		delete(m.subscribers[topic], ch)
+		if len(m.subscribers[topic]) == 0 {
+			delete(m.subscribers, topic)
+		}
+		m.mu.Unlock()
+
+		close(ch)
+	}()
+
+	return ch, nil
+}
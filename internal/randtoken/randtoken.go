@@ -0,0 +1,32 @@
+package randtoken
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+)
+
+// Size is the number of random bytes in a generated token.
+var Size = 32
+
+// Generate returns a random URL-safe token and its hash. Only the hash should
+// be stored, so a leaked table can't be used to redeem tokens.
+func Generate() (token string, hash string, err error) {
+	b := make([]byte, Size)
+
+	if _, err := rand.Read(b); err != nil {
+		return "", "", fmt.Errorf("error generating token: %v", err)
+	}
+
+	token = base64.RawURLEncoding.EncodeToString(b)
+
+	return token, Hash(token), nil
+}
+
+func Hash(token string) string {
+	sum := sha256.Sum256([]byte(token))
+
+	return hex.EncodeToString(sum[:])
+}
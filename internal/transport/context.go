@@ -9,7 +9,12 @@ import (
 type contextKey string
 
 var (
-	ContextAuthIDKey contextKey = "currentUserId"
+	ContextAuthIDKey    contextKey = "currentUserId"
+	ContextSessionIDKey contextKey = "currentSessionId"
+	ContextUserAgentKey contextKey = "userAgent"
+	ContextRoleKey      contextKey = "currentUserRole"
+	ContextClientIPKey  contextKey = "clientIP"
+	ContextScopesKey    contextKey = "scopes"
 )
 
 func GetUserIDFromContext(ctx context.Context) (string, error) {
@@ -28,3 +33,64 @@ func GetUserIDFromContext(ctx context.Context) (string, error) {
 func PutUserIDIntoContext(ctx context.Context, id string) context.Context {
 	return context.WithValue(ctx, ContextAuthIDKey, id)
 }
+
+func GetSessionIDFromContext(ctx context.Context) (string, error) {
+	sessionID, ok := ctx.Value(ContextSessionIDKey).(string)
+	if !ok || sessionID == "" {
+		return "", user.ErrNoSessionIDInContext
+	}
+
+	return sessionID, nil
+}
+
+func PutSessionIDIntoContext(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, ContextSessionIDKey, id)
+}
+
+func GetUserAgentFromContext(ctx context.Context) string {
+	userAgent, _ := ctx.Value(ContextUserAgentKey).(string)
+
+	return userAgent
+}
+
+func PutUserAgentIntoContext(ctx context.Context, userAgent string) context.Context {
+	return context.WithValue(ctx, ContextUserAgentKey, userAgent)
+}
+
+func GetClientIPFromContext(ctx context.Context) string {
+	ip, _ := ctx.Value(ContextClientIPKey).(string)
+
+	return ip
+}
+
+func PutClientIPIntoContext(ctx context.Context, ip string) context.Context {
+	return context.WithValue(ctx, ContextClientIPKey, ip)
+}
+
+// GetRoleFromContext returns the role of the authenticated user, defaulting to
+// user.RoleUser when none was put into the context.
+func GetRoleFromContext(ctx context.Context) user.Role {
+	role, ok := ctx.Value(ContextRoleKey).(user.Role)
+	if !ok || role == "" {
+		return user.RoleUser
+	}
+
+	return role
+}
+
+func PutRoleIntoContext(ctx context.Context, role user.Role) context.Context {
+	return context.WithValue(ctx, ContextRoleKey, role)
+}
+
+// GetScopesFromContext returns the scopes of the personal access token the
+// request was authenticated with. ok is false for requests made with a
+// session, which are not limited by scopes.
+func GetScopesFromContext(ctx context.Context) (scopes []user.Scope, ok bool) {
+	scopes, ok = ctx.Value(ContextScopesKey).([]user.Scope)
+
+	return scopes, ok
+}
+
+func PutScopesIntoContext(ctx context.Context, scopes []user.Scope) context.Context {
+	return context.WithValue(ctx, ContextScopesKey, scopes)
+}
@@ -0,0 +1,97 @@
+package user
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidResetToken        = fmt.Errorf("%w: invalid or expired reset token", ErrValidation)
+	ErrInvalidVerificationToken = fmt.Errorf("%w: invalid or expired verification token", ErrValidation)
+	ErrEmailAlreadyVerified     = fmt.Errorf("%w: email already verified", ErrValidation)
+	ErrEmailNotVerified         = fmt.Errorf("%w: email not verified", ErrForbidden)
+	ErrDeletionScheduled        = fmt.Errorf("%w: account deletion already scheduled", ErrValidation)
+	ErrDeletionNotScheduled     = fmt.Errorf("%w: account deletion not scheduled", ErrValidation)
+)
+
+var PasswordResetTokenLifeTime = time.Hour
+
+// AccountDeletionGracePeriod is how long users have to change their mind
+// after asking for their account to be deleted.
+var AccountDeletionGracePeriod = time.Hour * 24 * 30
+
+// PasswordResetURL is where reset links in emails point to; the token is
+// appended as the token query parameter.
+var PasswordResetURL = "http://localhost:8080/reset-password"
+
+// EmailVerificationURL is where verification links in emails point to; the
+// token is appended as the token query parameter.
+var EmailVerificationURL = "http://localhost:8080/verify-email"
+
+// EmailChangeURL is where email change confirmation links point to; the token
+// is appended as the token query parameter.
+var EmailChangeURL = "http://localhost:8080/confirm-email-change"
+
+type AccountService interface {
+	RequestPasswordReset(ctx context.Context, email string) error
+	ResetPassword(ctx context.Context, input ResetPasswordInput) error
+	VerifyEmail(ctx context.Context, token string) (UserModel, error)
+	ResendVerification(ctx context.Context) error
+	// DeleteScheduledAccounts anonymizes the accounts whose grace period
+	// is over and returns how many were, along with the errors of the
+	// others.
+	DeleteScheduledAccounts(ctx context.Context) (int, error)
+}
+
+type EmailVerifier interface {
+	SendVerificationEmail(ctx context.Context, user UserModel) error
+	// SendEmailChangeConfirmation mails newEmail a link confirming the switch.
+	SendEmailChangeConfirmation(ctx context.Context, user UserModel, newEmail string) error
+}
+
+type PasswordResetToken struct {
+	ID        string
+	UserID    string
+	TokenHash string
+	ExpiredAt time.Time
+	UsedAt    *time.Time
+	CreatedAt time.Time
+}
+
+type PasswordResetRepo interface {
+	Create(ctx context.Context, userID string, tokenHash string) (PasswordResetToken, error)
+	// Reset marks the unexpired, unused token with tokenHash as used, along
+	// with every other reset token of its user, sets password as the password
+	// of that user and revokes all their sessions, in one transaction.
+	Reset(ctx context.Context, tokenHash string, password string) (PasswordResetToken, error)
+}
+
+type ResetPasswordInput struct {
+	Token           string
+	Password        string
+	ConfirmPassword string
+}
+
+func (in *ResetPasswordInput) Sanitize() {
+	in.Token = strings.TrimSpace(in.Token)
+	in.Password = strings.TrimSpace(in.Password)
+	in.ConfirmPassword = strings.TrimSpace(in.ConfirmPassword)
+}
+
+func (in ResetPasswordInput) Validate() error {
+	if in.Token == "" {
+		return ErrInvalidResetToken
+	}
+
+	if len(in.Password) < PasswordMinLength {
+		return fmt.Errorf("%w: password not long enough, (%d) characters at least", ErrValidation, PasswordMinLength)
+	}
+
+	if in.Password != in.ConfirmPassword {
+		return fmt.Errorf("%w: confirm password must match the password", ErrValidation)
+	}
+
+	return nil
+}
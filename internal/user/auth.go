@@ -7,17 +7,22 @@ import (
 	"net/http"
 	"net/mail"
 	"strings"
+	"time"
 )
 
 var (
-	ErrInvalidCredentials = errors.New("invalid email or password")
-	ErrValidation         = errors.New("validation error")
-	ErrNotFound           = errors.New("not found")
-	ErrInvalidToken       = errors.New("invalid token")
-	ErrNoUserIDInContext  = errors.New("no user id in context")
-	ErrGenerateToken      = errors.New("error generating token")
-	ErrUnauthenticated    = errors.New("unauthenticated")
-	ErrForbidden          = errors.New("forbidden")
+	ErrInvalidCredentials   = errors.New("invalid email or password")
+	ErrValidation           = errors.New("validation error")
+	ErrNotFound             = errors.New("not found")
+	ErrInvalidToken         = errors.New("invalid token")
+	ErrNoUserIDInContext    = errors.New("no user id in context")
+	ErrNoSessionIDInContext = errors.New("no session id in context")
+	ErrGenerateToken        = errors.New("error generating token")
+	ErrUnauthenticated      = errors.New("unauthenticated")
+	ErrForbidden            = errors.New("forbidden")
+	ErrInvalidPassword      = fmt.Errorf("%w: current password is incorrect", ErrValidation)
+	ErrSameEmail            = fmt.Errorf("%w: new email must differ from the current one", ErrValidation)
+	ErrInvalidEmailChange   = fmt.Errorf("%w: invalid or expired email change token", ErrValidation)
 )
 
 var (
@@ -27,24 +32,86 @@ var (
 
 type AuthService interface {
 	Register(ctx context.Context, input RegisterInput) (AuthResponse, error)
-	Login(ctx context.Context, input LoginInput) (AuthResponse, error)
+	Login(ctx context.Context, input LoginInput) (LoginResponse, error)
+	VerifyTwoFactor(ctx context.Context, input VerifyTwoFactorInput) (AuthResponse, error)
+	LoginWithPasskey(ctx context.Context, input PasskeyLoginInput) (AuthResponse, error)
+	// RequestLoginLink emails a single-use login link to the account with
+	// email. It succeeds for unknown emails too, so it can't be used to find
+	// accounts, and returns a *LoginLockedError while logins of email or the
+	// client IP are locked.
+	RequestLoginLink(ctx context.Context, email string) error
+	ConsumeLoginLink(ctx context.Context, token string) (AuthResponse, error)
+	LoginWithProvider(ctx context.Context, input SocialLoginInput) (LoginResponse, error)
+	RefreshToken(ctx context.Context, token string) (AuthResponse, error)
+	Sessions(ctx context.Context) ([]Session, error)
+	Logout(ctx context.Context) error
+	RevokeSession(ctx context.Context, id string) error
+	RevokeAllSessions(ctx context.Context) error
+	ChangePassword(ctx context.Context, input ChangePasswordInput) error
+	ChangeEmail(ctx context.Context, input ChangeEmailInput) error
+	ConfirmEmailChange(ctx context.Context, token string) (UserModel, error)
+	// DeleteAccount schedules the deletion of the current user's account
+	// after AccountDeletionGracePeriod and signs them out of every session,
+	// personal access token and OAuth client. Users who signed up through
+	// social login have no password they know; they set one with a password
+	// reset first.
+	DeleteAccount(ctx context.Context, password string) (UserModel, error)
+	CancelAccountDeletion(ctx context.Context) (UserModel, error)
 }
 
 type AuthTokenService interface {
-	CreateAccessToken(ctx context.Context, user UserModel) (string, error)
+	CreateAccessToken(ctx context.Context, user UserModel, sessionID string) (string, error)
 	CreateRefreshToken(ctx context.Context, user UserModel, tokenID string) (string, error)
 	ParseToken(ctx context.Context, payload string) (AuthToken, error)
 	ParseTokenFromRequest(ctx context.Context, r *http.Request) (AuthToken, error)
+	CreateEmailVerificationToken(ctx context.Context, user UserModel) (string, error)
+	ParseEmailVerificationToken(ctx context.Context, payload string) (EmailVerificationToken, error)
+	CreateEmailChangeToken(ctx context.Context, user UserModel, newEmail string) (string, error)
+	ParseEmailChangeToken(ctx context.Context, payload string) (EmailChangeToken, error)
+	CreateTwoFactorChallengeToken(ctx context.Context, user UserModel) (string, error)
+	ParseTwoFactorChallengeToken(ctx context.Context, payload string) (TwoFactorChallengeToken, error)
+	CreateOAuthAccessToken(ctx context.Context, grant OAuthGrant) (string, error)
+	ParseOAuthAccessToken(ctx context.Context, payload string) (OAuthAccessToken, error)
 }
 
 type AuthToken struct {
-	ID  string
-	Sub string
+	ID        string
+	Sub       string
+	SessionID string
+	Role      Role
+}
+
+type EmailVerificationToken struct {
+	Sub   string
+	Email string
+}
+
+type EmailChangeToken struct {
+	Sub      string
+	Email    string
+	NewEmail string
+}
+
+type Session struct {
+	ID         string
+	Name       string
+	Current    bool
+	LastUsedAt time.Time
+	ExpiredAt  time.Time
+	CreatedAt  time.Time
 }
 
 type AuthResponse struct {
-	AccessToken string
-	User        UserModel
+	AccessToken  string
+	RefreshToken string
+	User         UserModel
+}
+
+// LoginResponse holds the tokens, or only a Challenge when the user has
+// two-factor authentication enabled.
+type LoginResponse struct {
+	AuthResponse
+	Challenge *TwoFactorChallenge
 }
 
 type RegisterInput struct {
@@ -68,15 +135,27 @@ func (in RegisterInput) Validate() error {
 		return fmt.Errorf("%w: username not long enough, (%d) characters at least", ErrValidation, UsernameMinLength)
 	}
 
-	if _, err := mail.ParseAddress(in.Email); err != nil {
+	if err := validateEmail(in.Email); err != nil {
+		return err
+	}
+
+	return validatePassword(in.Password, in.ConfirmPassword)
+}
+
+func validateEmail(email string) error {
+	if _, err := mail.ParseAddress(email); err != nil {
 		return fmt.Errorf("%w: email not valid", ErrValidation)
 	}
 
-	if len(in.Password) < PasswordMinLength {
+	return nil
+}
+
+func validatePassword(password, confirmPassword string) error {
+	if len(password) < PasswordMinLength {
 		return fmt.Errorf("%w: password not long enough, (%d) characters at least", ErrValidation, PasswordMinLength)
 	}
 
-	if in.Password != in.ConfirmPassword {
+	if password != confirmPassword {
 		return fmt.Errorf("%w: confirm password must match the password", ErrValidation)
 	}
 
@@ -106,3 +185,47 @@ func (in LoginInput) Validate() error {
 
 	return nil
 }
+
+type ChangePasswordInput struct {
+	CurrentPassword string
+	Password        string
+	ConfirmPassword string
+}
+
+func (in *ChangePasswordInput) Sanitize() {
+	in.CurrentPassword = strings.TrimSpace(in.CurrentPassword)
+	in.Password = strings.TrimSpace(in.Password)
+	in.ConfirmPassword = strings.TrimSpace(in.ConfirmPassword)
+}
+
+func (in ChangePasswordInput) Validate() error {
+	if len(in.CurrentPassword) < 1 {
+		return fmt.Errorf("%w: current password required", ErrValidation)
+	}
+
+	return validatePassword(in.Password, in.ConfirmPassword)
+}
+
+type ChangeEmailInput struct {
+	Email    string
+	Password string
+}
+
+func (in *ChangeEmailInput) Sanitize() {
+	in.Email = strings.TrimSpace(in.Email)
+	in.Email = strings.ToLower(in.Email)
+
+	in.Password = strings.TrimSpace(in.Password)
+}
+
+func (in ChangeEmailInput) Validate() error {
+	if err := validateEmail(in.Email); err != nil {
+		return err
+	}
+
+	if len(in.Password) < 1 {
+		return fmt.Errorf("%w: password required", ErrValidation)
+	}
+
+	return nil
+}
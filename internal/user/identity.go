@@ -0,0 +1,83 @@
+package user
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/RianNegreiros/go-graphql-api/internal/oidc"
+)
+
+var (
+	ErrUnknownProvider         = fmt.Errorf("%w: unknown identity provider", ErrValidation)
+	ErrInvalidSocialLogin      = fmt.Errorf("%w: invalid or expired login attempt", ErrValidation)
+	ErrProviderEmailUnverified = fmt.Errorf("%w: the identity provider hasn't verified your email", ErrForbidden)
+	ErrIdentityEmailTaken      = fmt.Errorf("%w: an account already uses this email, log in with your password and verify your email first", ErrForbidden)
+)
+
+var SocialLoginStateLifeTime = time.Minute * 10
+
+// SocialLoginURL is the client app page users land on after logging in with
+// an identity provider. The tokens, or the error, are passed in the URL
+// fragment so they don't reach any server logs.
+var SocialLoginURL = "http://localhost:8080/social-login"
+
+// IdentityProvider is an OpenID Connect provider users can log in with.
+type IdentityProvider interface {
+	AuthCodeURL(state, nonce, codeVerifier string) string
+	Exchange(ctx context.Context, code, codeVerifier, nonce string) (oidc.Claims, error)
+}
+
+type SocialLoginService interface {
+	// AuthURL starts a login with provider and returns where to send the
+	// user. The provider sends them back with the input of
+	// AuthService.LoginWithProvider.
+	AuthURL(ctx context.Context, provider string) (string, error)
+}
+
+// IdentityVerifier is what AuthService needs to log users in with an
+// identity provider.
+type IdentityVerifier interface {
+	// VerifyLogin finishes a login started with SocialLoginService.AuthURL and
+	// returns the ID of the user it signs in. Unknown identities are linked
+	// to the account with the same verified email, or get a new account.
+	VerifyLogin(ctx context.Context, input SocialLoginInput) (string, error)
+}
+
+type IdentityRepo interface {
+	Create(ctx context.Context, i Identity) (Identity, error)
+	GetByProviderSubject(ctx context.Context, provider string, subject string) (Identity, error)
+	CreateState(ctx context.Context, s SocialLoginState) error
+	// ConsumeState deletes the unexpired state issued for provider and
+	// returns it, so it can only be used once. It returns ErrNotFound if
+	// there is none.
+	ConsumeState(ctx context.Context, state string, provider string) (SocialLoginState, error)
+}
+
+// Identity links a user to their account at an identity provider.
+type Identity struct {
+	ID        string
+	UserID    string
+	Provider  string
+	Subject   string
+	Email     string
+	CreatedAt time.Time
+}
+
+// SocialLoginState is what we need to remember between sending the user to
+// the provider and them coming back.
+type SocialLoginState struct {
+	State        string
+	Provider     string
+	Nonce        string
+	CodeVerifier string
+	ExpiredAt    time.Time
+	CreatedAt    time.Time
+}
+
+// SocialLoginInput is what the provider sends back to the callback.
+type SocialLoginInput struct {
+	Provider string
+	Code     string
+	State    string
+}
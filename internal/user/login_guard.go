@@ -0,0 +1,46 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
+var ErrLoginLocked = errors.New("too many failed login attempts")
+
+// LoginLockedError is returned when an account or client must wait before
+// trying to log in again.
+type LoginLockedError struct {
+	RetryAfter time.Duration
+}
+
+func (e *LoginLockedError) Error() string {
+	return fmt.Sprintf("%s, try again in %d seconds", ErrLoginLocked, e.RetrySeconds())
+}
+
+func (e *LoginLockedError) Unwrap() error {
+	return ErrLoginLocked
+}
+
+// RetrySeconds rounds RetryAfter up to whole seconds.
+func (e *LoginLockedError) RetrySeconds() int {
+	return int(math.Ceil(e.RetryAfter.Seconds()))
+}
+
+// LoginGuard tracks failed logins per account and per client IP. The ip may be
+// empty when unknown, in which case only the account is tracked.
+type LoginGuard interface {
+	// Allow reserves an attempt for email and ip, counted as a failure until
+	// Release or Succeed takes it back, so parallel attempts can't get past
+	// the limit. It returns a *LoginLockedError when email or ip must wait
+	// before trying again.
+	Allow(ctx context.Context, email, ip string) error
+	// Release takes back the attempt reserved by Allow, keeping the earlier
+	// failures.
+	Release(ctx context.Context, email, ip string) error
+	// Succeed takes back the attempt reserved by Allow and forgets the
+	// failures of the account once the user is fully authenticated.
+	Succeed(ctx context.Context, email, ip string) error
+}
@@ -0,0 +1,34 @@
+package user
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+var (
+	ErrInvalidLoginLink   = fmt.Errorf("%w: invalid or expired login link", ErrValidation)
+	ErrLoginLinkTwoFactor = fmt.Errorf("%w: accounts with two-factor authentication must log in with their password", ErrForbidden)
+)
+
+var LoginLinkTokenLifeTime = time.Minute * 15
+
+// LoginLinkURL is where login links in emails point to; the token is appended
+// as the token query parameter.
+var LoginLinkURL = "http://localhost:8080/login-link"
+
+type LoginLinkToken struct {
+	ID        string
+	UserID    string
+	TokenHash string
+	ExpiredAt time.Time
+	UsedAt    *time.Time
+	CreatedAt time.Time
+}
+
+type LoginLinkRepo interface {
+	Create(ctx context.Context, userID string, tokenHash string) (LoginLinkToken, error)
+	// Consume marks the unexpired, unused token with tokenHash as used, along
+	// with every other login link token of its user, and returns it.
+	Consume(ctx context.Context, tokenHash string) (LoginLinkToken, error)
+}
@@ -0,0 +1,357 @@
+package user
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidOAuthClient   = fmt.Errorf("%w: invalid client", ErrValidation)
+	ErrInvalidRedirectURI   = fmt.Errorf("%w: redirect uri not registered for the client", ErrValidation)
+	ErrInvalidOAuthScope    = fmt.Errorf("%w: scope not allowed for the client", ErrValidation)
+	ErrInvalidOAuthGrant    = fmt.Errorf("%w: invalid or expired grant", ErrValidation)
+	ErrUnsupportedGrantType = fmt.Errorf("%w: unsupported grant type", ErrValidation)
+	ErrInvalidOAuthToken    = fmt.Errorf("%w: invalid oauth access token", ErrUnauthenticated)
+)
+
+var (
+	OAuthClientNameMaxLength   = 100
+	OAuthCodeLifeTime          = time.Minute * 5
+	OAuthRefreshTokenLifeTime  = time.Hour * 24 * 30
+	OAuthRefreshTokenPrefix    = "ort_"
+	OAuthClientMaxRedirectURIs = 10
+)
+
+// OAuthConsentURL is the client app page asking users whether to let a
+// third-party app act on their behalf. It receives the query of the
+// authorization request as is.
+var OAuthConsentURL = "http://localhost:8080/oauth/authorize"
+
+type OAuthService interface {
+	// CreateClient registers a third-party app owned by the current user.
+	// Confidential clients get a secret, which is only ever shown this once.
+	CreateClient(ctx context.Context, input CreateOAuthClientInput) (CreatedOAuthClient, error)
+	Clients(ctx context.Context) ([]OAuthClient, error)
+	DeleteClient(ctx context.Context, id string) error
+	// Authorization checks an authorization request before the user is
+	// asked for their consent.
+	Authorization(ctx context.Context, input OAuthAuthorizationInput) (OAuthAuthorization, error)
+	// Authorize records the current user's consent and returns the redirect
+	// URL carrying the authorization code back to the client.
+	Authorize(ctx context.Context, input OAuthAuthorizationInput) (string, error)
+	Consents(ctx context.Context) ([]OAuthConsent, error)
+	// RevokeConsent forgets the consent given to the client and revokes every
+	// token it holds for the current user.
+	RevokeConsent(ctx context.Context, clientID string) error
+	Token(ctx context.Context, input OAuthTokenInput) (OAuthTokenResponse, error)
+	// Revoke revokes the grant of an access or refresh token issued to the
+	// client. Unknown tokens are ignored, as RFC 7009 asks.
+	Revoke(ctx context.Context, client OAuthClientCredentials, token string) error
+	Introspect(ctx context.Context, client OAuthClientCredentials, token string) (OAuthIntrospection, error)
+	// Authenticate returns the active grant of an access token.
+	Authenticate(ctx context.Context, token string) (OAuthGrant, error)
+}
+
+type OAuthRepo interface {
+	CreateClient(ctx context.Context, c OAuthClient) (OAuthClient, error)
+	GetClientByID(ctx context.Context, id string) (OAuthClient, error)
+	GetClientsByUserID(ctx context.Context, userID string) ([]OAuthClient, error)
+	// DeleteClient deletes the client of the user with id, along with its
+	// consents and tokens. It returns ErrNotFound if the user has no such
+	// client.
+	DeleteClient(ctx context.Context, id string, userID string) error
+	// SaveConsent adds the scopes to the consent the user gave the client.
+	SaveConsent(ctx context.Context, c OAuthConsent) (OAuthConsent, error)
+	GetConsent(ctx context.Context, userID string, clientID string) (OAuthConsent, error)
+	GetConsentsByUserID(ctx context.Context, userID string) ([]OAuthConsent, error)
+	// DeleteConsent deletes the consent and revokes the grants of the
+	// client for the user. It returns ErrNotFound if there is no consent.
+	DeleteConsent(ctx context.Context, userID string, clientID string) error
+	CreateCode(ctx context.Context, c OAuthAuthorizationCode) error
+	// ConsumeCode deletes the unexpired code with codeHash and returns it, so
+	// it can only be redeemed once. It returns ErrNotFound if there is none.
+	ConsumeCode(ctx context.Context, codeHash string) (OAuthAuthorizationCode, error)
+	CreateGrant(ctx context.Context, g OAuthGrant) (OAuthGrant, error)
+	// GetActiveGrant returns the unrevoked, unexpired grant with id. It
+	// returns ErrNotFound if there is none.
+	GetActiveGrant(ctx context.Context, id string) (OAuthGrant, error)
+	GetActiveGrantByRefreshTokenHash(ctx context.Context, refreshTokenHash string) (OAuthGrant, error)
+	// RotateGrant replaces the refresh token of the active grant with
+	// refreshTokenHash, extending its lifetime. It returns ErrNotFound if
+	// there is no such grant, so a refresh token only works once.
+	RotateGrant(ctx context.Context, refreshTokenHash string, newRefreshTokenHash string) (OAuthGrant, error)
+	RevokeGrant(ctx context.Context, id string) error
+}
+
+// OAuthClient is a third-party app users can authorize. Public clients, like
+// mobile or single-page apps, can't keep a secret and have none.
+type OAuthClient struct {
+	ID           string
+	UserID       string
+	Name         string
+	SecretHash   *string
+	RedirectURIs []string `db:"redirect_uris"`
+	Scopes       []Scope
+	CreatedAt    time.Time
+}
+
+func (c OAuthClient) IsConfidential() bool {
+	return c.SecretHash != nil
+}
+
+func (c OAuthClient) HasRedirectURI(uri string) bool {
+	for _, u := range c.RedirectURIs {
+		if u == uri {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (c OAuthClient) HasScope(scope Scope) bool {
+	for _, s := range c.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+
+	return false
+}
+
+type CreatedOAuthClient struct {
+	OAuthClient
+	// Secret is empty for public clients.
+	Secret string
+}
+
+type CreateOAuthClientInput struct {
+	Name         string
+	RedirectURIs []string
+	Scopes       []Scope
+	Confidential bool
+}
+
+func (in *CreateOAuthClientInput) Sanitize() {
+	in.Name = strings.TrimSpace(in.Name)
+
+	for i, u := range in.RedirectURIs {
+		in.RedirectURIs[i] = strings.TrimSpace(u)
+	}
+
+	in.Scopes = uniqueScopes(in.Scopes)
+}
+
+func (in CreateOAuthClientInput) Validate() error {
+	if in.Name == "" {
+		return fmt.Errorf("%w: name is required", ErrValidation)
+	}
+
+	if len(in.Name) > OAuthClientNameMaxLength {
+		return fmt.Errorf("%w: name too long, (%d) characters at most", ErrValidation, OAuthClientNameMaxLength)
+	}
+
+	if len(in.RedirectURIs) == 0 {
+		return fmt.Errorf("%w: at least one redirect uri is required", ErrValidation)
+	}
+
+	if len(in.RedirectURIs) > OAuthClientMaxRedirectURIs {
+		return fmt.Errorf("%w: too many redirect uris, (%d) at most", ErrValidation, OAuthClientMaxRedirectURIs)
+	}
+
+	for _, u := range in.RedirectURIs {
+		if !isValidRedirectURI(u) {
+			return fmt.Errorf("%w: invalid redirect uri %q", ErrValidation, u)
+		}
+	}
+
+	if len(in.Scopes) == 0 {
+		return fmt.Errorf("%w: at least one scope is required", ErrValidation)
+	}
+
+	for _, s := range in.Scopes {
+		if !s.IsValid() {
+			return fmt.Errorf("%w: invalid scope %q", ErrValidation, s)
+		}
+	}
+
+	return nil
+}
+
+// isValidRedirectURI only accepts absolute https URIs without a fragment,
+// or http ones on the loopback for apps in development and native apps.
+func isValidRedirectURI(uri string) bool {
+	u, err := url.Parse(uri)
+	if err != nil || u.Host == "" || u.Fragment != "" || u.User != nil {
+		return false
+	}
+
+	switch u.Scheme {
+	case "https":
+		return true
+	case "http":
+		host := u.Hostname()
+		return host == "localhost" || host == "127.0.0.1" || host == "::1"
+	default:
+		return false
+	}
+}
+
+// OAuthAuthorizationInput is the authorization request of RFC 6749, with
+// PKCE (RFC 7636) required.
+type OAuthAuthorizationInput struct {
+	ResponseType        string
+	ClientID            string
+	RedirectURI         string
+	Scope               string
+	State               string
+	CodeChallenge       string
+	CodeChallengeMethod string
+}
+
+// OAuthAuthorization is what the user is asked to consent to.
+type OAuthAuthorization struct {
+	Client      OAuthClient
+	RedirectURI string
+	Scopes      []Scope
+	// Consented is true when the user already granted every scope, so the
+	// consent page may skip asking.
+	Consented bool
+}
+
+type OAuthConsent struct {
+	UserID     string
+	ClientID   string
+	ClientName string
+	Scopes     []Scope
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+func (c OAuthConsent) Covers(scopes []Scope) bool {
+	for _, scope := range scopes {
+		found := false
+
+		for _, s := range c.Scopes {
+			if s == scope {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+type OAuthAuthorizationCode struct {
+	CodeHash    string
+	ClientID    string
+	UserID      string
+	RedirectURI string
+	// RedirectURIRequired is set when the authorization request named the
+	// redirect URI, the token request must then repeat it (RFC 6749 §4.1.3).
+	RedirectURIRequired bool
+	Scopes              []Scope
+	CodeChallenge       string
+	ExpiredAt           time.Time
+	CreatedAt           time.Time
+}
+
+// OAuthGrant is the access a user gave a client, carried by an access token
+// and renewed with its refresh token.
+type OAuthGrant struct {
+	ID               string
+	ClientID         string
+	UserID           string
+	Scopes           []Scope
+	RefreshTokenHash string
+	ExpiredAt        time.Time
+	RevokedAt        *time.Time
+	CreatedAt        time.Time
+}
+
+// OAuthClientCredentials authenticate a client at the token, revocation and
+// introspection endpoints.
+type OAuthClientCredentials struct {
+	ClientID     string
+	ClientSecret string
+}
+
+type OAuthTokenInput struct {
+	OAuthClientCredentials
+	// GrantType is "authorization_code" or "refresh_token".
+	GrantType    string
+	Code         string
+	RedirectURI  string
+	CodeVerifier string
+	RefreshToken string
+}
+
+type OAuthTokenResponse struct {
+	AccessToken  string
+	RefreshToken string
+	ExpiresIn    time.Duration
+	Scopes       []Scope
+}
+
+// OAuthIntrospection describes a token as RFC 7662 does. Only Active is set
+// for tokens which aren't.
+type OAuthIntrospection struct {
+	Active    bool
+	TokenType string
+	ClientID  string
+	UserID    string
+	Scopes    []Scope
+	ExpiredAt time.Time
+}
+
+// OAuthAccessToken is a parsed access token issued to a client.
+type OAuthAccessToken struct {
+	Sub       string
+	ClientID  string
+	GrantID   string
+	Scopes    []Scope
+	ExpiredAt time.Time
+}
+
+// ParseScopes reads the space separated scopes of an OAuth request.
+func ParseScopes(s string) []Scope {
+	var scopes []Scope
+
+	for _, f := range strings.Fields(s) {
+		scopes = append(scopes, Scope(f))
+	}
+
+	return uniqueScopes(scopes)
+}
+
+func FormatScopes(scopes []Scope) string {
+	s := make([]string, len(scopes))
+
+	for i, scope := range scopes {
+		s[i] = string(scope)
+	}
+
+	return strings.Join(s, " ")
+}
+
+func uniqueScopes(scopes []Scope) []Scope {
+	seen := make(map[Scope]bool, len(scopes))
+	unique := make([]Scope, 0, len(scopes))
+
+	for _, s := range scopes {
+		if !seen[s] {
+			seen[s] = true
+			unique = append(unique, s)
+		}
+	}
+
+	return unique
+}
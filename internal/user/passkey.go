@@ -0,0 +1,155 @@
+package user
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/RianNegreiros/go-graphql-api/internal/webauthn"
+)
+
+var (
+	ErrInvalidPasskey  = fmt.Errorf("%w: invalid or expired passkey response", ErrValidation)
+	ErrPasskeyReplayed = fmt.Errorf("%w: passkey signature counter didn't increase, the credential may have been cloned", ErrInvalidPasskey)
+	ErrPasskeyTaken    = fmt.Errorf("%w: passkey already registered", ErrValidation)
+)
+
+var (
+	PasskeyNameMaxLength     = 100
+	PasskeyChallengeLifeTime = time.Minute * 5
+)
+
+// PasskeyRelyingParty identifies the API to authenticators. Passkeys only
+// work on pages served from its Origins.
+var PasskeyRelyingParty = webauthn.RelyingParty{
+	ID:      "localhost",
+	Name:    "go-graphql-api",
+	Origins: []string{"http://localhost:8080"},
+}
+
+type PasskeyCeremony string
+
+const (
+	PasskeyRegistration PasskeyCeremony = "registration"
+	PasskeyLogin        PasskeyCeremony = "login"
+)
+
+type PasskeyService interface {
+	// BeginRegistration returns the options for navigator.credentials.create.
+	BeginRegistration(ctx context.Context) (PasskeyCreationOptions, error)
+	FinishRegistration(ctx context.Context, input FinishPasskeyRegistrationInput) (Passkey, error)
+	// BeginLogin returns the options for navigator.credentials.get. The
+	// response is traded for tokens with AuthService.LoginWithPasskey.
+	BeginLogin(ctx context.Context) (PasskeyRequestOptions, error)
+	List(ctx context.Context) ([]Passkey, error)
+	Delete(ctx context.Context, id string) error
+}
+
+// PasskeyVerifier is what AuthService needs to log users in with a passkey.
+type PasskeyVerifier interface {
+	// VerifyLogin checks the response to BeginLogin's challenge and returns
+	// the ID of the user it signs in.
+	VerifyLogin(ctx context.Context, input PasskeyLoginInput) (string, error)
+}
+
+type PasskeyRepo interface {
+	Create(ctx context.Context, p Passkey) (Passkey, error)
+	GetByCredentialID(ctx context.Context, credentialID []byte) (Passkey, error)
+	GetByUserID(ctx context.Context, userID string) ([]Passkey, error)
+	// UpdateSignCount records a use of the passkey. It returns
+	// ErrPasskeyReplayed unless signCount is greater than the stored one, or
+	// both are zero for authenticators without a counter.
+	UpdateSignCount(ctx context.Context, id string, signCount int64) error
+	// Delete deletes the passkey of the user with id. It returns ErrNotFound
+	// if the user has no such passkey.
+	Delete(ctx context.Context, id string, userID string) error
+	CreateChallenge(ctx context.Context, c PasskeyChallenge) error
+	// ConsumeChallenge deletes the unexpired challenge issued for ceremony
+	// and returns it, so it can only be answered once. It returns
+	// ErrNotFound if there is none.
+	ConsumeChallenge(ctx context.Context, challenge string, ceremony PasskeyCeremony) (PasskeyChallenge, error)
+}
+
+type Passkey struct {
+	ID           string
+	UserID       string
+	Name         string
+	CredentialID []byte
+	PublicKey    []byte
+	SignCount    int64
+	LastUsedAt   *time.Time
+	CreatedAt    time.Time
+}
+
+type PasskeyChallenge struct {
+	Challenge string
+	// UserID is only set for registrations, logins don't know the user yet.
+	UserID    *string
+	Ceremony  PasskeyCeremony
+	ExpiredAt time.Time
+	CreatedAt time.Time
+}
+
+// PasskeyCreationOptions mirror PublicKeyCredentialCreationOptions. Binary
+// values are base64url encoded.
+type PasskeyCreationOptions struct {
+	Challenge            string
+	RPID                 string
+	RPName               string
+	UserID               string
+	UserName             string
+	UserDisplayName      string
+	Algorithms           []int
+	ExcludeCredentialIDs []string
+	Timeout              time.Duration
+}
+
+// PasskeyRequestOptions mirror PublicKeyCredentialRequestOptions. No
+// credentials are listed, the authenticator offers the passkeys it holds.
+type PasskeyRequestOptions struct {
+	Challenge string
+	RPID      string
+	Timeout   time.Duration
+}
+
+// FinishPasskeyRegistrationInput holds the base64url encoded response of
+// navigator.credentials.create.
+type FinishPasskeyRegistrationInput struct {
+	Name              string
+	ClientDataJSON    string
+	AttestationObject string
+}
+
+func (in *FinishPasskeyRegistrationInput) Sanitize() {
+	in.Name = strings.TrimSpace(in.Name)
+	in.ClientDataJSON = strings.TrimSpace(in.ClientDataJSON)
+	in.AttestationObject = strings.TrimSpace(in.AttestationObject)
+}
+
+func (in FinishPasskeyRegistrationInput) Validate() error {
+	if in.Name == "" {
+		return fmt.Errorf("%w: name is required", ErrValidation)
+	}
+
+	if len(in.Name) > PasskeyNameMaxLength {
+		return fmt.Errorf("%w: name too long, (%d) characters at most", ErrValidation, PasskeyNameMaxLength)
+	}
+
+	if in.ClientDataJSON == "" || in.AttestationObject == "" {
+		return ErrInvalidPasskey
+	}
+
+	return nil
+}
+
+// PasskeyLoginInput holds the base64url encoded response of
+// navigator.credentials.get.
+type PasskeyLoginInput struct {
+	CredentialID      string
+	ClientDataJSON    string
+	AuthenticatorData string
+	Signature         string
+	// UserHandle is optional, but must match the passkey's user when set.
+	UserHandle string
+}
@@ -0,0 +1,13 @@
+package user
+
+// PasswordHasher hashes passwords into self-describing strings, so hashes of
+// older algorithms or parameters can still be checked and upgraded.
+type PasswordHasher interface {
+	Hash(password string) (string, error)
+	// Verify reports whether password matches hash. Hashes it can't parse
+	// return an error rather than false.
+	Verify(hash, password string) (bool, error)
+	// NeedsRehash reports whether hash was made with another algorithm or
+	// parameters than Hash currently uses.
+	NeedsRehash(hash string) bool
+}
@@ -0,0 +1,130 @@
+package user
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInsufficientScope  = fmt.Errorf("%w: access token lacks the required scope", ErrForbidden)
+	ErrSessionRequired    = fmt.Errorf("%w: not allowed with a scoped access token, log in instead", ErrForbidden)
+	ErrInvalidAccessToken = fmt.Errorf("%w: invalid personal access token", ErrUnauthenticated)
+)
+
+// PersonalAccessTokenPrefix starts every personal access token, telling them
+// apart from JWT access tokens in the Authorization header.
+const PersonalAccessTokenPrefix = "pat_"
+
+var PersonalAccessTokenNameMaxLength = 100
+
+// Scope limits what a personal access token or a token issued to an OAuth
+// client may do. Requests made with a session are granted every scope.
+type Scope string
+
+const (
+	ScopePostsRead  Scope = "posts:read"
+	ScopePostsWrite Scope = "posts:write"
+	// ScopeUsersRead reads the private fields of the user's own profile.
+	ScopeUsersRead  Scope = "users:read"
+	ScopeUsersWrite Scope = "users:write"
+)
+
+var Scopes = []Scope{ScopePostsRead, ScopePostsWrite, ScopeUsersRead, ScopeUsersWrite}
+
+func (s Scope) IsValid() bool {
+	for _, scope := range Scopes {
+		if s == scope {
+			return true
+		}
+	}
+
+	return false
+}
+
+type PersonalAccessTokenService interface {
+	// Create returns the new token along with its secret, which is only ever
+	// shown this once.
+	Create(ctx context.Context, input CreatePersonalAccessTokenInput) (CreatedPersonalAccessToken, error)
+	List(ctx context.Context) ([]PersonalAccessToken, error)
+	Revoke(ctx context.Context, id string) error
+	// Authenticate returns the active token, recording its use.
+	Authenticate(ctx context.Context, token string) (PersonalAccessToken, error)
+}
+
+type PersonalAccessTokenRepo interface {
+	Create(ctx context.Context, t PersonalAccessToken) (PersonalAccessToken, error)
+	GetActiveByUserID(ctx context.Context, userID string) ([]PersonalAccessToken, error)
+	// Revoke revokes the token of the user with id. It returns ErrNotFound if
+	// the user has no such active token.
+	Revoke(ctx context.Context, id string, userID string) error
+	// Use marks the active token with tokenHash as used now and returns it.
+	// It returns ErrNotFound if there is none.
+	Use(ctx context.Context, tokenHash string) (PersonalAccessToken, error)
+}
+
+type PersonalAccessToken struct {
+	ID         string
+	UserID     string
+	Name       string
+	TokenHash  string
+	Scopes     []Scope
+	LastUsedAt *time.Time
+	ExpiredAt  *time.Time
+	RevokedAt  *time.Time
+	CreatedAt  time.Time
+}
+
+func (t PersonalAccessToken) HasScope(scope Scope) bool {
+	for _, s := range t.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+
+	return false
+}
+
+type CreatedPersonalAccessToken struct {
+	PersonalAccessToken
+	Token string
+}
+
+type CreatePersonalAccessTokenInput struct {
+	Name   string
+	Scopes []Scope
+	// ExpiredAt is optional, tokens without one are valid until revoked.
+	ExpiredAt *time.Time
+}
+
+func (in *CreatePersonalAccessTokenInput) Sanitize() {
+	in.Name = strings.TrimSpace(in.Name)
+	in.Scopes = uniqueScopes(in.Scopes)
+}
+
+func (in CreatePersonalAccessTokenInput) Validate() error {
+	if in.Name == "" {
+		return fmt.Errorf("%w: name is required", ErrValidation)
+	}
+
+	if len(in.Name) > PersonalAccessTokenNameMaxLength {
+		return fmt.Errorf("%w: name too long, (%d) characters at most", ErrValidation, PersonalAccessTokenNameMaxLength)
+	}
+
+	if len(in.Scopes) == 0 {
+		return fmt.Errorf("%w: at least one scope is required", ErrValidation)
+	}
+
+	for _, s := range in.Scopes {
+		if !s.IsValid() {
+			return fmt.Errorf("%w: invalid scope %q", ErrValidation, s)
+		}
+	}
+
+	if in.ExpiredAt != nil && !in.ExpiredAt.After(time.Now()) {
+		return fmt.Errorf("%w: expiration must be in the future", ErrValidation)
+	}
+
+	return nil
+}
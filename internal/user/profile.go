@@ -0,0 +1,112 @@
+package user
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"unicode/utf8"
+)
+
+var (
+	DisplayNameMaxLength = 50
+	BioMaxLength         = 160
+	LocationMaxLength    = 30
+	WebsiteMaxLength     = 100
+	AvatarURLMaxLength   = 255
+)
+
+// UpdateProfileInput holds the profile fields to change. Nil fields are left
+// untouched and empty strings clear the field.
+type UpdateProfileInput struct {
+	DisplayName *string
+	Bio         *string
+	Location    *string
+	Website     *string
+	AvatarURL   *string
+}
+
+func (in *UpdateProfileInput) Sanitize() {
+	for _, field := range []*string{in.DisplayName, in.Bio, in.Location, in.Website, in.AvatarURL} {
+		if field != nil {
+			*field = strings.TrimSpace(*field)
+		}
+	}
+}
+
+func (in UpdateProfileInput) Validate() error {
+	if err := validateMaxLength("display name", in.DisplayName, DisplayNameMaxLength); err != nil {
+		return err
+	}
+
+	if err := validateMaxLength("bio", in.Bio, BioMaxLength); err != nil {
+		return err
+	}
+
+	if err := validateMaxLength("location", in.Location, LocationMaxLength); err != nil {
+		return err
+	}
+
+	if err := validateMaxLength("website", in.Website, WebsiteMaxLength); err != nil {
+		return err
+	}
+
+	if err := validateURL("website", in.Website); err != nil {
+		return err
+	}
+
+	if err := validateMaxLength("avatar url", in.AvatarURL, AvatarURLMaxLength); err != nil {
+		return err
+	}
+
+	if err := validateURL("avatar url", in.AvatarURL); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Apply copies the fields set in the input onto u.
+func (in UpdateProfileInput) Apply(u UserModel) UserModel {
+	if in.DisplayName != nil {
+		u.DisplayName = *in.DisplayName
+	}
+
+	if in.Bio != nil {
+		u.Bio = *in.Bio
+	}
+
+	if in.Location != nil {
+		u.Location = *in.Location
+	}
+
+	if in.Website != nil {
+		u.Website = *in.Website
+	}
+
+	if in.AvatarURL != nil {
+		u.AvatarURL = *in.AvatarURL
+	}
+
+	return u
+}
+
+func validateMaxLength(name string, field *string, max int) error {
+	if field != nil && utf8.RuneCountInString(*field) > max {
+		return fmt.Errorf("%w: %s too long, (%d) characters at max", ErrValidation, name, max)
+	}
+
+	return nil
+}
+
+func validateURL(name string, field *string) error {
+	if field == nil || *field == "" {
+		return nil
+	}
+
+	u, err := url.Parse(*field)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("%w: %s must be an http or https url", ErrValidation, name)
+	}
+
+	return nil
+}
@@ -0,0 +1,49 @@
+package user
+
+type Role string
+
+const (
+	RoleUser      Role = "user"
+	RoleModerator Role = "moderator"
+	RoleAdmin     Role = "admin"
+)
+
+var roleRanks = map[Role]int{
+	RoleUser:      1,
+	RoleModerator: 2,
+	RoleAdmin:     3,
+}
+
+func (r Role) IsValid() bool {
+	_, ok := roleRanks[r]
+	return ok
+}
+
+// Satisfies reports whether r grants at least the permissions of required.
+// Roles are ordered user < moderator < admin.
+func (r Role) Satisfies(required Role) bool {
+	return r.IsValid() && roleRanks[r] >= roleRanks[required]
+}
+
+type Permission string
+
+const (
+	PermissionDeleteAnyPost Permission = "posts:delete_any"
+	PermissionManageRoles   Permission = "roles:manage"
+)
+
+var rolePermissions = map[Role][]Permission{
+	RoleModerator: {PermissionDeleteAnyPost},
+	RoleAdmin:     {PermissionDeleteAnyPost, PermissionManageRoles},
+}
+
+// Can reports whether the role is granted the permission.
+func (r Role) Can(p Permission) bool {
+	for _, granted := range rolePermissions[r] {
+		if granted == p {
+			return true
+		}
+	}
+
+	return false
+}
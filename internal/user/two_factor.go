@@ -0,0 +1,100 @@
+package user
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+var (
+	ErrTotpAlreadyEnabled = fmt.Errorf("%w: two-factor authentication already enabled", ErrValidation)
+	ErrTotpNotEnabled     = fmt.Errorf("%w: two-factor authentication not enabled", ErrValidation)
+	ErrTotpNotSetUp       = fmt.Errorf("%w: two-factor authentication not set up, call enableTotp first", ErrValidation)
+	ErrInvalidTwoFactor   = fmt.Errorf("%w: invalid two-factor code", ErrValidation)
+	ErrInvalidChallenge   = fmt.Errorf("%w: invalid or expired two-factor challenge", ErrValidation)
+)
+
+var RecoveryCodeCount = 10
+
+// TotpIssuer names the service in authenticator apps.
+var TotpIssuer = "go-graphql-api"
+
+type TwoFactorService interface {
+	// EnableTotp generates a new secret for the current user. It is pending
+	// until ConfirmTotp proves the authenticator app was set up.
+	EnableTotp(ctx context.Context) (TotpSetup, error)
+	// ConfirmTotp enables two-factor authentication and returns the recovery
+	// codes, which are only ever shown this once.
+	ConfirmTotp(ctx context.Context, code string) ([]string, error)
+	DisableTotp(ctx context.Context, code string) error
+	RegenerateRecoveryCodes(ctx context.Context, code string) ([]string, error)
+	Status(ctx context.Context) (TwoFactorStatus, error)
+}
+
+// TwoFactorVerifier is what AuthService needs to run the second step of a
+// login.
+type TwoFactorVerifier interface {
+	IsEnabled(ctx context.Context, userID string) (bool, error)
+	// Verify accepts either a TOTP code or an unused recovery code, which is
+	// then used up.
+	Verify(ctx context.Context, userID string, code string) error
+}
+
+type TwoFactorRepo interface {
+	GetTotp(ctx context.Context, userID string) (Totp, error)
+	// SaveTotp stores a pending secret, replacing any previous pending one.
+	SaveTotp(ctx context.Context, userID string, secret string) (Totp, error)
+	// ConfirmTotp enables the pending secret, recording step as used, and
+	// replaces the recovery codes.
+	ConfirmTotp(ctx context.Context, userID string, step int64, recoveryCodeHashes []string) error
+	// UseTotpStep records step as used. It returns ErrInvalidTwoFactor if a
+	// step as recent was already used, so codes can't be replayed.
+	UseTotpStep(ctx context.Context, userID string, step int64) error
+	// DeleteTotp disables two-factor authentication and drops the recovery
+	// codes.
+	DeleteTotp(ctx context.Context, userID string) error
+	ReplaceRecoveryCodes(ctx context.Context, userID string, hashes []string) error
+	// UseRecoveryCode marks the unused recovery code with hash as used. It
+	// returns ErrNotFound if there is none.
+	UseRecoveryCode(ctx context.Context, userID string, hash string) error
+	CountRecoveryCodes(ctx context.Context, userID string) (int, error)
+}
+
+type Totp struct {
+	UserID       string
+	Secret       string
+	LastUsedStep int64
+	ConfirmedAt  *time.Time
+	CreatedAt    time.Time
+}
+
+func (t Totp) IsEnabled() bool {
+	return t.ConfirmedAt != nil
+}
+
+type TotpSetup struct {
+	Secret string
+	URI    string
+}
+
+type TwoFactorStatus struct {
+	Enabled                bool
+	RecoveryCodesRemaining int
+}
+
+// TwoFactorChallenge is returned by Login instead of tokens when the user has
+// two-factor authentication enabled. Its token is traded for tokens with
+// AuthService.VerifyTwoFactor.
+type TwoFactorChallenge struct {
+	Token     string
+	ExpiredAt time.Time
+}
+
+type TwoFactorChallengeToken struct {
+	Sub string
+}
+
+type VerifyTwoFactorInput struct {
+	Token string
+	Code  string
+}
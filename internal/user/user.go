@@ -3,16 +3,30 @@ package user
 import (
 	"context"
 	"errors"
+	"fmt"
 	"time"
+
+	"github.com/RianNegreiros/go-graphql-api/internal/pagination"
 )
 
 var (
-	ErrUsernameTaken = errors.New("username already taken")
-	ErrEmailTaken    = errors.New("email already taken")
+	ErrUsernameTaken    = errors.New("username already taken")
+	ErrEmailTaken       = errors.New("email already taken")
+	ErrCannotFollowSelf = fmt.Errorf("%w: cannot follow yourself", ErrValidation)
+	ErrCannotChangeSelf = fmt.Errorf("%w: cannot change your own role", ErrValidation)
+	ErrInvalidRole      = fmt.Errorf("%w: invalid role", ErrValidation)
 )
 
 type UserService interface {
 	GetByID(ctx context.Context, id string) (UserModel, error)
+	GetByUsername(ctx context.Context, username string) (UserModel, error)
+	UpdateProfile(ctx context.Context, input UpdateProfileInput) (UserModel, error)
+	GrantRole(ctx context.Context, userID string, role Role) (UserModel, error)
+	RevokeRole(ctx context.Context, userID string, role Role) (UserModel, error)
+	Follow(ctx context.Context, userID string) (UserModel, error)
+	Unfollow(ctx context.Context, userID string) (UserModel, error)
+	Followers(ctx context.Context, userID string, input pagination.Input) (pagination.Page[UserModel], error)
+	Following(ctx context.Context, userID string, input pagination.Input) (pagination.Page[UserModel], error)
 }
 
 type UserRepo interface {
@@ -21,13 +35,57 @@ type UserRepo interface {
 	GetByEmail(ctx context.Context, email string) (UserModel, error)
 	GetByID(ctx context.Context, id string) (UserModel, error)
 	GetByIds(ctx context.Context, ids []string) ([]UserModel, error)
+	UpdateProfile(ctx context.Context, user UserModel) (UserModel, error)
+	UpdateRole(ctx context.Context, userID string, role Role) (UserModel, error)
+	UpdatePassword(ctx context.Context, userID string, password string) error
+	MarkEmailVerified(ctx context.Context, userID string) (UserModel, error)
+	UpdateEmail(ctx context.Context, userID string, email string) (UserModel, error)
+	Follow(ctx context.Context, followerID, followeeID string) error
+	Unfollow(ctx context.Context, followerID, followeeID string) error
+	// GetFollowers loads a page of followers for each of userIDs.
+	GetFollowers(ctx context.Context, userIDs []string, args pagination.Args) (map[string]pagination.Page[UserModel], error)
+	// GetFollowing loads a page of followed users for each of userIDs.
+	GetFollowing(ctx context.Context, userIDs []string, args pagination.Args) (map[string]pagination.Page[UserModel], error)
+	CountFollowers(ctx context.Context, userIDs []string) (map[string]int, error)
+	CountFollowing(ctx context.Context, userIDs []string) (map[string]int, error)
+	GetFollowedBy(ctx context.Context, followerID string, userIDs []string) (map[string]bool, error)
+	// ScheduleDeletion marks the account for deletion at, revoking its
+	// sessions, personal access tokens and OAuth grants, or cancels its
+	// deletion when at is nil.
+	ScheduleDeletion(ctx context.Context, userID string, at *time.Time) (UserModel, error)
+	// GetDueForDeletion returns the ids of the accounts scheduled for
+	// deletion before now.
+	GetDueForDeletion(ctx context.Context, now time.Time) ([]string, error)
+	// Anonymize erases the personal data of the user, keeping a row without
+	// any so that replies from others to their posts stay in their thread.
+	Anonymize(ctx context.Context, userID string) error
 }
 
 type UserModel struct {
-	ID        string
-	Username  string
-	Email     string
-	Password  string
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	ID              string
+	Username        string
+	Email           string
+	Password        string `json:"-"`
+	DisplayName     string
+	Bio             string
+	Location        string
+	Website         string
+	AvatarURL       string
+	Role            Role
+	EmailVerifiedAt *time.Time
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+	// DeletionScheduledAt is when the account will be deleted, if the user
+	// asked for it.
+	DeletionScheduledAt *time.Time
+	// DeletedAt is set once the account was anonymized.
+	DeletedAt *time.Time
+}
+
+func (u UserModel) IsEmailVerified() bool {
+	return u.EmailVerifiedAt != nil
+}
+
+func (u UserModel) IsDeleted() bool {
+	return u.DeletedAt != nil
 }
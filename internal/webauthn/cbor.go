@@ -0,0 +1,153 @@
+package webauthn
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+)
+
+var errInvalidCBOR = errors.New("invalid CBOR")
+
+// maxCBORDepth bounds nesting, authenticators never need more than a few
+// levels.
+const maxCBORDepth = 16
+
+// decodeCBOR decodes the first CBOR item of data and returns the remaining
+// bytes. It supports the subset authenticators emit (RFC 8949 with definite
+// lengths only): integers as int64, byte strings as []byte, text strings,
+// arrays as []interface{}, maps as map[interface{}]interface{}, booleans,
+// null and floats. Tags are skipped.
+func decodeCBOR(data []byte) (interface{}, []byte, error) {
+	return decodeCBORItem(data, 0)
+}
+
+func decodeCBORItem(data []byte, depth int) (interface{}, []byte, error) {
+	if depth > maxCBORDepth || len(data) == 0 {
+		return nil, nil, errInvalidCBOR
+	}
+
+	major := data[0] >> 5
+	info := data[0] & 0x1f
+	data = data[1:]
+
+	if major == 7 {
+		return decodeCBORSimple(info, data)
+	}
+
+	n, data, err := decodeCBORArgument(info, data)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	switch major {
+	case 0:
+		if n > math.MaxInt64 {
+			return nil, nil, errInvalidCBOR
+		}
+
+		return int64(n), data, nil
+	case 1:
+		if n > math.MaxInt64 {
+			return nil, nil, errInvalidCBOR
+		}
+
+		return -1 - int64(n), data, nil
+	case 2, 3:
+		if n > uint64(len(data)) {
+			return nil, nil, errInvalidCBOR
+		}
+
+		b := make([]byte, n)
+		copy(b, data[:n])
+
+		if major == 3 {
+			return string(b), data[n:], nil
+		}
+
+		return b, data[n:], nil
+	case 4:
+		// Every item takes at least a byte.
+		if n > uint64(len(data)) {
+			return nil, nil, errInvalidCBOR
+		}
+
+		items := make([]interface{}, n)
+
+		for i := range items {
+			items[i], data, err = decodeCBORItem(data, depth+1)
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+
+		return items, data, nil
+	case 5:
+		if n > uint64(len(data))/2 {
+			return nil, nil, errInvalidCBOR
+		}
+
+		m := make(map[interface{}]interface{}, n)
+
+		for i := uint64(0); i < n; i++ {
+			var k, v interface{}
+
+			k, data, err = decodeCBORItem(data, depth+1)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			switch k.(type) {
+			case int64, string:
+			default:
+				return nil, nil, errInvalidCBOR
+			}
+
+			v, data, err = decodeCBORItem(data, depth+1)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			m[k] = v
+		}
+
+		return m, data, nil
+	default:
+		// Tags only annotate the item which follows.
+		return decodeCBORItem(data, depth+1)
+	}
+}
+
+func decodeCBORArgument(info byte, data []byte) (uint64, []byte, error) {
+	switch {
+	case info < 24:
+		return uint64(info), data, nil
+	case info == 24 && len(data) >= 1:
+		return uint64(data[0]), data[1:], nil
+	case info == 25 && len(data) >= 2:
+		return uint64(binary.BigEndian.Uint16(data)), data[2:], nil
+	case info == 26 && len(data) >= 4:
+		return uint64(binary.BigEndian.Uint32(data)), data[4:], nil
+	case info == 27 && len(data) >= 8:
+		return binary.BigEndian.Uint64(data), data[8:], nil
+	default:
+		// Reserved values and indefinite lengths.
+		return 0, nil, errInvalidCBOR
+	}
+}
+
+func decodeCBORSimple(info byte, data []byte) (interface{}, []byte, error) {
+	switch {
+	case info == 20:
+		return false, data, nil
+	case info == 21:
+		return true, data, nil
+	case info == 22 || info == 23:
+		return nil, data, nil
+	case info == 26 && len(data) >= 4:
+		return float64(math.Float32frombits(binary.BigEndian.Uint32(data))), data[4:], nil
+	case info == 27 && len(data) >= 8:
+		return math.Float64frombits(binary.BigEndian.Uint64(data)), data[8:], nil
+	default:
+		return nil, nil, errInvalidCBOR
+	}
+}
@@ -0,0 +1,131 @@
+package webauthn
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// COSE algorithm identifiers, see the IANA COSE Algorithms registry.
+const (
+	AlgES256 = -7
+	AlgEdDSA = -8
+	AlgRS256 = -257
+)
+
+// Algorithms are the algorithms of the credentials we accept, in order of
+// preference.
+var Algorithms = []int{AlgES256, AlgEdDSA, AlgRS256}
+
+var ErrUnsupportedKey = errors.New("unsupported credential public key")
+
+const (
+	coseKeyType      = 1
+	coseKeyAlgorithm = 3
+	coseKeyCurve     = -1
+	coseKeyX         = -2
+	coseKeyY         = -3
+	coseKeyRSAN      = -1
+	coseKeyRSAE      = -2
+
+	coseKeyTypeOKP = 1
+	coseKeyTypeEC2 = 2
+	coseKeyTypeRSA = 3
+
+	coseCurveP256    = 1
+	coseCurveEd25519 = 6
+)
+
+// PublicKey is a credential public key decoded from its COSE_Key encoding.
+type PublicKey struct {
+	Algorithm int
+	Key       crypto.PublicKey
+}
+
+// ParsePublicKey decodes a COSE_Key of one of the Algorithms.
+func ParsePublicKey(data []byte) (PublicKey, error) {
+	v, rest, err := decodeCBOR(data)
+	if err != nil || len(rest) != 0 {
+		return PublicKey{}, fmt.Errorf("%w: %v", ErrUnsupportedKey, errInvalidCBOR)
+	}
+
+	m, ok := v.(map[interface{}]interface{})
+	if !ok {
+		return PublicKey{}, ErrUnsupportedKey
+	}
+
+	kty, _ := m[int64(coseKeyType)].(int64)
+	alg, _ := m[int64(coseKeyAlgorithm)].(int64)
+
+	switch {
+	case kty == coseKeyTypeEC2 && alg == AlgES256:
+		crv, _ := m[int64(coseKeyCurve)].(int64)
+		x, _ := m[int64(coseKeyX)].([]byte)
+		y, _ := m[int64(coseKeyY)].([]byte)
+
+		if crv != coseCurveP256 || len(x) != 32 || len(y) != 32 {
+			return PublicKey{}, ErrUnsupportedKey
+		}
+
+		key := &ecdsa.PublicKey{
+			Curve: elliptic.P256(),
+			X:     new(big.Int).SetBytes(x),
+			Y:     new(big.Int).SetBytes(y),
+		}
+
+		if !key.Curve.IsOnCurve(key.X, key.Y) {
+			return PublicKey{}, ErrUnsupportedKey
+		}
+
+		return PublicKey{Algorithm: AlgES256, Key: key}, nil
+	case kty == coseKeyTypeOKP && alg == AlgEdDSA:
+		crv, _ := m[int64(coseKeyCurve)].(int64)
+		x, _ := m[int64(coseKeyX)].([]byte)
+
+		if crv != coseCurveEd25519 || len(x) != ed25519.PublicKeySize {
+			return PublicKey{}, ErrUnsupportedKey
+		}
+
+		return PublicKey{Algorithm: AlgEdDSA, Key: ed25519.PublicKey(x)}, nil
+	case kty == coseKeyTypeRSA && alg == AlgRS256:
+		n, _ := m[int64(coseKeyRSAN)].([]byte)
+		e, _ := m[int64(coseKeyRSAE)].([]byte)
+
+		if len(n) < 256 || len(e) == 0 || len(e) > 4 {
+			return PublicKey{}, ErrUnsupportedKey
+		}
+
+		return PublicKey{
+			Algorithm: AlgRS256,
+			Key: &rsa.PublicKey{
+				N: new(big.Int).SetBytes(n),
+				E: int(new(big.Int).SetBytes(e).Int64()),
+			},
+		}, nil
+	default:
+		return PublicKey{}, ErrUnsupportedKey
+	}
+}
+
+// Verify checks sig over data. ES256 signatures are ASN.1 encoded, as
+// WebAuthn specifies.
+func (k PublicKey) Verify(data, sig []byte) bool {
+	switch key := k.Key.(type) {
+	case *ecdsa.PublicKey:
+		digest := sha256.Sum256(data)
+		return ecdsa.VerifyASN1(key, digest[:], sig)
+	case ed25519.PublicKey:
+		return ed25519.Verify(key, data, sig)
+	case *rsa.PublicKey:
+		digest := sha256.Sum256(data)
+		return rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], sig) == nil
+	default:
+		return false
+	}
+}
@@ -0,0 +1,241 @@
+// Package webauthn verifies the responses of WebAuthn registration and
+// authentication ceremonies (https://www.w3.org/TR/webauthn-2/). Attestation
+// statements aren't verified: we request "none" attestation, so credentials
+// are trusted on first use like a password would be.
+package webauthn
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrInvalidClientData        = errors.New("invalid client data")
+	ErrInvalidAuthenticatorData = errors.New("invalid authenticator data")
+	ErrInvalidAttestation       = errors.New("invalid attestation object")
+	ErrInvalidSignature         = errors.New("invalid signature")
+	ErrUserNotVerified          = errors.New("user presence and verification required")
+)
+
+const (
+	ClientDataTypeCreate = "webauthn.create"
+	ClientDataTypeGet    = "webauthn.get"
+)
+
+const (
+	flagUserPresent            = 0x01
+	flagUserVerified           = 0x04
+	flagAttestedCredentialData = 0x40
+
+	authenticatorDataMinLength = 37
+)
+
+// RelyingParty is us, as seen by authenticators. ID is the domain
+// credentials are scoped to, and Origins the origins of the pages allowed to
+// run ceremonies.
+type RelyingParty struct {
+	ID      string
+	Name    string
+	Origins []string
+}
+
+type ClientData struct {
+	Type      string `json:"type"`
+	Challenge string `json:"challenge"`
+	Origin    string `json:"origin"`
+}
+
+type AuthenticatorData struct {
+	RPIDHash  []byte
+	Flags     byte
+	SignCount uint32
+	// CredentialID and PublicKey are only set when registering.
+	CredentialID []byte
+	PublicKey    []byte
+}
+
+func (d AuthenticatorData) UserPresent() bool {
+	return d.Flags&flagUserPresent != 0
+}
+
+func (d AuthenticatorData) UserVerified() bool {
+	return d.Flags&flagUserVerified != 0
+}
+
+// Credential is a newly registered credential.
+type Credential struct {
+	ID []byte
+	// PublicKey is the COSE_Key encoding, as passed to ParsePublicKey.
+	PublicKey []byte
+	SignCount uint32
+}
+
+// VerifyRegistration verifies the response of navigator.credentials.create
+// and returns the new credential. The caller checks ClientData.Challenge is
+// one it issued.
+func (rp RelyingParty) VerifyRegistration(clientDataJSON, attestationObject []byte) (ClientData, Credential, error) {
+	cd, err := rp.verifyClientData(clientDataJSON, ClientDataTypeCreate)
+	if err != nil {
+		return ClientData{}, Credential{}, err
+	}
+
+	v, rest, err := decodeCBOR(attestationObject)
+	if err != nil || len(rest) != 0 {
+		return ClientData{}, Credential{}, ErrInvalidAttestation
+	}
+
+	m, ok := v.(map[interface{}]interface{})
+	if !ok {
+		return ClientData{}, Credential{}, ErrInvalidAttestation
+	}
+
+	authData, ok := m["authData"].([]byte)
+	if !ok {
+		return ClientData{}, Credential{}, ErrInvalidAttestation
+	}
+
+	ad, err := rp.verifyAuthenticatorData(authData)
+	if err != nil {
+		return ClientData{}, Credential{}, err
+	}
+
+	if ad.CredentialID == nil {
+		return ClientData{}, Credential{}, fmt.Errorf("%w: no attested credential", ErrInvalidAuthenticatorData)
+	}
+
+	if _, err := ParsePublicKey(ad.PublicKey); err != nil {
+		return ClientData{}, Credential{}, err
+	}
+
+	return cd, Credential{
+		ID:        ad.CredentialID,
+		PublicKey: ad.PublicKey,
+		SignCount: ad.SignCount,
+	}, nil
+}
+
+// VerifyAssertion verifies the response of navigator.credentials.get was
+// signed by the credential with publicKey. The caller checks
+// ClientData.Challenge is one it issued, and that the sign count grew.
+func (rp RelyingParty) VerifyAssertion(publicKey, clientDataJSON, authenticatorData, signature []byte) (ClientData, AuthenticatorData, error) {
+	key, err := ParsePublicKey(publicKey)
+	if err != nil {
+		return ClientData{}, AuthenticatorData{}, err
+	}
+
+	cd, err := rp.verifyClientData(clientDataJSON, ClientDataTypeGet)
+	if err != nil {
+		return ClientData{}, AuthenticatorData{}, err
+	}
+
+	ad, err := rp.verifyAuthenticatorData(authenticatorData)
+	if err != nil {
+		return ClientData{}, AuthenticatorData{}, err
+	}
+
+	clientDataHash := sha256.Sum256(clientDataJSON)
+
+	signed := make([]byte, 0, len(authenticatorData)+len(clientDataHash))
+	signed = append(signed, authenticatorData...)
+	signed = append(signed, clientDataHash[:]...)
+
+	if !key.Verify(signed, signature) {
+		return ClientData{}, AuthenticatorData{}, ErrInvalidSignature
+	}
+
+	return cd, ad, nil
+}
+
+func (rp RelyingParty) verifyClientData(data []byte, typ string) (ClientData, error) {
+	cd := ClientData{}
+
+	if err := json.Unmarshal(data, &cd); err != nil {
+		return ClientData{}, ErrInvalidClientData
+	}
+
+	if cd.Type != typ {
+		return ClientData{}, fmt.Errorf("%w: type %q, %q expected", ErrInvalidClientData, cd.Type, typ)
+	}
+
+	if cd.Challenge == "" {
+		return ClientData{}, fmt.Errorf("%w: no challenge", ErrInvalidClientData)
+	}
+
+	for _, origin := range rp.Origins {
+		if cd.Origin == origin {
+			return cd, nil
+		}
+	}
+
+	return ClientData{}, fmt.Errorf("%w: unexpected origin %q", ErrInvalidClientData, cd.Origin)
+}
+
+// verifyAuthenticatorData parses data and checks it was made for us, with
+// the user present and verified: passkeys replace the password, so the
+// authenticator must have checked a PIN or biometric.
+func (rp RelyingParty) verifyAuthenticatorData(data []byte) (AuthenticatorData, error) {
+	ad, err := ParseAuthenticatorData(data)
+	if err != nil {
+		return AuthenticatorData{}, err
+	}
+
+	rpIDHash := sha256.Sum256([]byte(rp.ID))
+
+	if !bytes.Equal(ad.RPIDHash, rpIDHash[:]) {
+		return AuthenticatorData{}, fmt.Errorf("%w: credential scoped to another relying party", ErrInvalidAuthenticatorData)
+	}
+
+	if !ad.UserPresent() || !ad.UserVerified() {
+		return AuthenticatorData{}, ErrUserNotVerified
+	}
+
+	return ad, nil
+}
+
+// ParseAuthenticatorData decodes data without checking who it was made for.
+func ParseAuthenticatorData(data []byte) (AuthenticatorData, error) {
+	if len(data) < authenticatorDataMinLength {
+		return AuthenticatorData{}, ErrInvalidAuthenticatorData
+	}
+
+	ad := AuthenticatorData{
+		RPIDHash:  data[:32],
+		Flags:     data[32],
+		SignCount: binary.BigEndian.Uint32(data[33:37]),
+	}
+
+	if ad.Flags&flagAttestedCredentialData == 0 {
+		return ad, nil
+	}
+
+	// aaguid (16) || credentialIdLength (2) || credentialId || credentialPublicKey
+	rest := data[authenticatorDataMinLength:]
+	if len(rest) < 18 {
+		return AuthenticatorData{}, ErrInvalidAuthenticatorData
+	}
+
+	idLength := int(binary.BigEndian.Uint16(rest[16:18]))
+	rest = rest[18:]
+
+	if idLength == 0 || idLength > 1023 || len(rest) < idLength {
+		return AuthenticatorData{}, ErrInvalidAuthenticatorData
+	}
+
+	ad.CredentialID = rest[:idLength]
+	rest = rest[idLength:]
+
+	// The key is followed by the extensions, if any, so its length is only
+	// known once decoded.
+	_, after, err := decodeCBOR(rest)
+	if err != nil {
+		return AuthenticatorData{}, ErrInvalidAuthenticatorData
+	}
+
+	ad.PublicKey = rest[:len(rest)-len(after)]
+
+	return ad, nil
+}
@@ -0,0 +1,29 @@
+// Code generated by mockery v2.33.1. DO NOT EDIT.
+
+package mocks
+
+import mock "github.com/stretchr/testify/mock"
+
+// LoginResult is an autogenerated mock type for the LoginResult type
+type LoginResult struct {
+	mock.Mock
+}
+
+// IsLoginResult provides a mock function with given fields:
+func (_m *LoginResult) IsLoginResult() {
+	_m.Called()
+}
+
+// NewLoginResult creates a new instance of LoginResult. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewLoginResult(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *LoginResult {
+	mock := &LoginResult{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -14,24 +14,736 @@ type MutationResolver struct {
 	mock.Mock
 }
 
+// AuthorizeOAuthClient provides a mock function with given fields: ctx, input
+func (_m *MutationResolver) AuthorizeOAuthClient(ctx context.Context, input graph.OAuthAuthorizationInput) (string, error) {
+	ret := _m.Called(ctx, input)
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, graph.OAuthAuthorizationInput) (string, error)); ok {
+		return rf(ctx, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, graph.OAuthAuthorizationInput) string); ok {
+		r0 = rf(ctx, input)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, graph.OAuthAuthorizationInput) error); ok {
+		r1 = rf(ctx, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BeginPasskeyLogin provides a mock function with given fields: ctx
+func (_m *MutationResolver) BeginPasskeyLogin(ctx context.Context) (*graph.PasskeyRequestOptions, error) {
+	ret := _m.Called(ctx)
+
+	var r0 *graph.PasskeyRequestOptions
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*graph.PasskeyRequestOptions, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *graph.PasskeyRequestOptions); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*graph.PasskeyRequestOptions)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BeginPasskeyRegistration provides a mock function with given fields: ctx
+func (_m *MutationResolver) BeginPasskeyRegistration(ctx context.Context) (*graph.PasskeyCreationOptions, error) {
+	ret := _m.Called(ctx)
+
+	var r0 *graph.PasskeyCreationOptions
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*graph.PasskeyCreationOptions, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *graph.PasskeyCreationOptions); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*graph.PasskeyCreationOptions)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CancelAccountDeletion provides a mock function with given fields: ctx
+func (_m *MutationResolver) CancelAccountDeletion(ctx context.Context) (*graph.User, error) {
+	ret := _m.Called(ctx)
+
+	var r0 *graph.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*graph.User, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *graph.User); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*graph.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ChangeEmail provides a mock function with given fields: ctx, input
+func (_m *MutationResolver) ChangeEmail(ctx context.Context, input graph.ChangeEmailInput) (bool, error) {
+	ret := _m.Called(ctx, input)
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, graph.ChangeEmailInput) (bool, error)); ok {
+		return rf(ctx, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, graph.ChangeEmailInput) bool); ok {
+		r0 = rf(ctx, input)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, graph.ChangeEmailInput) error); ok {
+		r1 = rf(ctx, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ChangePassword provides a mock function with given fields: ctx, input
+func (_m *MutationResolver) ChangePassword(ctx context.Context, input graph.ChangePasswordInput) (bool, error) {
+	ret := _m.Called(ctx, input)
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, graph.ChangePasswordInput) (bool, error)); ok {
+		return rf(ctx, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, graph.ChangePasswordInput) bool); ok {
+		r0 = rf(ctx, input)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, graph.ChangePasswordInput) error); ok {
+		r1 = rf(ctx, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ConfirmEmailChange provides a mock function with given fields: ctx, token
+func (_m *MutationResolver) ConfirmEmailChange(ctx context.Context, token string) (*graph.User, error) {
+	ret := _m.Called(ctx, token)
+
+	var r0 *graph.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*graph.User, error)); ok {
+		return rf(ctx, token)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *graph.User); ok {
+		r0 = rf(ctx, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*graph.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ConfirmTotp provides a mock function with given fields: ctx, code
+func (_m *MutationResolver) ConfirmTotp(ctx context.Context, code string) ([]string, error) {
+	ret := _m.Called(ctx, code)
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]string, error)); ok {
+		return rf(ctx, code)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []string); ok {
+		r0 = rf(ctx, code)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, code)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ConsumeLoginLink provides a mock function with given fields: ctx, token
+func (_m *MutationResolver) ConsumeLoginLink(ctx context.Context, token string) (*graph.AuthResponse, error) {
+	ret := _m.Called(ctx, token)
+
+	var r0 *graph.AuthResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*graph.AuthResponse, error)); ok {
+		return rf(ctx, token)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *graph.AuthResponse); ok {
+		r0 = rf(ctx, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*graph.AuthResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateOAuthClient provides a mock function with given fields: ctx, input
+func (_m *MutationResolver) CreateOAuthClient(ctx context.Context, input graph.CreateOAuthClientInput) (*graph.CreatedOAuthClient, error) {
+	ret := _m.Called(ctx, input)
+
+	var r0 *graph.CreatedOAuthClient
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, graph.CreateOAuthClientInput) (*graph.CreatedOAuthClient, error)); ok {
+		return rf(ctx, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, graph.CreateOAuthClientInput) *graph.CreatedOAuthClient); ok {
+		r0 = rf(ctx, input)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*graph.CreatedOAuthClient)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, graph.CreateOAuthClientInput) error); ok {
+		r1 = rf(ctx, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreatePersonalAccessToken provides a mock function with given fields: ctx, input
+func (_m *MutationResolver) CreatePersonalAccessToken(ctx context.Context, input graph.CreatePersonalAccessTokenInput) (*graph.CreatedPersonalAccessToken, error) {
+	ret := _m.Called(ctx, input)
+
+	var r0 *graph.CreatedPersonalAccessToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, graph.CreatePersonalAccessTokenInput) (*graph.CreatedPersonalAccessToken, error)); ok {
+		return rf(ctx, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, graph.CreatePersonalAccessTokenInput) *graph.CreatedPersonalAccessToken); ok {
+		r0 = rf(ctx, input)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*graph.CreatedPersonalAccessToken)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, graph.CreatePersonalAccessTokenInput) error); ok {
+		r1 = rf(ctx, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreatePost provides a mock function with given fields: ctx, input
 func (_m *MutationResolver) CreatePost(ctx context.Context, input graph.CreatePostInput) (*graph.Post, error) {
 	ret := _m.Called(ctx, input)
 
-	var r0 *graph.Post
+	var r0 *graph.Post
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, graph.CreatePostInput) (*graph.Post, error)); ok {
+		return rf(ctx, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, graph.CreatePostInput) *graph.Post); ok {
+		r0 = rf(ctx, input)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*graph.Post)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, graph.CreatePostInput) error); ok {
+		r1 = rf(ctx, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateReply provides a mock function with given fields: ctx, parentID, input
+func (_m *MutationResolver) CreateReply(ctx context.Context, parentID string, input *graph.CreatePostInput) (*graph.Post, error) {
+	ret := _m.Called(ctx, parentID, input)
+
+	var r0 *graph.Post
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *graph.CreatePostInput) (*graph.Post, error)); ok {
+		return rf(ctx, parentID, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *graph.CreatePostInput) *graph.Post); ok {
+		r0 = rf(ctx, parentID, input)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*graph.Post)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *graph.CreatePostInput) error); ok {
+		r1 = rf(ctx, parentID, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteAccount provides a mock function with given fields: ctx, password
+func (_m *MutationResolver) DeleteAccount(ctx context.Context, password string) (*graph.User, error) {
+	ret := _m.Called(ctx, password)
+
+	var r0 *graph.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*graph.User, error)); ok {
+		return rf(ctx, password)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *graph.User); ok {
+		r0 = rf(ctx, password)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*graph.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, password)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteOAuthClient provides a mock function with given fields: ctx, id
+func (_m *MutationResolver) DeleteOAuthClient(ctx context.Context, id string) (bool, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (bool, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeletePasskey provides a mock function with given fields: ctx, id
+func (_m *MutationResolver) DeletePasskey(ctx context.Context, id string) (bool, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (bool, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeletePost provides a mock function with given fields: ctx, id
+func (_m *MutationResolver) DeletePost(ctx context.Context, id string) (bool, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (bool, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DisableTotp provides a mock function with given fields: ctx, code
+func (_m *MutationResolver) DisableTotp(ctx context.Context, code string) (bool, error) {
+	ret := _m.Called(ctx, code)
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (bool, error)); ok {
+		return rf(ctx, code)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = rf(ctx, code)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, code)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// EnableTotp provides a mock function with given fields: ctx
+func (_m *MutationResolver) EnableTotp(ctx context.Context) (*graph.TotpSetup, error) {
+	ret := _m.Called(ctx)
+
+	var r0 *graph.TotpSetup
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*graph.TotpSetup, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *graph.TotpSetup); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*graph.TotpSetup)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FinishPasskeyRegistration provides a mock function with given fields: ctx, input
+func (_m *MutationResolver) FinishPasskeyRegistration(ctx context.Context, input graph.FinishPasskeyRegistrationInput) (*graph.Passkey, error) {
+	ret := _m.Called(ctx, input)
+
+	var r0 *graph.Passkey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, graph.FinishPasskeyRegistrationInput) (*graph.Passkey, error)); ok {
+		return rf(ctx, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, graph.FinishPasskeyRegistrationInput) *graph.Passkey); ok {
+		r0 = rf(ctx, input)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*graph.Passkey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, graph.FinishPasskeyRegistrationInput) error); ok {
+		r1 = rf(ctx, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FollowUser provides a mock function with given fields: ctx, userID
+func (_m *MutationResolver) FollowUser(ctx context.Context, userID string) (*graph.User, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 *graph.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*graph.User, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *graph.User); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*graph.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GrantRole provides a mock function with given fields: ctx, userID, role
+func (_m *MutationResolver) GrantRole(ctx context.Context, userID string, role graph.Role) (*graph.User, error) {
+	ret := _m.Called(ctx, userID, role)
+
+	var r0 *graph.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, graph.Role) (*graph.User, error)); ok {
+		return rf(ctx, userID, role)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, graph.Role) *graph.User); ok {
+		r0 = rf(ctx, userID, role)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*graph.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, graph.Role) error); ok {
+		r1 = rf(ctx, userID, role)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LikePost provides a mock function with given fields: ctx, id
+func (_m *MutationResolver) LikePost(ctx context.Context, id string) (*graph.Post, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *graph.Post
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*graph.Post, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *graph.Post); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*graph.Post)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Login provides a mock function with given fields: ctx, input
+func (_m *MutationResolver) Login(ctx context.Context, input graph.LoginInput) (graph.LoginResult, error) {
+	ret := _m.Called(ctx, input)
+
+	var r0 graph.LoginResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, graph.LoginInput) (graph.LoginResult, error)); ok {
+		return rf(ctx, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, graph.LoginInput) graph.LoginResult); ok {
+		r0 = rf(ctx, input)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(graph.LoginResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, graph.LoginInput) error); ok {
+		r1 = rf(ctx, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LoginWithPasskey provides a mock function with given fields: ctx, input
+func (_m *MutationResolver) LoginWithPasskey(ctx context.Context, input graph.PasskeyLoginInput) (*graph.AuthResponse, error) {
+	ret := _m.Called(ctx, input)
+
+	var r0 *graph.AuthResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, graph.PasskeyLoginInput) (*graph.AuthResponse, error)); ok {
+		return rf(ctx, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, graph.PasskeyLoginInput) *graph.AuthResponse); ok {
+		r0 = rf(ctx, input)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*graph.AuthResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, graph.PasskeyLoginInput) error); ok {
+		r1 = rf(ctx, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Logout provides a mock function with given fields: ctx
+func (_m *MutationResolver) Logout(ctx context.Context) (bool, error) {
+	ret := _m.Called(ctx)
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (bool, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) bool); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RefreshToken provides a mock function with given fields: ctx, token
+func (_m *MutationResolver) RefreshToken(ctx context.Context, token string) (*graph.AuthResponse, error) {
+	ret := _m.Called(ctx, token)
+
+	var r0 *graph.AuthResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*graph.AuthResponse, error)); ok {
+		return rf(ctx, token)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *graph.AuthResponse); ok {
+		r0 = rf(ctx, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*graph.AuthResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RegenerateRecoveryCodes provides a mock function with given fields: ctx, code
+func (_m *MutationResolver) RegenerateRecoveryCodes(ctx context.Context, code string) ([]string, error) {
+	ret := _m.Called(ctx, code)
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]string, error)); ok {
+		return rf(ctx, code)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []string); ok {
+		r0 = rf(ctx, code)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, code)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Register provides a mock function with given fields: ctx, input
+func (_m *MutationResolver) Register(ctx context.Context, input graph.RegisterInput) (*graph.AuthResponse, error) {
+	ret := _m.Called(ctx, input)
+
+	var r0 *graph.AuthResponse
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, graph.CreatePostInput) (*graph.Post, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, graph.RegisterInput) (*graph.AuthResponse, error)); ok {
 		return rf(ctx, input)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, graph.CreatePostInput) *graph.Post); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, graph.RegisterInput) *graph.AuthResponse); ok {
 		r0 = rf(ctx, input)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*graph.Post)
+			r0 = ret.Get(0).(*graph.AuthResponse)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, graph.CreatePostInput) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, graph.RegisterInput) error); ok {
 		r1 = rf(ctx, input)
 	} else {
 		r1 = ret.Error(1)
@@ -40,24 +752,344 @@ func (_m *MutationResolver) CreatePost(ctx context.Context, input graph.CreatePo
 	return r0, r1
 }
 
-// Login provides a mock function with given fields: ctx, input
-func (_m *MutationResolver) Login(ctx context.Context, input graph.LoginInput) (*graph.AuthResponse, error) {
+// RemovePost provides a mock function with given fields: ctx, id, reason
+func (_m *MutationResolver) RemovePost(ctx context.Context, id string, reason string) (bool, error) {
+	ret := _m.Called(ctx, id, reason)
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (bool, error)); ok {
+		return rf(ctx, id, reason)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) bool); ok {
+		r0 = rf(ctx, id, reason)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, id, reason)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RequestLoginLink provides a mock function with given fields: ctx, email
+func (_m *MutationResolver) RequestLoginLink(ctx context.Context, email string) (bool, error) {
+	ret := _m.Called(ctx, email)
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (bool, error)); ok {
+		return rf(ctx, email)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = rf(ctx, email)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, email)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RequestPasswordReset provides a mock function with given fields: ctx, email
+func (_m *MutationResolver) RequestPasswordReset(ctx context.Context, email string) (bool, error) {
+	ret := _m.Called(ctx, email)
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (bool, error)); ok {
+		return rf(ctx, email)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = rf(ctx, email)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, email)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ResendVerification provides a mock function with given fields: ctx
+func (_m *MutationResolver) ResendVerification(ctx context.Context) (bool, error) {
+	ret := _m.Called(ctx)
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (bool, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) bool); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ResetPassword provides a mock function with given fields: ctx, input
+func (_m *MutationResolver) ResetPassword(ctx context.Context, input graph.ResetPasswordInput) (bool, error) {
 	ret := _m.Called(ctx, input)
 
-	var r0 *graph.AuthResponse
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, graph.ResetPasswordInput) (bool, error)); ok {
+		return rf(ctx, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, graph.ResetPasswordInput) bool); ok {
+		r0 = rf(ctx, input)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, graph.ResetPasswordInput) error); ok {
+		r1 = rf(ctx, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RevokeAllSessions provides a mock function with given fields: ctx
+func (_m *MutationResolver) RevokeAllSessions(ctx context.Context) (bool, error) {
+	ret := _m.Called(ctx)
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (bool, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) bool); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RevokeOAuthConsent provides a mock function with given fields: ctx, clientID
+func (_m *MutationResolver) RevokeOAuthConsent(ctx context.Context, clientID string) (bool, error) {
+	ret := _m.Called(ctx, clientID)
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (bool, error)); ok {
+		return rf(ctx, clientID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = rf(ctx, clientID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, clientID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RevokePersonalAccessToken provides a mock function with given fields: ctx, id
+func (_m *MutationResolver) RevokePersonalAccessToken(ctx context.Context, id string) (bool, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (bool, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RevokeRole provides a mock function with given fields: ctx, userID, role
+func (_m *MutationResolver) RevokeRole(ctx context.Context, userID string, role graph.Role) (*graph.User, error) {
+	ret := _m.Called(ctx, userID, role)
+
+	var r0 *graph.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, graph.Role) (*graph.User, error)); ok {
+		return rf(ctx, userID, role)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, graph.Role) *graph.User); ok {
+		r0 = rf(ctx, userID, role)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*graph.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, graph.Role) error); ok {
+		r1 = rf(ctx, userID, role)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RevokeSession provides a mock function with given fields: ctx, id
+func (_m *MutationResolver) RevokeSession(ctx context.Context, id string) (bool, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (bool, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UnfollowUser provides a mock function with given fields: ctx, userID
+func (_m *MutationResolver) UnfollowUser(ctx context.Context, userID string) (*graph.User, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 *graph.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*graph.User, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *graph.User); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*graph.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UnlikePost provides a mock function with given fields: ctx, id
+func (_m *MutationResolver) UnlikePost(ctx context.Context, id string) (*graph.Post, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *graph.Post
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*graph.Post, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *graph.Post); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*graph.Post)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdatePost provides a mock function with given fields: ctx, id, input
+func (_m *MutationResolver) UpdatePost(ctx context.Context, id string, input graph.UpdatePostInput) (*graph.Post, error) {
+	ret := _m.Called(ctx, id, input)
+
+	var r0 *graph.Post
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, graph.UpdatePostInput) (*graph.Post, error)); ok {
+		return rf(ctx, id, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, graph.UpdatePostInput) *graph.Post); ok {
+		r0 = rf(ctx, id, input)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*graph.Post)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, graph.UpdatePostInput) error); ok {
+		r1 = rf(ctx, id, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateProfile provides a mock function with given fields: ctx, input
+func (_m *MutationResolver) UpdateProfile(ctx context.Context, input graph.UpdateProfileInput) (*graph.User, error) {
+	ret := _m.Called(ctx, input)
+
+	var r0 *graph.User
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, graph.LoginInput) (*graph.AuthResponse, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, graph.UpdateProfileInput) (*graph.User, error)); ok {
 		return rf(ctx, input)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, graph.LoginInput) *graph.AuthResponse); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, graph.UpdateProfileInput) *graph.User); ok {
 		r0 = rf(ctx, input)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*graph.AuthResponse)
+			r0 = ret.Get(0).(*graph.User)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, graph.LoginInput) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, graph.UpdateProfileInput) error); ok {
 		r1 = rf(ctx, input)
 	} else {
 		r1 = ret.Error(1)
@@ -66,16 +1098,42 @@ func (_m *MutationResolver) Login(ctx context.Context, input graph.LoginInput) (
 	return r0, r1
 }
 
-// Register provides a mock function with given fields: ctx, input
-func (_m *MutationResolver) Register(ctx context.Context, input graph.RegisterInput) (*graph.AuthResponse, error) {
+// VerifyEmail provides a mock function with given fields: ctx, token
+func (_m *MutationResolver) VerifyEmail(ctx context.Context, token string) (*graph.User, error) {
+	ret := _m.Called(ctx, token)
+
+	var r0 *graph.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*graph.User, error)); ok {
+		return rf(ctx, token)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *graph.User); ok {
+		r0 = rf(ctx, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*graph.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// VerifyTwoFactor provides a mock function with given fields: ctx, input
+func (_m *MutationResolver) VerifyTwoFactor(ctx context.Context, input graph.VerifyTwoFactorInput) (*graph.AuthResponse, error) {
 	ret := _m.Called(ctx, input)
 
 	var r0 *graph.AuthResponse
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, graph.RegisterInput) (*graph.AuthResponse, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, graph.VerifyTwoFactorInput) (*graph.AuthResponse, error)); ok {
 		return rf(ctx, input)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, graph.RegisterInput) *graph.AuthResponse); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, graph.VerifyTwoFactorInput) *graph.AuthResponse); ok {
 		r0 = rf(ctx, input)
 	} else {
 		if ret.Get(0) != nil {
@@ -83,7 +1141,7 @@ func (_m *MutationResolver) Register(ctx context.Context, input graph.RegisterIn
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, graph.RegisterInput) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, graph.VerifyTwoFactorInput) error); ok {
 		r1 = rf(ctx, input)
 	} else {
 		r1 = ret.Error(1)
@@ -14,6 +14,132 @@ type PostResolver struct {
 	mock.Mock
 }
 
+// LikeCount provides a mock function with given fields: ctx, obj
+func (_m *PostResolver) LikeCount(ctx context.Context, obj *graph.Post) (int, error) {
+	ret := _m.Called(ctx, obj)
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *graph.Post) (int, error)); ok {
+		return rf(ctx, obj)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *graph.Post) int); ok {
+		r0 = rf(ctx, obj)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *graph.Post) error); ok {
+		r1 = rf(ctx, obj)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Parent provides a mock function with given fields: ctx, obj
+func (_m *PostResolver) Parent(ctx context.Context, obj *graph.Post) (*graph.Post, error) {
+	ret := _m.Called(ctx, obj)
+
+	var r0 *graph.Post
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *graph.Post) (*graph.Post, error)); ok {
+		return rf(ctx, obj)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *graph.Post) *graph.Post); ok {
+		r0 = rf(ctx, obj)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*graph.Post)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *graph.Post) error); ok {
+		r1 = rf(ctx, obj)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Replies provides a mock function with given fields: ctx, obj, first, after
+func (_m *PostResolver) Replies(ctx context.Context, obj *graph.Post, first *int, after *string) (*graph.PostConnection, error) {
+	ret := _m.Called(ctx, obj, first, after)
+
+	var r0 *graph.PostConnection
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *graph.Post, *int, *string) (*graph.PostConnection, error)); ok {
+		return rf(ctx, obj, first, after)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *graph.Post, *int, *string) *graph.PostConnection); ok {
+		r0 = rf(ctx, obj, first, after)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*graph.PostConnection)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *graph.Post, *int, *string) error); ok {
+		r1 = rf(ctx, obj, first, after)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReplyCount provides a mock function with given fields: ctx, obj
+func (_m *PostResolver) ReplyCount(ctx context.Context, obj *graph.Post) (int, error) {
+	ret := _m.Called(ctx, obj)
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *graph.Post) (int, error)); ok {
+		return rf(ctx, obj)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *graph.Post) int); ok {
+		r0 = rf(ctx, obj)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *graph.Post) error); ok {
+		r1 = rf(ctx, obj)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Revisions provides a mock function with given fields: ctx, obj
+func (_m *PostResolver) Revisions(ctx context.Context, obj *graph.Post) ([]*graph.PostRevision, error) {
+	ret := _m.Called(ctx, obj)
+
+	var r0 []*graph.PostRevision
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *graph.Post) ([]*graph.PostRevision, error)); ok {
+		return rf(ctx, obj)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *graph.Post) []*graph.PostRevision); ok {
+		r0 = rf(ctx, obj)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*graph.PostRevision)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *graph.Post) error); ok {
+		r1 = rf(ctx, obj)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // User provides a mock function with given fields: ctx, obj
 func (_m *PostResolver) User(ctx context.Context, obj *graph.Post) (*graph.User, error) {
 	ret := _m.Called(ctx, obj)
@@ -40,6 +166,30 @@ func (_m *PostResolver) User(ctx context.Context, obj *graph.Post) (*graph.User,
 	return r0, r1
 }
 
+// ViewerHasLiked provides a mock function with given fields: ctx, obj
+func (_m *PostResolver) ViewerHasLiked(ctx context.Context, obj *graph.Post) (bool, error) {
+	ret := _m.Called(ctx, obj)
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *graph.Post) (bool, error)); ok {
+		return rf(ctx, obj)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *graph.Post) bool); ok {
+		r0 = rf(ctx, obj)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *graph.Post) error); ok {
+		r1 = rf(ctx, obj)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewPostResolver creates a new instance of PostResolver. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewPostResolver(t interface {
@@ -14,6 +14,84 @@ type QueryResolver struct {
 	mock.Mock
 }
 
+// ExportMyData provides a mock function with given fields: ctx, format
+func (_m *QueryResolver) ExportMyData(ctx context.Context, format *graph.DataExportFormat) (*graph.DataExport, error) {
+	ret := _m.Called(ctx, format)
+
+	var r0 *graph.DataExport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *graph.DataExportFormat) (*graph.DataExport, error)); ok {
+		return rf(ctx, format)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *graph.DataExportFormat) *graph.DataExport); ok {
+		r0 = rf(ctx, format)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*graph.DataExport)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *graph.DataExportFormat) error); ok {
+		r1 = rf(ctx, format)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// HomeTimeline provides a mock function with given fields: ctx, first, after
+func (_m *QueryResolver) HomeTimeline(ctx context.Context, first *int, after *string) (*graph.PostConnection, error) {
+	ret := _m.Called(ctx, first, after)
+
+	var r0 *graph.PostConnection
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *int, *string) (*graph.PostConnection, error)); ok {
+		return rf(ctx, first, after)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *int, *string) *graph.PostConnection); ok {
+		r0 = rf(ctx, first, after)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*graph.PostConnection)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *int, *string) error); ok {
+		r1 = rf(ctx, first, after)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LikedPosts provides a mock function with given fields: ctx, userID, first, after, last, before
+func (_m *QueryResolver) LikedPosts(ctx context.Context, userID string, first *int, after *string, last *int, before *string) (*graph.PostConnection, error) {
+	ret := _m.Called(ctx, userID, first, after, last, before)
+
+	var r0 *graph.PostConnection
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *int, *string, *int, *string) (*graph.PostConnection, error)); ok {
+		return rf(ctx, userID, first, after, last, before)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *int, *string, *int, *string) *graph.PostConnection); ok {
+		r0 = rf(ctx, userID, first, after, last, before)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*graph.PostConnection)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *int, *string, *int, *string) error); ok {
+		r1 = rf(ctx, userID, first, after, last, before)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Me provides a mock function with given fields: ctx
 func (_m *QueryResolver) Me(ctx context.Context) (*graph.User, error) {
 	ret := _m.Called(ctx)
@@ -40,6 +118,162 @@ func (_m *QueryResolver) Me(ctx context.Context) (*graph.User, error) {
 	return r0, r1
 }
 
+// MySessions provides a mock function with given fields: ctx
+func (_m *QueryResolver) MySessions(ctx context.Context) ([]*graph.Session, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []*graph.Session
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*graph.Session, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []*graph.Session); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*graph.Session)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// OauthAuthorization provides a mock function with given fields: ctx, input
+func (_m *QueryResolver) OauthAuthorization(ctx context.Context, input graph.OAuthAuthorizationInput) (*graph.OAuthAuthorization, error) {
+	ret := _m.Called(ctx, input)
+
+	var r0 *graph.OAuthAuthorization
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, graph.OAuthAuthorizationInput) (*graph.OAuthAuthorization, error)); ok {
+		return rf(ctx, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, graph.OAuthAuthorizationInput) *graph.OAuthAuthorization); ok {
+		r0 = rf(ctx, input)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*graph.OAuthAuthorization)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, graph.OAuthAuthorizationInput) error); ok {
+		r1 = rf(ctx, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// OauthClients provides a mock function with given fields: ctx
+func (_m *QueryResolver) OauthClients(ctx context.Context) ([]*graph.OAuthClient, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []*graph.OAuthClient
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*graph.OAuthClient, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []*graph.OAuthClient); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*graph.OAuthClient)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// OauthConsents provides a mock function with given fields: ctx
+func (_m *QueryResolver) OauthConsents(ctx context.Context) ([]*graph.OAuthConsent, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []*graph.OAuthConsent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*graph.OAuthConsent, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []*graph.OAuthConsent); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*graph.OAuthConsent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Passkeys provides a mock function with given fields: ctx
+func (_m *QueryResolver) Passkeys(ctx context.Context) ([]*graph.Passkey, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []*graph.Passkey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*graph.Passkey, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []*graph.Passkey); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*graph.Passkey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PersonalAccessTokens provides a mock function with given fields: ctx
+func (_m *QueryResolver) PersonalAccessTokens(ctx context.Context) ([]*graph.PersonalAccessToken, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []*graph.PersonalAccessToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*graph.PersonalAccessToken, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []*graph.PersonalAccessToken); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*graph.PersonalAccessToken)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Posts provides a mock function with given fields: ctx
 func (_m *QueryResolver) Posts(ctx context.Context) ([]*graph.Post, error) {
 	ret := _m.Called(ctx)
@@ -66,6 +300,110 @@ func (_m *QueryResolver) Posts(ctx context.Context) ([]*graph.Post, error) {
 	return r0, r1
 }
 
+// PostsConnection provides a mock function with given fields: ctx, first, after, last, before
+func (_m *QueryResolver) PostsConnection(ctx context.Context, first *int, after *string, last *int, before *string) (*graph.PostConnection, error) {
+	ret := _m.Called(ctx, first, after, last, before)
+
+	var r0 *graph.PostConnection
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *int, *string, *int, *string) (*graph.PostConnection, error)); ok {
+		return rf(ctx, first, after, last, before)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *int, *string, *int, *string) *graph.PostConnection); ok {
+		r0 = rf(ctx, first, after, last, before)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*graph.PostConnection)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *int, *string, *int, *string) error); ok {
+		r1 = rf(ctx, first, after, last, before)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Thread provides a mock function with given fields: ctx, rootID, depth
+func (_m *QueryResolver) Thread(ctx context.Context, rootID string, depth *int) ([]*graph.Post, error) {
+	ret := _m.Called(ctx, rootID, depth)
+
+	var r0 []*graph.Post
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *int) ([]*graph.Post, error)); ok {
+		return rf(ctx, rootID, depth)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *int) []*graph.Post); ok {
+		r0 = rf(ctx, rootID, depth)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*graph.Post)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *int) error); ok {
+		r1 = rf(ctx, rootID, depth)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TwoFactorStatus provides a mock function with given fields: ctx
+func (_m *QueryResolver) TwoFactorStatus(ctx context.Context) (*graph.TwoFactorStatus, error) {
+	ret := _m.Called(ctx)
+
+	var r0 *graph.TwoFactorStatus
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*graph.TwoFactorStatus, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *graph.TwoFactorStatus); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*graph.TwoFactorStatus)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// User provides a mock function with given fields: ctx, id, username
+func (_m *QueryResolver) User(ctx context.Context, id *string, username *string) (*graph.User, error) {
+	ret := _m.Called(ctx, id, username)
+
+	var r0 *graph.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *string, *string) (*graph.User, error)); ok {
+		return rf(ctx, id, username)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *string, *string) *graph.User); ok {
+		r0 = rf(ctx, id, username)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*graph.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *string, *string) error); ok {
+		r1 = rf(ctx, id, username)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewQueryResolver creates a new instance of QueryResolver. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewQueryResolver(t interface {
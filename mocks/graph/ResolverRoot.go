@@ -60,6 +60,38 @@ func (_m *ResolverRoot) Query() graph.QueryResolver {
 	return r0
 }
 
+// Subscription provides a mock function with given fields:
+func (_m *ResolverRoot) Subscription() graph.SubscriptionResolver {
+	ret := _m.Called()
+
+	var r0 graph.SubscriptionResolver
+	if rf, ok := ret.Get(0).(func() graph.SubscriptionResolver); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(graph.SubscriptionResolver)
+		}
+	}
+
+	return r0
+}
+
+// User provides a mock function with given fields:
+func (_m *ResolverRoot) User() graph.UserResolver {
+	ret := _m.Called()
+
+	var r0 graph.UserResolver
+	if rf, ok := ret.Get(0).(func() graph.UserResolver); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(graph.UserResolver)
+		}
+	}
+
+	return r0
+}
+
 // NewResolverRoot creates a new instance of ResolverRoot. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewResolverRoot(t interface {
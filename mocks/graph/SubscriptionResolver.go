@@ -0,0 +1,107 @@
+// Code generated by mockery v2.33.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	graph "github.com/RianNegreiros/go-graphql-api/graph"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// SubscriptionResolver is an autogenerated mock type for the SubscriptionResolver type
+type SubscriptionResolver struct {
+	mock.Mock
+}
+
+// PostCreated provides a mock function with given fields: ctx
+func (_m *SubscriptionResolver) PostCreated(ctx context.Context) (<-chan *graph.Post, error) {
+	ret := _m.Called(ctx)
+
+	var r0 <-chan *graph.Post
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (<-chan *graph.Post, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) <-chan *graph.Post); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan *graph.Post)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PostDeleted provides a mock function with given fields: ctx
+func (_m *SubscriptionResolver) PostDeleted(ctx context.Context) (<-chan *graph.Post, error) {
+	ret := _m.Called(ctx)
+
+	var r0 <-chan *graph.Post
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (<-chan *graph.Post, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) <-chan *graph.Post); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan *graph.Post)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReplyAdded provides a mock function with given fields: ctx, parentID
+func (_m *SubscriptionResolver) ReplyAdded(ctx context.Context, parentID string) (<-chan *graph.Post, error) {
+	ret := _m.Called(ctx, parentID)
+
+	var r0 <-chan *graph.Post
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (<-chan *graph.Post, error)); ok {
+		return rf(ctx, parentID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) <-chan *graph.Post); ok {
+		r0 = rf(ctx, parentID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan *graph.Post)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, parentID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewSubscriptionResolver creates a new instance of SubscriptionResolver. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewSubscriptionResolver(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *SubscriptionResolver {
+	mock := &SubscriptionResolver{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
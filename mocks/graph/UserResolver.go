@@ -0,0 +1,101 @@
+// Code generated by mockery v2.33.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	graph "github.com/RianNegreiros/go-graphql-api/graph"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// UserResolver is an autogenerated mock type for the UserResolver type
+type UserResolver struct {
+	mock.Mock
+}
+
+// FollowerCount provides a mock function with given fields: ctx, obj
+func (_m *UserResolver) FollowerCount(ctx context.Context, obj *graph.User) (int, error) {
+	ret := _m.Called(ctx, obj)
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *graph.User) (int, error)); ok {
+		return rf(ctx, obj)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *graph.User) int); ok {
+		r0 = rf(ctx, obj)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *graph.User) error); ok {
+		r1 = rf(ctx, obj)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FollowingCount provides a mock function with given fields: ctx, obj
+func (_m *UserResolver) FollowingCount(ctx context.Context, obj *graph.User) (int, error) {
+	ret := _m.Called(ctx, obj)
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *graph.User) (int, error)); ok {
+		return rf(ctx, obj)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *graph.User) int); ok {
+		r0 = rf(ctx, obj)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *graph.User) error); ok {
+		r1 = rf(ctx, obj)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ViewerIsFollowing provides a mock function with given fields: ctx, obj
+func (_m *UserResolver) ViewerIsFollowing(ctx context.Context, obj *graph.User) (bool, error) {
+	ret := _m.Called(ctx, obj)
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *graph.User) (bool, error)); ok {
+		return rf(ctx, obj)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *graph.User) bool); ok {
+		r0 = rf(ctx, obj)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *graph.User) error); ok {
+		r1 = rf(ctx, obj)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewUserResolver creates a new instance of UserResolver. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewUserResolver(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *UserResolver {
+	mock := &UserResolver{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
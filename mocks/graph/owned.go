@@ -0,0 +1,38 @@
+// Code generated by mockery v2.33.1. DO NOT EDIT.
+
+package mocks
+
+import mock "github.com/stretchr/testify/mock"
+
+// owned is an autogenerated mock type for the owned type
+type owned struct {
+	mock.Mock
+}
+
+// OwnerID provides a mock function with given fields:
+func (_m *owned) OwnerID() string {
+	ret := _m.Called()
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// newOwned creates a new instance of owned. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func newOwned(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *owned {
+	mock := &owned{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,53 @@
+// Code generated by mockery v2.33.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	dataexport "github.com/RianNegreiros/go-graphql-api/internal/dataexport"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Service is an autogenerated mock type for the Service type
+type Service struct {
+	mock.Mock
+}
+
+// Export provides a mock function with given fields: ctx, format
+func (_m *Service) Export(ctx context.Context, format dataexport.Format) (dataexport.Archive, error) {
+	ret := _m.Called(ctx, format)
+
+	var r0 dataexport.Archive
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, dataexport.Format) (dataexport.Archive, error)); ok {
+		return rf(ctx, format)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, dataexport.Format) dataexport.Archive); ok {
+		r0 = rf(ctx, format)
+	} else {
+		r0 = ret.Get(0).(dataexport.Archive)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, dataexport.Format) error); ok {
+		r1 = rf(ctx, format)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewService creates a new instance of Service. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Service {
+	mock := &Service{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -38,6 +38,56 @@ func (_m *RefreshTokenRepo) Create(ctx context.Context, params jwt.CreateRefresh
 	return r0, r1
 }
 
+// GetActiveByFamilyID provides a mock function with given fields: ctx, familyID
+func (_m *RefreshTokenRepo) GetActiveByFamilyID(ctx context.Context, familyID string) (jwt.RefreshToken, error) {
+	ret := _m.Called(ctx, familyID)
+
+	var r0 jwt.RefreshToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (jwt.RefreshToken, error)); ok {
+		return rf(ctx, familyID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) jwt.RefreshToken); ok {
+		r0 = rf(ctx, familyID)
+	} else {
+		r0 = ret.Get(0).(jwt.RefreshToken)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, familyID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetActiveByUserID provides a mock function with given fields: ctx, userID
+func (_m *RefreshTokenRepo) GetActiveByUserID(ctx context.Context, userID string) ([]jwt.RefreshToken, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 []jwt.RefreshToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]jwt.RefreshToken, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []jwt.RefreshToken); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]jwt.RefreshToken)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetByID provides a mock function with given fields: ctx, id
 func (_m *RefreshTokenRepo) GetByID(ctx context.Context, id string) (jwt.RefreshToken, error) {
 	ret := _m.Called(ctx, id)
@@ -62,6 +112,72 @@ func (_m *RefreshTokenRepo) GetByID(ctx context.Context, id string) (jwt.Refresh
 	return r0, r1
 }
 
+// RevokeAllByUserID provides a mock function with given fields: ctx, userID
+func (_m *RefreshTokenRepo) RevokeAllByUserID(ctx context.Context, userID string) error {
+	ret := _m.Called(ctx, userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RevokeAllByUserIDExcept provides a mock function with given fields: ctx, userID, familyID
+func (_m *RefreshTokenRepo) RevokeAllByUserIDExcept(ctx context.Context, userID string, familyID string) error {
+	ret := _m.Called(ctx, userID, familyID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, userID, familyID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RevokeFamily provides a mock function with given fields: ctx, familyID
+func (_m *RefreshTokenRepo) RevokeFamily(ctx context.Context, familyID string) error {
+	ret := _m.Called(ctx, familyID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, familyID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Rotate provides a mock function with given fields: ctx, id, params
+func (_m *RefreshTokenRepo) Rotate(ctx context.Context, id string, params jwt.CreateRefreshTokenParams) (jwt.RefreshToken, error) {
+	ret := _m.Called(ctx, id, params)
+
+	var r0 jwt.RefreshToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, jwt.CreateRefreshTokenParams) (jwt.RefreshToken, error)); ok {
+		return rf(ctx, id, params)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, jwt.CreateRefreshTokenParams) jwt.RefreshToken); ok {
+		r0 = rf(ctx, id, params)
+	} else {
+		r0 = ret.Get(0).(jwt.RefreshToken)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, jwt.CreateRefreshTokenParams) error); ok {
+		r1 = rf(ctx, id, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewRefreshTokenRepo creates a new instance of RefreshTokenRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewRefreshTokenRepo(t interface {
@@ -0,0 +1,73 @@
+// Code generated by mockery v2.33.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	loginlimit "github.com/RianNegreiros/go-graphql-api/internal/loginlimit"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// Store is an autogenerated mock type for the Store type
+type Store struct {
+	mock.Mock
+}
+
+// Release provides a mock function with given fields: ctx, key
+func (_m *Store) Release(ctx context.Context, key string) error {
+	ret := _m.Called(ctx, key)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, key)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Reserve provides a mock function with given fields: ctx, key, window, check
+func (_m *Store) Reserve(ctx context.Context, key string, window time.Duration, check func(loginlimit.Attempts) error) error {
+	ret := _m.Called(ctx, key, window, check)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration, func(loginlimit.Attempts) error) error); ok {
+		r0 = rf(ctx, key, window, check)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Reset provides a mock function with given fields: ctx, key
+func (_m *Store) Reset(ctx context.Context, key string) error {
+	ret := _m.Called(ctx, key)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, key)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewStore creates a new instance of Store. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Store {
+	mock := &Store{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
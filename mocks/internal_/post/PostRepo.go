@@ -5,8 +5,10 @@ package mocks
 import (
 	context "context"
 
-	post "github.com/RianNegreiros/go-graphql-api/internal/post"
+	pagination "github.com/RianNegreiros/go-graphql-api/internal/pagination"
 	mock "github.com/stretchr/testify/mock"
+
+	post "github.com/RianNegreiros/go-graphql-api/internal/post"
 )
 
 // PostRepo is an autogenerated mock type for the PostRepo type
@@ -14,25 +16,51 @@ type PostRepo struct {
 	mock.Mock
 }
 
-// All provides a mock function with given fields: ctx
-func (_m *PostRepo) All(ctx context.Context) ([]post.Post, error) {
-	ret := _m.Called(ctx)
+// CountLikes provides a mock function with given fields: ctx, postIDs
+func (_m *PostRepo) CountLikes(ctx context.Context, postIDs []string) (map[string]int, error) {
+	ret := _m.Called(ctx, postIDs)
 
-	var r0 []post.Post
+	var r0 map[string]int
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context) ([]post.Post, error)); ok {
-		return rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, []string) (map[string]int, error)); ok {
+		return rf(ctx, postIDs)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context) []post.Post); ok {
-		r0 = rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, []string) map[string]int); ok {
+		r0 = rf(ctx, postIDs)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]post.Post)
+			r0 = ret.Get(0).(map[string]int)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, postIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CountReplies provides a mock function with given fields: ctx, parentIDs
+func (_m *PostRepo) CountReplies(ctx context.Context, parentIDs []string) (map[string]int, error) {
+	ret := _m.Called(ctx, parentIDs)
+
+	var r0 map[string]int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) (map[string]int, error)); ok {
+		return rf(ctx, parentIDs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string) map[string]int); ok {
+		r0 = rf(ctx, parentIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]int)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = rf(ctx)
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, parentIDs)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -78,6 +106,32 @@ func (_m *PostRepo) Delete(ctx context.Context, id string) error {
 	return r0
 }
 
+// GetAllByUserID provides a mock function with given fields: ctx, userID
+func (_m *PostRepo) GetAllByUserID(ctx context.Context, userID string) ([]post.Post, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 []post.Post
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]post.Post, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []post.Post); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]post.Post)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetByID provides a mock function with given fields: ctx, id
 func (_m *PostRepo) GetByID(ctx context.Context, id string) (post.Post, error) {
 	ret := _m.Called(ctx, id)
@@ -102,6 +156,274 @@ func (_m *PostRepo) GetByID(ctx context.Context, id string) (post.Post, error) {
 	return r0, r1
 }
 
+// GetByIds provides a mock function with given fields: ctx, ids
+func (_m *PostRepo) GetByIds(ctx context.Context, ids []string) ([]post.Post, error) {
+	ret := _m.Called(ctx, ids)
+
+	var r0 []post.Post
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) ([]post.Post, error)); ok {
+		return rf(ctx, ids)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string) []post.Post); ok {
+		r0 = rf(ctx, ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]post.Post)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetHomeTimeline provides a mock function with given fields: ctx, userID, args
+func (_m *PostRepo) GetHomeTimeline(ctx context.Context, userID string, args pagination.Args) (pagination.Page[post.Post], error) {
+	ret := _m.Called(ctx, userID, args)
+
+	var r0 pagination.Page[post.Post]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, pagination.Args) (pagination.Page[post.Post], error)); ok {
+		return rf(ctx, userID, args)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, pagination.Args) pagination.Page[post.Post]); ok {
+		r0 = rf(ctx, userID, args)
+	} else {
+		r0 = ret.Get(0).(pagination.Page[post.Post])
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, pagination.Args) error); ok {
+		r1 = rf(ctx, userID, args)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLikedByUser provides a mock function with given fields: ctx, userID, postIDs
+func (_m *PostRepo) GetLikedByUser(ctx context.Context, userID string, postIDs []string) (map[string]bool, error) {
+	ret := _m.Called(ctx, userID, postIDs)
+
+	var r0 map[string]bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) (map[string]bool, error)); ok {
+		return rf(ctx, userID, postIDs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) map[string]bool); ok {
+		r0 = rf(ctx, userID, postIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]bool)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, []string) error); ok {
+		r1 = rf(ctx, userID, postIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLikedPosts provides a mock function with given fields: ctx, userID, args
+func (_m *PostRepo) GetLikedPosts(ctx context.Context, userID string, args pagination.Args) (pagination.Page[post.Post], error) {
+	ret := _m.Called(ctx, userID, args)
+
+	var r0 pagination.Page[post.Post]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, pagination.Args) (pagination.Page[post.Post], error)); ok {
+		return rf(ctx, userID, args)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, pagination.Args) pagination.Page[post.Post]); ok {
+		r0 = rf(ctx, userID, args)
+	} else {
+		r0 = ret.Get(0).(pagination.Page[post.Post])
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, pagination.Args) error); ok {
+		r1 = rf(ctx, userID, args)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetReplies provides a mock function with given fields: ctx, parentIDs, args
+func (_m *PostRepo) GetReplies(ctx context.Context, parentIDs []string, args pagination.Args) (map[string]pagination.Page[post.Post], error) {
+	ret := _m.Called(ctx, parentIDs, args)
+
+	var r0 map[string]pagination.Page[post.Post]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string, pagination.Args) (map[string]pagination.Page[post.Post], error)); ok {
+		return rf(ctx, parentIDs, args)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string, pagination.Args) map[string]pagination.Page[post.Post]); ok {
+		r0 = rf(ctx, parentIDs, args)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]pagination.Page[post.Post])
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string, pagination.Args) error); ok {
+		r1 = rf(ctx, parentIDs, args)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRevisions provides a mock function with given fields: ctx, postIDs
+func (_m *PostRepo) GetRevisions(ctx context.Context, postIDs []string) (map[string][]post.Revision, error) {
+	ret := _m.Called(ctx, postIDs)
+
+	var r0 map[string][]post.Revision
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) (map[string][]post.Revision, error)); ok {
+		return rf(ctx, postIDs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string) map[string][]post.Revision); ok {
+		r0 = rf(ctx, postIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string][]post.Revision)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, postIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetThread provides a mock function with given fields: ctx, rootID, depth
+func (_m *PostRepo) GetThread(ctx context.Context, rootID string, depth int) ([]post.Post, error) {
+	ret := _m.Called(ctx, rootID, depth)
+
+	var r0 []post.Post
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) ([]post.Post, error)); ok {
+		return rf(ctx, rootID, depth)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) []post.Post); ok {
+		r0 = rf(ctx, rootID, depth)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]post.Post)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = rf(ctx, rootID, depth)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Like provides a mock function with given fields: ctx, userID, postID
+func (_m *PostRepo) Like(ctx context.Context, userID string, postID string) error {
+	ret := _m.Called(ctx, userID, postID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, userID, postID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Paginate provides a mock function with given fields: ctx, args
+func (_m *PostRepo) Paginate(ctx context.Context, args pagination.Args) (pagination.Page[post.Post], error) {
+	ret := _m.Called(ctx, args)
+
+	var r0 pagination.Page[post.Post]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, pagination.Args) (pagination.Page[post.Post], error)); ok {
+		return rf(ctx, args)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, pagination.Args) pagination.Page[post.Post]); ok {
+		r0 = rf(ctx, args)
+	} else {
+		r0 = ret.Get(0).(pagination.Page[post.Post])
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, pagination.Args) error); ok {
+		r1 = rf(ctx, args)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Remove provides a mock function with given fields: ctx, removal
+func (_m *PostRepo) Remove(ctx context.Context, removal post.Removal) error {
+	ret := _m.Called(ctx, removal)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, post.Removal) error); ok {
+		r0 = rf(ctx, removal)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Unlike provides a mock function with given fields: ctx, userID, postID
+func (_m *PostRepo) Unlike(ctx context.Context, userID string, postID string) error {
+	ret := _m.Called(ctx, userID, postID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, userID, postID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Update provides a mock function with given fields: ctx, Post
+func (_m *PostRepo) Update(ctx context.Context, Post post.Post) (post.Post, error) {
+	ret := _m.Called(ctx, Post)
+
+	var r0 post.Post
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, post.Post) (post.Post, error)); ok {
+		return rf(ctx, Post)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, post.Post) post.Post); ok {
+		r0 = rf(ctx, Post)
+	} else {
+		r0 = ret.Get(0).(post.Post)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, post.Post) error); ok {
+		r1 = rf(ctx, Post)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewPostRepo creates a new instance of PostRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewPostRepo(t interface {
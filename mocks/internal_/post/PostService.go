@@ -5,8 +5,10 @@ package mocks
 import (
 	context "context"
 
-	post "github.com/RianNegreiros/go-graphql-api/internal/post"
+	pagination "github.com/RianNegreiros/go-graphql-api/internal/pagination"
 	mock "github.com/stretchr/testify/mock"
+
+	post "github.com/RianNegreiros/go-graphql-api/internal/post"
 )
 
 // PostService is an autogenerated mock type for the PostService type
@@ -14,32 +16,6 @@ type PostService struct {
 	mock.Mock
 }
 
-// All provides a mock function with given fields: ctx
-func (_m *PostService) All(ctx context.Context) ([]post.Post, error) {
-	ret := _m.Called(ctx)
-
-	var r0 []post.Post
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context) ([]post.Post, error)); ok {
-		return rf(ctx)
-	}
-	if rf, ok := ret.Get(0).(func(context.Context) []post.Post); ok {
-		r0 = rf(ctx)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]post.Post)
-		}
-	}
-
-	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = rf(ctx)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
 // Create provides a mock function with given fields: ctx, input
 func (_m *PostService) Create(ctx context.Context, input post.CreatePostInput) (post.Post, error) {
 	ret := _m.Called(ctx, input)
@@ -126,6 +102,268 @@ func (_m *PostService) GetByID(ctx context.Context, id string) (post.Post, error
 	return r0, r1
 }
 
+// HomeTimeline provides a mock function with given fields: ctx, input
+func (_m *PostService) HomeTimeline(ctx context.Context, input pagination.Input) (pagination.Page[post.Post], error) {
+	ret := _m.Called(ctx, input)
+
+	var r0 pagination.Page[post.Post]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, pagination.Input) (pagination.Page[post.Post], error)); ok {
+		return rf(ctx, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, pagination.Input) pagination.Page[post.Post]); ok {
+		r0 = rf(ctx, input)
+	} else {
+		r0 = ret.Get(0).(pagination.Page[post.Post])
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, pagination.Input) error); ok {
+		r1 = rf(ctx, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Like provides a mock function with given fields: ctx, id
+func (_m *PostService) Like(ctx context.Context, id string) (post.Post, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 post.Post
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (post.Post, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) post.Post); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(post.Post)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LikedPosts provides a mock function with given fields: ctx, userID, input
+func (_m *PostService) LikedPosts(ctx context.Context, userID string, input pagination.Input) (pagination.Page[post.Post], error) {
+	ret := _m.Called(ctx, userID, input)
+
+	var r0 pagination.Page[post.Post]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, pagination.Input) (pagination.Page[post.Post], error)); ok {
+		return rf(ctx, userID, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, pagination.Input) pagination.Page[post.Post]); ok {
+		r0 = rf(ctx, userID, input)
+	} else {
+		r0 = ret.Get(0).(pagination.Page[post.Post])
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, pagination.Input) error); ok {
+		r1 = rf(ctx, userID, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Paginate provides a mock function with given fields: ctx, input
+func (_m *PostService) Paginate(ctx context.Context, input pagination.Input) (pagination.Page[post.Post], error) {
+	ret := _m.Called(ctx, input)
+
+	var r0 pagination.Page[post.Post]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, pagination.Input) (pagination.Page[post.Post], error)); ok {
+		return rf(ctx, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, pagination.Input) pagination.Page[post.Post]); ok {
+		r0 = rf(ctx, input)
+	} else {
+		r0 = ret.Get(0).(pagination.Page[post.Post])
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, pagination.Input) error); ok {
+		r1 = rf(ctx, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PostCreated provides a mock function with given fields: ctx
+func (_m *PostService) PostCreated(ctx context.Context) (<-chan post.Post, error) {
+	ret := _m.Called(ctx)
+
+	var r0 <-chan post.Post
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (<-chan post.Post, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) <-chan post.Post); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan post.Post)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PostDeleted provides a mock function with given fields: ctx
+func (_m *PostService) PostDeleted(ctx context.Context) (<-chan post.Post, error) {
+	ret := _m.Called(ctx)
+
+	var r0 <-chan post.Post
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (<-chan post.Post, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) <-chan post.Post); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan post.Post)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Remove provides a mock function with given fields: ctx, id, input
+func (_m *PostService) Remove(ctx context.Context, id string, input post.RemovePostInput) error {
+	ret := _m.Called(ctx, id, input)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, post.RemovePostInput) error); ok {
+		r0 = rf(ctx, id, input)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ReplyAdded provides a mock function with given fields: ctx, parentID
+func (_m *PostService) ReplyAdded(ctx context.Context, parentID string) (<-chan post.Post, error) {
+	ret := _m.Called(ctx, parentID)
+
+	var r0 <-chan post.Post
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (<-chan post.Post, error)); ok {
+		return rf(ctx, parentID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) <-chan post.Post); ok {
+		r0 = rf(ctx, parentID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan post.Post)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, parentID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Thread provides a mock function with given fields: ctx, rootID, depth
+func (_m *PostService) Thread(ctx context.Context, rootID string, depth int) ([]post.Post, error) {
+	ret := _m.Called(ctx, rootID, depth)
+
+	var r0 []post.Post
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) ([]post.Post, error)); ok {
+		return rf(ctx, rootID, depth)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) []post.Post); ok {
+		r0 = rf(ctx, rootID, depth)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]post.Post)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = rf(ctx, rootID, depth)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Unlike provides a mock function with given fields: ctx, id
+func (_m *PostService) Unlike(ctx context.Context, id string) (post.Post, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 post.Post
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (post.Post, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) post.Post); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(post.Post)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Update provides a mock function with given fields: ctx, id, input
+func (_m *PostService) Update(ctx context.Context, id string, input post.UpdatePostInput) (post.Post, error) {
+	ret := _m.Called(ctx, id, input)
+
+	var r0 post.Post
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, post.UpdatePostInput) (post.Post, error)); ok {
+		return rf(ctx, id, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, post.UpdatePostInput) post.Post); ok {
+		r0 = rf(ctx, id, input)
+	} else {
+		r0 = ret.Get(0).(post.Post)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, post.UpdatePostInput) error); ok {
+		r1 = rf(ctx, id, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewPostService creates a new instance of PostService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewPostService(t interface {
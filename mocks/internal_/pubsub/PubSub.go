@@ -0,0 +1,68 @@
+// Code generated by mockery v2.33.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// PubSub is an autogenerated mock type for the PubSub type
+type PubSub struct {
+	mock.Mock
+}
+
+// Publish provides a mock function with given fields: ctx, topic, payload
+func (_m *PubSub) Publish(ctx context.Context, topic string, payload []byte) error {
+	ret := _m.Called(ctx, topic, payload)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []byte) error); ok {
+		r0 = rf(ctx, topic, payload)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Subscribe provides a mock function with given fields: ctx, topic
+func (_m *PubSub) Subscribe(ctx context.Context, topic string) (<-chan []byte, error) {
+	ret := _m.Called(ctx, topic)
+
+	var r0 <-chan []byte
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (<-chan []byte, error)); ok {
+		return rf(ctx, topic)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) <-chan []byte); ok {
+		r0 = rf(ctx, topic)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan []byte)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, topic)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewPubSub creates a new instance of PubSub. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewPubSub(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *PubSub {
+	mock := &PubSub{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,119 @@
+// Code generated by mockery v2.33.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	user "github.com/RianNegreiros/go-graphql-api/internal/user"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// AccountService is an autogenerated mock type for the AccountService type
+type AccountService struct {
+	mock.Mock
+}
+
+// DeleteScheduledAccounts provides a mock function with given fields: ctx
+func (_m *AccountService) DeleteScheduledAccounts(ctx context.Context) (int, error) {
+	ret := _m.Called(ctx)
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (int, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) int); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RequestPasswordReset provides a mock function with given fields: ctx, email
+func (_m *AccountService) RequestPasswordReset(ctx context.Context, email string) error {
+	ret := _m.Called(ctx, email)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, email)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ResendVerification provides a mock function with given fields: ctx
+func (_m *AccountService) ResendVerification(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ResetPassword provides a mock function with given fields: ctx, input
+func (_m *AccountService) ResetPassword(ctx context.Context, input user.ResetPasswordInput) error {
+	ret := _m.Called(ctx, input)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, user.ResetPasswordInput) error); ok {
+		r0 = rf(ctx, input)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// VerifyEmail provides a mock function with given fields: ctx, token
+func (_m *AccountService) VerifyEmail(ctx context.Context, token string) (user.UserModel, error) {
+	ret := _m.Called(ctx, token)
+
+	var r0 user.UserModel
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (user.UserModel, error)); ok {
+		return rf(ctx, token)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) user.UserModel); ok {
+		r0 = rf(ctx, token)
+	} else {
+		r0 = ret.Get(0).(user.UserModel)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewAccountService creates a new instance of AccountService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAccountService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AccountService {
+	mock := &AccountService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -14,22 +14,170 @@ type AuthService struct {
 	mock.Mock
 }
 
+// CancelAccountDeletion provides a mock function with given fields: ctx
+func (_m *AuthService) CancelAccountDeletion(ctx context.Context) (user.UserModel, error) {
+	ret := _m.Called(ctx)
+
+	var r0 user.UserModel
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (user.UserModel, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) user.UserModel); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(user.UserModel)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ChangeEmail provides a mock function with given fields: ctx, input
+func (_m *AuthService) ChangeEmail(ctx context.Context, input user.ChangeEmailInput) error {
+	ret := _m.Called(ctx, input)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, user.ChangeEmailInput) error); ok {
+		r0 = rf(ctx, input)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ChangePassword provides a mock function with given fields: ctx, input
+func (_m *AuthService) ChangePassword(ctx context.Context, input user.ChangePasswordInput) error {
+	ret := _m.Called(ctx, input)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, user.ChangePasswordInput) error); ok {
+		r0 = rf(ctx, input)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ConfirmEmailChange provides a mock function with given fields: ctx, token
+func (_m *AuthService) ConfirmEmailChange(ctx context.Context, token string) (user.UserModel, error) {
+	ret := _m.Called(ctx, token)
+
+	var r0 user.UserModel
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (user.UserModel, error)); ok {
+		return rf(ctx, token)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) user.UserModel); ok {
+		r0 = rf(ctx, token)
+	} else {
+		r0 = ret.Get(0).(user.UserModel)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ConsumeLoginLink provides a mock function with given fields: ctx, token
+func (_m *AuthService) ConsumeLoginLink(ctx context.Context, token string) (user.AuthResponse, error) {
+	ret := _m.Called(ctx, token)
+
+	var r0 user.AuthResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (user.AuthResponse, error)); ok {
+		return rf(ctx, token)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) user.AuthResponse); ok {
+		r0 = rf(ctx, token)
+	} else {
+		r0 = ret.Get(0).(user.AuthResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteAccount provides a mock function with given fields: ctx, password
+func (_m *AuthService) DeleteAccount(ctx context.Context, password string) (user.UserModel, error) {
+	ret := _m.Called(ctx, password)
+
+	var r0 user.UserModel
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (user.UserModel, error)); ok {
+		return rf(ctx, password)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) user.UserModel); ok {
+		r0 = rf(ctx, password)
+	} else {
+		r0 = ret.Get(0).(user.UserModel)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, password)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Login provides a mock function with given fields: ctx, input
-func (_m *AuthService) Login(ctx context.Context, input user.LoginInput) (user.AuthResponse, error) {
+func (_m *AuthService) Login(ctx context.Context, input user.LoginInput) (user.LoginResponse, error) {
+	ret := _m.Called(ctx, input)
+
+	var r0 user.LoginResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, user.LoginInput) (user.LoginResponse, error)); ok {
+		return rf(ctx, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, user.LoginInput) user.LoginResponse); ok {
+		r0 = rf(ctx, input)
+	} else {
+		r0 = ret.Get(0).(user.LoginResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, user.LoginInput) error); ok {
+		r1 = rf(ctx, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LoginWithPasskey provides a mock function with given fields: ctx, input
+func (_m *AuthService) LoginWithPasskey(ctx context.Context, input user.PasskeyLoginInput) (user.AuthResponse, error) {
 	ret := _m.Called(ctx, input)
 
 	var r0 user.AuthResponse
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, user.LoginInput) (user.AuthResponse, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, user.PasskeyLoginInput) (user.AuthResponse, error)); ok {
 		return rf(ctx, input)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, user.LoginInput) user.AuthResponse); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, user.PasskeyLoginInput) user.AuthResponse); ok {
 		r0 = rf(ctx, input)
 	} else {
 		r0 = ret.Get(0).(user.AuthResponse)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, user.LoginInput) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, user.PasskeyLoginInput) error); ok {
 		r1 = rf(ctx, input)
 	} else {
 		r1 = ret.Error(1)
@@ -38,6 +186,68 @@ func (_m *AuthService) Login(ctx context.Context, input user.LoginInput) (user.A
 	return r0, r1
 }
 
+// LoginWithProvider provides a mock function with given fields: ctx, input
+func (_m *AuthService) LoginWithProvider(ctx context.Context, input user.SocialLoginInput) (user.LoginResponse, error) {
+	ret := _m.Called(ctx, input)
+
+	var r0 user.LoginResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, user.SocialLoginInput) (user.LoginResponse, error)); ok {
+		return rf(ctx, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, user.SocialLoginInput) user.LoginResponse); ok {
+		r0 = rf(ctx, input)
+	} else {
+		r0 = ret.Get(0).(user.LoginResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, user.SocialLoginInput) error); ok {
+		r1 = rf(ctx, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Logout provides a mock function with given fields: ctx
+func (_m *AuthService) Logout(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RefreshToken provides a mock function with given fields: ctx, token
+func (_m *AuthService) RefreshToken(ctx context.Context, token string) (user.AuthResponse, error) {
+	ret := _m.Called(ctx, token)
+
+	var r0 user.AuthResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (user.AuthResponse, error)); ok {
+		return rf(ctx, token)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) user.AuthResponse); ok {
+		r0 = rf(ctx, token)
+	} else {
+		r0 = ret.Get(0).(user.AuthResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Register provides a mock function with given fields: ctx, input
 func (_m *AuthService) Register(ctx context.Context, input user.RegisterInput) (user.AuthResponse, error) {
 	ret := _m.Called(ctx, input)
@@ -62,6 +272,98 @@ func (_m *AuthService) Register(ctx context.Context, input user.RegisterInput) (
 	return r0, r1
 }
 
+// RequestLoginLink provides a mock function with given fields: ctx, email
+func (_m *AuthService) RequestLoginLink(ctx context.Context, email string) error {
+	ret := _m.Called(ctx, email)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, email)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RevokeAllSessions provides a mock function with given fields: ctx
+func (_m *AuthService) RevokeAllSessions(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RevokeSession provides a mock function with given fields: ctx, id
+func (_m *AuthService) RevokeSession(ctx context.Context, id string) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Sessions provides a mock function with given fields: ctx
+func (_m *AuthService) Sessions(ctx context.Context) ([]user.Session, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []user.Session
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]user.Session, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []user.Session); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]user.Session)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// VerifyTwoFactor provides a mock function with given fields: ctx, input
+func (_m *AuthService) VerifyTwoFactor(ctx context.Context, input user.VerifyTwoFactorInput) (user.AuthResponse, error) {
+	ret := _m.Called(ctx, input)
+
+	var r0 user.AuthResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, user.VerifyTwoFactorInput) (user.AuthResponse, error)); ok {
+		return rf(ctx, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, user.VerifyTwoFactorInput) user.AuthResponse); ok {
+		r0 = rf(ctx, input)
+	} else {
+		r0 = ret.Get(0).(user.AuthResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, user.VerifyTwoFactorInput) error); ok {
+		r1 = rf(ctx, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewAuthService creates a new instance of AuthService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewAuthService(t interface {
@@ -16,8 +16,56 @@ type AuthTokenService struct {
 	mock.Mock
 }
 
-// CreateAccessToken provides a mock function with given fields: ctx, _a1
-func (_m *AuthTokenService) CreateAccessToken(ctx context.Context, _a1 user.UserModel) (string, error) {
+// CreateAccessToken provides a mock function with given fields: ctx, _a1, sessionID
+func (_m *AuthTokenService) CreateAccessToken(ctx context.Context, _a1 user.UserModel, sessionID string) (string, error) {
+	ret := _m.Called(ctx, _a1, sessionID)
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, user.UserModel, string) (string, error)); ok {
+		return rf(ctx, _a1, sessionID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, user.UserModel, string) string); ok {
+		r0 = rf(ctx, _a1, sessionID)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, user.UserModel, string) error); ok {
+		r1 = rf(ctx, _a1, sessionID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateEmailChangeToken provides a mock function with given fields: ctx, _a1, newEmail
+func (_m *AuthTokenService) CreateEmailChangeToken(ctx context.Context, _a1 user.UserModel, newEmail string) (string, error) {
+	ret := _m.Called(ctx, _a1, newEmail)
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, user.UserModel, string) (string, error)); ok {
+		return rf(ctx, _a1, newEmail)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, user.UserModel, string) string); ok {
+		r0 = rf(ctx, _a1, newEmail)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, user.UserModel, string) error); ok {
+		r1 = rf(ctx, _a1, newEmail)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateEmailVerificationToken provides a mock function with given fields: ctx, _a1
+func (_m *AuthTokenService) CreateEmailVerificationToken(ctx context.Context, _a1 user.UserModel) (string, error) {
 	ret := _m.Called(ctx, _a1)
 
 	var r0 string
@@ -40,6 +88,30 @@ func (_m *AuthTokenService) CreateAccessToken(ctx context.Context, _a1 user.User
 	return r0, r1
 }
 
+// CreateOAuthAccessToken provides a mock function with given fields: ctx, grant
+func (_m *AuthTokenService) CreateOAuthAccessToken(ctx context.Context, grant user.OAuthGrant) (string, error) {
+	ret := _m.Called(ctx, grant)
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, user.OAuthGrant) (string, error)); ok {
+		return rf(ctx, grant)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, user.OAuthGrant) string); ok {
+		r0 = rf(ctx, grant)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, user.OAuthGrant) error); ok {
+		r1 = rf(ctx, grant)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreateRefreshToken provides a mock function with given fields: ctx, _a1, tokenID
 func (_m *AuthTokenService) CreateRefreshToken(ctx context.Context, _a1 user.UserModel, tokenID string) (string, error) {
 	ret := _m.Called(ctx, _a1, tokenID)
@@ -64,6 +136,102 @@ func (_m *AuthTokenService) CreateRefreshToken(ctx context.Context, _a1 user.Use
 	return r0, r1
 }
 
+// CreateTwoFactorChallengeToken provides a mock function with given fields: ctx, _a1
+func (_m *AuthTokenService) CreateTwoFactorChallengeToken(ctx context.Context, _a1 user.UserModel) (string, error) {
+	ret := _m.Called(ctx, _a1)
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, user.UserModel) (string, error)); ok {
+		return rf(ctx, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, user.UserModel) string); ok {
+		r0 = rf(ctx, _a1)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, user.UserModel) error); ok {
+		r1 = rf(ctx, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ParseEmailChangeToken provides a mock function with given fields: ctx, payload
+func (_m *AuthTokenService) ParseEmailChangeToken(ctx context.Context, payload string) (user.EmailChangeToken, error) {
+	ret := _m.Called(ctx, payload)
+
+	var r0 user.EmailChangeToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (user.EmailChangeToken, error)); ok {
+		return rf(ctx, payload)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) user.EmailChangeToken); ok {
+		r0 = rf(ctx, payload)
+	} else {
+		r0 = ret.Get(0).(user.EmailChangeToken)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ParseEmailVerificationToken provides a mock function with given fields: ctx, payload
+func (_m *AuthTokenService) ParseEmailVerificationToken(ctx context.Context, payload string) (user.EmailVerificationToken, error) {
+	ret := _m.Called(ctx, payload)
+
+	var r0 user.EmailVerificationToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (user.EmailVerificationToken, error)); ok {
+		return rf(ctx, payload)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) user.EmailVerificationToken); ok {
+		r0 = rf(ctx, payload)
+	} else {
+		r0 = ret.Get(0).(user.EmailVerificationToken)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ParseOAuthAccessToken provides a mock function with given fields: ctx, payload
+func (_m *AuthTokenService) ParseOAuthAccessToken(ctx context.Context, payload string) (user.OAuthAccessToken, error) {
+	ret := _m.Called(ctx, payload)
+
+	var r0 user.OAuthAccessToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (user.OAuthAccessToken, error)); ok {
+		return rf(ctx, payload)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) user.OAuthAccessToken); ok {
+		r0 = rf(ctx, payload)
+	} else {
+		r0 = ret.Get(0).(user.OAuthAccessToken)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ParseToken provides a mock function with given fields: ctx, payload
 func (_m *AuthTokenService) ParseToken(ctx context.Context, payload string) (user.AuthToken, error) {
 	ret := _m.Called(ctx, payload)
@@ -112,6 +280,30 @@ func (_m *AuthTokenService) ParseTokenFromRequest(ctx context.Context, r *http.R
 	return r0, r1
 }
 
+// ParseTwoFactorChallengeToken provides a mock function with given fields: ctx, payload
+func (_m *AuthTokenService) ParseTwoFactorChallengeToken(ctx context.Context, payload string) (user.TwoFactorChallengeToken, error) {
+	ret := _m.Called(ctx, payload)
+
+	var r0 user.TwoFactorChallengeToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (user.TwoFactorChallengeToken, error)); ok {
+		return rf(ctx, payload)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) user.TwoFactorChallengeToken); ok {
+		r0 = rf(ctx, payload)
+	} else {
+		r0 = ret.Get(0).(user.TwoFactorChallengeToken)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewAuthTokenService creates a new instance of AuthTokenService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewAuthTokenService(t interface {
@@ -0,0 +1,57 @@
+// Code generated by mockery v2.33.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	user "github.com/RianNegreiros/go-graphql-api/internal/user"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// EmailVerifier is an autogenerated mock type for the EmailVerifier type
+type EmailVerifier struct {
+	mock.Mock
+}
+
+// SendEmailChangeConfirmation provides a mock function with given fields: ctx, _a1, newEmail
+func (_m *EmailVerifier) SendEmailChangeConfirmation(ctx context.Context, _a1 user.UserModel, newEmail string) error {
+	ret := _m.Called(ctx, _a1, newEmail)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, user.UserModel, string) error); ok {
+		r0 = rf(ctx, _a1, newEmail)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SendVerificationEmail provides a mock function with given fields: ctx, _a1
+func (_m *EmailVerifier) SendVerificationEmail(ctx context.Context, _a1 user.UserModel) error {
+	ret := _m.Called(ctx, _a1)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, user.UserModel) error); ok {
+		r0 = rf(ctx, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewEmailVerifier creates a new instance of EmailVerifier. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewEmailVerifier(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *EmailVerifier {
+	mock := &EmailVerifier{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,67 @@
+// Code generated by mockery v2.33.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	oidc "github.com/RianNegreiros/go-graphql-api/internal/oidc"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IdentityProvider is an autogenerated mock type for the IdentityProvider type
+type IdentityProvider struct {
+	mock.Mock
+}
+
+// AuthCodeURL provides a mock function with given fields: state, nonce, codeVerifier
+func (_m *IdentityProvider) AuthCodeURL(state string, nonce string, codeVerifier string) string {
+	ret := _m.Called(state, nonce, codeVerifier)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(string, string, string) string); ok {
+		r0 = rf(state, nonce, codeVerifier)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// Exchange provides a mock function with given fields: ctx, code, codeVerifier, nonce
+func (_m *IdentityProvider) Exchange(ctx context.Context, code string, codeVerifier string, nonce string) (oidc.Claims, error) {
+	ret := _m.Called(ctx, code, codeVerifier, nonce)
+
+	var r0 oidc.Claims
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) (oidc.Claims, error)); ok {
+		return rf(ctx, code, codeVerifier, nonce)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) oidc.Claims); ok {
+		r0 = rf(ctx, code, codeVerifier, nonce)
+	} else {
+		r0 = ret.Get(0).(oidc.Claims)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, code, codeVerifier, nonce)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewIdentityProvider creates a new instance of IdentityProvider. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIdentityProvider(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IdentityProvider {
+	mock := &IdentityProvider{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,115 @@
+// Code generated by mockery v2.33.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	user "github.com/RianNegreiros/go-graphql-api/internal/user"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IdentityRepo is an autogenerated mock type for the IdentityRepo type
+type IdentityRepo struct {
+	mock.Mock
+}
+
+// ConsumeState provides a mock function with given fields: ctx, state, provider
+func (_m *IdentityRepo) ConsumeState(ctx context.Context, state string, provider string) (user.SocialLoginState, error) {
+	ret := _m.Called(ctx, state, provider)
+
+	var r0 user.SocialLoginState
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (user.SocialLoginState, error)); ok {
+		return rf(ctx, state, provider)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) user.SocialLoginState); ok {
+		r0 = rf(ctx, state, provider)
+	} else {
+		r0 = ret.Get(0).(user.SocialLoginState)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, state, provider)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Create provides a mock function with given fields: ctx, i
+func (_m *IdentityRepo) Create(ctx context.Context, i user.Identity) (user.Identity, error) {
+	ret := _m.Called(ctx, i)
+
+	var r0 user.Identity
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, user.Identity) (user.Identity, error)); ok {
+		return rf(ctx, i)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, user.Identity) user.Identity); ok {
+		r0 = rf(ctx, i)
+	} else {
+		r0 = ret.Get(0).(user.Identity)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, user.Identity) error); ok {
+		r1 = rf(ctx, i)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateState provides a mock function with given fields: ctx, s
+func (_m *IdentityRepo) CreateState(ctx context.Context, s user.SocialLoginState) error {
+	ret := _m.Called(ctx, s)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, user.SocialLoginState) error); ok {
+		r0 = rf(ctx, s)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetByProviderSubject provides a mock function with given fields: ctx, provider, subject
+func (_m *IdentityRepo) GetByProviderSubject(ctx context.Context, provider string, subject string) (user.Identity, error) {
+	ret := _m.Called(ctx, provider, subject)
+
+	var r0 user.Identity
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (user.Identity, error)); ok {
+		return rf(ctx, provider, subject)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) user.Identity); ok {
+		r0 = rf(ctx, provider, subject)
+	} else {
+		r0 = ret.Get(0).(user.Identity)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, provider, subject)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewIdentityRepo creates a new instance of IdentityRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIdentityRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IdentityRepo {
+	mock := &IdentityRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
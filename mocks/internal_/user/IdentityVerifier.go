@@ -0,0 +1,53 @@
+// Code generated by mockery v2.33.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	user "github.com/RianNegreiros/go-graphql-api/internal/user"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IdentityVerifier is an autogenerated mock type for the IdentityVerifier type
+type IdentityVerifier struct {
+	mock.Mock
+}
+
+// VerifyLogin provides a mock function with given fields: ctx, input
+func (_m *IdentityVerifier) VerifyLogin(ctx context.Context, input user.SocialLoginInput) (string, error) {
+	ret := _m.Called(ctx, input)
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, user.SocialLoginInput) (string, error)); ok {
+		return rf(ctx, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, user.SocialLoginInput) string); ok {
+		r0 = rf(ctx, input)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, user.SocialLoginInput) error); ok {
+		r1 = rf(ctx, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewIdentityVerifier creates a new instance of IdentityVerifier. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIdentityVerifier(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IdentityVerifier {
+	mock := &IdentityVerifier{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,70 @@
+// Code generated by mockery v2.33.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// LoginGuard is an autogenerated mock type for the LoginGuard type
+type LoginGuard struct {
+	mock.Mock
+}
+
+// Allow provides a mock function with given fields: ctx, email, ip
+func (_m *LoginGuard) Allow(ctx context.Context, email string, ip string) error {
+	ret := _m.Called(ctx, email, ip)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, email, ip)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Release provides a mock function with given fields: ctx, email, ip
+func (_m *LoginGuard) Release(ctx context.Context, email string, ip string) error {
+	ret := _m.Called(ctx, email, ip)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, email, ip)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Succeed provides a mock function with given fields: ctx, email, ip
+func (_m *LoginGuard) Succeed(ctx context.Context, email string, ip string) error {
+	ret := _m.Called(ctx, email, ip)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, email, ip)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewLoginGuard creates a new instance of LoginGuard. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewLoginGuard(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *LoginGuard {
+	mock := &LoginGuard{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,77 @@
+// Code generated by mockery v2.33.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	user "github.com/RianNegreiros/go-graphql-api/internal/user"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// LoginLinkRepo is an autogenerated mock type for the LoginLinkRepo type
+type LoginLinkRepo struct {
+	mock.Mock
+}
+
+// Consume provides a mock function with given fields: ctx, tokenHash
+func (_m *LoginLinkRepo) Consume(ctx context.Context, tokenHash string) (user.LoginLinkToken, error) {
+	ret := _m.Called(ctx, tokenHash)
+
+	var r0 user.LoginLinkToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (user.LoginLinkToken, error)); ok {
+		return rf(ctx, tokenHash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) user.LoginLinkToken); ok {
+		r0 = rf(ctx, tokenHash)
+	} else {
+		r0 = ret.Get(0).(user.LoginLinkToken)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, tokenHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Create provides a mock function with given fields: ctx, userID, tokenHash
+func (_m *LoginLinkRepo) Create(ctx context.Context, userID string, tokenHash string) (user.LoginLinkToken, error) {
+	ret := _m.Called(ctx, userID, tokenHash)
+
+	var r0 user.LoginLinkToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (user.LoginLinkToken, error)); ok {
+		return rf(ctx, userID, tokenHash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) user.LoginLinkToken); ok {
+		r0 = rf(ctx, userID, tokenHash)
+	} else {
+		r0 = ret.Get(0).(user.LoginLinkToken)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, userID, tokenHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewLoginLinkRepo creates a new instance of LoginLinkRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewLoginLinkRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *LoginLinkRepo {
+	mock := &LoginLinkRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
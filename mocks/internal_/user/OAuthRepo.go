@@ -0,0 +1,353 @@
+// Code generated by mockery v2.33.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	user "github.com/RianNegreiros/go-graphql-api/internal/user"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// OAuthRepo is an autogenerated mock type for the OAuthRepo type
+type OAuthRepo struct {
+	mock.Mock
+}
+
+// ConsumeCode provides a mock function with given fields: ctx, codeHash
+func (_m *OAuthRepo) ConsumeCode(ctx context.Context, codeHash string) (user.OAuthAuthorizationCode, error) {
+	ret := _m.Called(ctx, codeHash)
+
+	var r0 user.OAuthAuthorizationCode
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (user.OAuthAuthorizationCode, error)); ok {
+		return rf(ctx, codeHash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) user.OAuthAuthorizationCode); ok {
+		r0 = rf(ctx, codeHash)
+	} else {
+		r0 = ret.Get(0).(user.OAuthAuthorizationCode)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, codeHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateClient provides a mock function with given fields: ctx, c
+func (_m *OAuthRepo) CreateClient(ctx context.Context, c user.OAuthClient) (user.OAuthClient, error) {
+	ret := _m.Called(ctx, c)
+
+	var r0 user.OAuthClient
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, user.OAuthClient) (user.OAuthClient, error)); ok {
+		return rf(ctx, c)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, user.OAuthClient) user.OAuthClient); ok {
+		r0 = rf(ctx, c)
+	} else {
+		r0 = ret.Get(0).(user.OAuthClient)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, user.OAuthClient) error); ok {
+		r1 = rf(ctx, c)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateCode provides a mock function with given fields: ctx, c
+func (_m *OAuthRepo) CreateCode(ctx context.Context, c user.OAuthAuthorizationCode) error {
+	ret := _m.Called(ctx, c)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, user.OAuthAuthorizationCode) error); ok {
+		r0 = rf(ctx, c)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateGrant provides a mock function with given fields: ctx, g
+func (_m *OAuthRepo) CreateGrant(ctx context.Context, g user.OAuthGrant) (user.OAuthGrant, error) {
+	ret := _m.Called(ctx, g)
+
+	var r0 user.OAuthGrant
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, user.OAuthGrant) (user.OAuthGrant, error)); ok {
+		return rf(ctx, g)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, user.OAuthGrant) user.OAuthGrant); ok {
+		r0 = rf(ctx, g)
+	} else {
+		r0 = ret.Get(0).(user.OAuthGrant)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, user.OAuthGrant) error); ok {
+		r1 = rf(ctx, g)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteClient provides a mock function with given fields: ctx, id, userID
+func (_m *OAuthRepo) DeleteClient(ctx context.Context, id string, userID string) error {
+	ret := _m.Called(ctx, id, userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, id, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteConsent provides a mock function with given fields: ctx, userID, clientID
+func (_m *OAuthRepo) DeleteConsent(ctx context.Context, userID string, clientID string) error {
+	ret := _m.Called(ctx, userID, clientID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, userID, clientID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetActiveGrant provides a mock function with given fields: ctx, id
+func (_m *OAuthRepo) GetActiveGrant(ctx context.Context, id string) (user.OAuthGrant, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 user.OAuthGrant
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (user.OAuthGrant, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) user.OAuthGrant); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(user.OAuthGrant)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetActiveGrantByRefreshTokenHash provides a mock function with given fields: ctx, refreshTokenHash
+func (_m *OAuthRepo) GetActiveGrantByRefreshTokenHash(ctx context.Context, refreshTokenHash string) (user.OAuthGrant, error) {
+	ret := _m.Called(ctx, refreshTokenHash)
+
+	var r0 user.OAuthGrant
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (user.OAuthGrant, error)); ok {
+		return rf(ctx, refreshTokenHash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) user.OAuthGrant); ok {
+		r0 = rf(ctx, refreshTokenHash)
+	} else {
+		r0 = ret.Get(0).(user.OAuthGrant)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, refreshTokenHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetClientByID provides a mock function with given fields: ctx, id
+func (_m *OAuthRepo) GetClientByID(ctx context.Context, id string) (user.OAuthClient, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 user.OAuthClient
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (user.OAuthClient, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) user.OAuthClient); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(user.OAuthClient)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetClientsByUserID provides a mock function with given fields: ctx, userID
+func (_m *OAuthRepo) GetClientsByUserID(ctx context.Context, userID string) ([]user.OAuthClient, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 []user.OAuthClient
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]user.OAuthClient, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []user.OAuthClient); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]user.OAuthClient)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetConsent provides a mock function with given fields: ctx, userID, clientID
+func (_m *OAuthRepo) GetConsent(ctx context.Context, userID string, clientID string) (user.OAuthConsent, error) {
+	ret := _m.Called(ctx, userID, clientID)
+
+	var r0 user.OAuthConsent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (user.OAuthConsent, error)); ok {
+		return rf(ctx, userID, clientID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) user.OAuthConsent); ok {
+		r0 = rf(ctx, userID, clientID)
+	} else {
+		r0 = ret.Get(0).(user.OAuthConsent)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, userID, clientID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetConsentsByUserID provides a mock function with given fields: ctx, userID
+func (_m *OAuthRepo) GetConsentsByUserID(ctx context.Context, userID string) ([]user.OAuthConsent, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 []user.OAuthConsent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]user.OAuthConsent, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []user.OAuthConsent); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]user.OAuthConsent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RevokeGrant provides a mock function with given fields: ctx, id
+func (_m *OAuthRepo) RevokeGrant(ctx context.Context, id string) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RotateGrant provides a mock function with given fields: ctx, refreshTokenHash, newRefreshTokenHash
+func (_m *OAuthRepo) RotateGrant(ctx context.Context, refreshTokenHash string, newRefreshTokenHash string) (user.OAuthGrant, error) {
+	ret := _m.Called(ctx, refreshTokenHash, newRefreshTokenHash)
+
+	var r0 user.OAuthGrant
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (user.OAuthGrant, error)); ok {
+		return rf(ctx, refreshTokenHash, newRefreshTokenHash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) user.OAuthGrant); ok {
+		r0 = rf(ctx, refreshTokenHash, newRefreshTokenHash)
+	} else {
+		r0 = ret.Get(0).(user.OAuthGrant)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, refreshTokenHash, newRefreshTokenHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SaveConsent provides a mock function with given fields: ctx, c
+func (_m *OAuthRepo) SaveConsent(ctx context.Context, c user.OAuthConsent) (user.OAuthConsent, error) {
+	ret := _m.Called(ctx, c)
+
+	var r0 user.OAuthConsent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, user.OAuthConsent) (user.OAuthConsent, error)); ok {
+		return rf(ctx, c)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, user.OAuthConsent) user.OAuthConsent); ok {
+		r0 = rf(ctx, c)
+	} else {
+		r0 = ret.Get(0).(user.OAuthConsent)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, user.OAuthConsent) error); ok {
+		r1 = rf(ctx, c)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewOAuthRepo creates a new instance of OAuthRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewOAuthRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *OAuthRepo {
+	mock := &OAuthRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
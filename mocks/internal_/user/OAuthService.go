@@ -0,0 +1,267 @@
+// Code generated by mockery v2.33.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	user "github.com/RianNegreiros/go-graphql-api/internal/user"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// OAuthService is an autogenerated mock type for the OAuthService type
+type OAuthService struct {
+	mock.Mock
+}
+
+// Authenticate provides a mock function with given fields: ctx, token
+func (_m *OAuthService) Authenticate(ctx context.Context, token string) (user.OAuthGrant, error) {
+	ret := _m.Called(ctx, token)
+
+	var r0 user.OAuthGrant
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (user.OAuthGrant, error)); ok {
+		return rf(ctx, token)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) user.OAuthGrant); ok {
+		r0 = rf(ctx, token)
+	} else {
+		r0 = ret.Get(0).(user.OAuthGrant)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Authorization provides a mock function with given fields: ctx, input
+func (_m *OAuthService) Authorization(ctx context.Context, input user.OAuthAuthorizationInput) (user.OAuthAuthorization, error) {
+	ret := _m.Called(ctx, input)
+
+	var r0 user.OAuthAuthorization
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, user.OAuthAuthorizationInput) (user.OAuthAuthorization, error)); ok {
+		return rf(ctx, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, user.OAuthAuthorizationInput) user.OAuthAuthorization); ok {
+		r0 = rf(ctx, input)
+	} else {
+		r0 = ret.Get(0).(user.OAuthAuthorization)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, user.OAuthAuthorizationInput) error); ok {
+		r1 = rf(ctx, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Authorize provides a mock function with given fields: ctx, input
+func (_m *OAuthService) Authorize(ctx context.Context, input user.OAuthAuthorizationInput) (string, error) {
+	ret := _m.Called(ctx, input)
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, user.OAuthAuthorizationInput) (string, error)); ok {
+		return rf(ctx, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, user.OAuthAuthorizationInput) string); ok {
+		r0 = rf(ctx, input)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, user.OAuthAuthorizationInput) error); ok {
+		r1 = rf(ctx, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Clients provides a mock function with given fields: ctx
+func (_m *OAuthService) Clients(ctx context.Context) ([]user.OAuthClient, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []user.OAuthClient
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]user.OAuthClient, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []user.OAuthClient); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]user.OAuthClient)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Consents provides a mock function with given fields: ctx
+func (_m *OAuthService) Consents(ctx context.Context) ([]user.OAuthConsent, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []user.OAuthConsent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]user.OAuthConsent, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []user.OAuthConsent); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]user.OAuthConsent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateClient provides a mock function with given fields: ctx, input
+func (_m *OAuthService) CreateClient(ctx context.Context, input user.CreateOAuthClientInput) (user.CreatedOAuthClient, error) {
+	ret := _m.Called(ctx, input)
+
+	var r0 user.CreatedOAuthClient
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, user.CreateOAuthClientInput) (user.CreatedOAuthClient, error)); ok {
+		return rf(ctx, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, user.CreateOAuthClientInput) user.CreatedOAuthClient); ok {
+		r0 = rf(ctx, input)
+	} else {
+		r0 = ret.Get(0).(user.CreatedOAuthClient)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, user.CreateOAuthClientInput) error); ok {
+		r1 = rf(ctx, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteClient provides a mock function with given fields: ctx, id
+func (_m *OAuthService) DeleteClient(ctx context.Context, id string) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Introspect provides a mock function with given fields: ctx, client, token
+func (_m *OAuthService) Introspect(ctx context.Context, client user.OAuthClientCredentials, token string) (user.OAuthIntrospection, error) {
+	ret := _m.Called(ctx, client, token)
+
+	var r0 user.OAuthIntrospection
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, user.OAuthClientCredentials, string) (user.OAuthIntrospection, error)); ok {
+		return rf(ctx, client, token)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, user.OAuthClientCredentials, string) user.OAuthIntrospection); ok {
+		r0 = rf(ctx, client, token)
+	} else {
+		r0 = ret.Get(0).(user.OAuthIntrospection)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, user.OAuthClientCredentials, string) error); ok {
+		r1 = rf(ctx, client, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Revoke provides a mock function with given fields: ctx, client, token
+func (_m *OAuthService) Revoke(ctx context.Context, client user.OAuthClientCredentials, token string) error {
+	ret := _m.Called(ctx, client, token)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, user.OAuthClientCredentials, string) error); ok {
+		r0 = rf(ctx, client, token)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RevokeConsent provides a mock function with given fields: ctx, clientID
+func (_m *OAuthService) RevokeConsent(ctx context.Context, clientID string) error {
+	ret := _m.Called(ctx, clientID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, clientID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Token provides a mock function with given fields: ctx, input
+func (_m *OAuthService) Token(ctx context.Context, input user.OAuthTokenInput) (user.OAuthTokenResponse, error) {
+	ret := _m.Called(ctx, input)
+
+	var r0 user.OAuthTokenResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, user.OAuthTokenInput) (user.OAuthTokenResponse, error)); ok {
+		return rf(ctx, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, user.OAuthTokenInput) user.OAuthTokenResponse); ok {
+		r0 = rf(ctx, input)
+	} else {
+		r0 = ret.Get(0).(user.OAuthTokenResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, user.OAuthTokenInput) error); ok {
+		r1 = rf(ctx, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewOAuthService creates a new instance of OAuthService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewOAuthService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *OAuthService {
+	mock := &OAuthService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
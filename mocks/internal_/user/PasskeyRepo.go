@@ -0,0 +1,169 @@
+// Code generated by mockery v2.33.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	user "github.com/RianNegreiros/go-graphql-api/internal/user"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// PasskeyRepo is an autogenerated mock type for the PasskeyRepo type
+type PasskeyRepo struct {
+	mock.Mock
+}
+
+// ConsumeChallenge provides a mock function with given fields: ctx, challenge, ceremony
+func (_m *PasskeyRepo) ConsumeChallenge(ctx context.Context, challenge string, ceremony user.PasskeyCeremony) (user.PasskeyChallenge, error) {
+	ret := _m.Called(ctx, challenge, ceremony)
+
+	var r0 user.PasskeyChallenge
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, user.PasskeyCeremony) (user.PasskeyChallenge, error)); ok {
+		return rf(ctx, challenge, ceremony)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, user.PasskeyCeremony) user.PasskeyChallenge); ok {
+		r0 = rf(ctx, challenge, ceremony)
+	} else {
+		r0 = ret.Get(0).(user.PasskeyChallenge)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, user.PasskeyCeremony) error); ok {
+		r1 = rf(ctx, challenge, ceremony)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Create provides a mock function with given fields: ctx, p
+func (_m *PasskeyRepo) Create(ctx context.Context, p user.Passkey) (user.Passkey, error) {
+	ret := _m.Called(ctx, p)
+
+	var r0 user.Passkey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, user.Passkey) (user.Passkey, error)); ok {
+		return rf(ctx, p)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, user.Passkey) user.Passkey); ok {
+		r0 = rf(ctx, p)
+	} else {
+		r0 = ret.Get(0).(user.Passkey)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, user.Passkey) error); ok {
+		r1 = rf(ctx, p)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateChallenge provides a mock function with given fields: ctx, c
+func (_m *PasskeyRepo) CreateChallenge(ctx context.Context, c user.PasskeyChallenge) error {
+	ret := _m.Called(ctx, c)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, user.PasskeyChallenge) error); ok {
+		r0 = rf(ctx, c)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Delete provides a mock function with given fields: ctx, id, userID
+func (_m *PasskeyRepo) Delete(ctx context.Context, id string, userID string) error {
+	ret := _m.Called(ctx, id, userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, id, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetByCredentialID provides a mock function with given fields: ctx, credentialID
+func (_m *PasskeyRepo) GetByCredentialID(ctx context.Context, credentialID []byte) (user.Passkey, error) {
+	ret := _m.Called(ctx, credentialID)
+
+	var r0 user.Passkey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []byte) (user.Passkey, error)); ok {
+		return rf(ctx, credentialID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []byte) user.Passkey); ok {
+		r0 = rf(ctx, credentialID)
+	} else {
+		r0 = ret.Get(0).(user.Passkey)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []byte) error); ok {
+		r1 = rf(ctx, credentialID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByUserID provides a mock function with given fields: ctx, userID
+func (_m *PasskeyRepo) GetByUserID(ctx context.Context, userID string) ([]user.Passkey, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 []user.Passkey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]user.Passkey, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []user.Passkey); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]user.Passkey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateSignCount provides a mock function with given fields: ctx, id, signCount
+func (_m *PasskeyRepo) UpdateSignCount(ctx context.Context, id string, signCount int64) error {
+	ret := _m.Called(ctx, id, signCount)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) error); ok {
+		r0 = rf(ctx, id, signCount)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewPasskeyRepo creates a new instance of PasskeyRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewPasskeyRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *PasskeyRepo {
+	mock := &PasskeyRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
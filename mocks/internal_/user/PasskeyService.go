@@ -0,0 +1,141 @@
+// Code generated by mockery v2.33.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	user "github.com/RianNegreiros/go-graphql-api/internal/user"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// PasskeyService is an autogenerated mock type for the PasskeyService type
+type PasskeyService struct {
+	mock.Mock
+}
+
+// BeginLogin provides a mock function with given fields: ctx
+func (_m *PasskeyService) BeginLogin(ctx context.Context) (user.PasskeyRequestOptions, error) {
+	ret := _m.Called(ctx)
+
+	var r0 user.PasskeyRequestOptions
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (user.PasskeyRequestOptions, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) user.PasskeyRequestOptions); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(user.PasskeyRequestOptions)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BeginRegistration provides a mock function with given fields: ctx
+func (_m *PasskeyService) BeginRegistration(ctx context.Context) (user.PasskeyCreationOptions, error) {
+	ret := _m.Called(ctx)
+
+	var r0 user.PasskeyCreationOptions
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (user.PasskeyCreationOptions, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) user.PasskeyCreationOptions); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(user.PasskeyCreationOptions)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Delete provides a mock function with given fields: ctx, id
+func (_m *PasskeyService) Delete(ctx context.Context, id string) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FinishRegistration provides a mock function with given fields: ctx, input
+func (_m *PasskeyService) FinishRegistration(ctx context.Context, input user.FinishPasskeyRegistrationInput) (user.Passkey, error) {
+	ret := _m.Called(ctx, input)
+
+	var r0 user.Passkey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, user.FinishPasskeyRegistrationInput) (user.Passkey, error)); ok {
+		return rf(ctx, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, user.FinishPasskeyRegistrationInput) user.Passkey); ok {
+		r0 = rf(ctx, input)
+	} else {
+		r0 = ret.Get(0).(user.Passkey)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, user.FinishPasskeyRegistrationInput) error); ok {
+		r1 = rf(ctx, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// List provides a mock function with given fields: ctx
+func (_m *PasskeyService) List(ctx context.Context) ([]user.Passkey, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []user.Passkey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]user.Passkey, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []user.Passkey); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]user.Passkey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewPasskeyService creates a new instance of PasskeyService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewPasskeyService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *PasskeyService {
+	mock := &PasskeyService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
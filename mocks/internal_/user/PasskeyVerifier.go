@@ -0,0 +1,53 @@
+// Code generated by mockery v2.33.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	user "github.com/RianNegreiros/go-graphql-api/internal/user"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// PasskeyVerifier is an autogenerated mock type for the PasskeyVerifier type
+type PasskeyVerifier struct {
+	mock.Mock
+}
+
+// VerifyLogin provides a mock function with given fields: ctx, input
+func (_m *PasskeyVerifier) VerifyLogin(ctx context.Context, input user.PasskeyLoginInput) (string, error) {
+	ret := _m.Called(ctx, input)
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, user.PasskeyLoginInput) (string, error)); ok {
+		return rf(ctx, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, user.PasskeyLoginInput) string); ok {
+		r0 = rf(ctx, input)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, user.PasskeyLoginInput) error); ok {
+		r1 = rf(ctx, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewPasskeyVerifier creates a new instance of PasskeyVerifier. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewPasskeyVerifier(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *PasskeyVerifier {
+	mock := &PasskeyVerifier{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
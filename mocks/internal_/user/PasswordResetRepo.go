@@ -0,0 +1,77 @@
+// Code generated by mockery v2.33.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	user "github.com/RianNegreiros/go-graphql-api/internal/user"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// PasswordResetRepo is an autogenerated mock type for the PasswordResetRepo type
+type PasswordResetRepo struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: ctx, userID, tokenHash
+func (_m *PasswordResetRepo) Create(ctx context.Context, userID string, tokenHash string) (user.PasswordResetToken, error) {
+	ret := _m.Called(ctx, userID, tokenHash)
+
+	var r0 user.PasswordResetToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (user.PasswordResetToken, error)); ok {
+		return rf(ctx, userID, tokenHash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) user.PasswordResetToken); ok {
+		r0 = rf(ctx, userID, tokenHash)
+	} else {
+		r0 = ret.Get(0).(user.PasswordResetToken)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, userID, tokenHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Reset provides a mock function with given fields: ctx, tokenHash, password
+func (_m *PasswordResetRepo) Reset(ctx context.Context, tokenHash string, password string) (user.PasswordResetToken, error) {
+	ret := _m.Called(ctx, tokenHash, password)
+
+	var r0 user.PasswordResetToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (user.PasswordResetToken, error)); ok {
+		return rf(ctx, tokenHash, password)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) user.PasswordResetToken); ok {
+		r0 = rf(ctx, tokenHash, password)
+	} else {
+		r0 = ret.Get(0).(user.PasswordResetToken)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, tokenHash, password)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewPasswordResetRepo creates a new instance of PasswordResetRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewPasswordResetRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *PasswordResetRepo {
+	mock := &PasswordResetRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,117 @@
+// Code generated by mockery v2.33.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	user "github.com/RianNegreiros/go-graphql-api/internal/user"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// PersonalAccessTokenRepo is an autogenerated mock type for the PersonalAccessTokenRepo type
+type PersonalAccessTokenRepo struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: ctx, t
+func (_m *PersonalAccessTokenRepo) Create(ctx context.Context, t user.PersonalAccessToken) (user.PersonalAccessToken, error) {
+	ret := _m.Called(ctx, t)
+
+	var r0 user.PersonalAccessToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, user.PersonalAccessToken) (user.PersonalAccessToken, error)); ok {
+		return rf(ctx, t)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, user.PersonalAccessToken) user.PersonalAccessToken); ok {
+		r0 = rf(ctx, t)
+	} else {
+		r0 = ret.Get(0).(user.PersonalAccessToken)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, user.PersonalAccessToken) error); ok {
+		r1 = rf(ctx, t)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetActiveByUserID provides a mock function with given fields: ctx, userID
+func (_m *PersonalAccessTokenRepo) GetActiveByUserID(ctx context.Context, userID string) ([]user.PersonalAccessToken, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 []user.PersonalAccessToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]user.PersonalAccessToken, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []user.PersonalAccessToken); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]user.PersonalAccessToken)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Revoke provides a mock function with given fields: ctx, id, userID
+func (_m *PersonalAccessTokenRepo) Revoke(ctx context.Context, id string, userID string) error {
+	ret := _m.Called(ctx, id, userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, id, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Use provides a mock function with given fields: ctx, tokenHash
+func (_m *PersonalAccessTokenRepo) Use(ctx context.Context, tokenHash string) (user.PersonalAccessToken, error) {
+	ret := _m.Called(ctx, tokenHash)
+
+	var r0 user.PersonalAccessToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (user.PersonalAccessToken, error)); ok {
+		return rf(ctx, tokenHash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) user.PersonalAccessToken); ok {
+		r0 = rf(ctx, tokenHash)
+	} else {
+		r0 = ret.Get(0).(user.PersonalAccessToken)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, tokenHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewPersonalAccessTokenRepo creates a new instance of PersonalAccessTokenRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewPersonalAccessTokenRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *PersonalAccessTokenRepo {
+	mock := &PersonalAccessTokenRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
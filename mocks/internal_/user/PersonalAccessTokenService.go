@@ -0,0 +1,117 @@
+// Code generated by mockery v2.33.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	user "github.com/RianNegreiros/go-graphql-api/internal/user"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// PersonalAccessTokenService is an autogenerated mock type for the PersonalAccessTokenService type
+type PersonalAccessTokenService struct {
+	mock.Mock
+}
+
+// Authenticate provides a mock function with given fields: ctx, token
+func (_m *PersonalAccessTokenService) Authenticate(ctx context.Context, token string) (user.PersonalAccessToken, error) {
+	ret := _m.Called(ctx, token)
+
+	var r0 user.PersonalAccessToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (user.PersonalAccessToken, error)); ok {
+		return rf(ctx, token)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) user.PersonalAccessToken); ok {
+		r0 = rf(ctx, token)
+	} else {
+		r0 = ret.Get(0).(user.PersonalAccessToken)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Create provides a mock function with given fields: ctx, input
+func (_m *PersonalAccessTokenService) Create(ctx context.Context, input user.CreatePersonalAccessTokenInput) (user.CreatedPersonalAccessToken, error) {
+	ret := _m.Called(ctx, input)
+
+	var r0 user.CreatedPersonalAccessToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, user.CreatePersonalAccessTokenInput) (user.CreatedPersonalAccessToken, error)); ok {
+		return rf(ctx, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, user.CreatePersonalAccessTokenInput) user.CreatedPersonalAccessToken); ok {
+		r0 = rf(ctx, input)
+	} else {
+		r0 = ret.Get(0).(user.CreatedPersonalAccessToken)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, user.CreatePersonalAccessTokenInput) error); ok {
+		r1 = rf(ctx, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// List provides a mock function with given fields: ctx
+func (_m *PersonalAccessTokenService) List(ctx context.Context) ([]user.PersonalAccessToken, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []user.PersonalAccessToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]user.PersonalAccessToken, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []user.PersonalAccessToken); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]user.PersonalAccessToken)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Revoke provides a mock function with given fields: ctx, id
+func (_m *PersonalAccessTokenService) Revoke(ctx context.Context, id string) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewPersonalAccessTokenService creates a new instance of PersonalAccessTokenService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewPersonalAccessTokenService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *PersonalAccessTokenService {
+	mock := &PersonalAccessTokenService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
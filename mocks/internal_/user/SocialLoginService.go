@@ -0,0 +1,52 @@
+// Code generated by mockery v2.33.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// SocialLoginService is an autogenerated mock type for the SocialLoginService type
+type SocialLoginService struct {
+	mock.Mock
+}
+
+// AuthURL provides a mock function with given fields: ctx, provider
+func (_m *SocialLoginService) AuthURL(ctx context.Context, provider string) (string, error) {
+	ret := _m.Called(ctx, provider)
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (string, error)); ok {
+		return rf(ctx, provider)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = rf(ctx, provider)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, provider)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewSocialLoginService creates a new instance of SocialLoginService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewSocialLoginService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *SocialLoginService {
+	mock := &SocialLoginService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
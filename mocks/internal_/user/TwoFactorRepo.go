@@ -0,0 +1,171 @@
+// Code generated by mockery v2.33.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	user "github.com/RianNegreiros/go-graphql-api/internal/user"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// TwoFactorRepo is an autogenerated mock type for the TwoFactorRepo type
+type TwoFactorRepo struct {
+	mock.Mock
+}
+
+// ConfirmTotp provides a mock function with given fields: ctx, userID, step, recoveryCodeHashes
+func (_m *TwoFactorRepo) ConfirmTotp(ctx context.Context, userID string, step int64, recoveryCodeHashes []string) error {
+	ret := _m.Called(ctx, userID, step, recoveryCodeHashes)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64, []string) error); ok {
+		r0 = rf(ctx, userID, step, recoveryCodeHashes)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CountRecoveryCodes provides a mock function with given fields: ctx, userID
+func (_m *TwoFactorRepo) CountRecoveryCodes(ctx context.Context, userID string) (int, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (int, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) int); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteTotp provides a mock function with given fields: ctx, userID
+func (_m *TwoFactorRepo) DeleteTotp(ctx context.Context, userID string) error {
+	ret := _m.Called(ctx, userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetTotp provides a mock function with given fields: ctx, userID
+func (_m *TwoFactorRepo) GetTotp(ctx context.Context, userID string) (user.Totp, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 user.Totp
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (user.Totp, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) user.Totp); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(user.Totp)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReplaceRecoveryCodes provides a mock function with given fields: ctx, userID, hashes
+func (_m *TwoFactorRepo) ReplaceRecoveryCodes(ctx context.Context, userID string, hashes []string) error {
+	ret := _m.Called(ctx, userID, hashes)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) error); ok {
+		r0 = rf(ctx, userID, hashes)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SaveTotp provides a mock function with given fields: ctx, userID, secret
+func (_m *TwoFactorRepo) SaveTotp(ctx context.Context, userID string, secret string) (user.Totp, error) {
+	ret := _m.Called(ctx, userID, secret)
+
+	var r0 user.Totp
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (user.Totp, error)); ok {
+		return rf(ctx, userID, secret)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) user.Totp); ok {
+		r0 = rf(ctx, userID, secret)
+	} else {
+		r0 = ret.Get(0).(user.Totp)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, userID, secret)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UseRecoveryCode provides a mock function with given fields: ctx, userID, hash
+func (_m *TwoFactorRepo) UseRecoveryCode(ctx context.Context, userID string, hash string) error {
+	ret := _m.Called(ctx, userID, hash)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, userID, hash)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UseTotpStep provides a mock function with given fields: ctx, userID, step
+func (_m *TwoFactorRepo) UseTotpStep(ctx context.Context, userID string, step int64) error {
+	ret := _m.Called(ctx, userID, step)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) error); ok {
+		r0 = rf(ctx, userID, step)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewTwoFactorRepo creates a new instance of TwoFactorRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewTwoFactorRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *TwoFactorRepo {
+	mock := &TwoFactorRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
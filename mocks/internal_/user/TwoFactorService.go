@@ -0,0 +1,143 @@
+// Code generated by mockery v2.33.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	user "github.com/RianNegreiros/go-graphql-api/internal/user"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// TwoFactorService is an autogenerated mock type for the TwoFactorService type
+type TwoFactorService struct {
+	mock.Mock
+}
+
+// ConfirmTotp provides a mock function with given fields: ctx, code
+func (_m *TwoFactorService) ConfirmTotp(ctx context.Context, code string) ([]string, error) {
+	ret := _m.Called(ctx, code)
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]string, error)); ok {
+		return rf(ctx, code)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []string); ok {
+		r0 = rf(ctx, code)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, code)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DisableTotp provides a mock function with given fields: ctx, code
+func (_m *TwoFactorService) DisableTotp(ctx context.Context, code string) error {
+	ret := _m.Called(ctx, code)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, code)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// EnableTotp provides a mock function with given fields: ctx
+func (_m *TwoFactorService) EnableTotp(ctx context.Context) (user.TotpSetup, error) {
+	ret := _m.Called(ctx)
+
+	var r0 user.TotpSetup
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (user.TotpSetup, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) user.TotpSetup); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(user.TotpSetup)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RegenerateRecoveryCodes provides a mock function with given fields: ctx, code
+func (_m *TwoFactorService) RegenerateRecoveryCodes(ctx context.Context, code string) ([]string, error) {
+	ret := _m.Called(ctx, code)
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]string, error)); ok {
+		return rf(ctx, code)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []string); ok {
+		r0 = rf(ctx, code)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, code)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Status provides a mock function with given fields: ctx
+func (_m *TwoFactorService) Status(ctx context.Context) (user.TwoFactorStatus, error) {
+	ret := _m.Called(ctx)
+
+	var r0 user.TwoFactorStatus
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (user.TwoFactorStatus, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) user.TwoFactorStatus); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(user.TwoFactorStatus)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewTwoFactorService creates a new instance of TwoFactorService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewTwoFactorService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *TwoFactorService {
+	mock := &TwoFactorService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,66 @@
+// Code generated by mockery v2.33.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// TwoFactorVerifier is an autogenerated mock type for the TwoFactorVerifier type
+type TwoFactorVerifier struct {
+	mock.Mock
+}
+
+// IsEnabled provides a mock function with given fields: ctx, userID
+func (_m *TwoFactorVerifier) IsEnabled(ctx context.Context, userID string) (bool, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (bool, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Verify provides a mock function with given fields: ctx, userID, code
+func (_m *TwoFactorVerifier) Verify(ctx context.Context, userID string, code string) error {
+	ret := _m.Called(ctx, userID, code)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, userID, code)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewTwoFactorVerifier creates a new instance of TwoFactorVerifier. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewTwoFactorVerifier(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *TwoFactorVerifier {
+	mock := &TwoFactorVerifier{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -5,8 +5,12 @@ package mocks
 import (
 	context "context"
 
-	user "github.com/RianNegreiros/go-graphql-api/internal/user"
+	pagination "github.com/RianNegreiros/go-graphql-api/internal/pagination"
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+
+	user "github.com/RianNegreiros/go-graphql-api/internal/user"
 )
 
 // UserRepo is an autogenerated mock type for the UserRepo type
@@ -14,6 +18,72 @@ type UserRepo struct {
 	mock.Mock
 }
 
+// Anonymize provides a mock function with given fields: ctx, userID
+func (_m *UserRepo) Anonymize(ctx context.Context, userID string) error {
+	ret := _m.Called(ctx, userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CountFollowers provides a mock function with given fields: ctx, userIDs
+func (_m *UserRepo) CountFollowers(ctx context.Context, userIDs []string) (map[string]int, error) {
+	ret := _m.Called(ctx, userIDs)
+
+	var r0 map[string]int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) (map[string]int, error)); ok {
+		return rf(ctx, userIDs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string) map[string]int); ok {
+		r0 = rf(ctx, userIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]int)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, userIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CountFollowing provides a mock function with given fields: ctx, userIDs
+func (_m *UserRepo) CountFollowing(ctx context.Context, userIDs []string) (map[string]int, error) {
+	ret := _m.Called(ctx, userIDs)
+
+	var r0 map[string]int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) (map[string]int, error)); ok {
+		return rf(ctx, userIDs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string) map[string]int); ok {
+		r0 = rf(ctx, userIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]int)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, userIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Create provides a mock function with given fields: ctx, _a1
 func (_m *UserRepo) Create(ctx context.Context, _a1 user.UserModel) (user.UserModel, error) {
 	ret := _m.Called(ctx, _a1)
@@ -38,6 +108,20 @@ func (_m *UserRepo) Create(ctx context.Context, _a1 user.UserModel) (user.UserMo
 	return r0, r1
 }
 
+// Follow provides a mock function with given fields: ctx, followerID, followeeID
+func (_m *UserRepo) Follow(ctx context.Context, followerID string, followeeID string) error {
+	ret := _m.Called(ctx, followerID, followeeID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, followerID, followeeID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // GetByEmail provides a mock function with given fields: ctx, email
 func (_m *UserRepo) GetByEmail(ctx context.Context, email string) (user.UserModel, error) {
 	ret := _m.Called(ctx, email)
@@ -136,6 +220,258 @@ func (_m *UserRepo) GetByUsername(ctx context.Context, username string) (user.Us
 	return r0, r1
 }
 
+// GetDueForDeletion provides a mock function with given fields: ctx, now
+func (_m *UserRepo) GetDueForDeletion(ctx context.Context, now time.Time) ([]string, error) {
+	ret := _m.Called(ctx, now)
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) ([]string, error)); ok {
+		return rf(ctx, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) []string); ok {
+		r0 = rf(ctx, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetFollowedBy provides a mock function with given fields: ctx, followerID, userIDs
+func (_m *UserRepo) GetFollowedBy(ctx context.Context, followerID string, userIDs []string) (map[string]bool, error) {
+	ret := _m.Called(ctx, followerID, userIDs)
+
+	var r0 map[string]bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) (map[string]bool, error)); ok {
+		return rf(ctx, followerID, userIDs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) map[string]bool); ok {
+		r0 = rf(ctx, followerID, userIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]bool)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, []string) error); ok {
+		r1 = rf(ctx, followerID, userIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetFollowers provides a mock function with given fields: ctx, userIDs, args
+func (_m *UserRepo) GetFollowers(ctx context.Context, userIDs []string, args pagination.Args) (map[string]pagination.Page[user.UserModel], error) {
+	ret := _m.Called(ctx, userIDs, args)
+
+	var r0 map[string]pagination.Page[user.UserModel]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string, pagination.Args) (map[string]pagination.Page[user.UserModel], error)); ok {
+		return rf(ctx, userIDs, args)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string, pagination.Args) map[string]pagination.Page[user.UserModel]); ok {
+		r0 = rf(ctx, userIDs, args)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]pagination.Page[user.UserModel])
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string, pagination.Args) error); ok {
+		r1 = rf(ctx, userIDs, args)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetFollowing provides a mock function with given fields: ctx, userIDs, args
+func (_m *UserRepo) GetFollowing(ctx context.Context, userIDs []string, args pagination.Args) (map[string]pagination.Page[user.UserModel], error) {
+	ret := _m.Called(ctx, userIDs, args)
+
+	var r0 map[string]pagination.Page[user.UserModel]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string, pagination.Args) (map[string]pagination.Page[user.UserModel], error)); ok {
+		return rf(ctx, userIDs, args)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string, pagination.Args) map[string]pagination.Page[user.UserModel]); ok {
+		r0 = rf(ctx, userIDs, args)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]pagination.Page[user.UserModel])
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string, pagination.Args) error); ok {
+		r1 = rf(ctx, userIDs, args)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MarkEmailVerified provides a mock function with given fields: ctx, userID
+func (_m *UserRepo) MarkEmailVerified(ctx context.Context, userID string) (user.UserModel, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 user.UserModel
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (user.UserModel, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) user.UserModel); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(user.UserModel)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ScheduleDeletion provides a mock function with given fields: ctx, userID, at
+func (_m *UserRepo) ScheduleDeletion(ctx context.Context, userID string, at *time.Time) (user.UserModel, error) {
+	ret := _m.Called(ctx, userID, at)
+
+	var r0 user.UserModel
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *time.Time) (user.UserModel, error)); ok {
+		return rf(ctx, userID, at)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *time.Time) user.UserModel); ok {
+		r0 = rf(ctx, userID, at)
+	} else {
+		r0 = ret.Get(0).(user.UserModel)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *time.Time) error); ok {
+		r1 = rf(ctx, userID, at)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Unfollow provides a mock function with given fields: ctx, followerID, followeeID
+func (_m *UserRepo) Unfollow(ctx context.Context, followerID string, followeeID string) error {
+	ret := _m.Called(ctx, followerID, followeeID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, followerID, followeeID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateEmail provides a mock function with given fields: ctx, userID, email
+func (_m *UserRepo) UpdateEmail(ctx context.Context, userID string, email string) (user.UserModel, error) {
+	ret := _m.Called(ctx, userID, email)
+
+	var r0 user.UserModel
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (user.UserModel, error)); ok {
+		return rf(ctx, userID, email)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) user.UserModel); ok {
+		r0 = rf(ctx, userID, email)
+	} else {
+		r0 = ret.Get(0).(user.UserModel)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, userID, email)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdatePassword provides a mock function with given fields: ctx, userID, password
+func (_m *UserRepo) UpdatePassword(ctx context.Context, userID string, password string) error {
+	ret := _m.Called(ctx, userID, password)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, userID, password)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateProfile provides a mock function with given fields: ctx, _a1
+func (_m *UserRepo) UpdateProfile(ctx context.Context, _a1 user.UserModel) (user.UserModel, error) {
+	ret := _m.Called(ctx, _a1)
+
+	var r0 user.UserModel
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, user.UserModel) (user.UserModel, error)); ok {
+		return rf(ctx, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, user.UserModel) user.UserModel); ok {
+		r0 = rf(ctx, _a1)
+	} else {
+		r0 = ret.Get(0).(user.UserModel)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, user.UserModel) error); ok {
+		r1 = rf(ctx, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateRole provides a mock function with given fields: ctx, userID, role
+func (_m *UserRepo) UpdateRole(ctx context.Context, userID string, role user.Role) (user.UserModel, error) {
+	ret := _m.Called(ctx, userID, role)
+
+	var r0 user.UserModel
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, user.Role) (user.UserModel, error)); ok {
+		return rf(ctx, userID, role)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, user.Role) user.UserModel); ok {
+		r0 = rf(ctx, userID, role)
+	} else {
+		r0 = ret.Get(0).(user.UserModel)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, user.Role) error); ok {
+		r1 = rf(ctx, userID, role)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewUserRepo creates a new instance of UserRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewUserRepo(t interface {
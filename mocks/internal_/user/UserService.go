@@ -5,8 +5,10 @@ package mocks
 import (
 	context "context"
 
-	user "github.com/RianNegreiros/go-graphql-api/internal/user"
+	pagination "github.com/RianNegreiros/go-graphql-api/internal/pagination"
 	mock "github.com/stretchr/testify/mock"
+
+	user "github.com/RianNegreiros/go-graphql-api/internal/user"
 )
 
 // UserService is an autogenerated mock type for the UserService type
@@ -14,6 +16,78 @@ type UserService struct {
 	mock.Mock
 }
 
+// Follow provides a mock function with given fields: ctx, userID
+func (_m *UserService) Follow(ctx context.Context, userID string) (user.UserModel, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 user.UserModel
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (user.UserModel, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) user.UserModel); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(user.UserModel)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Followers provides a mock function with given fields: ctx, userID, input
+func (_m *UserService) Followers(ctx context.Context, userID string, input pagination.Input) (pagination.Page[user.UserModel], error) {
+	ret := _m.Called(ctx, userID, input)
+
+	var r0 pagination.Page[user.UserModel]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, pagination.Input) (pagination.Page[user.UserModel], error)); ok {
+		return rf(ctx, userID, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, pagination.Input) pagination.Page[user.UserModel]); ok {
+		r0 = rf(ctx, userID, input)
+	} else {
+		r0 = ret.Get(0).(pagination.Page[user.UserModel])
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, pagination.Input) error); ok {
+		r1 = rf(ctx, userID, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Following provides a mock function with given fields: ctx, userID, input
+func (_m *UserService) Following(ctx context.Context, userID string, input pagination.Input) (pagination.Page[user.UserModel], error) {
+	ret := _m.Called(ctx, userID, input)
+
+	var r0 pagination.Page[user.UserModel]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, pagination.Input) (pagination.Page[user.UserModel], error)); ok {
+		return rf(ctx, userID, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, pagination.Input) pagination.Page[user.UserModel]); ok {
+		r0 = rf(ctx, userID, input)
+	} else {
+		r0 = ret.Get(0).(pagination.Page[user.UserModel])
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, pagination.Input) error); ok {
+		r1 = rf(ctx, userID, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetByID provides a mock function with given fields: ctx, id
 func (_m *UserService) GetByID(ctx context.Context, id string) (user.UserModel, error) {
 	ret := _m.Called(ctx, id)
@@ -38,6 +112,126 @@ func (_m *UserService) GetByID(ctx context.Context, id string) (user.UserModel,
 	return r0, r1
 }
 
+// GetByUsername provides a mock function with given fields: ctx, username
+func (_m *UserService) GetByUsername(ctx context.Context, username string) (user.UserModel, error) {
+	ret := _m.Called(ctx, username)
+
+	var r0 user.UserModel
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (user.UserModel, error)); ok {
+		return rf(ctx, username)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) user.UserModel); ok {
+		r0 = rf(ctx, username)
+	} else {
+		r0 = ret.Get(0).(user.UserModel)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, username)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GrantRole provides a mock function with given fields: ctx, userID, role
+func (_m *UserService) GrantRole(ctx context.Context, userID string, role user.Role) (user.UserModel, error) {
+	ret := _m.Called(ctx, userID, role)
+
+	var r0 user.UserModel
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, user.Role) (user.UserModel, error)); ok {
+		return rf(ctx, userID, role)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, user.Role) user.UserModel); ok {
+		r0 = rf(ctx, userID, role)
+	} else {
+		r0 = ret.Get(0).(user.UserModel)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, user.Role) error); ok {
+		r1 = rf(ctx, userID, role)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RevokeRole provides a mock function with given fields: ctx, userID, role
+func (_m *UserService) RevokeRole(ctx context.Context, userID string, role user.Role) (user.UserModel, error) {
+	ret := _m.Called(ctx, userID, role)
+
+	var r0 user.UserModel
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, user.Role) (user.UserModel, error)); ok {
+		return rf(ctx, userID, role)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, user.Role) user.UserModel); ok {
+		r0 = rf(ctx, userID, role)
+	} else {
+		r0 = ret.Get(0).(user.UserModel)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, user.Role) error); ok {
+		r1 = rf(ctx, userID, role)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Unfollow provides a mock function with given fields: ctx, userID
+func (_m *UserService) Unfollow(ctx context.Context, userID string) (user.UserModel, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 user.UserModel
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (user.UserModel, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) user.UserModel); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(user.UserModel)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateProfile provides a mock function with given fields: ctx, input
+func (_m *UserService) UpdateProfile(ctx context.Context, input user.UpdateProfileInput) (user.UserModel, error) {
+	ret := _m.Called(ctx, input)
+
+	var r0 user.UserModel
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, user.UpdateProfileInput) (user.UserModel, error)); ok {
+		return rf(ctx, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, user.UpdateProfileInput) user.UserModel); ok {
+		r0 = rf(ctx, input)
+	} else {
+		r0 = ret.Get(0).(user.UserModel)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, user.UpdateProfileInput) error); ok {
+		r1 = rf(ctx, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewUserService creates a new instance of UserService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewUserService(t interface {
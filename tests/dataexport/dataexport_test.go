@@ -0,0 +1,93 @@
+package dataexport
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/RianNegreiros/go-graphql-api/internal/dataexport"
+	"github.com/RianNegreiros/go-graphql-api/internal/post"
+	"github.com/RianNegreiros/go-graphql-api/internal/user"
+	"github.com/stretchr/testify/require"
+)
+
+func export() dataexport.Export {
+	parentID := "post_id"
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	return dataexport.New(
+		user.UserModel{ID: "user_id", Username: "johndoe", Email: "johndoe@mail.com", Password: "hash"},
+		[]post.Post{
+			{ID: "reply_id", Body: "a reply", ParentID: &parentID},
+			{ID: "post_id", Body: "a post"},
+		},
+		[]user.Session{{ID: "family_id", Name: "firefox"}},
+		now,
+	)
+}
+
+func TestNew(t *testing.T) {
+	e := export()
+
+	require.Equal(t, "johndoe@mail.com", e.Profile.Email)
+	require.Len(t, e.Posts, 1)
+	require.Equal(t, "post_id", e.Posts[0].ID)
+	require.Len(t, e.Replies, 1)
+	require.Equal(t, "reply_id", e.Replies[0].ID)
+	require.Len(t, e.Sessions, 1)
+}
+
+func TestExport_Archive(t *testing.T) {
+	t.Run("json", func(t *testing.T) {
+		archive, err := export().Archive(dataexport.FormatJSON)
+		require.NoError(t, err)
+
+		require.Equal(t, "johndoe-20240301.json", archive.Filename)
+		require.Equal(t, "application/json", archive.ContentType)
+		require.NotContains(t, string(archive.Data), "hash")
+
+		var decoded dataexport.Export
+
+		require.NoError(t, json.Unmarshal(archive.Data, &decoded))
+		require.Equal(t, export(), decoded)
+	})
+
+	t.Run("zip", func(t *testing.T) {
+		archive, err := export().Archive(dataexport.FormatZIP)
+		require.NoError(t, err)
+
+		require.Equal(t, "johndoe-20240301.zip", archive.Filename)
+		require.Equal(t, "application/zip", archive.ContentType)
+
+		r, err := zip.NewReader(bytes.NewReader(archive.Data), int64(len(archive.Data)))
+		require.NoError(t, err)
+
+		files := map[string][]byte{}
+
+		for _, f := range r.File {
+			rc, err := f.Open()
+			require.NoError(t, err)
+
+			data, err := io.ReadAll(rc)
+			require.NoError(t, err)
+			rc.Close()
+
+			files[f.Name] = data
+		}
+
+		require.Len(t, files, 4)
+
+		var replies []dataexport.Post
+
+		require.NoError(t, json.Unmarshal(files["replies.json"], &replies))
+		require.Equal(t, export().Replies, replies)
+	})
+
+	t.Run("unsupported format", func(t *testing.T) {
+		_, err := export().Archive("tar")
+		require.ErrorIs(t, err, dataexport.ErrUnsupportedFormat)
+	})
+}
@@ -0,0 +1,152 @@
+//go:build integration
+
+package domain
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/RianNegreiros/go-graphql-api/internal/dataexport"
+	"github.com/RianNegreiros/go-graphql-api/internal/post"
+	"github.com/RianNegreiros/go-graphql-api/internal/user"
+	"github.com/RianNegreiros/go-graphql-api/tests/test_helpers"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntegrationAccountDeletion(t *testing.T) {
+	t.Run("keeps the replies of other users", func(t *testing.T) {
+		ctx := context.Background()
+
+		defer test_helpers.TeardownDB(ctx, t, db)
+
+		u := test_helpers.CreateUser(ctx, t, userRepo)
+		other := test_helpers.CreateUser(ctx, t, userRepo)
+		loggedIn := test_helpers.LoginUser(ctx, t, u)
+
+		root, err := postService.Create(loggedIn, post.CreatePostInput{Body: "root post"})
+		require.NoError(t, err)
+
+		reply, err := postService.CreateReply(test_helpers.LoginUser(ctx, t, other), root.ID, post.CreatePostInput{Body: "a reply"})
+		require.NoError(t, err)
+
+		alone, err := postService.Create(loggedIn, post.CreatePostInput{Body: "nobody replied"})
+		require.NoError(t, err)
+
+		past := time.Now().Add(-time.Minute)
+
+		_, err = userRepo.ScheduleDeletion(ctx, u.ID, &past)
+		require.NoError(t, err)
+
+		n, err := accountService.DeleteScheduledAccounts(ctx)
+		require.NoError(t, err)
+		require.Equal(t, 1, n)
+
+		anonymized, err := userRepo.GetByID(ctx, u.ID)
+		require.NoError(t, err)
+		require.True(t, anonymized.IsDeleted())
+		require.NotEqual(t, u.Email, anonymized.Email)
+		require.NotEqual(t, u.Username, anonymized.Username)
+
+		_, err = userRepo.GetByEmail(ctx, u.Email)
+		require.ErrorIs(t, err, user.ErrNotFound)
+
+		kept, err := postRepo.GetByID(ctx, root.ID)
+		require.NoError(t, err)
+		require.Equal(t, post.DeletedBody, kept.Body)
+
+		_, err = postRepo.GetByID(ctx, reply.ID)
+		require.NoError(t, err)
+
+		_, err = postRepo.GetByID(ctx, alone.ID)
+		require.ErrorIs(t, err, user.ErrNotFound)
+
+		n, err = accountService.DeleteScheduledAccounts(ctx)
+		require.NoError(t, err)
+		require.Zero(t, n)
+	})
+
+	t.Run("revokes personal access tokens and oauth grants", func(t *testing.T) {
+		ctx := context.Background()
+
+		defer test_helpers.TeardownDB(ctx, t, db)
+
+		u := test_helpers.CreateUser(ctx, t, userRepo)
+		loggedIn := test_helpers.LoginUser(ctx, t, u)
+
+		pat, err := patService.Create(loggedIn, user.CreatePersonalAccessTokenInput{
+			Name:   "bot",
+			Scopes: []user.Scope{user.ScopePostsWrite},
+		})
+		require.NoError(t, err)
+
+		c, err := oauthService.CreateClient(loggedIn, user.CreateOAuthClientInput{
+			Name:         "app",
+			RedirectURIs: []string{"https://app.example.com/callback"},
+			Scopes:       []user.Scope{user.ScopePostsRead},
+		})
+		require.NoError(t, err)
+
+		res, err := oauthService.Token(ctx, user.OAuthTokenInput{
+			OAuthClientCredentials: user.OAuthClientCredentials{ClientID: c.ID},
+			GrantType:              "authorization_code",
+			Code:                   authorizeOAuthClient(loggedIn, t, c, "posts:read"),
+			CodeVerifier:           codeVerifier,
+		})
+		require.NoError(t, err)
+
+		future := time.Now().Add(time.Hour)
+
+		_, err = userRepo.ScheduleDeletion(ctx, u.ID, &future)
+		require.NoError(t, err)
+
+		_, err = patService.Authenticate(ctx, pat.Token)
+		require.Error(t, err)
+
+		_, err = oauthService.Authenticate(ctx, res.AccessToken)
+		require.ErrorIs(t, err, user.ErrInvalidOAuthToken)
+	})
+
+	t.Run("waits for the grace period", func(t *testing.T) {
+		ctx := context.Background()
+
+		defer test_helpers.TeardownDB(ctx, t, db)
+
+		u := test_helpers.CreateUser(ctx, t, userRepo)
+
+		future := time.Now().Add(time.Hour)
+
+		_, err := userRepo.ScheduleDeletion(ctx, u.ID, &future)
+		require.NoError(t, err)
+
+		n, err := accountService.DeleteScheduledAccounts(ctx)
+		require.NoError(t, err)
+		require.Zero(t, n)
+
+		cancelled, err := userRepo.ScheduleDeletion(ctx, u.ID, nil)
+		require.NoError(t, err)
+		require.Nil(t, cancelled.DeletionScheduledAt)
+	})
+}
+
+func TestIntegrationDataExportService(t *testing.T) {
+	t.Run("exports posts and replies", func(t *testing.T) {
+		ctx := context.Background()
+
+		defer test_helpers.TeardownDB(ctx, t, db)
+
+		u := test_helpers.CreateUser(ctx, t, userRepo)
+		loggedIn := test_helpers.LoginUser(ctx, t, u)
+
+		p, err := postService.Create(loggedIn, post.CreatePostInput{Body: "a post"})
+		require.NoError(t, err)
+
+		_, err = postService.CreateReply(loggedIn, p.ID, post.CreatePostInput{Body: "a reply"})
+		require.NoError(t, err)
+
+		archive, err := dataExportService.Export(loggedIn, dataexport.FormatZIP)
+		require.NoError(t, err)
+		require.Equal(t, "application/zip", archive.ContentType)
+		require.NotEmpty(t, archive.Data)
+	})
+}
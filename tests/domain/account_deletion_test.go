@@ -0,0 +1,175 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/RianNegreiros/go-graphql-api/internal/domain"
+	"github.com/RianNegreiros/go-graphql-api/internal/transport"
+	"github.com/RianNegreiros/go-graphql-api/internal/user"
+	jwtMocks "github.com/RianNegreiros/go-graphql-api/mocks/internal_/jwt"
+	mailerMocks "github.com/RianNegreiros/go-graphql-api/mocks/internal_/mailer"
+	mocks "github.com/RianNegreiros/go-graphql-api/mocks/internal_/user"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestAuthService_DeleteAccount(t *testing.T) {
+	hash, err := bcrypt.GenerateFromPassword([]byte("password"), bcrypt.MinCost)
+	require.NoError(t, err)
+
+	current := user.UserModel{ID: "user_id", Email: "johndoe@mail.com", Password: string(hash)}
+
+	t.Run("schedules the deletion", func(t *testing.T) {
+		ctx := transport.PutUserIDIntoContext(context.Background(), "user_id")
+		ctx = transport.PutSessionIDIntoContext(ctx, "family_id")
+
+		userRepo := &mocks.UserRepo{}
+
+		userRepo.On("GetByID", mock.Anything, "user_id").Return(current, nil)
+		userRepo.On("GetByEmail", mock.Anything, current.Email).Return(current, nil)
+		userRepo.On("ScheduleDeletion", mock.Anything, "user_id", mock.MatchedBy(func(at *time.Time) bool {
+			return at != nil && at.Sub(time.Now().Add(user.AccountDeletionGracePeriod)).Abs() < time.Minute
+		})).Return(func(_ context.Context, _ string, at *time.Time) user.UserModel {
+			u := current
+			u.DeletionScheduledAt = at
+			return u
+		}, nil)
+
+		service := domain.NewAuthService(userRepo, &mocks.AuthTokenService{}, &jwtMocks.RefreshTokenRepo{}, &mocks.EmailVerifier{}, loginGuard(), twoFactorDisabled(), passwordHasher(), &mocks.PasskeyVerifier{}, &mocks.LoginLinkRepo{}, &mailerMocks.Mailer{}, &mocks.IdentityVerifier{})
+
+		u, err := service.DeleteAccount(ctx, "password")
+		require.NoError(t, err)
+		require.NotNil(t, u.DeletionScheduledAt)
+
+		userRepo.AssertExpectations(t)
+	})
+
+	t.Run("wrong password", func(t *testing.T) {
+		ctx := transport.PutUserIDIntoContext(context.Background(), "user_id")
+
+		userRepo := &mocks.UserRepo{}
+
+		userRepo.On("GetByID", mock.Anything, "user_id").Return(current, nil)
+		userRepo.On("GetByEmail", mock.Anything, current.Email).Return(current, nil)
+
+		service := domain.NewAuthService(userRepo, &mocks.AuthTokenService{}, &jwtMocks.RefreshTokenRepo{}, &mocks.EmailVerifier{}, loginGuard(), twoFactorDisabled(), passwordHasher(), &mocks.PasskeyVerifier{}, &mocks.LoginLinkRepo{}, &mailerMocks.Mailer{}, &mocks.IdentityVerifier{})
+
+		_, err := service.DeleteAccount(ctx, "wrong_password")
+		require.ErrorIs(t, err, user.ErrInvalidPassword)
+
+		userRepo.AssertNotCalled(t, "ScheduleDeletion", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("already scheduled", func(t *testing.T) {
+		ctx := transport.PutUserIDIntoContext(context.Background(), "user_id")
+
+		scheduled := current
+		at := time.Now().Add(time.Hour)
+		scheduled.DeletionScheduledAt = &at
+
+		userRepo := &mocks.UserRepo{}
+
+		userRepo.On("GetByID", mock.Anything, "user_id").Return(scheduled, nil)
+		userRepo.On("GetByEmail", mock.Anything, current.Email).Return(scheduled, nil)
+
+		service := domain.NewAuthService(userRepo, &mocks.AuthTokenService{}, &jwtMocks.RefreshTokenRepo{}, &mocks.EmailVerifier{}, loginGuard(), twoFactorDisabled(), passwordHasher(), &mocks.PasskeyVerifier{}, &mocks.LoginLinkRepo{}, &mailerMocks.Mailer{}, &mocks.IdentityVerifier{})
+
+		_, err := service.DeleteAccount(ctx, "password")
+		require.ErrorIs(t, err, user.ErrDeletionScheduled)
+	})
+
+	t.Run("scoped tokens can't delete accounts", func(t *testing.T) {
+		ctx := withPersonalAccessToken("user_id", user.ScopeUsersWrite)
+
+		userRepo := &mocks.UserRepo{}
+
+		service := domain.NewAuthService(userRepo, &mocks.AuthTokenService{}, &jwtMocks.RefreshTokenRepo{}, &mocks.EmailVerifier{}, loginGuard(), twoFactorDisabled(), passwordHasher(), &mocks.PasskeyVerifier{}, &mocks.LoginLinkRepo{}, &mailerMocks.Mailer{}, &mocks.IdentityVerifier{})
+
+		_, err := service.DeleteAccount(ctx, "password")
+		require.ErrorIs(t, err, user.ErrSessionRequired)
+
+		userRepo.AssertNotCalled(t, "GetByID", mock.Anything, mock.Anything)
+	})
+}
+
+func TestAuthService_CancelAccountDeletion(t *testing.T) {
+	t.Run("cancels a scheduled deletion", func(t *testing.T) {
+		ctx := transport.PutUserIDIntoContext(context.Background(), "user_id")
+
+		at := time.Now().Add(time.Hour)
+
+		userRepo := &mocks.UserRepo{}
+
+		userRepo.On("GetByID", mock.Anything, "user_id").
+			Return(user.UserModel{ID: "user_id", DeletionScheduledAt: &at}, nil)
+		userRepo.On("ScheduleDeletion", mock.Anything, "user_id", (*time.Time)(nil)).
+			Return(user.UserModel{ID: "user_id"}, nil)
+
+		service := domain.NewAuthService(userRepo, &mocks.AuthTokenService{}, &jwtMocks.RefreshTokenRepo{}, &mocks.EmailVerifier{}, loginGuard(), twoFactorDisabled(), passwordHasher(), &mocks.PasskeyVerifier{}, &mocks.LoginLinkRepo{}, &mailerMocks.Mailer{}, &mocks.IdentityVerifier{})
+
+		u, err := service.CancelAccountDeletion(ctx)
+		require.NoError(t, err)
+		require.Nil(t, u.DeletionScheduledAt)
+
+		userRepo.AssertExpectations(t)
+	})
+
+	t.Run("nothing scheduled", func(t *testing.T) {
+		ctx := transport.PutUserIDIntoContext(context.Background(), "user_id")
+
+		userRepo := &mocks.UserRepo{}
+
+		userRepo.On("GetByID", mock.Anything, "user_id").Return(user.UserModel{ID: "user_id"}, nil)
+
+		service := domain.NewAuthService(userRepo, &mocks.AuthTokenService{}, &jwtMocks.RefreshTokenRepo{}, &mocks.EmailVerifier{}, loginGuard(), twoFactorDisabled(), passwordHasher(), &mocks.PasskeyVerifier{}, &mocks.LoginLinkRepo{}, &mailerMocks.Mailer{}, &mocks.IdentityVerifier{})
+
+		_, err := service.CancelAccountDeletion(ctx)
+		require.ErrorIs(t, err, user.ErrDeletionNotScheduled)
+
+		userRepo.AssertNotCalled(t, "ScheduleDeletion", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestAccountService_DeleteScheduledAccounts(t *testing.T) {
+	t.Run("anonymizes the accounts due", func(t *testing.T) {
+		ctx := context.Background()
+
+		userRepo := &mocks.UserRepo{}
+
+		userRepo.On("GetDueForDeletion", mock.Anything, mock.Anything).Return([]string{"a", "b"}, nil)
+		userRepo.On("Anonymize", mock.Anything, "a").Return(nil)
+		userRepo.On("Anonymize", mock.Anything, "b").Return(nil)
+
+		service := domain.NewAccountService(userRepo, &jwtMocks.RefreshTokenRepo{}, &mocks.PasswordResetRepo{}, &mocks.AuthTokenService{}, &mailerMocks.Mailer{}, passwordHasher())
+
+		n, err := service.DeleteScheduledAccounts(ctx)
+		require.NoError(t, err)
+		require.Equal(t, 2, n)
+
+		userRepo.AssertExpectations(t)
+	})
+
+	t.Run("carries on past errors", func(t *testing.T) {
+		ctx := context.Background()
+
+		boom := errors.New("boom")
+
+		userRepo := &mocks.UserRepo{}
+
+		userRepo.On("GetDueForDeletion", mock.Anything, mock.Anything).Return([]string{"a", "b"}, nil)
+		userRepo.On("Anonymize", mock.Anything, "a").Return(boom)
+		userRepo.On("Anonymize", mock.Anything, "b").Return(nil)
+
+		service := domain.NewAccountService(userRepo, &jwtMocks.RefreshTokenRepo{}, &mocks.PasswordResetRepo{}, &mocks.AuthTokenService{}, &mailerMocks.Mailer{}, passwordHasher())
+
+		n, err := service.DeleteScheduledAccounts(ctx)
+		require.ErrorIs(t, err, boom)
+		require.Equal(t, 1, n)
+
+		userRepo.AssertExpectations(t)
+	})
+}
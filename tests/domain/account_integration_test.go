@@ -0,0 +1,59 @@
+//go:build integration
+
+package domain
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/RianNegreiros/go-graphql-api/internal/domain"
+	"github.com/RianNegreiros/go-graphql-api/internal/jwt"
+	"github.com/RianNegreiros/go-graphql-api/internal/user"
+	"github.com/RianNegreiros/go-graphql-api/tests/test_helpers"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntegrationAccountService_ResetPassword(t *testing.T) {
+	t.Run("reset tokens are single use and revoke sessions", func(t *testing.T) {
+		ctx := context.Background()
+
+		defer test_helpers.TeardownDB(ctx, t, db)
+
+		u := test_helpers.CreateUser(ctx, t, userRepo)
+
+		_, err := refreshTokenRepo.Create(ctx, jwt.CreateRefreshTokenParams{Sub: u.ID})
+		require.NoError(t, err)
+
+		m, sent := sentMessages(nil)
+
+		service := domain.NewAccountService(userRepo, refreshTokenRepo, resetRepo, authTokenService, m, passwordHasher())
+
+		require.NoError(t, service.RequestPasswordReset(ctx, u.Email))
+
+		_, token, found := strings.Cut(receiveMessage(t, sent).Body, "?token=")
+		require.True(t, found)
+		token = strings.Fields(token)[0]
+
+		input := user.ResetPasswordInput{
+			Token:           token,
+			Password:        "new_password",
+			ConfirmPassword: "new_password",
+		}
+
+		require.NoError(t, service.ResetPassword(ctx, input))
+
+		err = service.ResetPassword(ctx, input)
+		require.ErrorIs(t, err, user.ErrInvalidResetToken)
+
+		sessions, err := refreshTokenRepo.GetActiveByUserID(ctx, u.ID)
+		require.NoError(t, err)
+		require.Empty(t, sessions)
+
+		_, err = authService.Login(ctx, user.LoginInput{
+			Email:    u.Email,
+			Password: "new_password",
+		})
+		require.NoError(t, err)
+	})
+}
@@ -0,0 +1,309 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/RianNegreiros/go-graphql-api/internal/domain"
+	"github.com/RianNegreiros/go-graphql-api/internal/mailer"
+	"github.com/RianNegreiros/go-graphql-api/internal/randtoken"
+	"github.com/RianNegreiros/go-graphql-api/internal/transport"
+	"github.com/RianNegreiros/go-graphql-api/internal/user"
+	jwtMocks "github.com/RianNegreiros/go-graphql-api/mocks/internal_/jwt"
+	mailerMocks "github.com/RianNegreiros/go-graphql-api/mocks/internal_/mailer"
+	mocks "github.com/RianNegreiros/go-graphql-api/mocks/internal_/user"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// sentMessages returns a mailer that answers err and passes on every message
+// it is asked to send. Emails go out in the background.
+func sentMessages(err error) (*mailerMocks.Mailer, <-chan mailer.Message) {
+	sent := make(chan mailer.Message, 1)
+
+	m := &mailerMocks.Mailer{}
+
+	m.On("Send", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			sent <- args.Get(1).(mailer.Message)
+		}).
+		Return(err)
+
+	return m, sent
+}
+
+func receiveMessage(t *testing.T, sent <-chan mailer.Message) mailer.Message {
+	t.Helper()
+
+	select {
+	case msg := <-sent:
+		return msg
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for email")
+		return mailer.Message{}
+	}
+}
+
+func TestAccountService_RequestPasswordReset(t *testing.T) {
+	t.Run("emails a reset link", func(t *testing.T) {
+		ctx := context.Background()
+
+		userRepo := &mocks.UserRepo{}
+
+		userRepo.On("GetByEmail", mock.Anything, "johndoe@mail.com").
+			Return(user.UserModel{ID: "user_id", Email: "johndoe@mail.com"}, nil)
+
+		var storedHash string
+
+		resetRepo := &mocks.PasswordResetRepo{}
+
+		resetRepo.On("Create", mock.Anything, "user_id", mock.Anything).
+			Run(func(args mock.Arguments) {
+				storedHash = args.String(2)
+			}).
+			Return(user.PasswordResetToken{}, nil)
+
+		m, sent := sentMessages(nil)
+
+		service := domain.NewAccountService(userRepo, &jwtMocks.RefreshTokenRepo{}, resetRepo, &mocks.AuthTokenService{}, m, passwordHasher())
+
+		err := service.RequestPasswordReset(ctx, " JohnDoe@mail.com ")
+		require.NoError(t, err)
+
+		msg := receiveMessage(t, sent)
+		require.Equal(t, "johndoe@mail.com", msg.To)
+
+		_, token, found := strings.Cut(msg.Body, "?token=")
+		require.True(t, found)
+		token = strings.Fields(token)[0]
+
+		require.Equal(t, storedHash, randtoken.Hash(token))
+		require.NotContains(t, msg.Body, storedHash)
+	})
+
+	t.Run("mail failures are not reported", func(t *testing.T) {
+		ctx := context.Background()
+
+		userRepo := &mocks.UserRepo{}
+
+		userRepo.On("GetByEmail", mock.Anything, "johndoe@mail.com").
+			Return(user.UserModel{ID: "user_id", Email: "johndoe@mail.com"}, nil)
+
+		resetRepo := &mocks.PasswordResetRepo{}
+
+		resetRepo.On("Create", mock.Anything, "user_id", mock.Anything).
+			Return(user.PasswordResetToken{}, nil)
+
+		m, sent := sentMessages(errors.New("smtp unavailable"))
+
+		service := domain.NewAccountService(userRepo, &jwtMocks.RefreshTokenRepo{}, resetRepo, &mocks.AuthTokenService{}, m, passwordHasher())
+
+		err := service.RequestPasswordReset(ctx, "johndoe@mail.com")
+		require.NoError(t, err)
+
+		receiveMessage(t, sent)
+	})
+
+	t.Run("unknown email succeeds without sending", func(t *testing.T) {
+		ctx := context.Background()
+
+		userRepo := &mocks.UserRepo{}
+
+		userRepo.On("GetByEmail", mock.Anything, mock.Anything).
+			Return(user.UserModel{}, user.ErrNotFound)
+
+		m := &mailerMocks.Mailer{}
+
+		service := domain.NewAccountService(userRepo, &jwtMocks.RefreshTokenRepo{}, &mocks.PasswordResetRepo{}, &mocks.AuthTokenService{}, m, passwordHasher())
+
+		err := service.RequestPasswordReset(ctx, "nobody@mail.com")
+		require.NoError(t, err)
+
+		m.AssertNotCalled(t, "Send")
+	})
+}
+
+func TestAccountService_ResetPassword(t *testing.T) {
+	validInput := user.ResetPasswordInput{
+		Token:           "token",
+		Password:        "new_password",
+		ConfirmPassword: "new_password",
+	}
+
+	t.Run("sets the password and revokes every session", func(t *testing.T) {
+		ctx := context.Background()
+
+		resetRepo := &mocks.PasswordResetRepo{}
+
+		resetRepo.On("Reset", mock.Anything, randtoken.Hash("token"), mock.Anything).
+			Return(user.PasswordResetToken{UserID: "user_id"}, nil)
+
+		service := domain.NewAccountService(&mocks.UserRepo{}, &jwtMocks.RefreshTokenRepo{}, resetRepo, &mocks.AuthTokenService{}, &mailerMocks.Mailer{}, passwordHasher())
+
+		err := service.ResetPassword(ctx, validInput)
+		require.NoError(t, err)
+
+		resetRepo.AssertExpectations(t)
+	})
+
+	t.Run("invalid token", func(t *testing.T) {
+		ctx := context.Background()
+
+		resetRepo := &mocks.PasswordResetRepo{}
+
+		resetRepo.On("Reset", mock.Anything, mock.Anything, mock.Anything).
+			Return(user.PasswordResetToken{}, user.ErrNotFound)
+
+		service := domain.NewAccountService(&mocks.UserRepo{}, &jwtMocks.RefreshTokenRepo{}, resetRepo, &mocks.AuthTokenService{}, &mailerMocks.Mailer{}, passwordHasher())
+
+		err := service.ResetPassword(ctx, validInput)
+		require.ErrorIs(t, err, user.ErrInvalidResetToken)
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		ctx := context.Background()
+
+		resetRepo := &mocks.PasswordResetRepo{}
+
+		service := domain.NewAccountService(&mocks.UserRepo{}, &jwtMocks.RefreshTokenRepo{}, resetRepo, &mocks.AuthTokenService{}, &mailerMocks.Mailer{}, passwordHasher())
+
+		input := validInput
+		input.ConfirmPassword = "other_password"
+
+		err := service.ResetPassword(ctx, input)
+		require.ErrorIs(t, err, user.ErrValidation)
+
+		resetRepo.AssertNotCalled(t, "Reset")
+	})
+}
+
+func TestAccountService_VerifyEmail(t *testing.T) {
+	t.Run("marks the email as verified", func(t *testing.T) {
+		ctx := context.Background()
+
+		authTokenService := &mocks.AuthTokenService{}
+
+		authTokenService.On("ParseEmailVerificationToken", mock.Anything, "token").
+			Return(user.EmailVerificationToken{Sub: "user_id", Email: "johndoe@mail.com"}, nil)
+
+		userRepo := &mocks.UserRepo{}
+
+		userRepo.On("GetByID", mock.Anything, "user_id").
+			Return(user.UserModel{ID: "user_id", Email: "johndoe@mail.com"}, nil)
+
+		verifiedAt := time.Now()
+
+		userRepo.On("MarkEmailVerified", mock.Anything, "user_id").
+			Return(user.UserModel{ID: "user_id", EmailVerifiedAt: &verifiedAt}, nil)
+
+		service := domain.NewAccountService(userRepo, &jwtMocks.RefreshTokenRepo{}, &mocks.PasswordResetRepo{}, authTokenService, &mailerMocks.Mailer{}, passwordHasher())
+
+		u, err := service.VerifyEmail(ctx, "token")
+		require.NoError(t, err)
+		require.True(t, u.IsEmailVerified())
+	})
+
+	t.Run("token issued for a previous email", func(t *testing.T) {
+		ctx := context.Background()
+
+		authTokenService := &mocks.AuthTokenService{}
+
+		authTokenService.On("ParseEmailVerificationToken", mock.Anything, "token").
+			Return(user.EmailVerificationToken{Sub: "user_id", Email: "old@mail.com"}, nil)
+
+		userRepo := &mocks.UserRepo{}
+
+		userRepo.On("GetByID", mock.Anything, "user_id").
+			Return(user.UserModel{ID: "user_id", Email: "johndoe@mail.com"}, nil)
+
+		service := domain.NewAccountService(userRepo, &jwtMocks.RefreshTokenRepo{}, &mocks.PasswordResetRepo{}, authTokenService, &mailerMocks.Mailer{}, passwordHasher())
+
+		_, err := service.VerifyEmail(ctx, "token")
+		require.ErrorIs(t, err, user.ErrInvalidVerificationToken)
+
+		userRepo.AssertNotCalled(t, "MarkEmailVerified")
+	})
+
+	t.Run("invalid token", func(t *testing.T) {
+		ctx := context.Background()
+
+		authTokenService := &mocks.AuthTokenService{}
+
+		authTokenService.On("ParseEmailVerificationToken", mock.Anything, mock.Anything).
+			Return(user.EmailVerificationToken{}, user.ErrInvalidToken)
+
+		service := domain.NewAccountService(&mocks.UserRepo{}, &jwtMocks.RefreshTokenRepo{}, &mocks.PasswordResetRepo{}, authTokenService, &mailerMocks.Mailer{}, passwordHasher())
+
+		_, err := service.VerifyEmail(ctx, "token")
+		require.ErrorIs(t, err, user.ErrInvalidVerificationToken)
+	})
+}
+
+func TestAccountService_ResendVerification(t *testing.T) {
+	t.Run("sends a new verification email", func(t *testing.T) {
+		ctx := transport.PutUserIDIntoContext(context.Background(), "user_id")
+
+		userRepo := &mocks.UserRepo{}
+
+		userRepo.On("GetByID", mock.Anything, "user_id").
+			Return(user.UserModel{ID: "user_id", Email: "johndoe@mail.com"}, nil)
+
+		authTokenService := &mocks.AuthTokenService{}
+
+		authTokenService.On("CreateEmailVerificationToken", mock.Anything, mock.Anything).
+			Return("token", nil)
+
+		m := &mailerMocks.Mailer{}
+
+		m.On("Send", mock.Anything, mock.MatchedBy(func(msg mailer.Message) bool {
+			return msg.To == "johndoe@mail.com" && strings.Contains(msg.Body, "?token=token")
+		})).Return(nil)
+
+		service := domain.NewAccountService(userRepo, &jwtMocks.RefreshTokenRepo{}, &mocks.PasswordResetRepo{}, authTokenService, m, passwordHasher())
+
+		require.NoError(t, service.ResendVerification(ctx))
+
+		m.AssertExpectations(t)
+	})
+
+	t.Run("already verified", func(t *testing.T) {
+		ctx := transport.PutUserIDIntoContext(context.Background(), "user_id")
+
+		verifiedAt := time.Now()
+
+		userRepo := &mocks.UserRepo{}
+
+		userRepo.On("GetByID", mock.Anything, "user_id").
+			Return(user.UserModel{ID: "user_id", EmailVerifiedAt: &verifiedAt}, nil)
+
+		m := &mailerMocks.Mailer{}
+
+		service := domain.NewAccountService(userRepo, &jwtMocks.RefreshTokenRepo{}, &mocks.PasswordResetRepo{}, &mocks.AuthTokenService{}, m, passwordHasher())
+
+		err := service.ResendVerification(ctx)
+		require.ErrorIs(t, err, user.ErrEmailAlreadyVerified)
+
+		m.AssertNotCalled(t, "Send")
+	})
+
+	t.Run("scoped tokens can't resend it", func(t *testing.T) {
+		userRepo := &mocks.UserRepo{}
+
+		service := domain.NewAccountService(userRepo, &jwtMocks.RefreshTokenRepo{}, &mocks.PasswordResetRepo{}, &mocks.AuthTokenService{}, &mailerMocks.Mailer{}, passwordHasher())
+
+		err := service.ResendVerification(withPersonalAccessToken("user_id", user.ScopeUsersWrite))
+		require.ErrorIs(t, err, user.ErrSessionRequired)
+
+		userRepo.AssertNotCalled(t, "GetByID", mock.Anything, mock.Anything)
+	})
+
+	t.Run("unauthenticated", func(t *testing.T) {
+		service := domain.NewAccountService(&mocks.UserRepo{}, &jwtMocks.RefreshTokenRepo{}, &mocks.PasswordResetRepo{}, &mocks.AuthTokenService{}, &mailerMocks.Mailer{}, passwordHasher())
+
+		err := service.ResendVerification(context.Background())
+		require.ErrorIs(t, err, user.ErrUnauthenticated)
+	})
+}
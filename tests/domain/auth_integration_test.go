@@ -4,10 +4,23 @@ package domain
 
 import (
 	"context"
+	"errors"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/RianNegreiros/go-graphql-api/internal/domain"
+	"github.com/RianNegreiros/go-graphql-api/internal/jwt"
+	"github.com/RianNegreiros/go-graphql-api/internal/loginlimit"
+	"github.com/RianNegreiros/go-graphql-api/internal/mailer"
+	"github.com/RianNegreiros/go-graphql-api/internal/transport"
 	"github.com/RianNegreiros/go-graphql-api/internal/user"
+	mailerMocks "github.com/RianNegreiros/go-graphql-api/mocks/internal_/mailer"
+	"github.com/RianNegreiros/go-graphql-api/tests/faker"
 	"github.com/RianNegreiros/go-graphql-api/tests/test_helpers"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
@@ -70,3 +83,245 @@ func TestIntegrationAuthService_Register(t *testing.T) {
 		require.ErrorIs(t, err, user.ErrEmailTaken)
 	})
 }
+
+func TestIntegrationAuthService_RefreshToken(t *testing.T) {
+	validInput := user.RegisterInput{
+		Username:        "john",
+		Email:           "johndoe@mail.com",
+		Password:        "123456",
+		ConfirmPassword: "123456",
+	}
+
+	t.Run("rotates the refresh token", func(t *testing.T) {
+		ctx := context.Background()
+
+		defer test_helpers.TeardownDB(ctx, t, db)
+
+		registered, err := authService.Register(ctx, validInput)
+		require.NoError(t, err)
+
+		res, err := authService.RefreshToken(ctx, registered.RefreshToken)
+		require.NoError(t, err)
+
+		require.NotEmpty(t, res.AccessToken)
+		require.NotEmpty(t, res.RefreshToken)
+		require.NotEqual(t, registered.RefreshToken, res.RefreshToken)
+		require.Equal(t, registered.User.ID, res.User.ID)
+	})
+
+	t.Run("keeps when the session was opened", func(t *testing.T) {
+		ctx := context.Background()
+
+		defer test_helpers.TeardownDB(ctx, t, db)
+
+		registered, err := authService.Register(ctx, validInput)
+		require.NoError(t, err)
+
+		loggedIn := transport.PutUserIDIntoContext(ctx, registered.User.ID)
+
+		opened, err := authService.Sessions(loggedIn)
+		require.NoError(t, err)
+		require.Len(t, opened, 1)
+
+		time.Sleep(10 * time.Millisecond)
+
+		_, err = authService.RefreshToken(ctx, registered.RefreshToken)
+		require.NoError(t, err)
+
+		sessions, err := authService.Sessions(loggedIn)
+		require.NoError(t, err)
+		require.Len(t, sessions, 1)
+		require.Equal(t, opened[0].ID, sessions[0].ID)
+		require.True(t, opened[0].CreatedAt.Equal(sessions[0].CreatedAt))
+	})
+
+	t.Run("reusing a rotated token revokes the family", func(t *testing.T) {
+		ctx := context.Background()
+
+		defer test_helpers.TeardownDB(ctx, t, db)
+
+		registered, err := authService.Register(ctx, validInput)
+		require.NoError(t, err)
+
+		rotated, err := authService.RefreshToken(ctx, registered.RefreshToken)
+		require.NoError(t, err)
+
+		_, err = authService.RefreshToken(ctx, registered.RefreshToken)
+		require.ErrorIs(t, err, user.ErrInvalidToken)
+
+		_, err = authService.RefreshToken(ctx, rotated.RefreshToken)
+		require.ErrorIs(t, err, user.ErrInvalidToken)
+	})
+}
+
+func TestIntegrationAuthService_ChangeEmail(t *testing.T) {
+	t.Run("switches the email once confirmed and keeps the current session", func(t *testing.T) {
+		ctx := context.Background()
+
+		defer test_helpers.TeardownDB(ctx, t, db)
+
+		u := test_helpers.CreateUser(ctx, t, userRepo)
+
+		current, err := refreshTokenRepo.Create(ctx, jwt.CreateRefreshTokenParams{Sub: u.ID})
+		require.NoError(t, err)
+
+		_, err = refreshTokenRepo.Create(ctx, jwt.CreateRefreshTokenParams{Sub: u.ID})
+		require.NoError(t, err)
+
+		var sent mailer.Message
+
+		m := &mailerMocks.Mailer{}
+
+		m.On("Send", mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) {
+				sent = args.Get(1).(mailer.Message)
+			}).
+			Return(nil)
+
+		accounts := domain.NewAccountService(userRepo, refreshTokenRepo, resetRepo, authTokenService, m, passwordHasher())
+		service := domain.NewAuthService(userRepo, authTokenService, refreshTokenRepo, accounts, loginlimit.New(loginlimit.NewMemory()), twoFactorService, passwordHasher(), passkeyService, loginLinkRepo, m, socialLogin)
+
+		loggedIn := test_helpers.LoginUser(ctx, t, u)
+		loggedIn = transport.PutSessionIDIntoContext(loggedIn, current.FamilyID)
+
+		newEmail := faker.Email()
+
+		require.NoError(t, service.ChangeEmail(loggedIn, user.ChangeEmailInput{
+			Email:    newEmail,
+			Password: "password",
+		}))
+		require.Equal(t, newEmail, sent.To)
+
+		unchanged, err := userRepo.GetByID(ctx, u.ID)
+		require.NoError(t, err)
+		require.Equal(t, u.Email, unchanged.Email)
+
+		sessions, err := refreshTokenRepo.GetActiveByUserID(ctx, u.ID)
+		require.NoError(t, err)
+		require.Len(t, sessions, 1)
+		require.Equal(t, current.FamilyID, sessions[0].FamilyID)
+
+		_, token, found := strings.Cut(sent.Body, "?token=")
+		require.True(t, found)
+		token = strings.Fields(token)[0]
+
+		changed, err := service.ConfirmEmailChange(ctx, token)
+		require.NoError(t, err)
+		require.Equal(t, newEmail, changed.Email)
+		require.True(t, changed.IsEmailVerified())
+
+		_, err = service.ConfirmEmailChange(ctx, token)
+		require.ErrorIs(t, err, user.ErrInvalidEmailChange)
+	})
+}
+
+func TestIntegrationAuthService_LoginLink(t *testing.T) {
+	t.Run("logs in once with the emailed link", func(t *testing.T) {
+		ctx := context.Background()
+
+		defer test_helpers.TeardownDB(ctx, t, db)
+
+		u := test_helpers.CreateUser(ctx, t, userRepo)
+
+		m, messages := sentMessages(nil)
+
+		service := domain.NewAuthService(userRepo, authTokenService, refreshTokenRepo, accountService, loginlimit.New(loginlimit.NewMemory()), twoFactorService, passwordHasher(), passkeyService, loginLinkRepo, m, socialLogin)
+
+		require.NoError(t, service.RequestLoginLink(ctx, u.Email))
+
+		sent := receiveMessage(t, messages)
+		require.Equal(t, u.Email, sent.To)
+
+		_, token, found := strings.Cut(sent.Body, "?token=")
+		require.True(t, found)
+		token = strings.Fields(token)[0]
+
+		res, err := service.ConsumeLoginLink(ctx, token)
+		require.NoError(t, err)
+		require.Equal(t, u.ID, res.User.ID)
+		require.True(t, res.User.IsEmailVerified())
+
+		sessions, err := refreshTokenRepo.GetActiveByUserID(ctx, u.ID)
+		require.NoError(t, err)
+		require.Len(t, sessions, 1)
+
+		_, err = service.ConsumeLoginLink(ctx, token)
+		require.ErrorIs(t, err, user.ErrInvalidLoginLink)
+	})
+}
+
+func TestIntegrationAuthService_LoginLockout(t *testing.T) {
+	t.Run("backs off after repeated failures and resets on success", func(t *testing.T) {
+		ctx := context.Background()
+
+		defer test_helpers.TeardownDB(ctx, t, db)
+
+		u := test_helpers.CreateUser(ctx, t, userRepo)
+
+		wrong := user.LoginInput{Email: u.Email, Password: "wrong_password"}
+
+		for i := 0; i < loginlimit.AccountPolicy.FreeAttempts; i++ {
+			_, err := authService.Login(ctx, wrong)
+			require.ErrorIs(t, err, user.ErrInvalidCredentials)
+		}
+
+		_, err := authService.Login(ctx, user.LoginInput{Email: u.Email, Password: "password"})
+		require.ErrorIs(t, err, user.ErrLoginLocked)
+
+		require.NoError(t, authService.LoginGuard.Succeed(ctx, u.Email, ""))
+
+		_, err = authService.Login(ctx, user.LoginInput{Email: u.Email, Password: "password"})
+		require.NoError(t, err)
+	})
+
+	t.Run("parallel guesses can't get past the free attempts", func(t *testing.T) {
+		ctx := context.Background()
+
+		defer test_helpers.TeardownDB(ctx, t, db)
+
+		u := test_helpers.CreateUser(ctx, t, userRepo)
+
+		var (
+			wg      sync.WaitGroup
+			checked atomic.Int32
+		)
+
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+
+			go func() {
+				defer wg.Done()
+
+				_, err := authService.Login(ctx, user.LoginInput{Email: u.Email, Password: "wrong_password"})
+				if errors.Is(err, user.ErrInvalidCredentials) {
+					checked.Add(1)
+				}
+			}()
+		}
+
+		wg.Wait()
+
+		require.Equal(t, int32(loginlimit.AccountPolicy.FreeAttempts), checked.Load())
+	})
+}
+
+func TestIntegrationAuthService_LoginRehash(t *testing.T) {
+	t.Run("upgrades a bcrypt hash on login", func(t *testing.T) {
+		ctx := context.Background()
+
+		defer test_helpers.TeardownDB(ctx, t, db)
+
+		u := test_helpers.CreateUser(ctx, t, userRepo)
+		require.True(t, authService.PasswordHasher.NeedsRehash(u.Password))
+
+		_, err := authService.Login(ctx, user.LoginInput{Email: u.Email, Password: "password"})
+		require.NoError(t, err)
+
+		upgraded, err := userRepo.GetByID(ctx, u.ID)
+		require.NoError(t, err)
+		require.False(t, authService.PasswordHasher.NeedsRehash(upgraded.Password))
+
+		_, err = authService.Login(ctx, user.LoginInput{Email: u.Email, Password: "password"})
+		require.NoError(t, err)
+	})
+}
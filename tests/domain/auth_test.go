@@ -4,15 +4,54 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/RianNegreiros/go-graphql-api/internal/domain"
+	"github.com/RianNegreiros/go-graphql-api/internal/jwt"
+	"github.com/RianNegreiros/go-graphql-api/internal/password"
+	"github.com/RianNegreiros/go-graphql-api/internal/transport"
 	"github.com/RianNegreiros/go-graphql-api/internal/user"
+	jwtMocks "github.com/RianNegreiros/go-graphql-api/mocks/internal_/jwt"
+	mailerMocks "github.com/RianNegreiros/go-graphql-api/mocks/internal_/mailer"
 	mocks "github.com/RianNegreiros/go-graphql-api/mocks/internal_/user"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"golang.org/x/crypto/bcrypt"
 )
 
+// loginGuard returns a LoginGuard which never locks anyone out.
+func loginGuard() *mocks.LoginGuard {
+	lg := &mocks.LoginGuard{}
+
+	lg.On("Allow", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	lg.On("Release", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	lg.On("Succeed", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	return lg
+}
+
+// twoFactorDisabled returns a TwoFactorVerifier for users without two-factor
+// authentication.
+func twoFactorDisabled() *mocks.TwoFactorVerifier {
+	tf := &mocks.TwoFactorVerifier{}
+
+	tf.On("IsEnabled", mock.Anything, mock.Anything).Return(false, nil)
+
+	return tf
+}
+
+// passwordHasher returns a hasher with the cheapest argon2id parameters, so
+// tests don't spend their time hashing.
+func passwordHasher() *password.Hasher {
+	return password.New(password.Params{
+		Memory:      64,
+		Iterations:  1,
+		Parallelism: 1,
+		SaltLength:  16,
+		KeyLength:   32,
+	})
+}
+
 func TestAuthService_Register(t *testing.T) {
 	validInput := user.RegisterInput{
 		Username:        "john",
@@ -42,15 +81,30 @@ func TestAuthService_Register(t *testing.T) {
 
 		authTokenService := &mocks.AuthTokenService{}
 
-		authTokenService.On("CreateAccessToken", mock.Anything, mock.Anything).
+		authTokenService.On("CreateAccessToken", mock.Anything, mock.Anything, mock.Anything).
 			Return("access_token", nil)
 
-		service := domain.NewAuthService(userRepo, authTokenService)
+		authTokenService.On("CreateRefreshToken", mock.Anything, mock.Anything, "refresh_token_id").
+			Return("refresh_token", nil)
+
+		refreshTokenRepo := &jwtMocks.RefreshTokenRepo{}
+
+		refreshTokenRepo.On("Create", mock.Anything, mock.Anything).
+			Return(jwt.RefreshToken{ID: "refresh_token_id"}, nil)
+
+		emailVerifier := &mocks.EmailVerifier{}
+
+		emailVerifier.On("SendVerificationEmail", mock.Anything, mock.MatchedBy(func(u user.UserModel) bool {
+			return u.ID == "user_id"
+		})).Return(nil)
+
+		service := domain.NewAuthService(userRepo, authTokenService, refreshTokenRepo, emailVerifier, loginGuard(), twoFactorDisabled(), passwordHasher(), &mocks.PasskeyVerifier{}, &mocks.LoginLinkRepo{}, &mailerMocks.Mailer{}, &mocks.IdentityVerifier{})
 
 		res, err := service.Register(ctx, validInput)
 		require.NoError(t, err)
 
 		require.NotEmpty(t, res.AccessToken)
+		require.NotEmpty(t, res.RefreshToken)
 		require.NotEmpty(t, res.User.ID)
 		require.Equal(t, validInput.Username, res.User.Username)
 		require.Equal(t, validInput.Email, res.User.Email)
@@ -58,6 +112,46 @@ func TestAuthService_Register(t *testing.T) {
 
 		userRepo.AssertExpectations(t)
 		authTokenService.AssertExpectations(t)
+		refreshTokenRepo.AssertExpectations(t)
+		emailVerifier.AssertExpectations(t)
+	})
+
+	t.Run("verification email failure doesn't fail registration", func(t *testing.T) {
+		ctx := context.Background()
+
+		userRepo := &mocks.UserRepo{}
+
+		userRepo.On("GetByUsername", mock.Anything, mock.Anything).
+			Return(user.UserModel{}, user.ErrNotFound)
+
+		userRepo.On("GetByEmail", mock.Anything, mock.Anything).
+			Return(user.UserModel{}, user.ErrNotFound)
+
+		userRepo.On("Create", mock.Anything, mock.Anything).
+			Return(user.UserModel{ID: "user_id"}, nil)
+
+		authTokenService := &mocks.AuthTokenService{}
+
+		authTokenService.On("CreateAccessToken", mock.Anything, mock.Anything, mock.Anything).
+			Return("access_token", nil)
+
+		authTokenService.On("CreateRefreshToken", mock.Anything, mock.Anything, mock.Anything).
+			Return("refresh_token", nil)
+
+		refreshTokenRepo := &jwtMocks.RefreshTokenRepo{}
+
+		refreshTokenRepo.On("Create", mock.Anything, mock.Anything).
+			Return(jwt.RefreshToken{ID: "refresh_token_id"}, nil)
+
+		emailVerifier := &mocks.EmailVerifier{}
+
+		emailVerifier.On("SendVerificationEmail", mock.Anything, mock.Anything).
+			Return(errors.New("smtp down"))
+
+		service := domain.NewAuthService(userRepo, authTokenService, refreshTokenRepo, emailVerifier, loginGuard(), twoFactorDisabled(), passwordHasher(), &mocks.PasskeyVerifier{}, &mocks.LoginLinkRepo{}, &mailerMocks.Mailer{}, &mocks.IdentityVerifier{})
+
+		_, err := service.Register(ctx, validInput)
+		require.NoError(t, err)
 	})
 
 	t.Run("username taken", func(t *testing.T) {
@@ -70,7 +164,9 @@ func TestAuthService_Register(t *testing.T) {
 
 		authTokenService := &mocks.AuthTokenService{}
 
-		service := domain.NewAuthService(userRepo, authTokenService)
+		refreshTokenRepo := &jwtMocks.RefreshTokenRepo{}
+
+		service := domain.NewAuthService(userRepo, authTokenService, refreshTokenRepo, &mocks.EmailVerifier{}, loginGuard(), twoFactorDisabled(), passwordHasher(), &mocks.PasskeyVerifier{}, &mocks.LoginLinkRepo{}, &mailerMocks.Mailer{}, &mocks.IdentityVerifier{})
 
 		_, err := service.Register(ctx, validInput)
 		require.ErrorIs(t, err, user.ErrUsernameTaken)
@@ -78,6 +174,7 @@ func TestAuthService_Register(t *testing.T) {
 		userRepo.AssertNotCalled(t, "Create")
 		userRepo.AssertExpectations(t)
 		authTokenService.AssertExpectations(t)
+		refreshTokenRepo.AssertExpectations(t)
 	})
 
 	t.Run("email taken", func(t *testing.T) {
@@ -93,7 +190,9 @@ func TestAuthService_Register(t *testing.T) {
 
 		authTokenService := &mocks.AuthTokenService{}
 
-		service := domain.NewAuthService(userRepo, authTokenService)
+		refreshTokenRepo := &jwtMocks.RefreshTokenRepo{}
+
+		service := domain.NewAuthService(userRepo, authTokenService, refreshTokenRepo, &mocks.EmailVerifier{}, loginGuard(), twoFactorDisabled(), passwordHasher(), &mocks.PasskeyVerifier{}, &mocks.LoginLinkRepo{}, &mailerMocks.Mailer{}, &mocks.IdentityVerifier{})
 
 		_, err := service.Register(ctx, validInput)
 		require.ErrorIs(t, err, user.ErrEmailTaken)
@@ -101,6 +200,7 @@ func TestAuthService_Register(t *testing.T) {
 		userRepo.AssertNotCalled(t, "Create")
 		userRepo.AssertExpectations(t)
 		authTokenService.AssertExpectations(t)
+		refreshTokenRepo.AssertExpectations(t)
 	})
 
 	t.Run("error creating user", func(t *testing.T) {
@@ -119,13 +219,16 @@ func TestAuthService_Register(t *testing.T) {
 
 		authTokenService := &mocks.AuthTokenService{}
 
-		service := domain.NewAuthService(userRepo, authTokenService)
+		refreshTokenRepo := &jwtMocks.RefreshTokenRepo{}
+
+		service := domain.NewAuthService(userRepo, authTokenService, refreshTokenRepo, &mocks.EmailVerifier{}, loginGuard(), twoFactorDisabled(), passwordHasher(), &mocks.PasskeyVerifier{}, &mocks.LoginLinkRepo{}, &mailerMocks.Mailer{}, &mocks.IdentityVerifier{})
 
 		_, err := service.Register(ctx, validInput)
 		require.Error(t, err)
 
 		userRepo.AssertExpectations(t)
 		authTokenService.AssertExpectations(t)
+		refreshTokenRepo.AssertExpectations(t)
 	})
 
 	t.Run("invalid input", func(t *testing.T) {
@@ -135,7 +238,9 @@ func TestAuthService_Register(t *testing.T) {
 
 		authTokenService := &mocks.AuthTokenService{}
 
-		service := domain.NewAuthService(userRepo, authTokenService)
+		refreshTokenRepo := &jwtMocks.RefreshTokenRepo{}
+
+		service := domain.NewAuthService(userRepo, authTokenService, refreshTokenRepo, &mocks.EmailVerifier{}, loginGuard(), twoFactorDisabled(), passwordHasher(), &mocks.PasskeyVerifier{}, &mocks.LoginLinkRepo{}, &mailerMocks.Mailer{}, &mocks.IdentityVerifier{})
 
 		_, err := service.Register(ctx, user.RegisterInput{})
 		require.Error(t, err)
@@ -145,6 +250,7 @@ func TestAuthService_Register(t *testing.T) {
 		userRepo.AssertNotCalled(t, "Create")
 		userRepo.AssertExpectations(t)
 		authTokenService.AssertExpectations(t)
+		refreshTokenRepo.AssertExpectations(t)
 	})
 
 	t.Run("can't generate access token", func(t *testing.T) {
@@ -167,16 +273,27 @@ func TestAuthService_Register(t *testing.T) {
 
 		authTokenService := &mocks.AuthTokenService{}
 
-		authTokenService.On("CreateAccessToken", mock.Anything, mock.Anything).
+		authTokenService.On("CreateAccessToken", mock.Anything, mock.Anything, mock.Anything).
 			Return("", errors.New("error"))
 
-		service := domain.NewAuthService(userRepo, authTokenService)
+		refreshTokenRepo := &jwtMocks.RefreshTokenRepo{}
+
+		refreshTokenRepo.On("Create", mock.Anything, mock.Anything).
+			Return(jwt.RefreshToken{ID: "refresh_token_id"}, nil)
+
+		emailVerifier := &mocks.EmailVerifier{}
+
+		emailVerifier.On("SendVerificationEmail", mock.Anything, mock.Anything).
+			Return(nil)
+
+		service := domain.NewAuthService(userRepo, authTokenService, refreshTokenRepo, emailVerifier, loginGuard(), twoFactorDisabled(), passwordHasher(), &mocks.PasskeyVerifier{}, &mocks.LoginLinkRepo{}, &mailerMocks.Mailer{}, &mocks.IdentityVerifier{})
 
 		_, err := service.Register(ctx, validInput)
 		require.ErrorIs(t, err, user.ErrGenerateToken)
 
 		userRepo.AssertExpectations(t)
 		authTokenService.AssertExpectations(t)
+		refreshTokenRepo.AssertExpectations(t)
 	})
 }
 
@@ -189,7 +306,7 @@ func TestAuthService_Login(t *testing.T) {
 	t.Run("valid input", func(t *testing.T) {
 		ctx := context.Background()
 
-		hashedPassword, err := bcrypt.GenerateFromPassword([]byte(validInput.Password), bcrypt.DefaultCost)
+		hashedPassword, err := passwordHasher().Hash(validInput.Password)
 		require.NoError(t, err)
 
 		userRepo := &mocks.UserRepo{}
@@ -199,26 +316,110 @@ func TestAuthService_Login(t *testing.T) {
 				ID:       "user_id",
 				Username: "john",
 				Email:    validInput.Email,
-				Password: string(hashedPassword),
+				Password: hashedPassword,
 			}, nil)
 
 		authTokenService := &mocks.AuthTokenService{}
 
-		authTokenService.On("CreateAccessToken", mock.Anything, mock.Anything).
+		authTokenService.On("CreateAccessToken", mock.Anything, mock.Anything, mock.Anything).
 			Return("access_token", nil)
 
-		service := domain.NewAuthService(userRepo, authTokenService)
+		authTokenService.On("CreateRefreshToken", mock.Anything, mock.Anything, "refresh_token_id").
+			Return("refresh_token", nil)
+
+		refreshTokenRepo := &jwtMocks.RefreshTokenRepo{}
+
+		refreshTokenRepo.On("Create", mock.Anything, mock.Anything).
+			Return(jwt.RefreshToken{ID: "refresh_token_id"}, nil)
+
+		service := domain.NewAuthService(userRepo, authTokenService, refreshTokenRepo, &mocks.EmailVerifier{}, loginGuard(), twoFactorDisabled(), passwordHasher(), &mocks.PasskeyVerifier{}, &mocks.LoginLinkRepo{}, &mailerMocks.Mailer{}, &mocks.IdentityVerifier{})
 
 		res, err := service.Login(ctx, validInput)
 		require.NoError(t, err)
 
 		require.NotEmpty(t, res.AccessToken)
+		require.NotEmpty(t, res.RefreshToken)
 		require.NotEmpty(t, res.User.ID)
 		require.NotEmpty(t, res.User.Username)
 		require.Equal(t, validInput.Email, res.User.Email)
 
+		userRepo.AssertNotCalled(t, "UpdatePassword")
 		userRepo.AssertExpectations(t)
 		authTokenService.AssertExpectations(t)
+		refreshTokenRepo.AssertExpectations(t)
+	})
+
+	t.Run("upgrades outdated hashes", func(t *testing.T) {
+		ctx := context.Background()
+
+		legacy, err := bcrypt.GenerateFromPassword([]byte(validInput.Password), bcrypt.MinCost)
+		require.NoError(t, err)
+
+		userRepo := &mocks.UserRepo{}
+
+		userRepo.On("GetByEmail", mock.Anything, validInput.Email).
+			Return(user.UserModel{ID: "user_id", Email: validInput.Email, Password: string(legacy)}, nil)
+
+		userRepo.On("UpdatePassword", mock.Anything, "user_id", mock.MatchedBy(func(hash string) bool {
+			ok, err := passwordHasher().Verify(hash, validInput.Password)
+			return err == nil && ok && !passwordHasher().NeedsRehash(hash)
+		})).Return(nil)
+
+		authTokenService := &mocks.AuthTokenService{}
+
+		authTokenService.On("CreateAccessToken", mock.Anything, mock.Anything, mock.Anything).
+			Return("access_token", nil)
+
+		authTokenService.On("CreateRefreshToken", mock.Anything, mock.Anything, mock.Anything).
+			Return("refresh_token", nil)
+
+		refreshTokenRepo := &jwtMocks.RefreshTokenRepo{}
+
+		refreshTokenRepo.On("Create", mock.Anything, mock.Anything).
+			Return(jwt.RefreshToken{ID: "refresh_token_id"}, nil)
+
+		service := domain.NewAuthService(userRepo, authTokenService, refreshTokenRepo, &mocks.EmailVerifier{}, loginGuard(), twoFactorDisabled(), passwordHasher(), &mocks.PasskeyVerifier{}, &mocks.LoginLinkRepo{}, &mailerMocks.Mailer{}, &mocks.IdentityVerifier{})
+
+		_, err = service.Login(ctx, validInput)
+		require.NoError(t, err)
+
+		userRepo.AssertExpectations(t)
+	})
+
+	t.Run("failing to upgrade the hash doesn't fail the login", func(t *testing.T) {
+		ctx := context.Background()
+
+		legacy, err := bcrypt.GenerateFromPassword([]byte(validInput.Password), bcrypt.MinCost)
+		require.NoError(t, err)
+
+		userRepo := &mocks.UserRepo{}
+
+		userRepo.On("GetByEmail", mock.Anything, validInput.Email).
+			Return(user.UserModel{ID: "user_id", Email: validInput.Email, Password: string(legacy)}, nil)
+
+		userRepo.On("UpdatePassword", mock.Anything, "user_id", mock.Anything).
+			Return(errors.New("some error"))
+
+		authTokenService := &mocks.AuthTokenService{}
+
+		authTokenService.On("CreateAccessToken", mock.Anything, mock.Anything, mock.Anything).
+			Return("access_token", nil)
+
+		authTokenService.On("CreateRefreshToken", mock.Anything, mock.Anything, mock.Anything).
+			Return("refresh_token", nil)
+
+		refreshTokenRepo := &jwtMocks.RefreshTokenRepo{}
+
+		refreshTokenRepo.On("Create", mock.Anything, mock.Anything).
+			Return(jwt.RefreshToken{ID: "refresh_token_id"}, nil)
+
+		service := domain.NewAuthService(userRepo, authTokenService, refreshTokenRepo, &mocks.EmailVerifier{}, loginGuard(), twoFactorDisabled(), passwordHasher(), &mocks.PasskeyVerifier{}, &mocks.LoginLinkRepo{}, &mailerMocks.Mailer{}, &mocks.IdentityVerifier{})
+
+		res, err := service.Login(ctx, validInput)
+		require.NoError(t, err)
+		require.NotEmpty(t, res.AccessToken)
+
+		userRepo.AssertExpectations(t)
 	})
 
 	t.Run("invalid email", func(t *testing.T) {
@@ -231,13 +432,16 @@ func TestAuthService_Login(t *testing.T) {
 
 		authTokenService := &mocks.AuthTokenService{}
 
-		service := domain.NewAuthService(userRepo, authTokenService)
+		refreshTokenRepo := &jwtMocks.RefreshTokenRepo{}
+
+		service := domain.NewAuthService(userRepo, authTokenService, refreshTokenRepo, &mocks.EmailVerifier{}, loginGuard(), twoFactorDisabled(), passwordHasher(), &mocks.PasskeyVerifier{}, &mocks.LoginLinkRepo{}, &mailerMocks.Mailer{}, &mocks.IdentityVerifier{})
 
 		_, err := service.Login(ctx, validInput)
 		require.ErrorIs(t, err, user.ErrInvalidCredentials)
 
 		userRepo.AssertExpectations(t)
 		authTokenService.AssertExpectations(t)
+		refreshTokenRepo.AssertExpectations(t)
 	})
 
 	t.Run("get user by email error", func(t *testing.T) {
@@ -250,13 +454,16 @@ func TestAuthService_Login(t *testing.T) {
 
 		authTokenService := &mocks.AuthTokenService{}
 
-		service := domain.NewAuthService(userRepo, authTokenService)
+		refreshTokenRepo := &jwtMocks.RefreshTokenRepo{}
+
+		service := domain.NewAuthService(userRepo, authTokenService, refreshTokenRepo, &mocks.EmailVerifier{}, loginGuard(), twoFactorDisabled(), passwordHasher(), &mocks.PasskeyVerifier{}, &mocks.LoginLinkRepo{}, &mailerMocks.Mailer{}, &mocks.IdentityVerifier{})
 
 		_, err := service.Login(ctx, validInput)
 		require.Error(t, err)
 
 		userRepo.AssertExpectations(t)
 		authTokenService.AssertExpectations(t)
+		refreshTokenRepo.AssertExpectations(t)
 	})
 
 	t.Run("invalid input", func(t *testing.T) {
@@ -266,11 +473,623 @@ func TestAuthService_Login(t *testing.T) {
 
 		authTokenService := &mocks.AuthTokenService{}
 
-		service := domain.NewAuthService(userRepo, authTokenService)
+		refreshTokenRepo := &jwtMocks.RefreshTokenRepo{}
+
+		service := domain.NewAuthService(userRepo, authTokenService, refreshTokenRepo, &mocks.EmailVerifier{}, loginGuard(), twoFactorDisabled(), passwordHasher(), &mocks.PasskeyVerifier{}, &mocks.LoginLinkRepo{}, &mailerMocks.Mailer{}, &mocks.IdentityVerifier{})
 
 		_, err := service.Login(ctx, user.LoginInput{})
 		require.ErrorIs(t, err, user.ErrValidation)
 
 		userRepo.AssertNotCalled(t, "GetByEmail")
 	})
+
+	t.Run("locked out", func(t *testing.T) {
+		ctx := transport.PutClientIPIntoContext(context.Background(), "127.0.0.1")
+
+		userRepo := &mocks.UserRepo{}
+
+		lg := &mocks.LoginGuard{}
+
+		lg.On("Allow", mock.Anything, validInput.Email, "127.0.0.1").
+			Return(&user.LoginLockedError{RetryAfter: time.Minute})
+
+		service := domain.NewAuthService(userRepo, &mocks.AuthTokenService{}, &jwtMocks.RefreshTokenRepo{}, &mocks.EmailVerifier{}, lg, twoFactorDisabled(), passwordHasher(), &mocks.PasskeyVerifier{}, &mocks.LoginLinkRepo{}, &mailerMocks.Mailer{}, &mocks.IdentityVerifier{})
+
+		_, err := service.Login(ctx, validInput)
+		require.ErrorIs(t, err, user.ErrLoginLocked)
+
+		userRepo.AssertNotCalled(t, "GetByEmail")
+	})
+
+	t.Run("records failed attempts", func(t *testing.T) {
+		ctx := transport.PutClientIPIntoContext(context.Background(), "127.0.0.1")
+
+		userRepo := &mocks.UserRepo{}
+
+		userRepo.On("GetByEmail", mock.Anything, validInput.Email).
+			Return(user.UserModel{ID: "user_id", Email: validInput.Email, Password: "not_the_hash"}, nil)
+
+		lg := &mocks.LoginGuard{}
+
+		lg.On("Allow", mock.Anything, validInput.Email, "127.0.0.1").
+			Return(nil)
+
+		service := domain.NewAuthService(userRepo, &mocks.AuthTokenService{}, &jwtMocks.RefreshTokenRepo{}, &mocks.EmailVerifier{}, lg, twoFactorDisabled(), passwordHasher(), &mocks.PasskeyVerifier{}, &mocks.LoginLinkRepo{}, &mailerMocks.Mailer{}, &mocks.IdentityVerifier{})
+
+		_, err := service.Login(ctx, validInput)
+		require.ErrorIs(t, err, user.ErrInvalidCredentials)
+
+		lg.AssertExpectations(t)
+		lg.AssertNotCalled(t, "Release", mock.Anything, mock.Anything, mock.Anything)
+		lg.AssertNotCalled(t, "Succeed", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestAuthService_RefreshToken(t *testing.T) {
+	refreshTokenID := "0b5ef1c4-2f6a-4a3e-9c8f-1a2b3c4d5e6f"
+
+	existingToken := jwt.RefreshToken{
+		ID:        refreshTokenID,
+		UserID:    "user_id",
+		FamilyID:  "family_id",
+		ExpiredAt: time.Now().Add(time.Hour),
+	}
+
+	t.Run("rotates a valid refresh token", func(t *testing.T) {
+		ctx := context.Background()
+
+		userRepo := &mocks.UserRepo{}
+
+		userRepo.On("GetByID", mock.Anything, "user_id").
+			Return(user.UserModel{ID: "user_id"}, nil)
+
+		authTokenService := &mocks.AuthTokenService{}
+
+		authTokenService.On("ParseToken", mock.Anything, "refresh_token").
+			Return(user.AuthToken{ID: refreshTokenID, Sub: "user_id"}, nil)
+
+		authTokenService.On("CreateAccessToken", mock.Anything, mock.Anything, mock.Anything).
+			Return("access_token", nil)
+
+		authTokenService.On("CreateRefreshToken", mock.Anything, mock.Anything, "next_refresh_token_id").
+			Return("next_refresh_token", nil)
+
+		refreshTokenRepo := &jwtMocks.RefreshTokenRepo{}
+
+		refreshTokenRepo.On("GetByID", mock.Anything, refreshTokenID).
+			Return(existingToken, nil)
+
+		refreshTokenRepo.On("Rotate", mock.Anything, refreshTokenID, jwt.CreateRefreshTokenParams{
+			Sub:      "user_id",
+			FamilyID: "family_id",
+		}).Return(jwt.RefreshToken{ID: "next_refresh_token_id"}, nil)
+
+		service := domain.NewAuthService(userRepo, authTokenService, refreshTokenRepo, &mocks.EmailVerifier{}, loginGuard(), twoFactorDisabled(), passwordHasher(), &mocks.PasskeyVerifier{}, &mocks.LoginLinkRepo{}, &mailerMocks.Mailer{}, &mocks.IdentityVerifier{})
+
+		res, err := service.RefreshToken(ctx, "refresh_token")
+		require.NoError(t, err)
+
+		require.Equal(t, "access_token", res.AccessToken)
+		require.Equal(t, "next_refresh_token", res.RefreshToken)
+		require.Equal(t, "user_id", res.User.ID)
+
+		userRepo.AssertExpectations(t)
+		authTokenService.AssertExpectations(t)
+		refreshTokenRepo.AssertExpectations(t)
+	})
+
+	t.Run("invalid token", func(t *testing.T) {
+		ctx := context.Background()
+
+		userRepo := &mocks.UserRepo{}
+
+		authTokenService := &mocks.AuthTokenService{}
+
+		authTokenService.On("ParseToken", mock.Anything, mock.Anything).
+			Return(user.AuthToken{}, user.ErrInvalidToken)
+
+		refreshTokenRepo := &jwtMocks.RefreshTokenRepo{}
+
+		service := domain.NewAuthService(userRepo, authTokenService, refreshTokenRepo, &mocks.EmailVerifier{}, loginGuard(), twoFactorDisabled(), passwordHasher(), &mocks.PasskeyVerifier{}, &mocks.LoginLinkRepo{}, &mailerMocks.Mailer{}, &mocks.IdentityVerifier{})
+
+		_, err := service.RefreshToken(ctx, "refresh_token")
+		require.ErrorIs(t, err, user.ErrInvalidToken)
+
+		refreshTokenRepo.AssertNotCalled(t, "GetByID")
+		authTokenService.AssertExpectations(t)
+	})
+
+	t.Run("unknown token", func(t *testing.T) {
+		ctx := context.Background()
+
+		userRepo := &mocks.UserRepo{}
+
+		authTokenService := &mocks.AuthTokenService{}
+
+		authTokenService.On("ParseToken", mock.Anything, mock.Anything).
+			Return(user.AuthToken{ID: refreshTokenID, Sub: "user_id"}, nil)
+
+		refreshTokenRepo := &jwtMocks.RefreshTokenRepo{}
+
+		refreshTokenRepo.On("GetByID", mock.Anything, refreshTokenID).
+			Return(jwt.RefreshToken{}, user.ErrNotFound)
+
+		service := domain.NewAuthService(userRepo, authTokenService, refreshTokenRepo, &mocks.EmailVerifier{}, loginGuard(), twoFactorDisabled(), passwordHasher(), &mocks.PasskeyVerifier{}, &mocks.LoginLinkRepo{}, &mailerMocks.Mailer{}, &mocks.IdentityVerifier{})
+
+		_, err := service.RefreshToken(ctx, "refresh_token")
+		require.ErrorIs(t, err, user.ErrInvalidToken)
+
+		authTokenService.AssertExpectations(t)
+		refreshTokenRepo.AssertExpectations(t)
+	})
+
+	t.Run("expired token", func(t *testing.T) {
+		ctx := context.Background()
+
+		userRepo := &mocks.UserRepo{}
+
+		authTokenService := &mocks.AuthTokenService{}
+
+		authTokenService.On("ParseToken", mock.Anything, mock.Anything).
+			Return(user.AuthToken{ID: refreshTokenID, Sub: "user_id"}, nil)
+
+		refreshTokenRepo := &jwtMocks.RefreshTokenRepo{}
+
+		expiredToken := existingToken
+		expiredToken.ExpiredAt = time.Now().Add(-time.Hour)
+
+		refreshTokenRepo.On("GetByID", mock.Anything, refreshTokenID).
+			Return(expiredToken, nil)
+
+		service := domain.NewAuthService(userRepo, authTokenService, refreshTokenRepo, &mocks.EmailVerifier{}, loginGuard(), twoFactorDisabled(), passwordHasher(), &mocks.PasskeyVerifier{}, &mocks.LoginLinkRepo{}, &mailerMocks.Mailer{}, &mocks.IdentityVerifier{})
+
+		_, err := service.RefreshToken(ctx, "refresh_token")
+		require.ErrorIs(t, err, user.ErrInvalidToken)
+
+		refreshTokenRepo.AssertNotCalled(t, "Rotate")
+		refreshTokenRepo.AssertExpectations(t)
+	})
+
+	t.Run("reused token revokes the whole family", func(t *testing.T) {
+		ctx := context.Background()
+
+		userRepo := &mocks.UserRepo{}
+
+		authTokenService := &mocks.AuthTokenService{}
+
+		authTokenService.On("ParseToken", mock.Anything, mock.Anything).
+			Return(user.AuthToken{ID: refreshTokenID, Sub: "user_id"}, nil)
+
+		refreshTokenRepo := &jwtMocks.RefreshTokenRepo{}
+
+		revokedAt := time.Now()
+		rotatedToken := existingToken
+		rotatedToken.RevokedAt = &revokedAt
+
+		refreshTokenRepo.On("GetByID", mock.Anything, refreshTokenID).
+			Return(rotatedToken, nil)
+
+		refreshTokenRepo.On("RevokeFamily", mock.Anything, "family_id").
+			Return(nil)
+
+		service := domain.NewAuthService(userRepo, authTokenService, refreshTokenRepo, &mocks.EmailVerifier{}, loginGuard(), twoFactorDisabled(), passwordHasher(), &mocks.PasskeyVerifier{}, &mocks.LoginLinkRepo{}, &mailerMocks.Mailer{}, &mocks.IdentityVerifier{})
+
+		_, err := service.RefreshToken(ctx, "refresh_token")
+		require.ErrorIs(t, err, user.ErrInvalidToken)
+
+		refreshTokenRepo.AssertNotCalled(t, "Rotate")
+		refreshTokenRepo.AssertExpectations(t)
+	})
+
+	t.Run("concurrent rotation revokes the whole family", func(t *testing.T) {
+		ctx := context.Background()
+
+		userRepo := &mocks.UserRepo{}
+
+		userRepo.On("GetByID", mock.Anything, "user_id").
+			Return(user.UserModel{ID: "user_id"}, nil)
+
+		authTokenService := &mocks.AuthTokenService{}
+
+		authTokenService.On("ParseToken", mock.Anything, mock.Anything).
+			Return(user.AuthToken{ID: refreshTokenID, Sub: "user_id"}, nil)
+
+		refreshTokenRepo := &jwtMocks.RefreshTokenRepo{}
+
+		refreshTokenRepo.On("GetByID", mock.Anything, refreshTokenID).
+			Return(existingToken, nil)
+
+		refreshTokenRepo.On("Rotate", mock.Anything, refreshTokenID, mock.Anything).
+			Return(jwt.RefreshToken{}, jwt.ErrRefreshTokenRevoked)
+
+		refreshTokenRepo.On("RevokeFamily", mock.Anything, "family_id").
+			Return(nil)
+
+		service := domain.NewAuthService(userRepo, authTokenService, refreshTokenRepo, &mocks.EmailVerifier{}, loginGuard(), twoFactorDisabled(), passwordHasher(), &mocks.PasskeyVerifier{}, &mocks.LoginLinkRepo{}, &mailerMocks.Mailer{}, &mocks.IdentityVerifier{})
+
+		_, err := service.RefreshToken(ctx, "refresh_token")
+		require.ErrorIs(t, err, user.ErrInvalidToken)
+
+		refreshTokenRepo.AssertExpectations(t)
+	})
+}
+
+func TestAuthService_Sessions(t *testing.T) {
+	t.Run("lists active sessions and flags the current one", func(t *testing.T) {
+		ctx := transport.PutUserIDIntoContext(context.Background(), "user_id")
+		ctx = transport.PutSessionIDIntoContext(ctx, "family_id")
+
+		refreshTokenRepo := &jwtMocks.RefreshTokenRepo{}
+
+		refreshTokenRepo.On("GetActiveByUserID", mock.Anything, "user_id").
+			Return([]jwt.RefreshToken{
+				{ID: "1", FamilyID: "family_id", UserID: "user_id", Name: "laptop"},
+				{ID: "2", FamilyID: "other_family_id", UserID: "user_id", Name: "phone"},
+			}, nil)
+
+		service := domain.NewAuthService(&mocks.UserRepo{}, &mocks.AuthTokenService{}, refreshTokenRepo, &mocks.EmailVerifier{}, loginGuard(), twoFactorDisabled(), passwordHasher(), &mocks.PasskeyVerifier{}, &mocks.LoginLinkRepo{}, &mailerMocks.Mailer{}, &mocks.IdentityVerifier{})
+
+		sessions, err := service.Sessions(ctx)
+		require.NoError(t, err)
+
+		require.Len(t, sessions, 2)
+		require.Equal(t, "family_id", sessions[0].ID)
+		require.Equal(t, "laptop", sessions[0].Name)
+		require.True(t, sessions[0].Current)
+		require.False(t, sessions[1].Current)
+
+		refreshTokenRepo.AssertExpectations(t)
+	})
+
+	t.Run("unauthenticated", func(t *testing.T) {
+		ctx := context.Background()
+
+		refreshTokenRepo := &jwtMocks.RefreshTokenRepo{}
+
+		service := domain.NewAuthService(&mocks.UserRepo{}, &mocks.AuthTokenService{}, refreshTokenRepo, &mocks.EmailVerifier{}, loginGuard(), twoFactorDisabled(), passwordHasher(), &mocks.PasskeyVerifier{}, &mocks.LoginLinkRepo{}, &mailerMocks.Mailer{}, &mocks.IdentityVerifier{})
+
+		_, err := service.Sessions(ctx)
+		require.ErrorIs(t, err, user.ErrUnauthenticated)
+
+		refreshTokenRepo.AssertNotCalled(t, "GetActiveByUserID")
+	})
+}
+
+func TestAuthService_Logout(t *testing.T) {
+	t.Run("revokes the current session", func(t *testing.T) {
+		ctx := transport.PutUserIDIntoContext(context.Background(), "user_id")
+		ctx = transport.PutSessionIDIntoContext(ctx, "family_id")
+
+		refreshTokenRepo := &jwtMocks.RefreshTokenRepo{}
+
+		refreshTokenRepo.On("RevokeFamily", mock.Anything, "family_id").
+			Return(nil)
+
+		service := domain.NewAuthService(&mocks.UserRepo{}, &mocks.AuthTokenService{}, refreshTokenRepo, &mocks.EmailVerifier{}, loginGuard(), twoFactorDisabled(), passwordHasher(), &mocks.PasskeyVerifier{}, &mocks.LoginLinkRepo{}, &mailerMocks.Mailer{}, &mocks.IdentityVerifier{})
+
+		err := service.Logout(ctx)
+		require.NoError(t, err)
+
+		refreshTokenRepo.AssertExpectations(t)
+	})
+
+	t.Run("unauthenticated", func(t *testing.T) {
+		ctx := context.Background()
+
+		service := domain.NewAuthService(&mocks.UserRepo{}, &mocks.AuthTokenService{}, &jwtMocks.RefreshTokenRepo{}, &mocks.EmailVerifier{}, loginGuard(), twoFactorDisabled(), passwordHasher(), &mocks.PasskeyVerifier{}, &mocks.LoginLinkRepo{}, &mailerMocks.Mailer{}, &mocks.IdentityVerifier{})
+
+		err := service.Logout(ctx)
+		require.ErrorIs(t, err, user.ErrUnauthenticated)
+	})
+}
+
+func TestAuthService_RevokeSession(t *testing.T) {
+	sessionID := "0b5ef1c4-2f6a-4a3e-9c8f-1a2b3c4d5e6f"
+
+	t.Run("revokes an owned session", func(t *testing.T) {
+		ctx := transport.PutUserIDIntoContext(context.Background(), "user_id")
+
+		refreshTokenRepo := &jwtMocks.RefreshTokenRepo{}
+
+		refreshTokenRepo.On("GetActiveByFamilyID", mock.Anything, sessionID).
+			Return(jwt.RefreshToken{FamilyID: sessionID, UserID: "user_id"}, nil)
+
+		refreshTokenRepo.On("RevokeFamily", mock.Anything, sessionID).
+			Return(nil)
+
+		service := domain.NewAuthService(&mocks.UserRepo{}, &mocks.AuthTokenService{}, refreshTokenRepo, &mocks.EmailVerifier{}, loginGuard(), twoFactorDisabled(), passwordHasher(), &mocks.PasskeyVerifier{}, &mocks.LoginLinkRepo{}, &mailerMocks.Mailer{}, &mocks.IdentityVerifier{})
+
+		err := service.RevokeSession(ctx, sessionID)
+		require.NoError(t, err)
+
+		refreshTokenRepo.AssertExpectations(t)
+	})
+
+	t.Run("cannot revoke another user's session", func(t *testing.T) {
+		ctx := transport.PutUserIDIntoContext(context.Background(), "user_id")
+
+		refreshTokenRepo := &jwtMocks.RefreshTokenRepo{}
+
+		refreshTokenRepo.On("GetActiveByFamilyID", mock.Anything, sessionID).
+			Return(jwt.RefreshToken{FamilyID: sessionID, UserID: "other_user_id"}, nil)
+
+		service := domain.NewAuthService(&mocks.UserRepo{}, &mocks.AuthTokenService{}, refreshTokenRepo, &mocks.EmailVerifier{}, loginGuard(), twoFactorDisabled(), passwordHasher(), &mocks.PasskeyVerifier{}, &mocks.LoginLinkRepo{}, &mailerMocks.Mailer{}, &mocks.IdentityVerifier{})
+
+		err := service.RevokeSession(ctx, sessionID)
+		require.ErrorIs(t, err, user.ErrNotFound)
+
+		refreshTokenRepo.AssertNotCalled(t, "RevokeFamily")
+		refreshTokenRepo.AssertExpectations(t)
+	})
+}
+
+func TestAuthService_ChangePassword(t *testing.T) {
+	currentPassword, err := bcrypt.GenerateFromPassword([]byte("password"), bcrypt.MinCost)
+	require.NoError(t, err)
+
+	current := user.UserModel{ID: "user_id", Email: "johndoe@mail.com", Password: string(currentPassword)}
+
+	validInput := user.ChangePasswordInput{
+		CurrentPassword: "password",
+		Password:        "new_password",
+		ConfirmPassword: "new_password",
+	}
+
+	t.Run("changes the password and revokes other sessions", func(t *testing.T) {
+		ctx := transport.PutUserIDIntoContext(context.Background(), "user_id")
+		ctx = transport.PutSessionIDIntoContext(ctx, "family_id")
+
+		userRepo := &mocks.UserRepo{}
+
+		userRepo.On("GetByID", mock.Anything, "user_id").
+			Return(current, nil)
+
+		userRepo.On("GetByEmail", mock.Anything, current.Email).
+			Return(current, nil)
+
+		userRepo.On("UpdatePassword", mock.Anything, "user_id", mock.MatchedBy(func(hash string) bool {
+			ok, err := passwordHasher().Verify(hash, "new_password")
+			return err == nil && ok
+		})).Return(nil)
+
+		refreshTokenRepo := &jwtMocks.RefreshTokenRepo{}
+
+		refreshTokenRepo.On("RevokeAllByUserIDExcept", mock.Anything, "user_id", "family_id").
+			Return(nil)
+
+		service := domain.NewAuthService(userRepo, &mocks.AuthTokenService{}, refreshTokenRepo, &mocks.EmailVerifier{}, loginGuard(), twoFactorDisabled(), passwordHasher(), &mocks.PasskeyVerifier{}, &mocks.LoginLinkRepo{}, &mailerMocks.Mailer{}, &mocks.IdentityVerifier{})
+
+		err := service.ChangePassword(ctx, validInput)
+		require.NoError(t, err)
+
+		userRepo.AssertExpectations(t)
+		refreshTokenRepo.AssertExpectations(t)
+	})
+
+	t.Run("wrong current password", func(t *testing.T) {
+		ctx := transport.PutUserIDIntoContext(context.Background(), "user_id")
+
+		userRepo := &mocks.UserRepo{}
+
+		userRepo.On("GetByID", mock.Anything, "user_id").
+			Return(current, nil)
+
+		userRepo.On("GetByEmail", mock.Anything, current.Email).
+			Return(current, nil)
+
+		service := domain.NewAuthService(userRepo, &mocks.AuthTokenService{}, &jwtMocks.RefreshTokenRepo{}, &mocks.EmailVerifier{}, loginGuard(), twoFactorDisabled(), passwordHasher(), &mocks.PasskeyVerifier{}, &mocks.LoginLinkRepo{}, &mailerMocks.Mailer{}, &mocks.IdentityVerifier{})
+
+		input := validInput
+		input.CurrentPassword = "wrong_password"
+
+		err := service.ChangePassword(ctx, input)
+		require.ErrorIs(t, err, user.ErrInvalidPassword)
+
+		userRepo.AssertNotCalled(t, "UpdatePassword")
+	})
+
+	t.Run("invalid new password", func(t *testing.T) {
+		ctx := transport.PutUserIDIntoContext(context.Background(), "user_id")
+
+		userRepo := &mocks.UserRepo{}
+
+		service := domain.NewAuthService(userRepo, &mocks.AuthTokenService{}, &jwtMocks.RefreshTokenRepo{}, &mocks.EmailVerifier{}, loginGuard(), twoFactorDisabled(), passwordHasher(), &mocks.PasskeyVerifier{}, &mocks.LoginLinkRepo{}, &mailerMocks.Mailer{}, &mocks.IdentityVerifier{})
+
+		input := validInput
+		input.ConfirmPassword = "other_password"
+
+		err := service.ChangePassword(ctx, input)
+		require.ErrorIs(t, err, user.ErrValidation)
+
+		userRepo.AssertNotCalled(t, "GetByID")
+	})
+
+	t.Run("unauthenticated", func(t *testing.T) {
+		service := domain.NewAuthService(&mocks.UserRepo{}, &mocks.AuthTokenService{}, &jwtMocks.RefreshTokenRepo{}, &mocks.EmailVerifier{}, loginGuard(), twoFactorDisabled(), passwordHasher(), &mocks.PasskeyVerifier{}, &mocks.LoginLinkRepo{}, &mailerMocks.Mailer{}, &mocks.IdentityVerifier{})
+
+		err := service.ChangePassword(context.Background(), validInput)
+		require.ErrorIs(t, err, user.ErrUnauthenticated)
+	})
+}
+
+func TestAuthService_ChangeEmail(t *testing.T) {
+	currentPassword, err := bcrypt.GenerateFromPassword([]byte("password"), bcrypt.MinCost)
+	require.NoError(t, err)
+
+	current := user.UserModel{ID: "user_id", Email: "johndoe@mail.com", Password: string(currentPassword)}
+
+	validInput := user.ChangeEmailInput{
+		Email:    "John@Mail.com",
+		Password: "password",
+	}
+
+	t.Run("sends a confirmation to the new email", func(t *testing.T) {
+		ctx := transport.PutUserIDIntoContext(context.Background(), "user_id")
+		ctx = transport.PutSessionIDIntoContext(ctx, "family_id")
+
+		userRepo := &mocks.UserRepo{}
+
+		userRepo.On("GetByID", mock.Anything, "user_id").
+			Return(current, nil)
+
+		userRepo.On("GetByEmail", mock.Anything, current.Email).
+			Return(current, nil)
+
+		userRepo.On("GetByEmail", mock.Anything, "john@mail.com").
+			Return(user.UserModel{}, user.ErrNotFound)
+
+		emailVerifier := &mocks.EmailVerifier{}
+
+		emailVerifier.On("SendEmailChangeConfirmation", mock.Anything, current, "john@mail.com").
+			Return(nil)
+
+		refreshTokenRepo := &jwtMocks.RefreshTokenRepo{}
+
+		refreshTokenRepo.On("RevokeAllByUserIDExcept", mock.Anything, "user_id", "family_id").
+			Return(nil)
+
+		service := domain.NewAuthService(userRepo, &mocks.AuthTokenService{}, refreshTokenRepo, emailVerifier, loginGuard(), twoFactorDisabled(), passwordHasher(), &mocks.PasskeyVerifier{}, &mocks.LoginLinkRepo{}, &mailerMocks.Mailer{}, &mocks.IdentityVerifier{})
+
+		err := service.ChangeEmail(ctx, validInput)
+		require.NoError(t, err)
+
+		userRepo.AssertNotCalled(t, "UpdateEmail")
+		emailVerifier.AssertExpectations(t)
+		refreshTokenRepo.AssertExpectations(t)
+	})
+
+	t.Run("wrong password", func(t *testing.T) {
+		ctx := transport.PutUserIDIntoContext(context.Background(), "user_id")
+
+		userRepo := &mocks.UserRepo{}
+
+		userRepo.On("GetByID", mock.Anything, "user_id").
+			Return(current, nil)
+
+		userRepo.On("GetByEmail", mock.Anything, current.Email).
+			Return(current, nil)
+
+		emailVerifier := &mocks.EmailVerifier{}
+
+		service := domain.NewAuthService(userRepo, &mocks.AuthTokenService{}, &jwtMocks.RefreshTokenRepo{}, emailVerifier, loginGuard(), twoFactorDisabled(), passwordHasher(), &mocks.PasskeyVerifier{}, &mocks.LoginLinkRepo{}, &mailerMocks.Mailer{}, &mocks.IdentityVerifier{})
+
+		input := validInput
+		input.Password = "wrong_password"
+
+		err := service.ChangeEmail(ctx, input)
+		require.ErrorIs(t, err, user.ErrInvalidPassword)
+
+		emailVerifier.AssertNotCalled(t, "SendEmailChangeConfirmation")
+	})
+
+	t.Run("email taken", func(t *testing.T) {
+		ctx := transport.PutUserIDIntoContext(context.Background(), "user_id")
+
+		userRepo := &mocks.UserRepo{}
+
+		userRepo.On("GetByID", mock.Anything, "user_id").
+			Return(current, nil)
+
+		userRepo.On("GetByEmail", mock.Anything, current.Email).
+			Return(current, nil)
+
+		userRepo.On("GetByEmail", mock.Anything, "john@mail.com").
+			Return(user.UserModel{ID: "other_user_id"}, nil)
+
+		emailVerifier := &mocks.EmailVerifier{}
+
+		service := domain.NewAuthService(userRepo, &mocks.AuthTokenService{}, &jwtMocks.RefreshTokenRepo{}, emailVerifier, loginGuard(), twoFactorDisabled(), passwordHasher(), &mocks.PasskeyVerifier{}, &mocks.LoginLinkRepo{}, &mailerMocks.Mailer{}, &mocks.IdentityVerifier{})
+
+		err := service.ChangeEmail(ctx, validInput)
+		require.ErrorIs(t, err, user.ErrEmailTaken)
+
+		emailVerifier.AssertNotCalled(t, "SendEmailChangeConfirmation")
+	})
+
+	t.Run("same email", func(t *testing.T) {
+		ctx := transport.PutUserIDIntoContext(context.Background(), "user_id")
+
+		userRepo := &mocks.UserRepo{}
+
+		userRepo.On("GetByID", mock.Anything, "user_id").
+			Return(current, nil)
+
+		userRepo.On("GetByEmail", mock.Anything, current.Email).
+			Return(current, nil)
+
+		service := domain.NewAuthService(userRepo, &mocks.AuthTokenService{}, &jwtMocks.RefreshTokenRepo{}, &mocks.EmailVerifier{}, loginGuard(), twoFactorDisabled(), passwordHasher(), &mocks.PasskeyVerifier{}, &mocks.LoginLinkRepo{}, &mailerMocks.Mailer{}, &mocks.IdentityVerifier{})
+
+		input := validInput
+		input.Email = current.Email
+
+		err := service.ChangeEmail(ctx, input)
+		require.ErrorIs(t, err, user.ErrSameEmail)
+	})
+}
+
+func TestAuthService_ConfirmEmailChange(t *testing.T) {
+	current := user.UserModel{ID: "user_id", Email: "johndoe@mail.com"}
+
+	t.Run("switches the email", func(t *testing.T) {
+		ctx := context.Background()
+
+		authTokenService := &mocks.AuthTokenService{}
+
+		authTokenService.On("ParseEmailChangeToken", mock.Anything, "token").
+			Return(user.EmailChangeToken{Sub: "user_id", Email: current.Email, NewEmail: "john@mail.com"}, nil)
+
+		userRepo := &mocks.UserRepo{}
+
+		userRepo.On("GetByID", mock.Anything, "user_id").
+			Return(current, nil)
+
+		userRepo.On("GetByEmail", mock.Anything, "john@mail.com").
+			Return(user.UserModel{}, user.ErrNotFound)
+
+		userRepo.On("UpdateEmail", mock.Anything, "user_id", "john@mail.com").
+			Return(user.UserModel{ID: "user_id", Email: "john@mail.com"}, nil)
+
+		service := domain.NewAuthService(userRepo, authTokenService, &jwtMocks.RefreshTokenRepo{}, &mocks.EmailVerifier{}, loginGuard(), twoFactorDisabled(), passwordHasher(), &mocks.PasskeyVerifier{}, &mocks.LoginLinkRepo{}, &mailerMocks.Mailer{}, &mocks.IdentityVerifier{})
+
+		u, err := service.ConfirmEmailChange(ctx, "token")
+		require.NoError(t, err)
+		require.Equal(t, "john@mail.com", u.Email)
+
+		userRepo.AssertExpectations(t)
+	})
+
+	t.Run("token issued for a previous email", func(t *testing.T) {
+		ctx := context.Background()
+
+		authTokenService := &mocks.AuthTokenService{}
+
+		authTokenService.On("ParseEmailChangeToken", mock.Anything, "token").
+			Return(user.EmailChangeToken{Sub: "user_id", Email: "old@mail.com", NewEmail: "john@mail.com"}, nil)
+
+		userRepo := &mocks.UserRepo{}
+
+		userRepo.On("GetByID", mock.Anything, "user_id").
+			Return(current, nil)
+
+		service := domain.NewAuthService(userRepo, authTokenService, &jwtMocks.RefreshTokenRepo{}, &mocks.EmailVerifier{}, loginGuard(), twoFactorDisabled(), passwordHasher(), &mocks.PasskeyVerifier{}, &mocks.LoginLinkRepo{}, &mailerMocks.Mailer{}, &mocks.IdentityVerifier{})
+
+		_, err := service.ConfirmEmailChange(ctx, "token")
+		require.ErrorIs(t, err, user.ErrInvalidEmailChange)
+
+		userRepo.AssertNotCalled(t, "UpdateEmail")
+	})
+
+	t.Run("invalid token", func(t *testing.T) {
+		ctx := context.Background()
+
+		authTokenService := &mocks.AuthTokenService{}
+
+		authTokenService.On("ParseEmailChangeToken", mock.Anything, "token").
+			Return(user.EmailChangeToken{}, user.ErrInvalidToken)
+
+		service := domain.NewAuthService(&mocks.UserRepo{}, authTokenService, &jwtMocks.RefreshTokenRepo{}, &mocks.EmailVerifier{}, loginGuard(), twoFactorDisabled(), passwordHasher(), &mocks.PasskeyVerifier{}, &mocks.LoginLinkRepo{}, &mailerMocks.Mailer{}, &mocks.IdentityVerifier{})
+
+		_, err := service.ConfirmEmailChange(ctx, "token")
+		require.ErrorIs(t, err, user.ErrInvalidEmailChange)
+	})
 }
@@ -0,0 +1,74 @@
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/RianNegreiros/go-graphql-api/internal/dataexport"
+	"github.com/RianNegreiros/go-graphql-api/internal/domain"
+	"github.com/RianNegreiros/go-graphql-api/internal/jwt"
+	"github.com/RianNegreiros/go-graphql-api/internal/post"
+	"github.com/RianNegreiros/go-graphql-api/internal/transport"
+	"github.com/RianNegreiros/go-graphql-api/internal/user"
+	jwtMocks "github.com/RianNegreiros/go-graphql-api/mocks/internal_/jwt"
+	postMocks "github.com/RianNegreiros/go-graphql-api/mocks/internal_/post"
+	mocks "github.com/RianNegreiros/go-graphql-api/mocks/internal_/user"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDataExportService_Export(t *testing.T) {
+	t.Run("exports the data of the current user", func(t *testing.T) {
+		ctx := transport.PutUserIDIntoContext(context.Background(), "user_id")
+
+		userRepo := &mocks.UserRepo{}
+		postRepo := &postMocks.PostRepo{}
+		refreshTokenRepo := &jwtMocks.RefreshTokenRepo{}
+
+		userRepo.On("GetByID", mock.Anything, "user_id").
+			Return(user.UserModel{ID: "user_id", Username: "johndoe"}, nil)
+		postRepo.On("GetAllByUserID", mock.Anything, "user_id").
+			Return([]post.Post{{ID: "post_id", UserID: "user_id"}}, nil)
+		refreshTokenRepo.On("GetActiveByUserID", mock.Anything, "user_id").
+			Return([]jwt.RefreshToken{{FamilyID: "family_id", Name: "firefox"}}, nil)
+
+		service := domain.NewDataExportService(userRepo, postRepo, refreshTokenRepo)
+
+		archive, err := service.Export(ctx, dataexport.FormatJSON)
+		require.NoError(t, err)
+
+		var e dataexport.Export
+
+		require.NoError(t, json.Unmarshal(archive.Data, &e))
+		require.Equal(t, "user_id", e.Profile.ID)
+		require.Len(t, e.Posts, 1)
+		require.Equal(t, "family_id", e.Sessions[0].ID)
+
+		userRepo.AssertExpectations(t)
+		postRepo.AssertExpectations(t)
+		refreshTokenRepo.AssertExpectations(t)
+	})
+
+	t.Run("unsupported format", func(t *testing.T) {
+		ctx := transport.PutUserIDIntoContext(context.Background(), "user_id")
+
+		userRepo := &mocks.UserRepo{}
+
+		service := domain.NewDataExportService(userRepo, &postMocks.PostRepo{}, &jwtMocks.RefreshTokenRepo{})
+
+		_, err := service.Export(ctx, "tar")
+		require.ErrorIs(t, err, dataexport.ErrUnsupportedFormat)
+
+		userRepo.AssertNotCalled(t, "GetByID", mock.Anything, mock.Anything)
+	})
+
+	t.Run("scoped tokens can't export data", func(t *testing.T) {
+		ctx := withPersonalAccessToken("user_id", user.ScopePostsRead)
+
+		service := domain.NewDataExportService(&mocks.UserRepo{}, &postMocks.PostRepo{}, &jwtMocks.RefreshTokenRepo{})
+
+		_, err := service.Export(ctx, dataexport.FormatZIP)
+		require.ErrorIs(t, err, user.ErrSessionRequired)
+	})
+}
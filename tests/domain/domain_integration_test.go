@@ -4,6 +4,7 @@ package domain
 
 import (
 	"context"
+	"io"
 	"log"
 	"os"
 	"testing"
@@ -12,17 +13,37 @@ import (
 
 	"github.com/RianNegreiros/go-graphql-api/config"
 	"github.com/RianNegreiros/go-graphql-api/internal/domain"
+	"github.com/RianNegreiros/go-graphql-api/internal/loginlimit"
+	"github.com/RianNegreiros/go-graphql-api/internal/mailer"
 	"github.com/RianNegreiros/go-graphql-api/internal/postgres"
+	"github.com/RianNegreiros/go-graphql-api/internal/pubsub"
+	"github.com/RianNegreiros/go-graphql-api/internal/user"
 )
 
 var (
-	conf             *config.Config
-	db               *postgres.DB
-	authService      *domain.AuthService
-	userRepo         *postgres.UserRepo
-	postRepo         *postgres.PostRepo
-	authTokenService *jwt.TokenService
-	postService      *domain.PostService
+	conf              *config.Config
+	db                *postgres.DB
+	authService       *domain.AuthService
+	accountService    *domain.AccountService
+	twoFactorService  *domain.TwoFactorService
+	userRepo          *postgres.UserRepo
+	postRepo          *postgres.PostRepo
+	refreshTokenRepo  *postgres.RefreshTokenRepo
+	resetRepo         *postgres.PasswordResetRepo
+	twoFactorRepo     *postgres.TwoFactorRepo
+	patRepo           *postgres.PersonalAccessTokenRepo
+	passkeyRepo       *postgres.PasskeyRepo
+	loginLinkRepo     *postgres.LoginLinkRepo
+	identityRepo      *postgres.IdentityRepo
+	oauthRepo         *postgres.OAuthRepo
+	authTokenService  *jwt.TokenService
+	postService       *domain.PostService
+	userService       *domain.UserService
+	patService        *domain.PersonalAccessTokenService
+	passkeyService    *domain.PasskeyService
+	socialLogin       *domain.SocialLoginService
+	oauthService      *domain.OAuthService
+	dataExportService *domain.DataExportService
 )
 
 func TestMain(m *testing.M) {
@@ -45,11 +66,34 @@ func TestMain(m *testing.M) {
 
 	userRepo = postgres.NewUserRepo(db)
 	postRepo = postgres.NewPostRepo(db)
+	refreshTokenRepo = postgres.NewRefreshTokenRepo(db)
+	resetRepo = postgres.NewPasswordResetRepo(db)
+	twoFactorRepo = postgres.NewTwoFactorRepo(db)
+	patRepo = postgres.NewPersonalAccessTokenRepo(db)
+	passkeyRepo = postgres.NewPasskeyRepo(db)
+	loginLinkRepo = postgres.NewLoginLinkRepo(db)
+	identityRepo = postgres.NewIdentityRepo(db)
+	oauthRepo = postgres.NewOAuthRepo(db)
 
-	authTokenService = jwt.NewTokenService(conf)
+	var err error
 
-	authService = domain.NewAuthService(userRepo, authTokenService)
-	postService = domain.NewPostService(postRepo)
+	authTokenService, err = jwt.NewTokenService(conf)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	mail := mailer.NewLog(io.Discard, "no-reply@localhost")
+	accountService = domain.NewAccountService(userRepo, refreshTokenRepo, resetRepo, authTokenService, mail, passwordHasher())
+	loginGuard := loginlimit.New(postgres.NewLoginAttemptStore(db))
+	twoFactorService = domain.NewTwoFactorService(userRepo, twoFactorRepo, loginGuard)
+	passkeyService = domain.NewPasskeyService(userRepo, passkeyRepo)
+	socialLogin = domain.NewSocialLoginService(userRepo, identityRepo, passwordHasher(), map[string]user.IdentityProvider{})
+	authService = domain.NewAuthService(userRepo, authTokenService, refreshTokenRepo, accountService, loginGuard, twoFactorService, passwordHasher(), passkeyService, loginLinkRepo, mail, socialLogin)
+	postService = domain.NewPostService(postRepo, userRepo, pubsub.NewMemory())
+	userService = domain.NewUserService(userRepo)
+	patService = domain.NewPersonalAccessTokenService(patRepo)
+	oauthService = domain.NewOAuthService(oauthRepo, authTokenService)
+	dataExportService = domain.NewDataExportService(userRepo, postRepo, refreshTokenRepo)
 
 	os.Exit(m.Run())
 }
@@ -0,0 +1,277 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/RianNegreiros/go-graphql-api/internal/domain"
+	"github.com/RianNegreiros/go-graphql-api/internal/jwt"
+	"github.com/RianNegreiros/go-graphql-api/internal/loginlimit"
+	"github.com/RianNegreiros/go-graphql-api/internal/randtoken"
+	"github.com/RianNegreiros/go-graphql-api/internal/transport"
+	"github.com/RianNegreiros/go-graphql-api/internal/user"
+	jwtMocks "github.com/RianNegreiros/go-graphql-api/mocks/internal_/jwt"
+	mailerMocks "github.com/RianNegreiros/go-graphql-api/mocks/internal_/mailer"
+	mocks "github.com/RianNegreiros/go-graphql-api/mocks/internal_/user"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthService_RequestLoginLink(t *testing.T) {
+	t.Run("emails a link with the token", func(t *testing.T) {
+		ctx := context.Background()
+
+		userRepo := &mocks.UserRepo{}
+
+		userRepo.On("GetByEmail", mock.Anything, "johndoe@mail.com").
+			Return(user.UserModel{ID: "user_id", Email: "johndoe@mail.com"}, nil)
+
+		var storedHash string
+
+		loginLinkRepo := &mocks.LoginLinkRepo{}
+
+		loginLinkRepo.On("Create", mock.Anything, "user_id", mock.Anything).
+			Run(func(args mock.Arguments) {
+				storedHash = args.String(2)
+			}).
+			Return(user.LoginLinkToken{}, nil)
+
+		m, messages := sentMessages(nil)
+
+		service := domain.NewAuthService(userRepo, &mocks.AuthTokenService{}, &jwtMocks.RefreshTokenRepo{}, &mocks.EmailVerifier{}, loginGuard(), twoFactorDisabled(), passwordHasher(), &mocks.PasskeyVerifier{}, loginLinkRepo, m, &mocks.IdentityVerifier{})
+
+		err := service.RequestLoginLink(ctx, " JohnDoe@mail.com ")
+		require.NoError(t, err)
+
+		sent := receiveMessage(t, messages)
+
+		require.Equal(t, "johndoe@mail.com", sent.To)
+		require.Contains(t, sent.Body, user.LoginLinkURL+"?token=")
+
+		_, token, found := strings.Cut(sent.Body, "?token=")
+		require.True(t, found)
+		token = strings.Fields(token)[0]
+
+		require.Equal(t, storedHash, randtoken.Hash(token))
+		require.NotContains(t, sent.Body, storedHash)
+	})
+
+	t.Run("unknown email succeeds without sending", func(t *testing.T) {
+		ctx := context.Background()
+
+		userRepo := &mocks.UserRepo{}
+
+		userRepo.On("GetByEmail", mock.Anything, mock.Anything).
+			Return(user.UserModel{}, user.ErrNotFound)
+
+		loginLinkRepo := &mocks.LoginLinkRepo{}
+		m := &mailerMocks.Mailer{}
+
+		service := domain.NewAuthService(userRepo, &mocks.AuthTokenService{}, &jwtMocks.RefreshTokenRepo{}, &mocks.EmailVerifier{}, loginGuard(), twoFactorDisabled(), passwordHasher(), &mocks.PasskeyVerifier{}, loginLinkRepo, m, &mocks.IdentityVerifier{})
+
+		err := service.RequestLoginLink(ctx, "nobody@mail.com")
+		require.NoError(t, err)
+
+		loginLinkRepo.AssertNotCalled(t, "Create")
+		m.AssertNotCalled(t, "Send")
+	})
+
+	t.Run("refused while logins are locked", func(t *testing.T) {
+		ctx := transport.PutClientIPIntoContext(context.Background(), "127.0.0.1")
+
+		userRepo := &mocks.UserRepo{}
+
+		lg := &mocks.LoginGuard{}
+
+		lg.On("Allow", mock.Anything, "johndoe@mail.com", "127.0.0.1").
+			Return(&user.LoginLockedError{RetryAfter: time.Minute})
+
+		m := &mailerMocks.Mailer{}
+
+		service := domain.NewAuthService(userRepo, &mocks.AuthTokenService{}, &jwtMocks.RefreshTokenRepo{}, &mocks.EmailVerifier{}, lg, twoFactorDisabled(), passwordHasher(), &mocks.PasskeyVerifier{}, &mocks.LoginLinkRepo{}, m, &mocks.IdentityVerifier{})
+
+		err := service.RequestLoginLink(ctx, "johndoe@mail.com")
+		require.ErrorIs(t, err, user.ErrLoginLocked)
+
+		userRepo.AssertNotCalled(t, "GetByEmail", mock.Anything, mock.Anything)
+		m.AssertNotCalled(t, "Send", mock.Anything, mock.Anything)
+	})
+
+	t.Run("doesn't count as a failed login", func(t *testing.T) {
+		ctx := transport.PutClientIPIntoContext(context.Background(), "127.0.0.1")
+
+		userRepo := &mocks.UserRepo{}
+
+		userRepo.On("GetByEmail", mock.Anything, mock.Anything).
+			Return(user.UserModel{}, user.ErrNotFound)
+
+		lg := loginlimit.New(loginlimit.NewMemory())
+
+		service := domain.NewAuthService(userRepo, &mocks.AuthTokenService{}, &jwtMocks.RefreshTokenRepo{}, &mocks.EmailVerifier{}, lg, twoFactorDisabled(), passwordHasher(), &mocks.PasskeyVerifier{}, &mocks.LoginLinkRepo{}, &mailerMocks.Mailer{}, &mocks.IdentityVerifier{})
+
+		for i := 0; i < lg.Account.LockoutAttempts; i++ {
+			require.NoError(t, service.RequestLoginLink(ctx, "johndoe@mail.com"))
+		}
+
+		require.NoError(t, lg.Allow(ctx, "johndoe@mail.com", "127.0.0.1"))
+	})
+
+	t.Run("mail failures are not reported", func(t *testing.T) {
+		ctx := context.Background()
+
+		userRepo := &mocks.UserRepo{}
+
+		userRepo.On("GetByEmail", mock.Anything, "johndoe@mail.com").
+			Return(user.UserModel{ID: "user_id", Email: "johndoe@mail.com"}, nil)
+
+		loginLinkRepo := &mocks.LoginLinkRepo{}
+
+		loginLinkRepo.On("Create", mock.Anything, "user_id", mock.Anything).
+			Return(user.LoginLinkToken{}, nil)
+
+		m, messages := sentMessages(errors.New("smtp unavailable"))
+
+		service := domain.NewAuthService(userRepo, &mocks.AuthTokenService{}, &jwtMocks.RefreshTokenRepo{}, &mocks.EmailVerifier{}, loginGuard(), twoFactorDisabled(), passwordHasher(), &mocks.PasskeyVerifier{}, loginLinkRepo, m, &mocks.IdentityVerifier{})
+
+		err := service.RequestLoginLink(ctx, "johndoe@mail.com")
+		require.NoError(t, err)
+
+		receiveMessage(t, messages)
+	})
+}
+
+func TestAuthService_ConsumeLoginLink(t *testing.T) {
+	t.Run("signs the user in", func(t *testing.T) {
+		ctx := context.Background()
+
+		now := time.Now()
+
+		loginLinkRepo := &mocks.LoginLinkRepo{}
+
+		loginLinkRepo.On("Consume", mock.Anything, randtoken.Hash("token")).
+			Return(user.LoginLinkToken{UserID: "user_id"}, nil)
+
+		userRepo := &mocks.UserRepo{}
+
+		userRepo.On("GetByID", mock.Anything, "user_id").
+			Return(user.UserModel{ID: "user_id", EmailVerifiedAt: &now}, nil)
+
+		authTokenService := &mocks.AuthTokenService{}
+
+		authTokenService.On("CreateAccessToken", mock.Anything, mock.Anything, mock.Anything).
+			Return("access_token", nil)
+
+		authTokenService.On("CreateRefreshToken", mock.Anything, mock.Anything, "refresh_token_id").
+			Return("refresh_token", nil)
+
+		refreshTokenRepo := &jwtMocks.RefreshTokenRepo{}
+
+		refreshTokenRepo.On("Create", mock.Anything, mock.Anything).
+			Return(jwt.RefreshToken{ID: "refresh_token_id"}, nil)
+
+		service := domain.NewAuthService(userRepo, authTokenService, refreshTokenRepo, &mocks.EmailVerifier{}, loginGuard(), twoFactorDisabled(), passwordHasher(), &mocks.PasskeyVerifier{}, loginLinkRepo, &mailerMocks.Mailer{}, &mocks.IdentityVerifier{})
+
+		res, err := service.ConsumeLoginLink(ctx, " token ")
+		require.NoError(t, err)
+
+		require.Equal(t, "access_token", res.AccessToken)
+		require.Equal(t, "refresh_token", res.RefreshToken)
+		require.Equal(t, "user_id", res.User.ID)
+
+		userRepo.AssertNotCalled(t, "MarkEmailVerified")
+		loginLinkRepo.AssertExpectations(t)
+		userRepo.AssertExpectations(t)
+		authTokenService.AssertExpectations(t)
+		refreshTokenRepo.AssertExpectations(t)
+	})
+
+	t.Run("verifies the email", func(t *testing.T) {
+		ctx := context.Background()
+
+		now := time.Now()
+
+		loginLinkRepo := &mocks.LoginLinkRepo{}
+
+		loginLinkRepo.On("Consume", mock.Anything, randtoken.Hash("token")).
+			Return(user.LoginLinkToken{UserID: "user_id"}, nil)
+
+		userRepo := &mocks.UserRepo{}
+
+		userRepo.On("GetByID", mock.Anything, "user_id").
+			Return(user.UserModel{ID: "user_id"}, nil)
+
+		userRepo.On("MarkEmailVerified", mock.Anything, "user_id").
+			Return(user.UserModel{ID: "user_id", EmailVerifiedAt: &now}, nil)
+
+		authTokenService := &mocks.AuthTokenService{}
+
+		authTokenService.On("CreateAccessToken", mock.Anything, mock.Anything, mock.Anything).
+			Return("access_token", nil)
+
+		authTokenService.On("CreateRefreshToken", mock.Anything, mock.Anything, mock.Anything).
+			Return("refresh_token", nil)
+
+		refreshTokenRepo := &jwtMocks.RefreshTokenRepo{}
+
+		refreshTokenRepo.On("Create", mock.Anything, mock.Anything).
+			Return(jwt.RefreshToken{ID: "refresh_token_id"}, nil)
+
+		service := domain.NewAuthService(userRepo, authTokenService, refreshTokenRepo, &mocks.EmailVerifier{}, loginGuard(), twoFactorDisabled(), passwordHasher(), &mocks.PasskeyVerifier{}, loginLinkRepo, &mailerMocks.Mailer{}, &mocks.IdentityVerifier{})
+
+		res, err := service.ConsumeLoginLink(ctx, "token")
+		require.NoError(t, err)
+		require.True(t, res.User.IsEmailVerified())
+
+		userRepo.AssertExpectations(t)
+	})
+
+	t.Run("invalid token", func(t *testing.T) {
+		ctx := context.Background()
+
+		loginLinkRepo := &mocks.LoginLinkRepo{}
+
+		loginLinkRepo.On("Consume", mock.Anything, mock.Anything).
+			Return(user.LoginLinkToken{}, user.ErrNotFound)
+
+		refreshTokenRepo := &jwtMocks.RefreshTokenRepo{}
+
+		service := domain.NewAuthService(&mocks.UserRepo{}, &mocks.AuthTokenService{}, refreshTokenRepo, &mocks.EmailVerifier{}, loginGuard(), twoFactorDisabled(), passwordHasher(), &mocks.PasskeyVerifier{}, loginLinkRepo, &mailerMocks.Mailer{}, &mocks.IdentityVerifier{})
+
+		_, err := service.ConsumeLoginLink(ctx, "token")
+		require.ErrorIs(t, err, user.ErrInvalidLoginLink)
+
+		refreshTokenRepo.AssertNotCalled(t, "Create")
+	})
+
+	t.Run("refused with two-factor authentication enabled", func(t *testing.T) {
+		ctx := context.Background()
+
+		loginLinkRepo := &mocks.LoginLinkRepo{}
+
+		loginLinkRepo.On("Consume", mock.Anything, randtoken.Hash("token")).
+			Return(user.LoginLinkToken{UserID: "user_id"}, nil)
+
+		userRepo := &mocks.UserRepo{}
+
+		userRepo.On("GetByID", mock.Anything, "user_id").
+			Return(user.UserModel{ID: "user_id"}, nil)
+
+		twoFactor := &mocks.TwoFactorVerifier{}
+
+		twoFactor.On("IsEnabled", mock.Anything, "user_id").
+			Return(true, nil)
+
+		refreshTokenRepo := &jwtMocks.RefreshTokenRepo{}
+
+		service := domain.NewAuthService(userRepo, &mocks.AuthTokenService{}, refreshTokenRepo, &mocks.EmailVerifier{}, loginGuard(), twoFactor, passwordHasher(), &mocks.PasskeyVerifier{}, loginLinkRepo, &mailerMocks.Mailer{}, &mocks.IdentityVerifier{})
+
+		_, err := service.ConsumeLoginLink(ctx, "token")
+		require.ErrorIs(t, err, user.ErrLoginLinkTwoFactor)
+		require.ErrorIs(t, err, user.ErrForbidden)
+
+		refreshTokenRepo.AssertNotCalled(t, "Create")
+	})
+}
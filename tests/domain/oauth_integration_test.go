@@ -0,0 +1,150 @@
+//go:build integration
+
+package domain
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/RianNegreiros/go-graphql-api/internal/oidc"
+	"github.com/RianNegreiros/go-graphql-api/internal/post"
+	"github.com/RianNegreiros/go-graphql-api/internal/transport"
+	"github.com/RianNegreiros/go-graphql-api/internal/user"
+	"github.com/RianNegreiros/go-graphql-api/tests/test_helpers"
+	"github.com/stretchr/testify/require"
+)
+
+// authorizeOAuthClient runs the authorization request of the client as the
+// logged in user and returns the code it gets back.
+func authorizeOAuthClient(ctx context.Context, t *testing.T, c user.CreatedOAuthClient, scope string) string {
+	redirectURL, err := oauthService.Authorize(ctx, user.OAuthAuthorizationInput{
+		ResponseType:        "code",
+		ClientID:            c.ID,
+		Scope:               scope,
+		CodeChallenge:       oidc.CodeChallenge(codeVerifier),
+		CodeChallengeMethod: "S256",
+	})
+	require.NoError(t, err)
+
+	u, err := url.Parse(redirectURL)
+	require.NoError(t, err)
+
+	return u.Query().Get("code")
+}
+
+func TestIntegrationOAuthService(t *testing.T) {
+	t.Run("authorization code flow", func(t *testing.T) {
+		ctx := context.Background()
+
+		defer test_helpers.TeardownDB(ctx, t, db)
+
+		owner := test_helpers.CreateUser(ctx, t, userRepo)
+		u := test_helpers.CreateUser(ctx, t, userRepo)
+		loggedIn := test_helpers.LoginUser(ctx, t, u)
+
+		c, err := oauthService.CreateClient(test_helpers.LoginUser(ctx, t, owner), user.CreateOAuthClientInput{
+			Name:         "app",
+			RedirectURIs: []string{"https://app.example.com/callback"},
+			Scopes:       []user.Scope{user.ScopePostsRead, user.ScopePostsWrite},
+			Confidential: true,
+		})
+		require.NoError(t, err)
+
+		credentials := user.OAuthClientCredentials{ClientID: c.ID, ClientSecret: c.Secret}
+		code := authorizeOAuthClient(loggedIn, t, c, "posts:read")
+
+		tokenInput := user.OAuthTokenInput{
+			OAuthClientCredentials: credentials,
+			GrantType:              "authorization_code",
+			Code:                   code,
+			CodeVerifier:           codeVerifier,
+		}
+
+		res, err := oauthService.Token(ctx, tokenInput)
+		require.NoError(t, err)
+		require.Equal(t, []user.Scope{user.ScopePostsRead}, res.Scopes)
+
+		_, err = oauthService.Token(ctx, tokenInput)
+		require.ErrorIs(t, err, user.ErrInvalidOAuthGrant)
+
+		grant, err := oauthService.Authenticate(ctx, res.AccessToken)
+		require.NoError(t, err)
+		require.Equal(t, u.ID, grant.UserID)
+
+		appCtx := transport.PutUserIDIntoContext(ctx, grant.UserID)
+		appCtx = transport.PutScopesIntoContext(appCtx, grant.Scopes)
+
+		_, err = postService.Create(appCtx, post.CreatePostInput{Body: "posted by an app"})
+		require.ErrorIs(t, err, user.ErrInsufficientScope)
+
+		in, err := oauthService.Introspect(ctx, credentials, res.AccessToken)
+		require.NoError(t, err)
+		require.True(t, in.Active)
+		require.Equal(t, u.ID, in.UserID)
+
+		refreshed, err := oauthService.Token(ctx, user.OAuthTokenInput{
+			OAuthClientCredentials: credentials,
+			GrantType:              "refresh_token",
+			RefreshToken:           res.RefreshToken,
+		})
+		require.NoError(t, err)
+
+		_, err = oauthService.Token(ctx, user.OAuthTokenInput{
+			OAuthClientCredentials: credentials,
+			GrantType:              "refresh_token",
+			RefreshToken:           res.RefreshToken,
+		})
+		require.ErrorIs(t, err, user.ErrInvalidOAuthGrant)
+
+		require.NoError(t, oauthService.Revoke(ctx, credentials, refreshed.RefreshToken))
+
+		_, err = oauthService.Authenticate(ctx, refreshed.AccessToken)
+		require.ErrorIs(t, err, user.ErrInvalidOAuthToken)
+
+		in, err = oauthService.Introspect(ctx, credentials, refreshed.RefreshToken)
+		require.NoError(t, err)
+		require.False(t, in.Active)
+	})
+
+	t.Run("revoking consent revokes tokens", func(t *testing.T) {
+		ctx := context.Background()
+
+		defer test_helpers.TeardownDB(ctx, t, db)
+
+		u := test_helpers.CreateUser(ctx, t, userRepo)
+		loggedIn := test_helpers.LoginUser(ctx, t, u)
+
+		c, err := oauthService.CreateClient(loggedIn, user.CreateOAuthClientInput{
+			Name:         "app",
+			RedirectURIs: []string{"http://localhost:3000/callback"},
+			Scopes:       []user.Scope{user.ScopePostsRead, user.ScopePostsWrite},
+		})
+		require.NoError(t, err)
+
+		code := authorizeOAuthClient(loggedIn, t, c, "posts:read")
+		authorizeOAuthClient(loggedIn, t, c, "posts:write")
+
+		consents, err := oauthService.Consents(loggedIn)
+		require.NoError(t, err)
+		require.Len(t, consents, 1)
+		require.Equal(t, "app", consents[0].ClientName)
+		require.ElementsMatch(t, []user.Scope{user.ScopePostsRead, user.ScopePostsWrite}, consents[0].Scopes)
+
+		res, err := oauthService.Token(ctx, user.OAuthTokenInput{
+			OAuthClientCredentials: user.OAuthClientCredentials{ClientID: c.ID},
+			GrantType:              "authorization_code",
+			Code:                   code,
+			CodeVerifier:           codeVerifier,
+		})
+		require.NoError(t, err)
+
+		require.NoError(t, oauthService.RevokeConsent(loggedIn, c.ID))
+
+		_, err = oauthService.Authenticate(ctx, res.AccessToken)
+		require.ErrorIs(t, err, user.ErrInvalidOAuthToken)
+
+		err = oauthService.RevokeConsent(loggedIn, c.ID)
+		require.ErrorIs(t, err, user.ErrNotFound)
+	})
+}
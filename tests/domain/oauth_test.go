@@ -0,0 +1,637 @@
+package domain
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/RianNegreiros/go-graphql-api/internal/domain"
+	"github.com/RianNegreiros/go-graphql-api/internal/oidc"
+	"github.com/RianNegreiros/go-graphql-api/internal/randtoken"
+	"github.com/RianNegreiros/go-graphql-api/internal/transport"
+	"github.com/RianNegreiros/go-graphql-api/internal/user"
+	"github.com/RianNegreiros/go-graphql-api/internal/uuid"
+	mocks "github.com/RianNegreiros/go-graphql-api/mocks/internal_/user"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const codeVerifier = "dBjftJeZ4CVP-mJ92K9ptp7Lb8m0iTjgs1i8x8Dhvro5WQCNwbU"
+
+func oauthClient(confidentialSecret string) user.OAuthClient {
+	c := user.OAuthClient{
+		ID:           uuid.Generate(),
+		UserID:       "owner_id",
+		Name:         "app",
+		RedirectURIs: []string{"https://app.example.com/callback"},
+		Scopes:       []user.Scope{user.ScopePostsRead, user.ScopePostsWrite},
+	}
+
+	if confidentialSecret != "" {
+		hash := randtoken.Hash(confidentialSecret)
+		c.SecretHash = &hash
+	}
+
+	return c
+}
+
+func oauthAuthorizationInput(c user.OAuthClient) user.OAuthAuthorizationInput {
+	return user.OAuthAuthorizationInput{
+		ResponseType:        "code",
+		ClientID:            c.ID,
+		Scope:               "posts:read",
+		State:               "xyz",
+		CodeChallenge:       oidc.CodeChallenge(codeVerifier),
+		CodeChallengeMethod: "S256",
+	}
+}
+
+func TestOAuthService_CreateClient(t *testing.T) {
+	validInput := user.CreateOAuthClientInput{
+		Name:         "app",
+		RedirectURIs: []string{"https://app.example.com/callback"},
+		Scopes:       []user.Scope{user.ScopePostsRead},
+	}
+
+	t.Run("confidential clients get a secret once", func(t *testing.T) {
+		ctx := transport.PutUserIDIntoContext(context.Background(), "user_id")
+
+		var stored user.OAuthClient
+
+		repo := &mocks.OAuthRepo{}
+
+		repo.On("CreateClient", mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) {
+				stored = args.Get(1).(user.OAuthClient)
+			}).
+			Return(func(_ context.Context, c user.OAuthClient) user.OAuthClient {
+				c.ID = "client_id"
+				return c
+			}, nil)
+
+		service := domain.NewOAuthService(repo, &mocks.AuthTokenService{})
+
+		input := validInput
+		input.Confidential = true
+
+		created, err := service.CreateClient(ctx, input)
+		require.NoError(t, err)
+
+		require.Equal(t, "client_id", created.ID)
+		require.NotEmpty(t, created.Secret)
+		require.Equal(t, "user_id", stored.UserID)
+		require.Equal(t, randtoken.Hash(created.Secret), *stored.SecretHash)
+
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("public clients have no secret", func(t *testing.T) {
+		ctx := transport.PutUserIDIntoContext(context.Background(), "user_id")
+
+		repo := &mocks.OAuthRepo{}
+
+		repo.On("CreateClient", mock.Anything, mock.Anything).
+			Return(func(_ context.Context, c user.OAuthClient) user.OAuthClient {
+				return c
+			}, nil)
+
+		service := domain.NewOAuthService(repo, &mocks.AuthTokenService{})
+
+		created, err := service.CreateClient(ctx, validInput)
+		require.NoError(t, err)
+
+		require.Empty(t, created.Secret)
+		require.False(t, created.IsConfidential())
+
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("scoped tokens can't register clients", func(t *testing.T) {
+		ctx := withPersonalAccessToken("user_id", user.ScopePostsWrite, user.ScopeUsersWrite)
+
+		repo := &mocks.OAuthRepo{}
+
+		service := domain.NewOAuthService(repo, &mocks.AuthTokenService{})
+
+		_, err := service.CreateClient(ctx, validInput)
+		require.ErrorIs(t, err, user.ErrSessionRequired)
+
+		repo.AssertNotCalled(t, "CreateClient")
+	})
+}
+
+func TestOAuthService_Authorization(t *testing.T) {
+	t.Run("reports a previous consent", func(t *testing.T) {
+		ctx := transport.PutUserIDIntoContext(context.Background(), "user_id")
+		c := oauthClient("")
+
+		repo := &mocks.OAuthRepo{}
+
+		repo.On("GetClientByID", mock.Anything, c.ID).Return(c, nil)
+		repo.On("GetConsent", mock.Anything, "user_id", c.ID).
+			Return(user.OAuthConsent{Scopes: []user.Scope{user.ScopePostsRead}}, nil)
+
+		service := domain.NewOAuthService(repo, &mocks.AuthTokenService{})
+
+		a, err := service.Authorization(ctx, oauthAuthorizationInput(c))
+		require.NoError(t, err)
+
+		require.Equal(t, c.RedirectURIs[0], a.RedirectURI)
+		require.Equal(t, []user.Scope{user.ScopePostsRead}, a.Scopes)
+		require.True(t, a.Consented)
+
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("defaults to the scopes of the client", func(t *testing.T) {
+		ctx := transport.PutUserIDIntoContext(context.Background(), "user_id")
+		c := oauthClient("")
+
+		repo := &mocks.OAuthRepo{}
+
+		repo.On("GetClientByID", mock.Anything, c.ID).Return(c, nil)
+		repo.On("GetConsent", mock.Anything, "user_id", c.ID).
+			Return(user.OAuthConsent{Scopes: []user.Scope{user.ScopePostsRead}}, nil)
+
+		service := domain.NewOAuthService(repo, &mocks.AuthTokenService{})
+
+		input := oauthAuthorizationInput(c)
+		input.Scope = ""
+
+		a, err := service.Authorization(ctx, input)
+		require.NoError(t, err)
+
+		require.Equal(t, c.Scopes, a.Scopes)
+		require.False(t, a.Consented)
+	})
+
+	testCases := []struct {
+		name   string
+		modify func(in *user.OAuthAuthorizationInput)
+		err    error
+	}{
+		{
+			name:   "unknown client",
+			modify: func(in *user.OAuthAuthorizationInput) { in.ClientID = uuid.Generate() },
+			err:    user.ErrInvalidOAuthClient,
+		},
+		{
+			name:   "unregistered redirect uri",
+			modify: func(in *user.OAuthAuthorizationInput) { in.RedirectURI = "https://evil.example.com/callback" },
+			err:    user.ErrInvalidRedirectURI,
+		},
+		{
+			name:   "scope not allowed for the client",
+			modify: func(in *user.OAuthAuthorizationInput) { in.Scope = "posts:read users:write" },
+			err:    user.ErrInvalidOAuthScope,
+		},
+		{
+			name:   "missing pkce",
+			modify: func(in *user.OAuthAuthorizationInput) { in.CodeChallenge, in.CodeChallengeMethod = "", "" },
+			err:    user.ErrValidation,
+		},
+		{
+			name:   "plain pkce",
+			modify: func(in *user.OAuthAuthorizationInput) { in.CodeChallengeMethod = "plain" },
+			err:    user.ErrValidation,
+		},
+		{
+			name:   "implicit flow",
+			modify: func(in *user.OAuthAuthorizationInput) { in.ResponseType = "token" },
+			err:    user.ErrValidation,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := transport.PutUserIDIntoContext(context.Background(), "user_id")
+			c := oauthClient("")
+
+			repo := &mocks.OAuthRepo{}
+
+			repo.On("GetClientByID", mock.Anything, c.ID).Return(c, nil)
+			repo.On("GetClientByID", mock.Anything, mock.Anything).Return(user.OAuthClient{}, user.ErrNotFound)
+
+			service := domain.NewOAuthService(repo, &mocks.AuthTokenService{})
+
+			input := oauthAuthorizationInput(c)
+			tc.modify(&input)
+
+			_, err := service.Authorization(ctx, input)
+			require.ErrorIs(t, err, tc.err)
+
+			repo.AssertNotCalled(t, "GetConsent", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestOAuthService_Authorize(t *testing.T) {
+	t.Run("records the consent and redirects with a code", func(t *testing.T) {
+		ctx := transport.PutUserIDIntoContext(context.Background(), "user_id")
+		c := oauthClient("")
+
+		var code user.OAuthAuthorizationCode
+
+		repo := &mocks.OAuthRepo{}
+
+		repo.On("GetClientByID", mock.Anything, c.ID).Return(c, nil)
+		repo.On("SaveConsent", mock.Anything, mock.MatchedBy(func(consent user.OAuthConsent) bool {
+			return consent.UserID == "user_id" && consent.ClientID == c.ID
+		})).Return(user.OAuthConsent{}, nil)
+		repo.On("CreateCode", mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) {
+				code = args.Get(1).(user.OAuthAuthorizationCode)
+			}).
+			Return(nil)
+
+		service := domain.NewOAuthService(repo, &mocks.AuthTokenService{})
+
+		redirectURL, err := service.Authorize(ctx, oauthAuthorizationInput(c))
+		require.NoError(t, err)
+
+		u, err := url.Parse(redirectURL)
+		require.NoError(t, err)
+		require.True(t, strings.HasPrefix(redirectURL, c.RedirectURIs[0]+"?"))
+		require.Equal(t, "xyz", u.Query().Get("state"))
+		require.Equal(t, randtoken.Hash(u.Query().Get("code")), code.CodeHash)
+		require.Equal(t, "user_id", code.UserID)
+		require.Equal(t, oidc.CodeChallenge(codeVerifier), code.CodeChallenge)
+		require.False(t, code.RedirectURIRequired)
+		require.Equal(t, []user.Scope{user.ScopePostsRead}, code.Scopes)
+
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("scoped tokens can't authorize clients", func(t *testing.T) {
+		ctx := withPersonalAccessToken("user_id", user.ScopePostsWrite)
+		c := oauthClient("")
+
+		repo := &mocks.OAuthRepo{}
+
+		service := domain.NewOAuthService(repo, &mocks.AuthTokenService{})
+
+		_, err := service.Authorize(ctx, oauthAuthorizationInput(c))
+		require.ErrorIs(t, err, user.ErrSessionRequired)
+
+		repo.AssertNotCalled(t, "SaveConsent", mock.Anything, mock.Anything)
+	})
+}
+
+func TestOAuthService_Token(t *testing.T) {
+	authorizationCode := func(c user.OAuthClient) user.OAuthAuthorizationCode {
+		return user.OAuthAuthorizationCode{
+			ClientID:      c.ID,
+			UserID:        "user_id",
+			RedirectURI:   c.RedirectURIs[0],
+			Scopes:        []user.Scope{user.ScopePostsRead},
+			CodeChallenge: oidc.CodeChallenge(codeVerifier),
+		}
+	}
+
+	t.Run("redeems a code for tokens", func(t *testing.T) {
+		ctx := context.Background()
+		c := oauthClient("secret")
+
+		var grant user.OAuthGrant
+
+		repo := &mocks.OAuthRepo{}
+		authTokenService := &mocks.AuthTokenService{}
+
+		repo.On("GetClientByID", mock.Anything, c.ID).Return(c, nil)
+		repo.On("ConsumeCode", mock.Anything, randtoken.Hash("code")).Return(authorizationCode(c), nil)
+		repo.On("CreateGrant", mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) {
+				grant = args.Get(1).(user.OAuthGrant)
+			}).
+			Return(func(_ context.Context, g user.OAuthGrant) user.OAuthGrant {
+				g.ID = "grant_id"
+				return g
+			}, nil)
+		authTokenService.On("CreateOAuthAccessToken", mock.Anything, mock.MatchedBy(func(g user.OAuthGrant) bool {
+			return g.ID == "grant_id"
+		})).Return("access_token", nil)
+
+		service := domain.NewOAuthService(repo, authTokenService)
+
+		res, err := service.Token(ctx, user.OAuthTokenInput{
+			OAuthClientCredentials: user.OAuthClientCredentials{ClientID: c.ID, ClientSecret: "secret"},
+			GrantType:              "authorization_code",
+			Code:                   "code",
+			RedirectURI:            c.RedirectURIs[0],
+			CodeVerifier:           codeVerifier,
+		})
+		require.NoError(t, err)
+
+		require.Equal(t, "access_token", res.AccessToken)
+		require.True(t, strings.HasPrefix(res.RefreshToken, user.OAuthRefreshTokenPrefix))
+		require.Equal(t, randtoken.Hash(res.RefreshToken), grant.RefreshTokenHash)
+		require.Equal(t, "user_id", grant.UserID)
+		require.Equal(t, []user.Scope{user.ScopePostsRead}, res.Scopes)
+
+		repo.AssertExpectations(t)
+		authTokenService.AssertExpectations(t)
+	})
+
+	t.Run("wrong code verifier", func(t *testing.T) {
+		ctx := context.Background()
+		c := oauthClient("")
+
+		repo := &mocks.OAuthRepo{}
+
+		repo.On("GetClientByID", mock.Anything, c.ID).Return(c, nil)
+		repo.On("ConsumeCode", mock.Anything, randtoken.Hash("code")).Return(authorizationCode(c), nil)
+
+		service := domain.NewOAuthService(repo, &mocks.AuthTokenService{})
+
+		_, err := service.Token(ctx, user.OAuthTokenInput{
+			OAuthClientCredentials: user.OAuthClientCredentials{ClientID: c.ID},
+			GrantType:              "authorization_code",
+			Code:                   "code",
+			CodeVerifier:           strings.Repeat("a", 43),
+		})
+		require.ErrorIs(t, err, user.ErrInvalidOAuthGrant)
+
+		repo.AssertNotCalled(t, "CreateGrant", mock.Anything, mock.Anything)
+	})
+
+	t.Run("code verifier of the wrong length", func(t *testing.T) {
+		ctx := context.Background()
+		c := oauthClient("")
+
+		repo := &mocks.OAuthRepo{}
+
+		repo.On("GetClientByID", mock.Anything, c.ID).Return(c, nil)
+
+		service := domain.NewOAuthService(repo, &mocks.AuthTokenService{})
+
+		for _, verifier := range []string{strings.Repeat("a", 42), strings.Repeat("a", 129)} {
+			_, err := service.Token(ctx, user.OAuthTokenInput{
+				OAuthClientCredentials: user.OAuthClientCredentials{ClientID: c.ID},
+				GrantType:              "authorization_code",
+				Code:                   "code",
+				CodeVerifier:           verifier,
+			})
+			require.ErrorIs(t, err, user.ErrValidation)
+		}
+
+		repo.AssertNotCalled(t, "ConsumeCode", mock.Anything, mock.Anything)
+	})
+
+	t.Run("redirect uri named when authorizing is required", func(t *testing.T) {
+		ctx := context.Background()
+		c := oauthClient("")
+
+		code := authorizationCode(c)
+		code.RedirectURIRequired = true
+
+		repo := &mocks.OAuthRepo{}
+
+		repo.On("GetClientByID", mock.Anything, c.ID).Return(c, nil)
+		repo.On("ConsumeCode", mock.Anything, randtoken.Hash("code")).Return(code, nil)
+
+		service := domain.NewOAuthService(repo, &mocks.AuthTokenService{})
+
+		_, err := service.Token(ctx, user.OAuthTokenInput{
+			OAuthClientCredentials: user.OAuthClientCredentials{ClientID: c.ID},
+			GrantType:              "authorization_code",
+			Code:                   "code",
+			CodeVerifier:           codeVerifier,
+		})
+		require.ErrorIs(t, err, user.ErrInvalidOAuthGrant)
+
+		repo.AssertNotCalled(t, "CreateGrant", mock.Anything, mock.Anything)
+	})
+
+	t.Run("code issued to another client", func(t *testing.T) {
+		ctx := context.Background()
+		c := oauthClient("")
+		other := oauthClient("")
+
+		repo := &mocks.OAuthRepo{}
+
+		repo.On("GetClientByID", mock.Anything, c.ID).Return(c, nil)
+		repo.On("ConsumeCode", mock.Anything, randtoken.Hash("code")).Return(authorizationCode(other), nil)
+
+		service := domain.NewOAuthService(repo, &mocks.AuthTokenService{})
+
+		_, err := service.Token(ctx, user.OAuthTokenInput{
+			OAuthClientCredentials: user.OAuthClientCredentials{ClientID: c.ID},
+			GrantType:              "authorization_code",
+			Code:                   "code",
+			CodeVerifier:           codeVerifier,
+		})
+		require.ErrorIs(t, err, user.ErrInvalidOAuthGrant)
+
+		repo.AssertNotCalled(t, "CreateGrant", mock.Anything, mock.Anything)
+	})
+
+	t.Run("wrong client secret", func(t *testing.T) {
+		ctx := context.Background()
+		c := oauthClient("secret")
+
+		repo := &mocks.OAuthRepo{}
+
+		repo.On("GetClientByID", mock.Anything, c.ID).Return(c, nil)
+
+		service := domain.NewOAuthService(repo, &mocks.AuthTokenService{})
+
+		_, err := service.Token(ctx, user.OAuthTokenInput{
+			OAuthClientCredentials: user.OAuthClientCredentials{ClientID: c.ID, ClientSecret: "wrong"},
+			GrantType:              "authorization_code",
+			Code:                   "code",
+			CodeVerifier:           codeVerifier,
+		})
+		require.ErrorIs(t, err, user.ErrInvalidOAuthClient)
+
+		repo.AssertNotCalled(t, "ConsumeCode", mock.Anything, mock.Anything)
+	})
+
+	t.Run("unsupported grant type", func(t *testing.T) {
+		ctx := context.Background()
+		c := oauthClient("")
+
+		repo := &mocks.OAuthRepo{}
+
+		repo.On("GetClientByID", mock.Anything, c.ID).Return(c, nil)
+
+		service := domain.NewOAuthService(repo, &mocks.AuthTokenService{})
+
+		_, err := service.Token(ctx, user.OAuthTokenInput{
+			OAuthClientCredentials: user.OAuthClientCredentials{ClientID: c.ID},
+			GrantType:              "password",
+		})
+		require.ErrorIs(t, err, user.ErrUnsupportedGrantType)
+	})
+
+	t.Run("rotates refresh tokens", func(t *testing.T) {
+		ctx := context.Background()
+		c := oauthClient("")
+		grant := user.OAuthGrant{ID: "grant_id", ClientID: c.ID, UserID: "user_id", Scopes: []user.Scope{user.ScopePostsRead}}
+
+		var newHash string
+
+		repo := &mocks.OAuthRepo{}
+		authTokenService := &mocks.AuthTokenService{}
+
+		repo.On("GetClientByID", mock.Anything, c.ID).Return(c, nil)
+		repo.On("GetActiveGrantByRefreshTokenHash", mock.Anything, randtoken.Hash("ort_old")).Return(grant, nil)
+		repo.On("RotateGrant", mock.Anything, randtoken.Hash("ort_old"), mock.Anything).
+			Run(func(args mock.Arguments) {
+				newHash = args.String(2)
+			}).
+			Return(grant, nil)
+		authTokenService.On("CreateOAuthAccessToken", mock.Anything, grant).Return("access_token", nil)
+
+		service := domain.NewOAuthService(repo, authTokenService)
+
+		res, err := service.Token(ctx, user.OAuthTokenInput{
+			OAuthClientCredentials: user.OAuthClientCredentials{ClientID: c.ID},
+			GrantType:              "refresh_token",
+			RefreshToken:           "ort_old",
+		})
+		require.NoError(t, err)
+
+		require.Equal(t, randtoken.Hash(res.RefreshToken), newHash)
+		require.NotEqual(t, "ort_old", res.RefreshToken)
+
+		repo.AssertExpectations(t)
+	})
+}
+
+func TestOAuthService_Revoke(t *testing.T) {
+	t.Run("revokes the grant of a refresh token", func(t *testing.T) {
+		ctx := context.Background()
+		c := oauthClient("")
+
+		repo := &mocks.OAuthRepo{}
+
+		repo.On("GetClientByID", mock.Anything, c.ID).Return(c, nil)
+		repo.On("GetActiveGrantByRefreshTokenHash", mock.Anything, randtoken.Hash("ort_token")).
+			Return(user.OAuthGrant{ID: "grant_id", ClientID: c.ID}, nil)
+		repo.On("RevokeGrant", mock.Anything, "grant_id").Return(nil)
+
+		service := domain.NewOAuthService(repo, &mocks.AuthTokenService{})
+
+		err := service.Revoke(ctx, user.OAuthClientCredentials{ClientID: c.ID}, "ort_token")
+		require.NoError(t, err)
+
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("ignores tokens of other clients", func(t *testing.T) {
+		ctx := context.Background()
+		c := oauthClient("")
+
+		repo := &mocks.OAuthRepo{}
+
+		repo.On("GetClientByID", mock.Anything, c.ID).Return(c, nil)
+		repo.On("GetActiveGrantByRefreshTokenHash", mock.Anything, randtoken.Hash("ort_token")).
+			Return(user.OAuthGrant{ID: "grant_id", ClientID: uuid.Generate()}, nil)
+
+		service := domain.NewOAuthService(repo, &mocks.AuthTokenService{})
+
+		err := service.Revoke(ctx, user.OAuthClientCredentials{ClientID: c.ID}, "ort_token")
+		require.NoError(t, err)
+
+		repo.AssertNotCalled(t, "RevokeGrant", mock.Anything, mock.Anything)
+	})
+
+	t.Run("ignores unknown tokens", func(t *testing.T) {
+		ctx := context.Background()
+		c := oauthClient("")
+
+		repo := &mocks.OAuthRepo{}
+		authTokenService := &mocks.AuthTokenService{}
+
+		repo.On("GetClientByID", mock.Anything, c.ID).Return(c, nil)
+		authTokenService.On("ParseOAuthAccessToken", mock.Anything, "garbage").
+			Return(user.OAuthAccessToken{}, user.ErrInvalidOAuthToken)
+
+		service := domain.NewOAuthService(repo, authTokenService)
+
+		err := service.Revoke(ctx, user.OAuthClientCredentials{ClientID: c.ID}, "garbage")
+		require.NoError(t, err)
+	})
+}
+
+func TestOAuthService_Introspect(t *testing.T) {
+	t.Run("describes an access token", func(t *testing.T) {
+		ctx := context.Background()
+		c := oauthClient("secret")
+		grantID := uuid.Generate()
+		accessToken := user.OAuthAccessToken{Sub: "user_id", ClientID: c.ID, GrantID: grantID, Scopes: []user.Scope{user.ScopePostsRead}}
+
+		repo := &mocks.OAuthRepo{}
+		authTokenService := &mocks.AuthTokenService{}
+
+		repo.On("GetClientByID", mock.Anything, c.ID).Return(c, nil)
+		repo.On("GetActiveGrant", mock.Anything, grantID).
+			Return(user.OAuthGrant{ID: grantID, ClientID: c.ID, UserID: "user_id"}, nil)
+		authTokenService.On("ParseOAuthAccessToken", mock.Anything, "access_token").Return(accessToken, nil)
+
+		service := domain.NewOAuthService(repo, authTokenService)
+
+		in, err := service.Introspect(ctx, user.OAuthClientCredentials{ClientID: c.ID, ClientSecret: "secret"}, "access_token")
+		require.NoError(t, err)
+
+		require.True(t, in.Active)
+		require.Equal(t, "access_token", in.TokenType)
+		require.Equal(t, "user_id", in.UserID)
+		require.Equal(t, accessToken.Scopes, in.Scopes)
+	})
+
+	t.Run("revoked grants are inactive", func(t *testing.T) {
+		ctx := context.Background()
+		c := oauthClient("")
+		grantID := uuid.Generate()
+
+		repo := &mocks.OAuthRepo{}
+		authTokenService := &mocks.AuthTokenService{}
+
+		repo.On("GetClientByID", mock.Anything, c.ID).Return(c, nil)
+		repo.On("GetActiveGrant", mock.Anything, grantID).Return(user.OAuthGrant{}, user.ErrNotFound)
+		authTokenService.On("ParseOAuthAccessToken", mock.Anything, "access_token").
+			Return(user.OAuthAccessToken{Sub: "user_id", ClientID: c.ID, GrantID: grantID}, nil)
+
+		service := domain.NewOAuthService(repo, authTokenService)
+
+		in, err := service.Introspect(ctx, user.OAuthClientCredentials{ClientID: c.ID}, "access_token")
+		require.NoError(t, err)
+		require.False(t, in.Active)
+	})
+}
+
+func TestOAuthService_Authenticate(t *testing.T) {
+	t.Run("returns the grant of an access token", func(t *testing.T) {
+		ctx := context.Background()
+		grant := user.OAuthGrant{ID: uuid.Generate(), ClientID: "client_id", UserID: "user_id", Scopes: []user.Scope{user.ScopePostsRead}}
+
+		repo := &mocks.OAuthRepo{}
+		authTokenService := &mocks.AuthTokenService{}
+
+		repo.On("GetActiveGrant", mock.Anything, grant.ID).Return(grant, nil)
+		authTokenService.On("ParseOAuthAccessToken", mock.Anything, "access_token").
+			Return(user.OAuthAccessToken{Sub: "user_id", ClientID: "client_id", GrantID: grant.ID}, nil)
+
+		service := domain.NewOAuthService(repo, authTokenService)
+
+		g, err := service.Authenticate(ctx, "access_token")
+		require.NoError(t, err)
+		require.Equal(t, grant, g)
+	})
+
+	t.Run("refresh tokens don't authenticate", func(t *testing.T) {
+		ctx := context.Background()
+
+		repo := &mocks.OAuthRepo{}
+
+		repo.On("GetActiveGrantByRefreshTokenHash", mock.Anything, randtoken.Hash("ort_token")).
+			Return(user.OAuthGrant{ID: "grant_id"}, nil)
+
+		service := domain.NewOAuthService(repo, &mocks.AuthTokenService{})
+
+		_, err := service.Authenticate(ctx, "ort_token")
+		require.ErrorIs(t, err, user.ErrInvalidOAuthToken)
+	})
+}
@@ -0,0 +1,121 @@
+//go:build integration
+
+package domain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/RianNegreiros/go-graphql-api/internal/user"
+	"github.com/RianNegreiros/go-graphql-api/tests/test_helpers"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntegrationPasskeyService(t *testing.T) {
+	t.Run("register, login and delete", func(t *testing.T) {
+		ctx := context.Background()
+
+		defer test_helpers.TeardownDB(ctx, t, db)
+
+		u := test_helpers.CreateUser(ctx, t, userRepo)
+		loggedIn := test_helpers.LoginUser(ctx, t, u)
+
+		a := test_helpers.NewAuthenticator(t)
+
+		creation, err := passkeyService.BeginRegistration(loggedIn)
+		require.NoError(t, err)
+		require.Empty(t, creation.ExcludeCredentialIDs)
+
+		p, err := passkeyService.FinishRegistration(loggedIn, a.RegistrationInput(t, "laptop", creation.Challenge))
+		require.NoError(t, err)
+		require.Equal(t, u.ID, p.UserID)
+		require.Equal(t, a.CredentialID, p.CredentialID)
+
+		// The challenge can't be answered twice.
+		_, err = passkeyService.FinishRegistration(loggedIn, a.RegistrationInput(t, "laptop", creation.Challenge))
+		require.ErrorIs(t, err, user.ErrInvalidPasskey)
+
+		request, err := passkeyService.BeginLogin(ctx)
+		require.NoError(t, err)
+
+		res, err := authService.LoginWithPasskey(ctx, a.LoginInput(t, request.Challenge, u.ID))
+		require.NoError(t, err)
+		require.Equal(t, u.ID, res.User.ID)
+		require.NotEmpty(t, res.AccessToken)
+		require.NotEmpty(t, res.RefreshToken)
+
+		passkeys, err := passkeyService.List(loggedIn)
+		require.NoError(t, err)
+		require.Len(t, passkeys, 1)
+		require.Equal(t, int64(1), passkeys[0].SignCount)
+		require.NotNil(t, passkeys[0].LastUsedAt)
+
+		require.NoError(t, passkeyService.Delete(loggedIn, p.ID))
+
+		request, err = passkeyService.BeginLogin(ctx)
+		require.NoError(t, err)
+
+		_, err = authService.LoginWithPasskey(ctx, a.LoginInput(t, request.Challenge, u.ID))
+		require.ErrorIs(t, err, user.ErrInvalidPasskey)
+	})
+
+	t.Run("rejects cloned passkeys", func(t *testing.T) {
+		ctx := context.Background()
+
+		defer test_helpers.TeardownDB(ctx, t, db)
+
+		u := test_helpers.CreateUser(ctx, t, userRepo)
+		loggedIn := test_helpers.LoginUser(ctx, t, u)
+
+		a := test_helpers.NewAuthenticator(t)
+
+		creation, err := passkeyService.BeginRegistration(loggedIn)
+		require.NoError(t, err)
+
+		_, err = passkeyService.FinishRegistration(loggedIn, a.RegistrationInput(t, "laptop", creation.Challenge))
+		require.NoError(t, err)
+
+		for i := 0; i < 2; i++ {
+			request, err := passkeyService.BeginLogin(ctx)
+			require.NoError(t, err)
+
+			_, err = authService.LoginWithPasskey(ctx, a.LoginInput(t, request.Challenge, ""))
+			require.NoError(t, err)
+		}
+
+		clone := *a
+		clone.SignCount = 1
+
+		request, err := passkeyService.BeginLogin(ctx)
+		require.NoError(t, err)
+
+		_, err = authService.LoginWithPasskey(ctx, clone.LoginInput(t, request.Challenge, ""))
+		require.ErrorIs(t, err, user.ErrPasskeyReplayed)
+	})
+
+	t.Run("accepts authenticators without a counter", func(t *testing.T) {
+		ctx := context.Background()
+
+		defer test_helpers.TeardownDB(ctx, t, db)
+
+		u := test_helpers.CreateUser(ctx, t, userRepo)
+		loggedIn := test_helpers.LoginUser(ctx, t, u)
+
+		a := test_helpers.NewAuthenticator(t)
+		a.NoCounter = true
+
+		creation, err := passkeyService.BeginRegistration(loggedIn)
+		require.NoError(t, err)
+
+		_, err = passkeyService.FinishRegistration(loggedIn, a.RegistrationInput(t, "phone", creation.Challenge))
+		require.NoError(t, err)
+
+		for i := 0; i < 2; i++ {
+			request, err := passkeyService.BeginLogin(ctx)
+			require.NoError(t, err)
+
+			_, err = authService.LoginWithPasskey(ctx, a.LoginInput(t, request.Challenge, ""))
+			require.NoError(t, err)
+		}
+	})
+}
@@ -0,0 +1,423 @@
+package domain
+
+import (
+	"context"
+	"encoding/base64"
+	"testing"
+
+	"github.com/RianNegreiros/go-graphql-api/internal/domain"
+	"github.com/RianNegreiros/go-graphql-api/internal/jwt"
+	"github.com/RianNegreiros/go-graphql-api/internal/transport"
+	"github.com/RianNegreiros/go-graphql-api/internal/user"
+	"github.com/RianNegreiros/go-graphql-api/internal/webauthn"
+	jwtMocks "github.com/RianNegreiros/go-graphql-api/mocks/internal_/jwt"
+	mailerMocks "github.com/RianNegreiros/go-graphql-api/mocks/internal_/mailer"
+	mocks "github.com/RianNegreiros/go-graphql-api/mocks/internal_/user"
+	"github.com/RianNegreiros/go-graphql-api/tests/test_helpers"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPasskeyService_BeginRegistration(t *testing.T) {
+	t.Run("issues a challenge for the current user", func(t *testing.T) {
+		ctx := transport.PutUserIDIntoContext(context.Background(), "user_id")
+
+		userRepo := &mocks.UserRepo{}
+
+		userRepo.On("GetByID", mock.Anything, "user_id").
+			Return(user.UserModel{ID: "user_id", Username: "john", Email: "john@mail.com"}, nil)
+
+		var challenge user.PasskeyChallenge
+
+		passkeyRepo := &mocks.PasskeyRepo{}
+
+		passkeyRepo.On("GetByUserID", mock.Anything, "user_id").
+			Return([]user.Passkey{{CredentialID: []byte("existing")}}, nil)
+
+		passkeyRepo.On("CreateChallenge", mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) {
+				challenge = args.Get(1).(user.PasskeyChallenge)
+			}).
+			Return(nil)
+
+		service := domain.NewPasskeyService(userRepo, passkeyRepo)
+
+		opts, err := service.BeginRegistration(ctx)
+		require.NoError(t, err)
+
+		require.Equal(t, challenge.Challenge, opts.Challenge)
+		require.Equal(t, user.PasskeyRegistration, challenge.Ceremony)
+		require.Equal(t, "user_id", *challenge.UserID)
+		require.Equal(t, user.PasskeyRelyingParty.ID, opts.RPID)
+		require.Equal(t, base64.RawURLEncoding.EncodeToString([]byte("user_id")), opts.UserID)
+		require.Equal(t, "john@mail.com", opts.UserName)
+		require.Equal(t, webauthn.Algorithms, opts.Algorithms)
+		require.Equal(t, []string{base64.RawURLEncoding.EncodeToString([]byte("existing"))}, opts.ExcludeCredentialIDs)
+
+		userRepo.AssertExpectations(t)
+		passkeyRepo.AssertExpectations(t)
+	})
+
+	t.Run("can't be started with a personal access token", func(t *testing.T) {
+		ctx := withPersonalAccessToken("user_id", user.Scopes...)
+
+		passkeyRepo := &mocks.PasskeyRepo{}
+
+		service := domain.NewPasskeyService(&mocks.UserRepo{}, passkeyRepo)
+
+		_, err := service.BeginRegistration(ctx)
+		require.ErrorIs(t, err, user.ErrSessionRequired)
+
+		passkeyRepo.AssertNotCalled(t, "CreateChallenge")
+	})
+
+	t.Run("unauthenticated", func(t *testing.T) {
+		service := domain.NewPasskeyService(&mocks.UserRepo{}, &mocks.PasskeyRepo{})
+
+		_, err := service.BeginRegistration(context.Background())
+		require.ErrorIs(t, err, user.ErrUnauthenticated)
+	})
+}
+
+func TestPasskeyService_FinishRegistration(t *testing.T) {
+	userID := "user_id"
+
+	t.Run("registers the passkey", func(t *testing.T) {
+		ctx := transport.PutUserIDIntoContext(context.Background(), userID)
+
+		a := test_helpers.NewAuthenticator(t)
+
+		passkeyRepo := &mocks.PasskeyRepo{}
+
+		passkeyRepo.On("ConsumeChallenge", mock.Anything, "challenge", user.PasskeyRegistration).
+			Return(user.PasskeyChallenge{Challenge: "challenge", UserID: &userID}, nil)
+
+		passkeyRepo.On("GetByCredentialID", mock.Anything, a.CredentialID).
+			Return(user.Passkey{}, user.ErrNotFound)
+
+		passkeyRepo.On("Create", mock.Anything, user.Passkey{
+			UserID:       userID,
+			Name:         "laptop",
+			CredentialID: a.CredentialID,
+			PublicKey:    a.PublicKey(),
+		}).Return(user.Passkey{ID: "passkey_id", Name: "laptop"}, nil)
+
+		service := domain.NewPasskeyService(&mocks.UserRepo{}, passkeyRepo)
+
+		p, err := service.FinishRegistration(ctx, a.RegistrationInput(t, " laptop ", "challenge"))
+		require.NoError(t, err)
+		require.Equal(t, "passkey_id", p.ID)
+
+		passkeyRepo.AssertExpectations(t)
+	})
+
+	t.Run("rejects challenges issued to another user", func(t *testing.T) {
+		ctx := transport.PutUserIDIntoContext(context.Background(), userID)
+
+		a := test_helpers.NewAuthenticator(t)
+		otherUserID := "other_user_id"
+
+		passkeyRepo := &mocks.PasskeyRepo{}
+
+		passkeyRepo.On("ConsumeChallenge", mock.Anything, "challenge", user.PasskeyRegistration).
+			Return(user.PasskeyChallenge{Challenge: "challenge", UserID: &otherUserID}, nil)
+
+		service := domain.NewPasskeyService(&mocks.UserRepo{}, passkeyRepo)
+
+		_, err := service.FinishRegistration(ctx, a.RegistrationInput(t, "laptop", "challenge"))
+		require.ErrorIs(t, err, user.ErrInvalidPasskey)
+
+		passkeyRepo.AssertNotCalled(t, "Create")
+	})
+
+	t.Run("rejects unknown challenges", func(t *testing.T) {
+		ctx := transport.PutUserIDIntoContext(context.Background(), userID)
+
+		a := test_helpers.NewAuthenticator(t)
+
+		passkeyRepo := &mocks.PasskeyRepo{}
+
+		passkeyRepo.On("ConsumeChallenge", mock.Anything, "challenge", user.PasskeyRegistration).
+			Return(user.PasskeyChallenge{}, user.ErrNotFound)
+
+		service := domain.NewPasskeyService(&mocks.UserRepo{}, passkeyRepo)
+
+		_, err := service.FinishRegistration(ctx, a.RegistrationInput(t, "laptop", "challenge"))
+		require.ErrorIs(t, err, user.ErrInvalidPasskey)
+
+		passkeyRepo.AssertNotCalled(t, "Create")
+	})
+
+	t.Run("rejects passkeys already registered", func(t *testing.T) {
+		ctx := transport.PutUserIDIntoContext(context.Background(), userID)
+
+		a := test_helpers.NewAuthenticator(t)
+
+		passkeyRepo := &mocks.PasskeyRepo{}
+
+		passkeyRepo.On("ConsumeChallenge", mock.Anything, "challenge", user.PasskeyRegistration).
+			Return(user.PasskeyChallenge{Challenge: "challenge", UserID: &userID}, nil)
+
+		passkeyRepo.On("GetByCredentialID", mock.Anything, a.CredentialID).
+			Return(user.Passkey{ID: "passkey_id"}, nil)
+
+		service := domain.NewPasskeyService(&mocks.UserRepo{}, passkeyRepo)
+
+		_, err := service.FinishRegistration(ctx, a.RegistrationInput(t, "laptop", "challenge"))
+		require.ErrorIs(t, err, user.ErrPasskeyTaken)
+
+		passkeyRepo.AssertNotCalled(t, "Create")
+	})
+
+	t.Run("rejects invalid responses", func(t *testing.T) {
+		ctx := transport.PutUserIDIntoContext(context.Background(), userID)
+
+		a := test_helpers.NewAuthenticator(t)
+		a.Origin = "https://evil.example"
+
+		passkeyRepo := &mocks.PasskeyRepo{}
+
+		service := domain.NewPasskeyService(&mocks.UserRepo{}, passkeyRepo)
+
+		_, err := service.FinishRegistration(ctx, a.RegistrationInput(t, "laptop", "challenge"))
+		require.ErrorIs(t, err, user.ErrInvalidPasskey)
+
+		passkeyRepo.AssertNotCalled(t, "ConsumeChallenge")
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		ctx := transport.PutUserIDIntoContext(context.Background(), userID)
+
+		a := test_helpers.NewAuthenticator(t)
+
+		passkeyRepo := &mocks.PasskeyRepo{}
+
+		service := domain.NewPasskeyService(&mocks.UserRepo{}, passkeyRepo)
+
+		_, err := service.FinishRegistration(ctx, a.RegistrationInput(t, "", "challenge"))
+		require.ErrorIs(t, err, user.ErrValidation)
+
+		passkeyRepo.AssertNotCalled(t, "ConsumeChallenge")
+	})
+}
+
+func TestPasskeyService_VerifyLogin(t *testing.T) {
+	passkeyFor := func(a *test_helpers.Authenticator) user.Passkey {
+		return user.Passkey{
+			ID:           "passkey_id",
+			UserID:       "user_id",
+			CredentialID: a.CredentialID,
+			PublicKey:    a.PublicKey(),
+		}
+	}
+
+	t.Run("returns the user of the passkey", func(t *testing.T) {
+		a := test_helpers.NewAuthenticator(t)
+
+		passkeyRepo := &mocks.PasskeyRepo{}
+
+		passkeyRepo.On("GetByCredentialID", mock.Anything, a.CredentialID).
+			Return(passkeyFor(a), nil)
+
+		passkeyRepo.On("ConsumeChallenge", mock.Anything, "challenge", user.PasskeyLogin).
+			Return(user.PasskeyChallenge{Challenge: "challenge"}, nil)
+
+		passkeyRepo.On("UpdateSignCount", mock.Anything, "passkey_id", int64(1)).
+			Return(nil)
+
+		service := domain.NewPasskeyService(&mocks.UserRepo{}, passkeyRepo)
+
+		userID, err := service.VerifyLogin(context.Background(), a.LoginInput(t, "challenge", "user_id"))
+		require.NoError(t, err)
+		require.Equal(t, "user_id", userID)
+
+		passkeyRepo.AssertExpectations(t)
+	})
+
+	t.Run("rejects replayed sign counts", func(t *testing.T) {
+		a := test_helpers.NewAuthenticator(t)
+
+		passkeyRepo := &mocks.PasskeyRepo{}
+
+		passkeyRepo.On("GetByCredentialID", mock.Anything, a.CredentialID).
+			Return(passkeyFor(a), nil)
+
+		passkeyRepo.On("ConsumeChallenge", mock.Anything, "challenge", user.PasskeyLogin).
+			Return(user.PasskeyChallenge{Challenge: "challenge"}, nil)
+
+		passkeyRepo.On("UpdateSignCount", mock.Anything, "passkey_id", int64(1)).
+			Return(user.ErrPasskeyReplayed)
+
+		service := domain.NewPasskeyService(&mocks.UserRepo{}, passkeyRepo)
+
+		_, err := service.VerifyLogin(context.Background(), a.LoginInput(t, "challenge", ""))
+		require.ErrorIs(t, err, user.ErrPasskeyReplayed)
+		require.ErrorIs(t, err, user.ErrInvalidPasskey)
+	})
+
+	t.Run("rejects unknown passkeys", func(t *testing.T) {
+		a := test_helpers.NewAuthenticator(t)
+
+		passkeyRepo := &mocks.PasskeyRepo{}
+
+		passkeyRepo.On("GetByCredentialID", mock.Anything, a.CredentialID).
+			Return(user.Passkey{}, user.ErrNotFound)
+
+		service := domain.NewPasskeyService(&mocks.UserRepo{}, passkeyRepo)
+
+		_, err := service.VerifyLogin(context.Background(), a.LoginInput(t, "challenge", ""))
+		require.ErrorIs(t, err, user.ErrInvalidPasskey)
+
+		passkeyRepo.AssertNotCalled(t, "ConsumeChallenge")
+	})
+
+	t.Run("rejects another user handle", func(t *testing.T) {
+		a := test_helpers.NewAuthenticator(t)
+
+		passkeyRepo := &mocks.PasskeyRepo{}
+
+		passkeyRepo.On("GetByCredentialID", mock.Anything, a.CredentialID).
+			Return(passkeyFor(a), nil)
+
+		service := domain.NewPasskeyService(&mocks.UserRepo{}, passkeyRepo)
+
+		_, err := service.VerifyLogin(context.Background(), a.LoginInput(t, "challenge", "other_user_id"))
+		require.ErrorIs(t, err, user.ErrInvalidPasskey)
+
+		passkeyRepo.AssertNotCalled(t, "ConsumeChallenge")
+	})
+
+	t.Run("rejects signatures of other credentials", func(t *testing.T) {
+		a := test_helpers.NewAuthenticator(t)
+		other := test_helpers.NewAuthenticator(t)
+		other.CredentialID = a.CredentialID
+
+		passkeyRepo := &mocks.PasskeyRepo{}
+
+		passkeyRepo.On("GetByCredentialID", mock.Anything, a.CredentialID).
+			Return(passkeyFor(a), nil)
+
+		service := domain.NewPasskeyService(&mocks.UserRepo{}, passkeyRepo)
+
+		_, err := service.VerifyLogin(context.Background(), other.LoginInput(t, "challenge", ""))
+		require.ErrorIs(t, err, user.ErrInvalidPasskey)
+
+		passkeyRepo.AssertNotCalled(t, "ConsumeChallenge")
+		passkeyRepo.AssertNotCalled(t, "UpdateSignCount")
+	})
+
+	t.Run("rejects used challenges", func(t *testing.T) {
+		a := test_helpers.NewAuthenticator(t)
+
+		passkeyRepo := &mocks.PasskeyRepo{}
+
+		passkeyRepo.On("GetByCredentialID", mock.Anything, a.CredentialID).
+			Return(passkeyFor(a), nil)
+
+		passkeyRepo.On("ConsumeChallenge", mock.Anything, "challenge", user.PasskeyLogin).
+			Return(user.PasskeyChallenge{}, user.ErrNotFound)
+
+		service := domain.NewPasskeyService(&mocks.UserRepo{}, passkeyRepo)
+
+		_, err := service.VerifyLogin(context.Background(), a.LoginInput(t, "challenge", ""))
+		require.ErrorIs(t, err, user.ErrInvalidPasskey)
+
+		passkeyRepo.AssertNotCalled(t, "UpdateSignCount")
+	})
+
+	t.Run("rejects malformed input", func(t *testing.T) {
+		passkeyRepo := &mocks.PasskeyRepo{}
+
+		service := domain.NewPasskeyService(&mocks.UserRepo{}, passkeyRepo)
+
+		_, err := service.VerifyLogin(context.Background(), user.PasskeyLoginInput{CredentialID: "not base64!"})
+		require.ErrorIs(t, err, user.ErrInvalidPasskey)
+
+		passkeyRepo.AssertNotCalled(t, "GetByCredentialID")
+	})
+}
+
+func TestAuthService_LoginWithPasskey(t *testing.T) {
+	input := user.PasskeyLoginInput{CredentialID: "credential_id"}
+
+	t.Run("signs in the user of the passkey", func(t *testing.T) {
+		ctx := context.Background()
+
+		passkeys := &mocks.PasskeyVerifier{}
+
+		passkeys.On("VerifyLogin", mock.Anything, input).
+			Return("user_id", nil)
+
+		userRepo := &mocks.UserRepo{}
+
+		userRepo.On("GetByID", mock.Anything, "user_id").
+			Return(user.UserModel{ID: "user_id", Username: "john"}, nil)
+
+		authTokenService := &mocks.AuthTokenService{}
+
+		authTokenService.On("CreateAccessToken", mock.Anything, mock.Anything, mock.Anything).
+			Return("access_token", nil)
+
+		authTokenService.On("CreateRefreshToken", mock.Anything, mock.Anything, "refresh_token_id").
+			Return("refresh_token", nil)
+
+		refreshTokenRepo := &jwtMocks.RefreshTokenRepo{}
+
+		refreshTokenRepo.On("Create", mock.Anything, mock.Anything).
+			Return(jwt.RefreshToken{ID: "refresh_token_id"}, nil)
+
+		twoFactor := &mocks.TwoFactorVerifier{}
+
+		service := domain.NewAuthService(userRepo, authTokenService, refreshTokenRepo, &mocks.EmailVerifier{}, loginGuard(), twoFactor, passwordHasher(), passkeys, &mocks.LoginLinkRepo{}, &mailerMocks.Mailer{}, &mocks.IdentityVerifier{})
+
+		res, err := service.LoginWithPasskey(ctx, input)
+		require.NoError(t, err)
+
+		require.Equal(t, "access_token", res.AccessToken)
+		require.Equal(t, "refresh_token", res.RefreshToken)
+		require.Equal(t, "user_id", res.User.ID)
+
+		twoFactor.AssertNotCalled(t, "IsEnabled")
+		passkeys.AssertExpectations(t)
+		userRepo.AssertExpectations(t)
+		authTokenService.AssertExpectations(t)
+		refreshTokenRepo.AssertExpectations(t)
+	})
+
+	t.Run("invalid passkey", func(t *testing.T) {
+		ctx := context.Background()
+
+		passkeys := &mocks.PasskeyVerifier{}
+
+		passkeys.On("VerifyLogin", mock.Anything, input).
+			Return("", user.ErrInvalidPasskey)
+
+		refreshTokenRepo := &jwtMocks.RefreshTokenRepo{}
+
+		service := domain.NewAuthService(&mocks.UserRepo{}, &mocks.AuthTokenService{}, refreshTokenRepo, &mocks.EmailVerifier{}, loginGuard(), twoFactorDisabled(), passwordHasher(), passkeys, &mocks.LoginLinkRepo{}, &mailerMocks.Mailer{}, &mocks.IdentityVerifier{})
+
+		_, err := service.LoginWithPasskey(ctx, input)
+		require.ErrorIs(t, err, user.ErrInvalidPasskey)
+
+		refreshTokenRepo.AssertNotCalled(t, "Create")
+	})
+
+	t.Run("deleted user", func(t *testing.T) {
+		ctx := context.Background()
+
+		passkeys := &mocks.PasskeyVerifier{}
+
+		passkeys.On("VerifyLogin", mock.Anything, input).
+			Return("user_id", nil)
+
+		userRepo := &mocks.UserRepo{}
+
+		userRepo.On("GetByID", mock.Anything, "user_id").
+			Return(user.UserModel{}, user.ErrNotFound)
+
+		service := domain.NewAuthService(userRepo, &mocks.AuthTokenService{}, &jwtMocks.RefreshTokenRepo{}, &mocks.EmailVerifier{}, loginGuard(), twoFactorDisabled(), passwordHasher(), passkeys, &mocks.LoginLinkRepo{}, &mailerMocks.Mailer{}, &mocks.IdentityVerifier{})
+
+		_, err := service.LoginWithPasskey(ctx, input)
+		require.ErrorIs(t, err, user.ErrInvalidPasskey)
+	})
+}
@@ -0,0 +1,105 @@
+//go:build integration
+
+package domain
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/RianNegreiros/go-graphql-api/internal/post"
+	"github.com/RianNegreiros/go-graphql-api/internal/randtoken"
+	"github.com/RianNegreiros/go-graphql-api/internal/transport"
+	"github.com/RianNegreiros/go-graphql-api/internal/user"
+	"github.com/RianNegreiros/go-graphql-api/tests/test_helpers"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntegrationPersonalAccessTokenService(t *testing.T) {
+	t.Run("create, use and revoke", func(t *testing.T) {
+		ctx := context.Background()
+
+		defer test_helpers.TeardownDB(ctx, t, db)
+
+		u := test_helpers.CreateUser(ctx, t, userRepo)
+		loggedIn := test_helpers.LoginUser(ctx, t, u)
+
+		created, err := patService.Create(loggedIn, user.CreatePersonalAccessTokenInput{
+			Name:   "bot",
+			Scopes: []user.Scope{user.ScopePostsWrite},
+		})
+		require.NoError(t, err)
+		require.Nil(t, created.LastUsedAt)
+
+		pat, err := patService.Authenticate(ctx, created.Token)
+		require.NoError(t, err)
+		require.Equal(t, u.ID, pat.UserID)
+		require.Equal(t, []user.Scope{user.ScopePostsWrite}, pat.Scopes)
+		require.NotNil(t, pat.LastUsedAt)
+
+		botCtx := transport.PutUserIDIntoContext(ctx, pat.UserID)
+		botCtx = transport.PutScopesIntoContext(botCtx, pat.Scopes)
+
+		p, err := postService.Create(botCtx, post.CreatePostInput{Body: "posted by a bot"})
+		require.NoError(t, err)
+		require.Equal(t, u.ID, p.UserID)
+
+		tokens, err := patService.List(loggedIn)
+		require.NoError(t, err)
+		require.Len(t, tokens, 1)
+		require.Equal(t, created.ID, tokens[0].ID)
+
+		require.NoError(t, patService.Revoke(loggedIn, created.ID))
+
+		_, err = patService.Authenticate(ctx, created.Token)
+		require.ErrorIs(t, err, user.ErrInvalidAccessToken)
+
+		err = patService.Revoke(loggedIn, created.ID)
+		require.ErrorIs(t, err, user.ErrNotFound)
+	})
+
+	t.Run("expired tokens are refused", func(t *testing.T) {
+		ctx := context.Background()
+
+		defer test_helpers.TeardownDB(ctx, t, db)
+
+		u := test_helpers.CreateUser(ctx, t, userRepo)
+
+		expiredAt := time.Now().Add(-time.Minute)
+
+		token := user.PersonalAccessTokenPrefix + "expired"
+
+		_, err := patRepo.Create(ctx, user.PersonalAccessToken{
+			UserID:    u.ID,
+			Name:      "old bot",
+			TokenHash: randtoken.Hash(token),
+			Scopes:    []user.Scope{user.ScopePostsRead},
+			ExpiredAt: &expiredAt,
+		})
+		require.NoError(t, err)
+
+		_, err = patService.Authenticate(ctx, token)
+		require.ErrorIs(t, err, user.ErrInvalidAccessToken)
+	})
+
+	t.Run("tokens of other users can't be revoked", func(t *testing.T) {
+		ctx := context.Background()
+
+		defer test_helpers.TeardownDB(ctx, t, db)
+
+		owner := test_helpers.CreateUser(ctx, t, userRepo)
+		other := test_helpers.CreateUser(ctx, t, userRepo)
+
+		created, err := patService.Create(test_helpers.LoginUser(ctx, t, owner), user.CreatePersonalAccessTokenInput{
+			Name:   "bot",
+			Scopes: []user.Scope{user.ScopePostsRead},
+		})
+		require.NoError(t, err)
+
+		err = patService.Revoke(test_helpers.LoginUser(ctx, t, other), created.ID)
+		require.ErrorIs(t, err, user.ErrNotFound)
+
+		_, err = patService.Authenticate(ctx, created.Token)
+		require.NoError(t, err)
+	})
+}
@@ -0,0 +1,248 @@
+package domain
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/RianNegreiros/go-graphql-api/internal/domain"
+	"github.com/RianNegreiros/go-graphql-api/internal/post"
+	"github.com/RianNegreiros/go-graphql-api/internal/randtoken"
+	"github.com/RianNegreiros/go-graphql-api/internal/transport"
+	"github.com/RianNegreiros/go-graphql-api/internal/user"
+	"github.com/RianNegreiros/go-graphql-api/internal/uuid"
+	jwtMocks "github.com/RianNegreiros/go-graphql-api/mocks/internal_/jwt"
+	mailerMocks "github.com/RianNegreiros/go-graphql-api/mocks/internal_/mailer"
+	postMocks "github.com/RianNegreiros/go-graphql-api/mocks/internal_/post"
+	pubsubMocks "github.com/RianNegreiros/go-graphql-api/mocks/internal_/pubsub"
+	mocks "github.com/RianNegreiros/go-graphql-api/mocks/internal_/user"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// withPersonalAccessToken authenticates ctx like a request made with a
+// personal access token granted scopes.
+func withPersonalAccessToken(userID string, scopes ...user.Scope) context.Context {
+	ctx := transport.PutUserIDIntoContext(context.Background(), userID)
+
+	return transport.PutScopesIntoContext(ctx, scopes)
+}
+
+func TestPersonalAccessTokenService_Create(t *testing.T) {
+	validInput := user.CreatePersonalAccessTokenInput{
+		Name:   "bot",
+		Scopes: []user.Scope{user.ScopePostsWrite},
+	}
+
+	t.Run("stores the hash and returns the token once", func(t *testing.T) {
+		ctx := transport.PutUserIDIntoContext(context.Background(), "user_id")
+
+		var stored user.PersonalAccessToken
+
+		repo := &mocks.PersonalAccessTokenRepo{}
+
+		repo.On("Create", mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) {
+				stored = args.Get(1).(user.PersonalAccessToken)
+			}).
+			Return(func(_ context.Context, t user.PersonalAccessToken) user.PersonalAccessToken {
+				t.ID = "token_id"
+				return t
+			}, nil)
+
+		service := domain.NewPersonalAccessTokenService(repo)
+
+		created, err := service.Create(ctx, validInput)
+		require.NoError(t, err)
+
+		require.True(t, strings.HasPrefix(created.Token, user.PersonalAccessTokenPrefix))
+		require.Equal(t, "token_id", created.ID)
+		require.Equal(t, "user_id", stored.UserID)
+		require.Equal(t, validInput.Scopes, stored.Scopes)
+		require.Equal(t, randtoken.Hash(created.Token), stored.TokenHash)
+		require.NotContains(t, stored.TokenHash, created.Token)
+
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		ctx := transport.PutUserIDIntoContext(context.Background(), "user_id")
+
+		repo := &mocks.PersonalAccessTokenRepo{}
+
+		service := domain.NewPersonalAccessTokenService(repo)
+
+		_, err := service.Create(ctx, user.CreatePersonalAccessTokenInput{Name: "bot"})
+		require.ErrorIs(t, err, user.ErrValidation)
+
+		repo.AssertNotCalled(t, "Create")
+	})
+
+	t.Run("can't be created with a personal access token", func(t *testing.T) {
+		ctx := withPersonalAccessToken("user_id", user.Scopes...)
+
+		repo := &mocks.PersonalAccessTokenRepo{}
+
+		service := domain.NewPersonalAccessTokenService(repo)
+
+		_, err := service.Create(ctx, validInput)
+		require.ErrorIs(t, err, user.ErrSessionRequired)
+		require.ErrorIs(t, err, user.ErrForbidden)
+
+		repo.AssertNotCalled(t, "Create")
+	})
+
+	t.Run("unauthenticated", func(t *testing.T) {
+		service := domain.NewPersonalAccessTokenService(&mocks.PersonalAccessTokenRepo{})
+
+		_, err := service.Create(context.Background(), validInput)
+		require.ErrorIs(t, err, user.ErrUnauthenticated)
+	})
+}
+
+func TestPersonalAccessTokenService_Revoke(t *testing.T) {
+	id := "0b5ef1c4-2f6a-4a3e-9c8f-1a2b3c4d5e6f"
+
+	t.Run("revokes a token of the current user", func(t *testing.T) {
+		ctx := transport.PutUserIDIntoContext(context.Background(), "user_id")
+
+		repo := &mocks.PersonalAccessTokenRepo{}
+
+		repo.On("Revoke", mock.Anything, id, "user_id").
+			Return(nil)
+
+		service := domain.NewPersonalAccessTokenService(repo)
+
+		require.NoError(t, service.Revoke(ctx, id))
+
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("invalid id", func(t *testing.T) {
+		ctx := transport.PutUserIDIntoContext(context.Background(), "user_id")
+
+		service := domain.NewPersonalAccessTokenService(&mocks.PersonalAccessTokenRepo{})
+
+		require.ErrorIs(t, service.Revoke(ctx, "1"), uuid.ErrInvalidUUID)
+	})
+}
+
+func TestPersonalAccessTokenService_Authenticate(t *testing.T) {
+	token := user.PersonalAccessTokenPrefix + "secret"
+
+	t.Run("returns the active token", func(t *testing.T) {
+		repo := &mocks.PersonalAccessTokenRepo{}
+
+		repo.On("Use", mock.Anything, randtoken.Hash(token)).
+			Return(user.PersonalAccessToken{ID: "token_id", UserID: "user_id"}, nil)
+
+		service := domain.NewPersonalAccessTokenService(repo)
+
+		pat, err := service.Authenticate(context.Background(), token)
+		require.NoError(t, err)
+		require.Equal(t, "user_id", pat.UserID)
+
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("unknown, revoked or expired token", func(t *testing.T) {
+		repo := &mocks.PersonalAccessTokenRepo{}
+
+		repo.On("Use", mock.Anything, mock.Anything).
+			Return(user.PersonalAccessToken{}, user.ErrNotFound)
+
+		service := domain.NewPersonalAccessTokenService(repo)
+
+		_, err := service.Authenticate(context.Background(), token)
+		require.ErrorIs(t, err, user.ErrInvalidAccessToken)
+		require.ErrorIs(t, err, user.ErrUnauthenticated)
+	})
+
+	t.Run("not a personal access token", func(t *testing.T) {
+		repo := &mocks.PersonalAccessTokenRepo{}
+
+		service := domain.NewPersonalAccessTokenService(repo)
+
+		_, err := service.Authenticate(context.Background(), "eyJhbGciOiJIUzI1NiJ9")
+		require.ErrorIs(t, err, user.ErrInvalidAccessToken)
+
+		repo.AssertNotCalled(t, "Use")
+	})
+}
+
+func TestPersonalAccessToken_Scopes(t *testing.T) {
+	currentUserID := "0b5ef1c4-2f6a-4a3e-9c8f-1a2b3c4d5e6f"
+	otherUserID := "6a1b7c2d-3e4f-4a5b-8c6d-7e8f9a0b1c2d"
+
+	t.Run("posting requires posts:write", func(t *testing.T) {
+		ctx := withPersonalAccessToken(currentUserID, user.ScopePostsRead)
+
+		postRepo := &postMocks.PostRepo{}
+
+		service := domain.NewPostService(postRepo, &mocks.UserRepo{}, &pubsubMocks.PubSub{})
+
+		_, err := service.Create(ctx, post.CreatePostInput{Body: "hello"})
+		require.ErrorIs(t, err, user.ErrInsufficientScope)
+		require.ErrorIs(t, err, user.ErrForbidden)
+
+		postRepo.AssertNotCalled(t, "Create")
+	})
+
+	t.Run("following requires users:write", func(t *testing.T) {
+		userRepo := &mocks.UserRepo{}
+
+		service := domain.NewUserService(userRepo)
+
+		_, err := service.Follow(withPersonalAccessToken(currentUserID, user.ScopePostsWrite), otherUserID)
+		require.ErrorIs(t, err, user.ErrInsufficientScope)
+
+		userRepo.AssertNotCalled(t, "Follow")
+
+		userRepo.On("GetByID", mock.Anything, otherUserID).
+			Return(user.UserModel{ID: otherUserID}, nil)
+
+		userRepo.On("Follow", mock.Anything, currentUserID, otherUserID).
+			Return(nil)
+
+		_, err = service.Follow(withPersonalAccessToken(currentUserID, user.ScopeUsersWrite), otherUserID)
+		require.NoError(t, err)
+
+		userRepo.AssertExpectations(t)
+	})
+
+	t.Run("role permissions require a session", func(t *testing.T) {
+		ctx := withPersonalAccessToken(currentUserID, user.Scopes...)
+		ctx = transport.PutRoleIntoContext(ctx, user.RoleAdmin)
+
+		userRepo := &mocks.UserRepo{}
+
+		service := domain.NewUserService(userRepo)
+
+		_, err := service.GrantRole(ctx, otherUserID, user.RoleModerator)
+		require.ErrorIs(t, err, user.ErrSessionRequired)
+
+		userRepo.AssertNotCalled(t, "UpdateRole")
+	})
+
+	t.Run("account changes require a session", func(t *testing.T) {
+		ctx := withPersonalAccessToken(currentUserID, user.Scopes...)
+
+		userRepo := &mocks.UserRepo{}
+		refreshTokenRepo := &jwtMocks.RefreshTokenRepo{}
+
+		service := domain.NewAuthService(userRepo, &mocks.AuthTokenService{}, refreshTokenRepo, &mocks.EmailVerifier{}, loginGuard(), twoFactorDisabled(), passwordHasher(), &mocks.PasskeyVerifier{}, &mocks.LoginLinkRepo{}, &mailerMocks.Mailer{}, &mocks.IdentityVerifier{})
+
+		err := service.ChangePassword(ctx, user.ChangePasswordInput{
+			CurrentPassword: "password",
+			Password:        "new_password",
+			ConfirmPassword: "new_password",
+		})
+		require.ErrorIs(t, err, user.ErrSessionRequired)
+
+		_, err = service.Sessions(ctx)
+		require.ErrorIs(t, err, user.ErrSessionRequired)
+
+		userRepo.AssertNotCalled(t, "UpdatePassword")
+		refreshTokenRepo.AssertNotCalled(t, "GetActiveByUserID")
+	})
+}
@@ -6,8 +6,11 @@ package domain
 import (
 	"context"
 	"testing"
+	"time"
 
+	"github.com/RianNegreiros/go-graphql-api/internal/pagination"
 	"github.com/RianNegreiros/go-graphql-api/internal/post"
+	"github.com/RianNegreiros/go-graphql-api/internal/transport"
 	"github.com/RianNegreiros/go-graphql-api/internal/user"
 	"github.com/RianNegreiros/go-graphql-api/internal/uuid"
 	"github.com/RianNegreiros/go-graphql-api/tests/faker"
@@ -49,22 +52,83 @@ func TestIntegrationPostService_Create(t *testing.T) {
 	})
 }
 
-func TestIntegrationPostService_All(t *testing.T) {
-	t.Run("return all posts", func(t *testing.T) {
+func TestIntegrationPostService_Paginate(t *testing.T) {
+	t.Run("walks the feed forward and backward", func(t *testing.T) {
 		ctx := context.Background()
 
 		defer test_helpers.TeardownDB(ctx, t, db)
 
 		user := test_helpers.CreateUser(ctx, t, userRepo)
 
-		test_helpers.CreatePost(ctx, t, postRepo, user.ID)
-		test_helpers.CreatePost(ctx, t, postRepo, user.ID)
-		test_helpers.CreatePost(ctx, t, postRepo, user.ID)
+		for i := 0; i < 5; i++ {
+			test_helpers.CreatePost(ctx, t, postRepo, user.ID)
+		}
+
+		first := 2
+
+		page, err := postService.Paginate(ctx, pagination.Input{First: &first})
+		require.NoError(t, err)
+
+		require.Len(t, page.Edges, 2)
+		require.True(t, page.HasNextPage)
+		require.False(t, page.HasPreviousPage)
+		require.False(t, page.Edges[0].Node.CreatedAt.Before(page.Edges[1].Node.CreatedAt))
+
+		after := page.Edges[1].Cursor.Encode()
+
+		next, err := postService.Paginate(ctx, pagination.Input{First: &first, After: &after})
+		require.NoError(t, err)
+
+		require.Len(t, next.Edges, 2)
+		require.True(t, next.HasNextPage)
+		require.True(t, next.HasPreviousPage)
+		require.NotEqual(t, page.Edges[1].Node.ID, next.Edges[0].Node.ID)
+
+		before := next.Edges[0].Cursor.Encode()
 
-		posts, err := postService.All(ctx)
+		prev, err := postService.Paginate(ctx, pagination.Input{Last: &first, Before: &before})
 		require.NoError(t, err)
 
-		require.Len(t, posts, 3)
+		require.Len(t, prev.Edges, 2)
+		require.Equal(t, page.Edges[0].Node.ID, prev.Edges[0].Node.ID)
+		require.Equal(t, page.Edges[1].Node.ID, prev.Edges[1].Node.ID)
+		require.False(t, prev.HasPreviousPage)
+	})
+}
+
+func TestIntegrationPostService_HomeTimeline(t *testing.T) {
+	t.Run("not auth user has no home timeline", func(t *testing.T) {
+		ctx := context.Background()
+
+		_, err := postService.HomeTimeline(ctx, pagination.Input{})
+		require.ErrorIs(t, err, user.ErrUnauthenticated)
+	})
+
+	t.Run("returns own posts and posts of followed users", func(t *testing.T) {
+		ctx := context.Background()
+
+		defer test_helpers.TeardownDB(ctx, t, db)
+
+		currentUser := test_helpers.CreateUser(ctx, t, userRepo)
+		followedUser := test_helpers.CreateUser(ctx, t, userRepo)
+		otherUser := test_helpers.CreateUser(ctx, t, userRepo)
+
+		err := userRepo.Follow(ctx, currentUser.ID, followedUser.ID)
+		require.NoError(t, err)
+
+		own := test_helpers.CreatePost(ctx, t, postRepo, currentUser.ID)
+		followed := test_helpers.CreatePost(ctx, t, postRepo, followedUser.ID)
+		test_helpers.CreatePost(ctx, t, postRepo, otherUser.ID)
+
+		ctx = test_helpers.LoginUser(ctx, t, currentUser)
+
+		page, err := postService.HomeTimeline(ctx, pagination.Input{})
+		require.NoError(t, err)
+
+		require.Len(t, page.Edges, 2)
+		require.Equal(t, followed.ID, page.Edges[0].Node.ID)
+		require.Equal(t, own.ID, page.Edges[1].Node.ID)
+		require.False(t, page.HasNextPage)
 	})
 }
 
@@ -205,3 +269,415 @@ func TestIntegrationPostService_CreateReply(t *testing.T) {
 		require.NotEmpty(t, reply.CreatedAt, "reply.CreatedAt")
 	})
 }
+
+func TestIntegrationPostService_Thread(t *testing.T) {
+	t.Run("loads the conversation tree up to depth", func(t *testing.T) {
+		ctx := context.Background()
+
+		defer test_helpers.TeardownDB(ctx, t, db)
+
+		currentUser := test_helpers.CreateUser(ctx, t, userRepo)
+
+		ctx = test_helpers.LoginUser(ctx, t, currentUser)
+
+		root := test_helpers.CreatePost(ctx, t, postRepo, currentUser.ID)
+
+		reply, err := postService.CreateReply(ctx, root.ID, post.CreatePostInput{Body: faker.RandStr(20)})
+		require.NoError(t, err)
+
+		nested, err := postService.CreateReply(ctx, reply.ID, post.CreatePostInput{Body: faker.RandStr(20)})
+		require.NoError(t, err)
+
+		thread, err := postService.Thread(ctx, root.ID, post.DefaultThreadDepth)
+		require.NoError(t, err)
+
+		require.Len(t, thread, 3)
+		require.Equal(t, root.ID, thread[0].ID)
+		require.Equal(t, reply.ID, thread[1].ID)
+		require.Equal(t, nested.ID, thread[2].ID)
+
+		thread, err = postService.Thread(ctx, root.ID, 1)
+		require.NoError(t, err)
+
+		require.Len(t, thread, 2)
+	})
+
+	t.Run("keeps replies below siblings created at the same time", func(t *testing.T) {
+		ctx := context.Background()
+
+		defer test_helpers.TeardownDB(ctx, t, db)
+
+		currentUser := test_helpers.CreateUser(ctx, t, userRepo)
+
+		ctx = test_helpers.LoginUser(ctx, t, currentUser)
+
+		root := test_helpers.CreatePost(ctx, t, postRepo, currentUser.ID)
+
+		first, err := postService.CreateReply(ctx, root.ID, post.CreatePostInput{Body: faker.RandStr(20)})
+		require.NoError(t, err)
+
+		second, err := postService.CreateReply(ctx, root.ID, post.CreatePostInput{Body: faker.RandStr(20)})
+		require.NoError(t, err)
+
+		_, err = db.Pool.Exec(ctx, `UPDATE posts SET created_at = $1 WHERE id = ANY($2);`, first.CreatedAt, []string{first.ID, second.ID})
+		require.NoError(t, err)
+
+		if second.ID < first.ID {
+			first, second = second, first
+		}
+
+		firstReply, err := postService.CreateReply(ctx, first.ID, post.CreatePostInput{Body: faker.RandStr(20)})
+		require.NoError(t, err)
+
+		secondReply, err := postService.CreateReply(ctx, second.ID, post.CreatePostInput{Body: faker.RandStr(20)})
+		require.NoError(t, err)
+
+		thread, err := postService.Thread(ctx, root.ID, post.DefaultThreadDepth)
+		require.NoError(t, err)
+
+		ids := make([]string, len(thread))
+
+		for i, p := range thread {
+			ids[i] = p.ID
+		}
+
+		require.Equal(t, []string{root.ID, first.ID, firstReply.ID, second.ID, secondReply.ID}, ids)
+	})
+
+	t.Run("return error not found if the root doesn't exist", func(t *testing.T) {
+		ctx := context.Background()
+
+		_, err := postService.Thread(ctx, faker.UUID(), post.DefaultThreadDepth)
+		require.ErrorIs(t, err, user.ErrNotFound)
+	})
+
+	t.Run("return error if depth is out of range", func(t *testing.T) {
+		ctx := context.Background()
+
+		_, err := postService.Thread(ctx, faker.UUID(), post.MaxThreadDepth+1)
+		require.ErrorIs(t, err, user.ErrValidation)
+	})
+}
+
+func TestIntegrationPostRepo_GetReplies(t *testing.T) {
+	t.Run("loads replies of several posts in one call", func(t *testing.T) {
+		ctx := context.Background()
+
+		defer test_helpers.TeardownDB(ctx, t, db)
+
+		currentUser := test_helpers.CreateUser(ctx, t, userRepo)
+
+		ctx = test_helpers.LoginUser(ctx, t, currentUser)
+
+		first := test_helpers.CreatePost(ctx, t, postRepo, currentUser.ID)
+		second := test_helpers.CreatePost(ctx, t, postRepo, currentUser.ID)
+
+		for i := 0; i < 3; i++ {
+			_, err := postService.CreateReply(ctx, first.ID, post.CreatePostInput{Body: faker.RandStr(20)})
+			require.NoError(t, err)
+		}
+
+		pages, err := postRepo.GetReplies(ctx, []string{first.ID, second.ID}, pagination.Args{Limit: 2})
+		require.NoError(t, err)
+
+		require.Len(t, pages[first.ID].Edges, 2)
+		require.True(t, pages[first.ID].HasNextPage)
+		require.Empty(t, pages[second.ID].Edges)
+
+		counts, err := postRepo.CountReplies(ctx, []string{first.ID, second.ID})
+		require.NoError(t, err)
+
+		require.Equal(t, 3, counts[first.ID])
+		require.Equal(t, 0, counts[second.ID])
+	})
+}
+
+func TestIntegrationPostService_Update(t *testing.T) {
+	t.Run("not auth user cannot update a post", func(t *testing.T) {
+		ctx := context.Background()
+
+		_, err := postService.Update(ctx, faker.UUID(), post.UpdatePostInput{
+			Body: faker.RandStr(20),
+		})
+		require.ErrorIs(t, err, user.ErrUnauthenticated)
+	})
+
+	t.Run("cannot update a post if not the owner", func(t *testing.T) {
+		ctx := context.Background()
+
+		defer test_helpers.TeardownDB(ctx, t, db)
+
+		otherUser := test_helpers.CreateUser(ctx, t, userRepo)
+		currentUser := test_helpers.CreateUser(ctx, t, userRepo)
+
+		p := test_helpers.CreatePost(ctx, t, postRepo, otherUser.ID)
+
+		ctx = test_helpers.LoginUser(ctx, t, currentUser)
+
+		_, err := postService.Update(ctx, p.ID, post.UpdatePostInput{
+			Body: faker.RandStr(20),
+		})
+		require.ErrorIs(t, err, user.ErrForbidden)
+	})
+
+	t.Run("can update a post and keep its revision", func(t *testing.T) {
+		ctx := context.Background()
+
+		defer test_helpers.TeardownDB(ctx, t, db)
+
+		currentUser := test_helpers.CreateUser(ctx, t, userRepo)
+
+		p := test_helpers.CreatePost(ctx, t, postRepo, currentUser.ID)
+
+		ctx = test_helpers.LoginUser(ctx, t, currentUser)
+
+		input := post.UpdatePostInput{
+			Body: faker.RandStr(20),
+		}
+
+		updated, err := postService.Update(ctx, p.ID, input)
+		require.NoError(t, err)
+
+		require.Equal(t, input.Body, updated.Body)
+		require.True(t, updated.UpdatedAt.After(p.UpdatedAt))
+
+		revisions, err := postRepo.GetRevisions(ctx, []string{p.ID})
+		require.NoError(t, err)
+
+		require.Len(t, revisions[p.ID], 1)
+		require.Equal(t, p.Body, revisions[p.ID][0].Body)
+	})
+}
+
+func TestIntegrationPostService_Like(t *testing.T) {
+	t.Run("not auth user cannot like a post", func(t *testing.T) {
+		ctx := context.Background()
+
+		_, err := postService.Like(ctx, faker.UUID())
+		require.ErrorIs(t, err, user.ErrUnauthenticated)
+	})
+
+	t.Run("liking is idempotent", func(t *testing.T) {
+		ctx := context.Background()
+
+		defer test_helpers.TeardownDB(ctx, t, db)
+
+		currentUser := test_helpers.CreateUser(ctx, t, userRepo)
+
+		p := test_helpers.CreatePost(ctx, t, postRepo, currentUser.ID)
+
+		ctx = test_helpers.LoginUser(ctx, t, currentUser)
+
+		_, err := postService.Like(ctx, p.ID)
+		require.NoError(t, err)
+
+		_, err = postService.Like(ctx, p.ID)
+		require.NoError(t, err)
+
+		counts, err := postRepo.CountLikes(ctx, []string{p.ID})
+		require.NoError(t, err)
+		require.Equal(t, 1, counts[p.ID])
+
+		liked, err := postRepo.GetLikedByUser(ctx, currentUser.ID, []string{p.ID})
+		require.NoError(t, err)
+		require.True(t, liked[p.ID])
+
+		_, err = postService.Unlike(ctx, p.ID)
+		require.NoError(t, err)
+
+		_, err = postService.Unlike(ctx, p.ID)
+		require.NoError(t, err)
+
+		counts, err = postRepo.CountLikes(ctx, []string{p.ID})
+		require.NoError(t, err)
+		require.Equal(t, 0, counts[p.ID])
+	})
+
+	t.Run("cannot like a post that doesn't exist", func(t *testing.T) {
+		ctx := context.Background()
+
+		defer test_helpers.TeardownDB(ctx, t, db)
+
+		currentUser := test_helpers.CreateUser(ctx, t, userRepo)
+
+		ctx = test_helpers.LoginUser(ctx, t, currentUser)
+
+		_, err := postService.Like(ctx, faker.UUID())
+		require.ErrorIs(t, err, user.ErrNotFound)
+	})
+}
+
+func TestIntegrationPostService_LikedPosts(t *testing.T) {
+	t.Run("pages through liked posts, most recently liked first", func(t *testing.T) {
+		ctx := context.Background()
+
+		defer test_helpers.TeardownDB(ctx, t, db)
+
+		currentUser := test_helpers.CreateUser(ctx, t, userRepo)
+
+		older := test_helpers.CreatePost(ctx, t, postRepo, currentUser.ID)
+		newer := test_helpers.CreatePost(ctx, t, postRepo, currentUser.ID)
+		test_helpers.CreatePost(ctx, t, postRepo, currentUser.ID)
+
+		ctx = test_helpers.LoginUser(ctx, t, currentUser)
+
+		_, err := postService.Like(ctx, newer.ID)
+		require.NoError(t, err)
+
+		_, err = postService.Like(ctx, older.ID)
+		require.NoError(t, err)
+
+		first := 1
+
+		page, err := postService.LikedPosts(ctx, currentUser.ID, pagination.Input{First: &first})
+		require.NoError(t, err)
+
+		require.Len(t, page.Edges, 1)
+		require.Equal(t, older.ID, page.Edges[0].Node.ID)
+		require.True(t, page.HasNextPage)
+
+		after := page.Edges[0].Cursor.Encode()
+
+		page, err = postService.LikedPosts(ctx, currentUser.ID, pagination.Input{First: &first, After: &after})
+		require.NoError(t, err)
+
+		require.Len(t, page.Edges, 1)
+		require.Equal(t, newer.ID, page.Edges[0].Node.ID)
+		require.False(t, page.HasNextPage)
+	})
+}
+
+func TestIntegrationPostService_Subscriptions(t *testing.T) {
+	t.Run("not auth user cannot subscribe", func(t *testing.T) {
+		ctx := context.Background()
+
+		_, err := postService.PostCreated(ctx)
+		require.ErrorIs(t, err, user.ErrUnauthenticated)
+	})
+
+	t.Run("subscribers receive created, replied and deleted posts", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		defer test_helpers.TeardownDB(ctx, t, db)
+
+		currentUser := test_helpers.CreateUser(ctx, t, userRepo)
+
+		ctx = test_helpers.LoginUser(ctx, t, currentUser)
+
+		created, err := postService.PostCreated(ctx)
+		require.NoError(t, err)
+
+		deleted, err := postService.PostDeleted(ctx)
+		require.NoError(t, err)
+
+		p, err := postService.Create(ctx, post.CreatePostInput{
+			Body: faker.RandStr(20),
+		})
+		require.NoError(t, err)
+
+		require.Equal(t, p.ID, receivePost(t, created).ID)
+
+		replies, err := postService.ReplyAdded(ctx, p.ID)
+		require.NoError(t, err)
+
+		reply, err := postService.CreateReply(ctx, p.ID, post.CreatePostInput{
+			Body: faker.RandStr(20),
+		})
+		require.NoError(t, err)
+
+		require.Equal(t, reply.ID, receivePost(t, replies).ID)
+
+		require.NoError(t, postService.Delete(ctx, p.ID))
+
+		require.Equal(t, p.ID, receivePost(t, deleted).ID)
+	})
+}
+
+func receivePost(t *testing.T, posts <-chan post.Post) post.Post {
+	t.Helper()
+
+	select {
+	case p := <-posts:
+		return p
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for post")
+		return post.Post{}
+	}
+}
+
+func TestIntegrationPostService_Remove(t *testing.T) {
+	t.Run("regular users cannot remove posts", func(t *testing.T) {
+		ctx := context.Background()
+
+		defer test_helpers.TeardownDB(ctx, t, db)
+
+		author := test_helpers.CreateUser(ctx, t, userRepo)
+		p := test_helpers.CreatePost(ctx, t, postRepo, author.ID)
+
+		ctx = test_helpers.LoginUser(ctx, t, author)
+
+		err := postService.Remove(ctx, p.ID, post.RemovePostInput{Reason: "spam"})
+		require.ErrorIs(t, err, user.ErrForbidden)
+	})
+
+	t.Run("moderators can remove any post with a reason", func(t *testing.T) {
+		ctx := context.Background()
+
+		defer test_helpers.TeardownDB(ctx, t, db)
+
+		author := test_helpers.CreateUser(ctx, t, userRepo)
+		moderator := test_helpers.CreateUser(ctx, t, userRepo)
+		p := test_helpers.CreatePost(ctx, t, postRepo, author.ID)
+
+		_, err := userRepo.UpdateRole(ctx, moderator.ID, user.RoleModerator)
+		require.NoError(t, err)
+
+		ctx = test_helpers.LoginUser(ctx, t, moderator)
+		ctx = transport.PutRoleIntoContext(ctx, user.RoleModerator)
+
+		err = postService.Remove(ctx, p.ID, post.RemovePostInput{})
+		require.ErrorIs(t, err, user.ErrValidation)
+
+		err = postService.Remove(ctx, p.ID, post.RemovePostInput{Reason: "spam"})
+		require.NoError(t, err)
+
+		_, err = postRepo.GetByID(ctx, p.ID)
+		require.ErrorIs(t, err, user.ErrNotFound)
+
+		var reason string
+
+		err = db.Pool.QueryRow(ctx, `SELECT reason FROM post_removals WHERE post_id = $1 AND removed_by = $2;`, p.ID, moderator.ID).Scan(&reason)
+		require.NoError(t, err)
+		require.Equal(t, "spam", reason)
+	})
+}
+
+func TestIntegrationPostService_RequireVerifiedEmail(t *testing.T) {
+	defer func() {
+		post.RequireVerifiedEmail = false
+	}()
+
+	post.RequireVerifiedEmail = true
+
+	ctx := context.Background()
+
+	defer test_helpers.TeardownDB(ctx, t, db)
+
+	currentUser := test_helpers.CreateUser(ctx, t, userRepo)
+
+	ctx = test_helpers.LoginUser(ctx, t, currentUser)
+
+	input := post.CreatePostInput{
+		Body: faker.RandStr(20),
+	}
+
+	_, err := postService.Create(ctx, input)
+	require.ErrorIs(t, err, user.ErrEmailNotVerified)
+
+	_, err = userRepo.MarkEmailVerified(ctx, currentUser.ID)
+	require.NoError(t, err)
+
+	_, err = postService.Create(ctx, input)
+	require.NoError(t, err)
+}
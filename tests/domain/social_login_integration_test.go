@@ -0,0 +1,100 @@
+//go:build integration
+
+package domain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/RianNegreiros/go-graphql-api/internal/oidc"
+	"github.com/RianNegreiros/go-graphql-api/internal/user"
+	"github.com/RianNegreiros/go-graphql-api/tests/test_helpers"
+	"github.com/stretchr/testify/require"
+)
+
+// withProvider registers fake as the "test" provider for the test.
+func withProvider(t *testing.T, fake *test_helpers.OIDCProvider) {
+	p, err := oidc.Discover(context.Background(), fake.Config("http://localhost:8080/auth/test/callback"), nil)
+	require.NoError(t, err)
+
+	socialLogin.Providers["test"] = p
+
+	t.Cleanup(func() {
+		delete(socialLogin.Providers, "test")
+	})
+}
+
+func loginWithProvider(ctx context.Context, t *testing.T, fake *test_helpers.OIDCProvider) (user.LoginResponse, error) {
+	authURL, err := socialLogin.AuthURL(ctx, "test")
+	require.NoError(t, err)
+
+	code, state := fake.Authorize(t, authURL)
+
+	return authService.LoginWithProvider(ctx, user.SocialLoginInput{Provider: "test", Code: code, State: state})
+}
+
+func TestIntegrationSocialLogin(t *testing.T) {
+	t.Run("creates an account and logs back into it", func(t *testing.T) {
+		ctx := context.Background()
+
+		defer test_helpers.TeardownDB(ctx, t, db)
+
+		fake := test_helpers.NewOIDCProvider(t)
+		fake.Email = "social@mail.com"
+		fake.PreferredUsername = "social"
+		withProvider(t, fake)
+
+		res, err := loginWithProvider(ctx, t, fake)
+		require.NoError(t, err)
+		require.NotEmpty(t, res.AccessToken)
+		require.Equal(t, "social@mail.com", res.User.Email)
+		require.Equal(t, "social", res.User.Username)
+		require.True(t, res.User.IsEmailVerified())
+
+		again, err := loginWithProvider(ctx, t, fake)
+		require.NoError(t, err)
+		require.Equal(t, res.User.ID, again.User.ID)
+	})
+
+	t.Run("links an account with a verified email", func(t *testing.T) {
+		ctx := context.Background()
+
+		defer test_helpers.TeardownDB(ctx, t, db)
+
+		u := test_helpers.CreateUser(ctx, t, userRepo)
+
+		fake := test_helpers.NewOIDCProvider(t)
+		fake.Email = u.Email
+		withProvider(t, fake)
+
+		_, err := loginWithProvider(ctx, t, fake)
+		require.ErrorIs(t, err, user.ErrIdentityEmailTaken)
+
+		_, err = userRepo.MarkEmailVerified(ctx, u.ID)
+		require.NoError(t, err)
+
+		res, err := loginWithProvider(ctx, t, fake)
+		require.NoError(t, err)
+		require.Equal(t, u.ID, res.User.ID)
+	})
+
+	t.Run("state works once", func(t *testing.T) {
+		ctx := context.Background()
+
+		defer test_helpers.TeardownDB(ctx, t, db)
+
+		fake := test_helpers.NewOIDCProvider(t)
+		withProvider(t, fake)
+
+		authURL, err := socialLogin.AuthURL(ctx, "test")
+		require.NoError(t, err)
+
+		code, state := fake.Authorize(t, authURL)
+
+		_, err = authService.LoginWithProvider(ctx, user.SocialLoginInput{Provider: "test", Code: code, State: state})
+		require.NoError(t, err)
+
+		_, err = authService.LoginWithProvider(ctx, user.SocialLoginInput{Provider: "test", Code: code, State: state})
+		require.ErrorIs(t, err, user.ErrInvalidSocialLogin)
+	})
+}
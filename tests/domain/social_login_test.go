@@ -0,0 +1,370 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/RianNegreiros/go-graphql-api/internal/domain"
+	"github.com/RianNegreiros/go-graphql-api/internal/jwt"
+	"github.com/RianNegreiros/go-graphql-api/internal/oidc"
+	"github.com/RianNegreiros/go-graphql-api/internal/user"
+	jwtMocks "github.com/RianNegreiros/go-graphql-api/mocks/internal_/jwt"
+	mailerMocks "github.com/RianNegreiros/go-graphql-api/mocks/internal_/mailer"
+	mocks "github.com/RianNegreiros/go-graphql-api/mocks/internal_/user"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// identityProvider returns a provider whose exchanges yield claims.
+func identityProvider(claims oidc.Claims) *mocks.IdentityProvider {
+	p := &mocks.IdentityProvider{}
+
+	p.On("Exchange", mock.Anything, "code", "verifier", "nonce").Return(claims, nil)
+
+	return p
+}
+
+// socialLoginState returns a repo holding the state of a login with test.
+func socialLoginState() *mocks.IdentityRepo {
+	ir := &mocks.IdentityRepo{}
+
+	ir.On("ConsumeState", mock.Anything, "state", "test").
+		Return(user.SocialLoginState{State: "state", Provider: "test", Nonce: "nonce", CodeVerifier: "verifier"}, nil)
+
+	return ir
+}
+
+var socialLoginInput = user.SocialLoginInput{Provider: "test", Code: "code", State: "state"}
+
+func TestSocialLoginService_AuthURL(t *testing.T) {
+	t.Run("stores the state", func(t *testing.T) {
+		ctx := context.Background()
+
+		var stored user.SocialLoginState
+
+		identityRepo := &mocks.IdentityRepo{}
+
+		identityRepo.On("CreateState", mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) {
+				stored = args.Get(1).(user.SocialLoginState)
+			}).
+			Return(nil)
+
+		provider := &mocks.IdentityProvider{}
+
+		provider.On("AuthCodeURL", mock.Anything, mock.Anything, mock.Anything).
+			Return(func(state, nonce, verifier string) string {
+				return "https://provider/authorize?state=" + state
+			})
+
+		service := domain.NewSocialLoginService(&mocks.UserRepo{}, identityRepo, passwordHasher(), map[string]user.IdentityProvider{"test": provider})
+
+		authURL, err := service.AuthURL(ctx, "test")
+		require.NoError(t, err)
+
+		require.Equal(t, "https://provider/authorize?state="+stored.State, authURL)
+		require.Equal(t, "test", stored.Provider)
+		require.NotEmpty(t, stored.Nonce)
+		require.NotEmpty(t, stored.CodeVerifier)
+		require.NotEqual(t, stored.State, stored.Nonce)
+		require.WithinDuration(t, time.Now().Add(user.SocialLoginStateLifeTime), stored.ExpiredAt, time.Minute)
+	})
+
+	t.Run("unknown provider", func(t *testing.T) {
+		ctx := context.Background()
+
+		service := domain.NewSocialLoginService(&mocks.UserRepo{}, &mocks.IdentityRepo{}, passwordHasher(), map[string]user.IdentityProvider{})
+
+		_, err := service.AuthURL(ctx, "test")
+		require.ErrorIs(t, err, user.ErrUnknownProvider)
+	})
+}
+
+func TestSocialLoginService_VerifyLogin(t *testing.T) {
+	t.Run("known identity", func(t *testing.T) {
+		ctx := context.Background()
+
+		identityRepo := socialLoginState()
+
+		identityRepo.On("GetByProviderSubject", mock.Anything, "test", "subject").
+			Return(user.Identity{UserID: "user_id"}, nil)
+
+		provider := identityProvider(oidc.Claims{Subject: "subject"})
+
+		service := domain.NewSocialLoginService(&mocks.UserRepo{}, identityRepo, passwordHasher(), map[string]user.IdentityProvider{"test": provider})
+
+		userID, err := service.VerifyLogin(ctx, socialLoginInput)
+		require.NoError(t, err)
+		require.Equal(t, "user_id", userID)
+
+		identityRepo.AssertNotCalled(t, "Create")
+	})
+
+	t.Run("links the account with the same verified email", func(t *testing.T) {
+		ctx := context.Background()
+
+		now := time.Now()
+
+		identityRepo := socialLoginState()
+
+		identityRepo.On("GetByProviderSubject", mock.Anything, "test", "subject").
+			Return(user.Identity{}, user.ErrNotFound)
+
+		identityRepo.On("Create", mock.Anything, user.Identity{UserID: "user_id", Provider: "test", Subject: "subject", Email: "johndoe@mail.com"}).
+			Return(user.Identity{ID: "identity_id"}, nil)
+
+		userRepo := &mocks.UserRepo{}
+
+		userRepo.On("GetByEmail", mock.Anything, "johndoe@mail.com").
+			Return(user.UserModel{ID: "user_id", EmailVerifiedAt: &now}, nil)
+
+		provider := identityProvider(oidc.Claims{Subject: "subject", Email: "JohnDoe@mail.com", EmailVerified: true})
+
+		service := domain.NewSocialLoginService(userRepo, identityRepo, passwordHasher(), map[string]user.IdentityProvider{"test": provider})
+
+		userID, err := service.VerifyLogin(ctx, socialLoginInput)
+		require.NoError(t, err)
+		require.Equal(t, "user_id", userID)
+
+		identityRepo.AssertExpectations(t)
+		userRepo.AssertNotCalled(t, "Create")
+	})
+
+	t.Run("refuses to link an account with an unverified email", func(t *testing.T) {
+		ctx := context.Background()
+
+		identityRepo := socialLoginState()
+
+		identityRepo.On("GetByProviderSubject", mock.Anything, "test", "subject").
+			Return(user.Identity{}, user.ErrNotFound)
+
+		userRepo := &mocks.UserRepo{}
+
+		userRepo.On("GetByEmail", mock.Anything, "johndoe@mail.com").
+			Return(user.UserModel{ID: "user_id"}, nil)
+
+		provider := identityProvider(oidc.Claims{Subject: "subject", Email: "johndoe@mail.com", EmailVerified: true})
+
+		service := domain.NewSocialLoginService(userRepo, identityRepo, passwordHasher(), map[string]user.IdentityProvider{"test": provider})
+
+		_, err := service.VerifyLogin(ctx, socialLoginInput)
+		require.ErrorIs(t, err, user.ErrIdentityEmailTaken)
+
+		identityRepo.AssertNotCalled(t, "Create")
+	})
+
+	t.Run("refuses emails the provider didn't verify", func(t *testing.T) {
+		ctx := context.Background()
+
+		identityRepo := socialLoginState()
+
+		identityRepo.On("GetByProviderSubject", mock.Anything, "test", "subject").
+			Return(user.Identity{}, user.ErrNotFound)
+
+		userRepo := &mocks.UserRepo{}
+
+		provider := identityProvider(oidc.Claims{Subject: "subject", Email: "johndoe@mail.com"})
+
+		service := domain.NewSocialLoginService(userRepo, identityRepo, passwordHasher(), map[string]user.IdentityProvider{"test": provider})
+
+		_, err := service.VerifyLogin(ctx, socialLoginInput)
+		require.ErrorIs(t, err, user.ErrProviderEmailUnverified)
+
+		userRepo.AssertNotCalled(t, "GetByEmail")
+		identityRepo.AssertNotCalled(t, "Create")
+	})
+
+	t.Run("creates an account", func(t *testing.T) {
+		ctx := context.Background()
+
+		now := time.Now()
+
+		identityRepo := socialLoginState()
+
+		identityRepo.On("GetByProviderSubject", mock.Anything, "test", "subject").
+			Return(user.Identity{}, user.ErrNotFound)
+
+		identityRepo.On("Create", mock.Anything, mock.Anything).
+			Return(user.Identity{ID: "identity_id"}, nil)
+
+		userRepo := &mocks.UserRepo{}
+
+		userRepo.On("GetByEmail", mock.Anything, "johndoe@mail.com").
+			Return(user.UserModel{}, user.ErrNotFound)
+
+		userRepo.On("GetByUsername", mock.Anything, "johndoe").
+			Return(user.UserModel{ID: "other_user_id"}, nil)
+
+		userRepo.On("GetByUsername", mock.Anything, mock.Anything).
+			Return(user.UserModel{}, user.ErrNotFound)
+
+		var created user.UserModel
+
+		userRepo.On("Create", mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) {
+				created = args.Get(1).(user.UserModel)
+			}).
+			Return(user.UserModel{ID: "user_id"}, nil)
+
+		userRepo.On("MarkEmailVerified", mock.Anything, "user_id").
+			Return(user.UserModel{ID: "user_id", EmailVerifiedAt: &now}, nil)
+
+		provider := identityProvider(oidc.Claims{Subject: "subject", Email: "johndoe@mail.com", EmailVerified: true, PreferredUsername: "john doe!"})
+
+		service := domain.NewSocialLoginService(userRepo, identityRepo, passwordHasher(), map[string]user.IdentityProvider{"test": provider})
+
+		userID, err := service.VerifyLogin(ctx, socialLoginInput)
+		require.NoError(t, err)
+		require.Equal(t, "user_id", userID)
+
+		require.Equal(t, "johndoe@mail.com", created.Email)
+		require.Regexp(t, `^johndoe_.+$`, created.Username)
+		require.NotEmpty(t, created.Password)
+
+		userRepo.AssertExpectations(t)
+		identityRepo.AssertExpectations(t)
+	})
+
+	t.Run("invalid state", func(t *testing.T) {
+		ctx := context.Background()
+
+		identityRepo := &mocks.IdentityRepo{}
+
+		identityRepo.On("ConsumeState", mock.Anything, mock.Anything, mock.Anything).
+			Return(user.SocialLoginState{}, user.ErrNotFound)
+
+		provider := &mocks.IdentityProvider{}
+
+		service := domain.NewSocialLoginService(&mocks.UserRepo{}, identityRepo, passwordHasher(), map[string]user.IdentityProvider{"test": provider})
+
+		_, err := service.VerifyLogin(ctx, socialLoginInput)
+		require.ErrorIs(t, err, user.ErrInvalidSocialLogin)
+
+		provider.AssertNotCalled(t, "Exchange")
+	})
+
+	t.Run("failed exchange", func(t *testing.T) {
+		ctx := context.Background()
+
+		identityRepo := socialLoginState()
+
+		provider := &mocks.IdentityProvider{}
+
+		provider.On("Exchange", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return(oidc.Claims{}, errors.New("invalid_grant"))
+
+		service := domain.NewSocialLoginService(&mocks.UserRepo{}, identityRepo, passwordHasher(), map[string]user.IdentityProvider{"test": provider})
+
+		_, err := service.VerifyLogin(ctx, socialLoginInput)
+		require.ErrorIs(t, err, user.ErrInvalidSocialLogin)
+	})
+
+	t.Run("unknown provider", func(t *testing.T) {
+		ctx := context.Background()
+
+		identityRepo := &mocks.IdentityRepo{}
+
+		service := domain.NewSocialLoginService(&mocks.UserRepo{}, identityRepo, passwordHasher(), map[string]user.IdentityProvider{})
+
+		_, err := service.VerifyLogin(ctx, socialLoginInput)
+		require.ErrorIs(t, err, user.ErrUnknownProvider)
+
+		identityRepo.AssertNotCalled(t, "ConsumeState")
+	})
+}
+
+func TestAuthService_LoginWithProvider(t *testing.T) {
+	t.Run("signs the user in", func(t *testing.T) {
+		ctx := context.Background()
+
+		identities := &mocks.IdentityVerifier{}
+
+		identities.On("VerifyLogin", mock.Anything, socialLoginInput).
+			Return("user_id", nil)
+
+		userRepo := &mocks.UserRepo{}
+
+		userRepo.On("GetByID", mock.Anything, "user_id").
+			Return(user.UserModel{ID: "user_id"}, nil)
+
+		authTokenService := &mocks.AuthTokenService{}
+
+		authTokenService.On("CreateAccessToken", mock.Anything, mock.Anything, mock.Anything).
+			Return("access_token", nil)
+
+		authTokenService.On("CreateRefreshToken", mock.Anything, mock.Anything, "refresh_token_id").
+			Return("refresh_token", nil)
+
+		refreshTokenRepo := &jwtMocks.RefreshTokenRepo{}
+
+		refreshTokenRepo.On("Create", mock.Anything, mock.Anything).
+			Return(jwt.RefreshToken{ID: "refresh_token_id"}, nil)
+
+		service := domain.NewAuthService(userRepo, authTokenService, refreshTokenRepo, &mocks.EmailVerifier{}, loginGuard(), twoFactorDisabled(), passwordHasher(), &mocks.PasskeyVerifier{}, &mocks.LoginLinkRepo{}, &mailerMocks.Mailer{}, identities)
+
+		res, err := service.LoginWithProvider(ctx, socialLoginInput)
+		require.NoError(t, err)
+
+		require.Nil(t, res.Challenge)
+		require.Equal(t, "access_token", res.AccessToken)
+		require.Equal(t, "refresh_token", res.RefreshToken)
+		require.Equal(t, "user_id", res.User.ID)
+	})
+
+	t.Run("asks for the second factor", func(t *testing.T) {
+		ctx := context.Background()
+
+		identities := &mocks.IdentityVerifier{}
+
+		identities.On("VerifyLogin", mock.Anything, socialLoginInput).
+			Return("user_id", nil)
+
+		userRepo := &mocks.UserRepo{}
+
+		userRepo.On("GetByID", mock.Anything, "user_id").
+			Return(user.UserModel{ID: "user_id"}, nil)
+
+		twoFactor := &mocks.TwoFactorVerifier{}
+
+		twoFactor.On("IsEnabled", mock.Anything, "user_id").
+			Return(true, nil)
+
+		authTokenService := &mocks.AuthTokenService{}
+
+		authTokenService.On("CreateTwoFactorChallengeToken", mock.Anything, mock.Anything).
+			Return("challenge_token", nil)
+
+		refreshTokenRepo := &jwtMocks.RefreshTokenRepo{}
+
+		service := domain.NewAuthService(userRepo, authTokenService, refreshTokenRepo, &mocks.EmailVerifier{}, loginGuard(), twoFactor, passwordHasher(), &mocks.PasskeyVerifier{}, &mocks.LoginLinkRepo{}, &mailerMocks.Mailer{}, identities)
+
+		res, err := service.LoginWithProvider(ctx, socialLoginInput)
+		require.NoError(t, err)
+
+		require.NotNil(t, res.Challenge)
+		require.Equal(t, "challenge_token", res.Challenge.Token)
+		require.Empty(t, res.AccessToken)
+
+		refreshTokenRepo.AssertNotCalled(t, "Create")
+	})
+
+	t.Run("failed verification", func(t *testing.T) {
+		ctx := context.Background()
+
+		identities := &mocks.IdentityVerifier{}
+
+		identities.On("VerifyLogin", mock.Anything, mock.Anything).
+			Return("", user.ErrInvalidSocialLogin)
+
+		userRepo := &mocks.UserRepo{}
+
+		service := domain.NewAuthService(userRepo, &mocks.AuthTokenService{}, &jwtMocks.RefreshTokenRepo{}, &mocks.EmailVerifier{}, loginGuard(), twoFactorDisabled(), passwordHasher(), &mocks.PasskeyVerifier{}, &mocks.LoginLinkRepo{}, &mailerMocks.Mailer{}, identities)
+
+		_, err := service.LoginWithProvider(ctx, socialLoginInput)
+		require.ErrorIs(t, err, user.ErrInvalidSocialLogin)
+
+		userRepo.AssertNotCalled(t, "GetByID")
+	})
+}
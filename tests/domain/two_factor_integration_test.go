@@ -0,0 +1,65 @@
+//go:build integration
+
+package domain
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/RianNegreiros/go-graphql-api/internal/totp"
+	"github.com/RianNegreiros/go-graphql-api/internal/user"
+	"github.com/RianNegreiros/go-graphql-api/tests/test_helpers"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntegrationTwoFactorService(t *testing.T) {
+	t.Run("enable, log in with a recovery code and disable", func(t *testing.T) {
+		ctx := context.Background()
+
+		defer test_helpers.TeardownDB(ctx, t, db)
+
+		u := test_helpers.CreateUser(ctx, t, userRepo)
+
+		loggedIn := test_helpers.LoginUser(ctx, t, u)
+
+		setup, err := twoFactorService.EnableTotp(loggedIn)
+		require.NoError(t, err)
+
+		step := totp.Step(time.Now())
+
+		code, err := totp.Code(setup.Secret, step)
+		require.NoError(t, err)
+
+		codes, err := twoFactorService.ConfirmTotp(loggedIn, code)
+		require.NoError(t, err)
+		require.Len(t, codes, user.RecoveryCodeCount)
+
+		// The code used to confirm can't be replayed.
+		err = twoFactorService.Verify(ctx, u.ID, code)
+		require.ErrorIs(t, err, user.ErrInvalidTwoFactor)
+
+		res, err := authService.Login(ctx, user.LoginInput{Email: u.Email, Password: "password"})
+		require.NoError(t, err)
+		require.NotNil(t, res.Challenge)
+		require.Empty(t, res.AccessToken)
+
+		auth, err := authService.VerifyTwoFactor(ctx, user.VerifyTwoFactorInput{Token: res.Challenge.Token, Code: codes[0]})
+		require.NoError(t, err)
+		require.NotEmpty(t, auth.AccessToken)
+
+		_, err = authService.VerifyTwoFactor(ctx, user.VerifyTwoFactorInput{Token: res.Challenge.Token, Code: codes[0]})
+		require.ErrorIs(t, err, user.ErrInvalidTwoFactor)
+
+		status, err := twoFactorService.Status(loggedIn)
+		require.NoError(t, err)
+		require.Equal(t, user.TwoFactorStatus{Enabled: true, RecoveryCodesRemaining: user.RecoveryCodeCount - 1}, status)
+
+		require.NoError(t, twoFactorService.DisableTotp(loggedIn, codes[1]))
+
+		res, err = authService.Login(ctx, user.LoginInput{Email: u.Email, Password: "password"})
+		require.NoError(t, err)
+		require.Nil(t, res.Challenge)
+		require.NotEmpty(t, res.AccessToken)
+	})
+}
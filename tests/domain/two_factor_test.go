@@ -0,0 +1,354 @@
+package domain
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/RianNegreiros/go-graphql-api/internal/domain"
+	"github.com/RianNegreiros/go-graphql-api/internal/jwt"
+	"github.com/RianNegreiros/go-graphql-api/internal/randtoken"
+	"github.com/RianNegreiros/go-graphql-api/internal/totp"
+	"github.com/RianNegreiros/go-graphql-api/internal/transport"
+	"github.com/RianNegreiros/go-graphql-api/internal/user"
+	jwtMocks "github.com/RianNegreiros/go-graphql-api/mocks/internal_/jwt"
+	mailerMocks "github.com/RianNegreiros/go-graphql-api/mocks/internal_/mailer"
+	mocks "github.com/RianNegreiros/go-graphql-api/mocks/internal_/user"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const totpSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+// currentTotpCode returns the code of totpSecret for the current step.
+func currentTotpCode(t *testing.T) (string, int64) {
+	t.Helper()
+
+	step := totp.Step(time.Now())
+
+	code, err := totp.Code(totpSecret, step)
+	require.NoError(t, err)
+
+	return code, step
+}
+
+func TestTwoFactorService_EnableTotp(t *testing.T) {
+	t.Run("stores a pending secret", func(t *testing.T) {
+		ctx := transport.PutUserIDIntoContext(context.Background(), "user_id")
+
+		userRepo := &mocks.UserRepo{}
+
+		userRepo.On("GetByID", mock.Anything, "user_id").
+			Return(user.UserModel{ID: "user_id", Email: "johndoe@mail.com"}, nil)
+
+		twoFactorRepo := &mocks.TwoFactorRepo{}
+
+		twoFactorRepo.On("GetTotp", mock.Anything, "user_id").
+			Return(user.Totp{}, user.ErrNotFound)
+
+		twoFactorRepo.On("SaveTotp", mock.Anything, "user_id", mock.Anything).
+			Return(user.Totp{}, nil)
+
+		service := domain.NewTwoFactorService(userRepo, twoFactorRepo, loginGuard())
+
+		setup, err := service.EnableTotp(ctx)
+		require.NoError(t, err)
+
+		require.NotEmpty(t, setup.Secret)
+		require.True(t, strings.HasPrefix(setup.URI, "otpauth://totp/"))
+		require.Contains(t, setup.URI, "johndoe@mail.com")
+
+		twoFactorRepo.AssertCalled(t, "SaveTotp", mock.Anything, "user_id", setup.Secret)
+	})
+
+	t.Run("already enabled", func(t *testing.T) {
+		ctx := transport.PutUserIDIntoContext(context.Background(), "user_id")
+
+		confirmedAt := time.Now()
+
+		twoFactorRepo := &mocks.TwoFactorRepo{}
+
+		twoFactorRepo.On("GetTotp", mock.Anything, "user_id").
+			Return(user.Totp{UserID: "user_id", Secret: totpSecret, ConfirmedAt: &confirmedAt}, nil)
+
+		service := domain.NewTwoFactorService(&mocks.UserRepo{}, twoFactorRepo, loginGuard())
+
+		_, err := service.EnableTotp(ctx)
+		require.ErrorIs(t, err, user.ErrTotpAlreadyEnabled)
+
+		twoFactorRepo.AssertNotCalled(t, "SaveTotp")
+	})
+}
+
+func TestTwoFactorService_ConfirmTotp(t *testing.T) {
+	pending := user.Totp{UserID: "user_id", Secret: totpSecret}
+
+	t.Run("enables totp and returns recovery codes", func(t *testing.T) {
+		ctx := transport.PutUserIDIntoContext(context.Background(), "user_id")
+
+		userRepo := &mocks.UserRepo{}
+
+		userRepo.On("GetByID", mock.Anything, "user_id").
+			Return(user.UserModel{ID: "user_id", Email: "johndoe@mail.com"}, nil)
+
+		twoFactorRepo := &mocks.TwoFactorRepo{}
+
+		twoFactorRepo.On("GetTotp", mock.Anything, "user_id").
+			Return(pending, nil)
+
+		code, step := currentTotpCode(t)
+
+		var hashes []string
+
+		twoFactorRepo.On("ConfirmTotp", mock.Anything, "user_id", step, mock.Anything).
+			Run(func(args mock.Arguments) {
+				hashes = args.Get(3).([]string)
+			}).
+			Return(nil)
+
+		service := domain.NewTwoFactorService(userRepo, twoFactorRepo, loginGuard())
+
+		codes, err := service.ConfirmTotp(ctx, code)
+		require.NoError(t, err)
+
+		require.Len(t, codes, user.RecoveryCodeCount)
+		require.Len(t, hashes, user.RecoveryCodeCount)
+		require.Regexp(t, `^[a-z2-7]{5}-[a-z2-7]{5}$`, codes[0])
+		require.Equal(t, randtoken.Hash(strings.ReplaceAll(codes[0], "-", "")), hashes[0])
+	})
+
+	t.Run("invalid code counts as a failed attempt", func(t *testing.T) {
+		ctx := transport.PutUserIDIntoContext(context.Background(), "user_id")
+
+		userRepo := &mocks.UserRepo{}
+
+		userRepo.On("GetByID", mock.Anything, "user_id").
+			Return(user.UserModel{ID: "user_id", Email: "johndoe@mail.com"}, nil)
+
+		twoFactorRepo := &mocks.TwoFactorRepo{}
+
+		twoFactorRepo.On("GetTotp", mock.Anything, "user_id").
+			Return(pending, nil)
+
+		lg := loginGuard()
+
+		service := domain.NewTwoFactorService(userRepo, twoFactorRepo, lg)
+
+		_, err := service.ConfirmTotp(ctx, "000000x")
+		require.ErrorIs(t, err, user.ErrInvalidTwoFactor)
+
+		lg.AssertCalled(t, "Allow", mock.Anything, "johndoe@mail.com", "")
+		lg.AssertNotCalled(t, "Release", mock.Anything, mock.Anything, mock.Anything)
+		lg.AssertNotCalled(t, "Succeed", mock.Anything, mock.Anything, mock.Anything)
+		twoFactorRepo.AssertNotCalled(t, "ConfirmTotp")
+	})
+
+	t.Run("not set up", func(t *testing.T) {
+		ctx := transport.PutUserIDIntoContext(context.Background(), "user_id")
+
+		twoFactorRepo := &mocks.TwoFactorRepo{}
+
+		twoFactorRepo.On("GetTotp", mock.Anything, "user_id").
+			Return(user.Totp{}, user.ErrNotFound)
+
+		service := domain.NewTwoFactorService(&mocks.UserRepo{}, twoFactorRepo, loginGuard())
+
+		_, err := service.ConfirmTotp(ctx, "123456")
+		require.ErrorIs(t, err, user.ErrTotpNotSetUp)
+	})
+}
+
+func TestTwoFactorService_Verify(t *testing.T) {
+	confirmedAt := time.Now()
+	enabled := user.Totp{UserID: "user_id", Secret: totpSecret, ConfirmedAt: &confirmedAt}
+
+	newService := func(twoFactorRepo *mocks.TwoFactorRepo) *domain.TwoFactorService {
+		userRepo := &mocks.UserRepo{}
+
+		userRepo.On("GetByID", mock.Anything, "user_id").
+			Return(user.UserModel{ID: "user_id", Email: "johndoe@mail.com"}, nil)
+
+		twoFactorRepo.On("GetTotp", mock.Anything, "user_id").
+			Return(enabled, nil)
+
+		return domain.NewTwoFactorService(userRepo, twoFactorRepo, loginGuard())
+	}
+
+	t.Run("accepts a totp code once", func(t *testing.T) {
+		code, step := currentTotpCode(t)
+
+		twoFactorRepo := &mocks.TwoFactorRepo{}
+
+		twoFactorRepo.On("UseTotpStep", mock.Anything, "user_id", step).
+			Return(nil).Once()
+
+		twoFactorRepo.On("UseTotpStep", mock.Anything, "user_id", step).
+			Return(user.ErrInvalidTwoFactor)
+
+		service := newService(twoFactorRepo)
+
+		require.NoError(t, service.Verify(context.Background(), "user_id", code))
+
+		err := service.Verify(context.Background(), "user_id", code)
+		require.ErrorIs(t, err, user.ErrInvalidTwoFactor)
+	})
+
+	t.Run("accepts a recovery code", func(t *testing.T) {
+		twoFactorRepo := &mocks.TwoFactorRepo{}
+
+		twoFactorRepo.On("UseRecoveryCode", mock.Anything, "user_id", randtoken.Hash("abcdefghij")).
+			Return(nil)
+
+		service := newService(twoFactorRepo)
+
+		require.NoError(t, service.Verify(context.Background(), "user_id", " ABCDE-fghij "))
+	})
+
+	t.Run("rejects unknown codes", func(t *testing.T) {
+		twoFactorRepo := &mocks.TwoFactorRepo{}
+
+		twoFactorRepo.On("UseRecoveryCode", mock.Anything, "user_id", mock.Anything).
+			Return(user.ErrNotFound)
+
+		service := newService(twoFactorRepo)
+
+		err := service.Verify(context.Background(), "user_id", "abcde-fghij")
+		require.ErrorIs(t, err, user.ErrInvalidTwoFactor)
+	})
+
+	t.Run("not enabled", func(t *testing.T) {
+		twoFactorRepo := &mocks.TwoFactorRepo{}
+
+		twoFactorRepo.On("GetTotp", mock.Anything, "user_id").
+			Return(user.Totp{UserID: "user_id", Secret: totpSecret}, nil)
+
+		service := domain.NewTwoFactorService(&mocks.UserRepo{}, twoFactorRepo, loginGuard())
+
+		err := service.Verify(context.Background(), "user_id", "123456")
+		require.ErrorIs(t, err, user.ErrTotpNotEnabled)
+	})
+}
+
+func TestAuthService_LoginWithTwoFactor(t *testing.T) {
+	password, err := passwordHasher().Hash("password")
+	require.NoError(t, err)
+
+	u := user.UserModel{ID: "user_id", Email: "johndoe@mail.com", Password: password}
+
+	t.Run("login returns a challenge instead of tokens", func(t *testing.T) {
+		ctx := context.Background()
+
+		userRepo := &mocks.UserRepo{}
+
+		userRepo.On("GetByEmail", mock.Anything, u.Email).
+			Return(u, nil)
+
+		authTokenService := &mocks.AuthTokenService{}
+
+		authTokenService.On("CreateTwoFactorChallengeToken", mock.Anything, u).
+			Return("challenge_token", nil)
+
+		tf := &mocks.TwoFactorVerifier{}
+
+		tf.On("IsEnabled", mock.Anything, "user_id").
+			Return(true, nil)
+
+		lg := loginGuard()
+
+		refreshTokenRepo := &jwtMocks.RefreshTokenRepo{}
+
+		service := domain.NewAuthService(userRepo, authTokenService, refreshTokenRepo, &mocks.EmailVerifier{}, lg, tf, passwordHasher(), &mocks.PasskeyVerifier{}, &mocks.LoginLinkRepo{}, &mailerMocks.Mailer{}, &mocks.IdentityVerifier{})
+
+		res, err := service.Login(ctx, user.LoginInput{Email: u.Email, Password: "password"})
+		require.NoError(t, err)
+
+		require.NotNil(t, res.Challenge)
+		require.Equal(t, "challenge_token", res.Challenge.Token)
+		require.Empty(t, res.AccessToken)
+
+		refreshTokenRepo.AssertNotCalled(t, "Create")
+		lg.AssertCalled(t, "Release", mock.Anything, u.Email, "")
+		lg.AssertNotCalled(t, "Succeed", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("challenge and code are traded for tokens", func(t *testing.T) {
+		ctx := context.Background()
+
+		userRepo := &mocks.UserRepo{}
+
+		userRepo.On("GetByID", mock.Anything, "user_id").
+			Return(u, nil)
+
+		authTokenService := &mocks.AuthTokenService{}
+
+		authTokenService.On("ParseTwoFactorChallengeToken", mock.Anything, "challenge_token").
+			Return(user.TwoFactorChallengeToken{Sub: "user_id"}, nil)
+
+		authTokenService.On("CreateAccessToken", mock.Anything, mock.Anything, mock.Anything).
+			Return("access_token", nil)
+
+		authTokenService.On("CreateRefreshToken", mock.Anything, mock.Anything, "refresh_token_id").
+			Return("refresh_token", nil)
+
+		refreshTokenRepo := &jwtMocks.RefreshTokenRepo{}
+
+		refreshTokenRepo.On("Create", mock.Anything, mock.Anything).
+			Return(jwt.RefreshToken{ID: "refresh_token_id"}, nil)
+
+		tf := &mocks.TwoFactorVerifier{}
+
+		tf.On("Verify", mock.Anything, "user_id", "123456").
+			Return(nil)
+
+		service := domain.NewAuthService(userRepo, authTokenService, refreshTokenRepo, &mocks.EmailVerifier{}, loginGuard(), tf, passwordHasher(), &mocks.PasskeyVerifier{}, &mocks.LoginLinkRepo{}, &mailerMocks.Mailer{}, &mocks.IdentityVerifier{})
+
+		res, err := service.VerifyTwoFactor(ctx, user.VerifyTwoFactorInput{Token: "challenge_token", Code: "123456"})
+		require.NoError(t, err)
+
+		require.Equal(t, "access_token", res.AccessToken)
+		require.Equal(t, "refresh_token", res.RefreshToken)
+	})
+
+	t.Run("invalid code", func(t *testing.T) {
+		ctx := context.Background()
+
+		userRepo := &mocks.UserRepo{}
+
+		userRepo.On("GetByID", mock.Anything, "user_id").
+			Return(u, nil)
+
+		authTokenService := &mocks.AuthTokenService{}
+
+		authTokenService.On("ParseTwoFactorChallengeToken", mock.Anything, "challenge_token").
+			Return(user.TwoFactorChallengeToken{Sub: "user_id"}, nil)
+
+		tf := &mocks.TwoFactorVerifier{}
+
+		tf.On("Verify", mock.Anything, "user_id", "000000").
+			Return(user.ErrInvalidTwoFactor)
+
+		refreshTokenRepo := &jwtMocks.RefreshTokenRepo{}
+
+		service := domain.NewAuthService(userRepo, authTokenService, refreshTokenRepo, &mocks.EmailVerifier{}, loginGuard(), tf, passwordHasher(), &mocks.PasskeyVerifier{}, &mocks.LoginLinkRepo{}, &mailerMocks.Mailer{}, &mocks.IdentityVerifier{})
+
+		_, err := service.VerifyTwoFactor(ctx, user.VerifyTwoFactorInput{Token: "challenge_token", Code: "000000"})
+		require.ErrorIs(t, err, user.ErrInvalidTwoFactor)
+
+		refreshTokenRepo.AssertNotCalled(t, "Create")
+	})
+
+	t.Run("invalid challenge", func(t *testing.T) {
+		ctx := context.Background()
+
+		authTokenService := &mocks.AuthTokenService{}
+
+		authTokenService.On("ParseTwoFactorChallengeToken", mock.Anything, "access_token").
+			Return(user.TwoFactorChallengeToken{}, user.ErrInvalidToken)
+
+		service := domain.NewAuthService(&mocks.UserRepo{}, authTokenService, &jwtMocks.RefreshTokenRepo{}, &mocks.EmailVerifier{}, loginGuard(), &mocks.TwoFactorVerifier{}, passwordHasher(), &mocks.PasskeyVerifier{}, &mocks.LoginLinkRepo{}, &mailerMocks.Mailer{}, &mocks.IdentityVerifier{})
+
+		_, err := service.VerifyTwoFactor(ctx, user.VerifyTwoFactorInput{Token: "access_token", Code: "123456"})
+		require.ErrorIs(t, err, user.ErrInvalidChallenge)
+	})
+}
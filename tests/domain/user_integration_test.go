@@ -0,0 +1,112 @@
+//go:build integration
+
+package domain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/RianNegreiros/go-graphql-api/internal/pagination"
+	"github.com/RianNegreiros/go-graphql-api/internal/user"
+	"github.com/RianNegreiros/go-graphql-api/tests/test_helpers"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntegrationUserService_Follow(t *testing.T) {
+	t.Run("follow and unfollow a user", func(t *testing.T) {
+		ctx := context.Background()
+
+		defer test_helpers.TeardownDB(ctx, t, db)
+
+		currentUser := test_helpers.CreateUser(ctx, t, userRepo)
+		otherUser := test_helpers.CreateUser(ctx, t, userRepo)
+
+		ctx = test_helpers.LoginUser(ctx, t, currentUser)
+
+		_, err := userService.Follow(ctx, otherUser.ID)
+		require.NoError(t, err)
+
+		_, err = userService.Follow(ctx, otherUser.ID)
+		require.NoError(t, err)
+
+		followers, err := userService.Followers(ctx, otherUser.ID, pagination.Input{})
+		require.NoError(t, err)
+		require.Len(t, followers.Edges, 1)
+		require.Equal(t, currentUser.ID, followers.Edges[0].Node.ID)
+
+		following, err := userService.Following(ctx, currentUser.ID, pagination.Input{})
+		require.NoError(t, err)
+		require.Len(t, following.Edges, 1)
+		require.Equal(t, otherUser.ID, following.Edges[0].Node.ID)
+
+		counts, err := userRepo.CountFollowers(ctx, []string{otherUser.ID, currentUser.ID})
+		require.NoError(t, err)
+		require.Equal(t, 1, counts[otherUser.ID])
+		require.Equal(t, 0, counts[currentUser.ID])
+
+		pages, err := userRepo.GetFollowers(ctx, []string{otherUser.ID, currentUser.ID}, pagination.Args{Limit: 10})
+		require.NoError(t, err)
+		require.Len(t, pages[otherUser.ID].Edges, 1)
+		require.Empty(t, pages[currentUser.ID].Edges)
+
+		followed, err := userRepo.GetFollowedBy(ctx, currentUser.ID, []string{otherUser.ID})
+		require.NoError(t, err)
+		require.True(t, followed[otherUser.ID])
+
+		_, err = userService.Unfollow(ctx, otherUser.ID)
+		require.NoError(t, err)
+
+		counts, err = userRepo.CountFollowers(ctx, []string{otherUser.ID})
+		require.NoError(t, err)
+		require.Equal(t, 0, counts[otherUser.ID])
+	})
+
+	t.Run("database rejects self follows", func(t *testing.T) {
+		ctx := context.Background()
+
+		defer test_helpers.TeardownDB(ctx, t, db)
+
+		currentUser := test_helpers.CreateUser(ctx, t, userRepo)
+
+		err := userRepo.Follow(ctx, currentUser.ID, currentUser.ID)
+		require.Error(t, err)
+	})
+}
+
+func TestIntegrationUserService_UpdateProfile(t *testing.T) {
+	t.Run("updates only the given fields", func(t *testing.T) {
+		ctx := context.Background()
+
+		defer test_helpers.TeardownDB(ctx, t, db)
+
+		currentUser := test_helpers.CreateUser(ctx, t, userRepo)
+
+		ctx = test_helpers.LoginUser(ctx, t, currentUser)
+
+		displayName := "John Doe"
+		website := "https://johndoe.com"
+
+		updated, err := userService.UpdateProfile(ctx, user.UpdateProfileInput{
+			DisplayName: &displayName,
+			Website:     &website,
+		})
+		require.NoError(t, err)
+		require.Equal(t, displayName, updated.DisplayName)
+		require.Equal(t, website, updated.Website)
+		require.Empty(t, updated.Bio)
+
+		bio := "hello"
+
+		updated, err = userService.UpdateProfile(ctx, user.UpdateProfileInput{
+			Bio: &bio,
+		})
+		require.NoError(t, err)
+		require.Equal(t, displayName, updated.DisplayName)
+		require.Equal(t, bio, updated.Bio)
+
+		found, err := userService.GetByUsername(ctx, currentUser.Username)
+		require.NoError(t, err)
+		require.Equal(t, updated.ID, found.ID)
+		require.Equal(t, bio, found.Bio)
+	})
+}
@@ -0,0 +1,254 @@
+package domain
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/RianNegreiros/go-graphql-api/internal/domain"
+	"github.com/RianNegreiros/go-graphql-api/internal/transport"
+	"github.com/RianNegreiros/go-graphql-api/internal/user"
+	mocks "github.com/RianNegreiros/go-graphql-api/mocks/internal_/user"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserService_Follow(t *testing.T) {
+	currentUserID := "0b5ef1c4-2f6a-4a3e-9c8f-1a2b3c4d5e6f"
+	otherUserID := "6a1b7c2d-3e4f-4a5b-8c6d-7e8f9a0b1c2d"
+
+	t.Run("follows another user", func(t *testing.T) {
+		ctx := transport.PutUserIDIntoContext(context.Background(), currentUserID)
+
+		userRepo := &mocks.UserRepo{}
+
+		userRepo.On("GetByID", mock.Anything, otherUserID).
+			Return(user.UserModel{ID: otherUserID}, nil)
+
+		userRepo.On("Follow", mock.Anything, currentUserID, otherUserID).
+			Return(nil)
+
+		service := domain.NewUserService(userRepo)
+
+		followee, err := service.Follow(ctx, otherUserID)
+		require.NoError(t, err)
+		require.Equal(t, otherUserID, followee.ID)
+
+		userRepo.AssertExpectations(t)
+	})
+
+	t.Run("deleted accounts can't be followed", func(t *testing.T) {
+		ctx := transport.PutUserIDIntoContext(context.Background(), currentUserID)
+
+		deletedAt := time.Now()
+
+		userRepo := &mocks.UserRepo{}
+
+		userRepo.On("GetByID", mock.Anything, otherUserID).
+			Return(user.UserModel{ID: otherUserID, DeletedAt: &deletedAt}, nil)
+
+		service := domain.NewUserService(userRepo)
+
+		_, err := service.Follow(ctx, otherUserID)
+		require.ErrorIs(t, err, user.ErrNotFound)
+
+		userRepo.AssertNotCalled(t, "Follow", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("cannot follow yourself", func(t *testing.T) {
+		ctx := transport.PutUserIDIntoContext(context.Background(), currentUserID)
+
+		userRepo := &mocks.UserRepo{}
+
+		service := domain.NewUserService(userRepo)
+
+		_, err := service.Follow(ctx, currentUserID)
+		require.ErrorIs(t, err, user.ErrCannotFollowSelf)
+		require.ErrorIs(t, err, user.ErrValidation)
+
+		userRepo.AssertNotCalled(t, "Follow")
+	})
+
+	t.Run("unauthenticated", func(t *testing.T) {
+		ctx := context.Background()
+
+		userRepo := &mocks.UserRepo{}
+
+		service := domain.NewUserService(userRepo)
+
+		_, err := service.Follow(ctx, otherUserID)
+		require.ErrorIs(t, err, user.ErrUnauthenticated)
+
+		userRepo.AssertNotCalled(t, "Follow")
+	})
+}
+
+func TestUserService_GetByUsername(t *testing.T) {
+	t.Run("deleted accounts are not found", func(t *testing.T) {
+		ctx := context.Background()
+
+		deletedAt := time.Now()
+
+		userRepo := &mocks.UserRepo{}
+
+		userRepo.On("GetByUsername", mock.Anything, "deleted_user").
+			Return(user.UserModel{ID: "user_id", Username: "deleted_user", DeletedAt: &deletedAt}, nil)
+
+		service := domain.NewUserService(userRepo)
+
+		_, err := service.GetByUsername(ctx, "deleted_user")
+		require.ErrorIs(t, err, user.ErrNotFound)
+	})
+}
+
+func TestUserService_UpdateProfile(t *testing.T) {
+	currentUserID := "0b5ef1c4-2f6a-4a3e-9c8f-1a2b3c4d5e6f"
+
+	t.Run("updates the current user profile", func(t *testing.T) {
+		ctx := transport.PutUserIDIntoContext(context.Background(), currentUserID)
+
+		userRepo := &mocks.UserRepo{}
+
+		userRepo.On("GetByID", mock.Anything, currentUserID).
+			Return(user.UserModel{ID: currentUserID, Bio: "hello"}, nil)
+
+		userRepo.On("UpdateProfile", mock.Anything, user.UserModel{ID: currentUserID, Bio: "hello", DisplayName: "John Doe"}).
+			Return(user.UserModel{ID: currentUserID, Bio: "hello", DisplayName: "John Doe"}, nil)
+
+		service := domain.NewUserService(userRepo)
+
+		displayName := " John Doe "
+
+		u, err := service.UpdateProfile(ctx, user.UpdateProfileInput{
+			DisplayName: &displayName,
+		})
+		require.NoError(t, err)
+		require.Equal(t, "John Doe", u.DisplayName)
+
+		userRepo.AssertExpectations(t)
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		ctx := transport.PutUserIDIntoContext(context.Background(), currentUserID)
+
+		userRepo := &mocks.UserRepo{}
+
+		service := domain.NewUserService(userRepo)
+
+		website := "not a url"
+
+		_, err := service.UpdateProfile(ctx, user.UpdateProfileInput{
+			Website: &website,
+		})
+		require.ErrorIs(t, err, user.ErrValidation)
+
+		userRepo.AssertNotCalled(t, "UpdateProfile")
+	})
+
+	t.Run("unauthenticated", func(t *testing.T) {
+		userRepo := &mocks.UserRepo{}
+
+		service := domain.NewUserService(userRepo)
+
+		_, err := service.UpdateProfile(context.Background(), user.UpdateProfileInput{})
+		require.ErrorIs(t, err, user.ErrUnauthenticated)
+	})
+}
+
+func TestUserService_GrantRole(t *testing.T) {
+	adminID := "0b5ef1c4-2f6a-4a3e-9c8f-1a2b3c4d5e6f"
+	otherUserID := "6a1b7c2d-3e4f-4a5b-8c6d-7e8f9a0b1c2d"
+
+	adminCtx := func() context.Context {
+		ctx := transport.PutUserIDIntoContext(context.Background(), adminID)
+		return transport.PutRoleIntoContext(ctx, user.RoleAdmin)
+	}
+
+	t.Run("admin grants a role", func(t *testing.T) {
+		userRepo := &mocks.UserRepo{}
+
+		userRepo.On("UpdateRole", mock.Anything, otherUserID, user.RoleModerator).
+			Return(user.UserModel{ID: otherUserID, Role: user.RoleModerator}, nil)
+
+		service := domain.NewUserService(userRepo)
+
+		u, err := service.GrantRole(adminCtx(), otherUserID, user.RoleModerator)
+		require.NoError(t, err)
+		require.Equal(t, user.RoleModerator, u.Role)
+
+		userRepo.AssertExpectations(t)
+	})
+
+	t.Run("moderators cannot grant roles", func(t *testing.T) {
+		ctx := transport.PutUserIDIntoContext(context.Background(), adminID)
+		ctx = transport.PutRoleIntoContext(ctx, user.RoleModerator)
+
+		userRepo := &mocks.UserRepo{}
+
+		service := domain.NewUserService(userRepo)
+
+		_, err := service.GrantRole(ctx, otherUserID, user.RoleAdmin)
+		require.ErrorIs(t, err, user.ErrForbidden)
+
+		userRepo.AssertNotCalled(t, "UpdateRole")
+	})
+
+	t.Run("admin cannot change their own role", func(t *testing.T) {
+		userRepo := &mocks.UserRepo{}
+
+		service := domain.NewUserService(userRepo)
+
+		_, err := service.GrantRole(adminCtx(), adminID, user.RoleUser)
+		require.ErrorIs(t, err, user.ErrCannotChangeSelf)
+	})
+
+	t.Run("invalid role", func(t *testing.T) {
+		userRepo := &mocks.UserRepo{}
+
+		service := domain.NewUserService(userRepo)
+
+		_, err := service.GrantRole(adminCtx(), otherUserID, user.Role("root"))
+		require.ErrorIs(t, err, user.ErrInvalidRole)
+	})
+}
+
+func TestUserService_RevokeRole(t *testing.T) {
+	adminID := "0b5ef1c4-2f6a-4a3e-9c8f-1a2b3c4d5e6f"
+	otherUserID := "6a1b7c2d-3e4f-4a5b-8c6d-7e8f9a0b1c2d"
+
+	ctx := transport.PutUserIDIntoContext(context.Background(), adminID)
+	ctx = transport.PutRoleIntoContext(ctx, user.RoleAdmin)
+
+	t.Run("demotes the user back to user role", func(t *testing.T) {
+		userRepo := &mocks.UserRepo{}
+
+		userRepo.On("GetByID", mock.Anything, otherUserID).
+			Return(user.UserModel{ID: otherUserID, Role: user.RoleModerator}, nil)
+
+		userRepo.On("UpdateRole", mock.Anything, otherUserID, user.RoleUser).
+			Return(user.UserModel{ID: otherUserID, Role: user.RoleUser}, nil)
+
+		service := domain.NewUserService(userRepo)
+
+		u, err := service.RevokeRole(ctx, otherUserID, user.RoleModerator)
+		require.NoError(t, err)
+		require.Equal(t, user.RoleUser, u.Role)
+
+		userRepo.AssertExpectations(t)
+	})
+
+	t.Run("revoking a role the user doesn't have is a no-op", func(t *testing.T) {
+		userRepo := &mocks.UserRepo{}
+
+		userRepo.On("GetByID", mock.Anything, otherUserID).
+			Return(user.UserModel{ID: otherUserID, Role: user.RoleAdmin}, nil)
+
+		service := domain.NewUserService(userRepo)
+
+		u, err := service.RevokeRole(ctx, otherUserID, user.RoleModerator)
+		require.NoError(t, err)
+		require.Equal(t, user.RoleAdmin, u.Role)
+
+		userRepo.AssertNotCalled(t, "UpdateRole")
+	})
+}
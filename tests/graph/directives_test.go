@@ -0,0 +1,116 @@
+package graph
+
+import (
+	"context"
+	"testing"
+
+	"github.com/RianNegreiros/go-graphql-api/graph"
+	"github.com/RianNegreiros/go-graphql-api/internal/transport"
+	"github.com/RianNegreiros/go-graphql-api/internal/user"
+	"github.com/stretchr/testify/require"
+)
+
+func next(ctx context.Context) (interface{}, error) {
+	return "resolved", nil
+}
+
+func TestAuthDirective(t *testing.T) {
+	directives := graph.NewDirectives()
+
+	t.Run("rejects anonymous users", func(t *testing.T) {
+		_, err := directives.Auth(context.Background(), nil, next)
+		require.Error(t, err)
+	})
+
+	t.Run("resolves for authenticated users", func(t *testing.T) {
+		ctx := transport.PutUserIDIntoContext(context.Background(), "123")
+
+		res, err := directives.Auth(ctx, nil, next)
+		require.NoError(t, err)
+		require.Equal(t, "resolved", res)
+	})
+}
+
+func TestOwnerDirective(t *testing.T) {
+	directives := graph.NewDirectives()
+
+	ctx := transport.PutUserIDIntoContext(context.Background(), "123")
+
+	t.Run("resolves for the owner", func(t *testing.T) {
+		res, err := directives.Owner(ctx, &graph.User{ID: "123"}, next)
+		require.NoError(t, err)
+		require.Equal(t, "resolved", res)
+
+		res, err = directives.Owner(ctx, &graph.Post{UserID: "123"}, next)
+		require.NoError(t, err)
+		require.Equal(t, "resolved", res)
+	})
+
+	t.Run("rejects other users", func(t *testing.T) {
+		_, err := directives.Owner(ctx, &graph.User{ID: "456"}, next)
+		require.Error(t, err)
+	})
+
+	t.Run("rejects anonymous users", func(t *testing.T) {
+		_, err := directives.Owner(context.Background(), &graph.User{ID: "123"}, next)
+		require.Error(t, err)
+	})
+
+	t.Run("rejects objects without an owner", func(t *testing.T) {
+		_, err := directives.Owner(ctx, &graph.PageInfo{}, next)
+		require.Error(t, err)
+	})
+}
+
+func TestHasRoleDirective(t *testing.T) {
+	directives := graph.NewDirectives()
+
+	ctx := transport.PutUserIDIntoContext(context.Background(), "123")
+
+	t.Run("users default to the user role", func(t *testing.T) {
+		_, err := directives.HasRole(ctx, nil, next, graph.RoleUser)
+		require.NoError(t, err)
+
+		_, err = directives.HasRole(ctx, nil, next, graph.RoleModerator)
+		require.Error(t, err)
+	})
+
+	t.Run("higher roles satisfy lower ones", func(t *testing.T) {
+		ctx := transport.PutRoleIntoContext(ctx, user.RoleAdmin)
+
+		_, err := directives.HasRole(ctx, nil, next, graph.RoleModerator)
+		require.NoError(t, err)
+	})
+
+	t.Run("rejects anonymous users", func(t *testing.T) {
+		_, err := directives.HasRole(context.Background(), nil, next, graph.RoleUser)
+		require.Error(t, err)
+	})
+}
+
+func TestScopeDirective(t *testing.T) {
+	directives := graph.NewDirectives()
+
+	ctx := transport.PutUserIDIntoContext(context.Background(), "123")
+
+	t.Run("sessions hold every scope", func(t *testing.T) {
+		res, err := directives.Scope(ctx, nil, next, graph.ScopeUsersRead)
+		require.NoError(t, err)
+		require.Equal(t, "resolved", res)
+	})
+
+	t.Run("resolves for tokens holding the scope", func(t *testing.T) {
+		ctx := transport.PutScopesIntoContext(ctx, []user.Scope{user.ScopeUsersRead})
+
+		res, err := directives.Scope(ctx, nil, next, graph.ScopeUsersRead)
+		require.NoError(t, err)
+		require.Equal(t, "resolved", res)
+	})
+
+	t.Run("rejects tokens without the scope", func(t *testing.T) {
+		ctx := transport.PutScopesIntoContext(ctx, []user.Scope{user.ScopePostsRead})
+
+		_, err := directives.Scope(ctx, nil, next, graph.ScopeUsersRead)
+		require.Error(t, err)
+	})
+}
@@ -2,6 +2,7 @@ package jwt
 
 import (
 	"context"
+	"log"
 	"net/http/httptest"
 	"os"
 	"testing"
@@ -24,7 +25,12 @@ func TestMain(m *testing.M) {
 	config.LoadEnv(".env.test")
 	conf = config.New()
 
-	tokenService = jwt.NewTokenService(conf)
+	var err error
+
+	tokenService, err = jwt.NewTokenService(conf)
+	if err != nil {
+		log.Fatal(err)
+	}
 
 	os.Exit(m.Run())
 }
@@ -36,7 +42,7 @@ func TestTokenService_CreateAccessToken(t *testing.T) {
 			ID: "1",
 		}
 
-		token, err := tokenService.CreateAccessToken(ctx, u)
+		token, err := tokenService.CreateAccessToken(ctx, u, "session_id")
 		require.NoError(t, err)
 
 		jwt.Now = func() time.Time {
@@ -95,13 +101,30 @@ func TestTokenService_ParseToken(t *testing.T) {
 			ID: "1",
 		}
 
-		token, err := tokenService.CreateAccessToken(ctx, u)
+		token, err := tokenService.CreateAccessToken(ctx, u, "session_id")
 		require.NoError(t, err)
 
 		tok, err := tokenService.ParseToken(ctx, token)
 		require.NoError(t, err)
 
 		require.Equal(t, u.ID, tok.Sub)
+		require.Equal(t, "session_id", tok.SessionID)
+	})
+
+	t.Run("should carry the user role", func(t *testing.T) {
+		ctx := context.Background()
+		u := user.UserModel{
+			ID:   "1",
+			Role: user.RoleModerator,
+		}
+
+		token, err := tokenService.CreateAccessToken(ctx, u, "session_id")
+		require.NoError(t, err)
+
+		tok, err := tokenService.ParseToken(ctx, token)
+		require.NoError(t, err)
+
+		require.Equal(t, user.RoleModerator, tok.Role)
 	})
 
 	t.Run("should return error when token is invalid", func(t *testing.T) {
@@ -110,7 +133,7 @@ func TestTokenService_ParseToken(t *testing.T) {
 			ID: "1",
 		}
 
-		token, err := tokenService.CreateAccessToken(ctx, u)
+		token, err := tokenService.CreateAccessToken(ctx, u, "session_id")
 		require.NoError(t, err)
 
 		tok, err := tokenService.ParseToken(ctx, token+"invalid")
@@ -129,7 +152,7 @@ func TestTokenService_ParseToken(t *testing.T) {
 			return time.Now().Add(-jwt.AccessTokenLifeTime * 5)
 		}
 
-		token, err := tokenService.CreateAccessToken(ctx, u)
+		token, err := tokenService.CreateAccessToken(ctx, u, "session_id")
 		require.NoError(t, err)
 
 		_, err = tokenService.ParseToken(ctx, token)
@@ -148,7 +171,7 @@ func TestTokenService_ParseTokenFromRequest(t *testing.T) {
 
 		req := httptest.NewRequest("GET", "/", nil)
 
-		accessToken, err := tokenService.CreateAccessToken(ctx, u)
+		accessToken, err := tokenService.CreateAccessToken(ctx, u, "session_id")
 		require.NoError(t, err)
 
 		req.Header.Set("Authorization", accessToken)
@@ -174,7 +197,7 @@ func TestTokenService_ParseTokenFromRequest(t *testing.T) {
 
 		req := httptest.NewRequest("GET", "/", nil)
 
-		accessToken, err := tokenService.CreateAccessToken(ctx, u)
+		accessToken, err := tokenService.CreateAccessToken(ctx, u, "session_id")
 		require.NoError(t, err)
 
 		req.Header.Set("Authorization", accessToken+"invalid")
@@ -197,7 +220,7 @@ func TestTokenService_ParseTokenFromRequest(t *testing.T) {
 			return time.Now().Add(-jwt.AccessTokenLifeTime * 5)
 		}
 
-		accessToken, err := tokenService.CreateAccessToken(ctx, u)
+		accessToken, err := tokenService.CreateAccessToken(ctx, u, "session_id")
 		require.NoError(t, err)
 
 		req.Header.Set("Authorization", accessToken)
@@ -216,3 +239,166 @@ func teardownTimeNow(t *testing.T) {
 		return time.Now()
 	}
 }
+
+func TestTokenService_EmailVerificationToken(t *testing.T) {
+	ctx := context.Background()
+	u := user.UserModel{
+		ID:    "1",
+		Email: "johndoe@mail.com",
+	}
+
+	t.Run("should round trip", func(t *testing.T) {
+		token, err := tokenService.CreateEmailVerificationToken(ctx, u)
+		require.NoError(t, err)
+
+		tok, err := tokenService.ParseEmailVerificationToken(ctx, token)
+		require.NoError(t, err)
+
+		require.Equal(t, u.ID, tok.Sub)
+		require.Equal(t, u.Email, tok.Email)
+	})
+
+	t.Run("can't be used as an access token", func(t *testing.T) {
+		token, err := tokenService.CreateEmailVerificationToken(ctx, u)
+		require.NoError(t, err)
+
+		_, err = tokenService.ParseToken(ctx, token)
+		require.ErrorIs(t, err, user.ErrInvalidToken)
+	})
+
+	t.Run("access tokens can't verify emails", func(t *testing.T) {
+		token, err := tokenService.CreateAccessToken(ctx, u, "session_id")
+		require.NoError(t, err)
+
+		_, err = tokenService.ParseEmailVerificationToken(ctx, token)
+		require.ErrorIs(t, err, user.ErrInvalidToken)
+	})
+}
+
+func TestTokenService_EmailChangeToken(t *testing.T) {
+	ctx := context.Background()
+	u := user.UserModel{
+		ID:    "1",
+		Email: "johndoe@mail.com",
+	}
+
+	t.Run("should round trip", func(t *testing.T) {
+		token, err := tokenService.CreateEmailChangeToken(ctx, u, "john@mail.com")
+		require.NoError(t, err)
+
+		tok, err := tokenService.ParseEmailChangeToken(ctx, token)
+		require.NoError(t, err)
+
+		require.Equal(t, u.ID, tok.Sub)
+		require.Equal(t, u.Email, tok.Email)
+		require.Equal(t, "john@mail.com", tok.NewEmail)
+	})
+
+	t.Run("can't be used to verify an email", func(t *testing.T) {
+		token, err := tokenService.CreateEmailChangeToken(ctx, u, "john@mail.com")
+		require.NoError(t, err)
+
+		_, err = tokenService.ParseEmailVerificationToken(ctx, token)
+		require.ErrorIs(t, err, user.ErrInvalidToken)
+
+		_, err = tokenService.ParseToken(ctx, token)
+		require.ErrorIs(t, err, user.ErrInvalidToken)
+	})
+
+	t.Run("verification tokens can't change emails", func(t *testing.T) {
+		token, err := tokenService.CreateEmailVerificationToken(ctx, u)
+		require.NoError(t, err)
+
+		_, err = tokenService.ParseEmailChangeToken(ctx, token)
+		require.ErrorIs(t, err, user.ErrInvalidToken)
+	})
+}
+
+func TestTokenService_TwoFactorChallengeToken(t *testing.T) {
+	ctx := context.Background()
+	u := user.UserModel{
+		ID:    "1",
+		Email: "johndoe@mail.com",
+	}
+
+	t.Run("should round trip", func(t *testing.T) {
+		token, err := tokenService.CreateTwoFactorChallengeToken(ctx, u)
+		require.NoError(t, err)
+
+		tok, err := tokenService.ParseTwoFactorChallengeToken(ctx, token)
+		require.NoError(t, err)
+		require.Equal(t, u.ID, tok.Sub)
+	})
+
+	t.Run("can't be used as an access token", func(t *testing.T) {
+		token, err := tokenService.CreateTwoFactorChallengeToken(ctx, u)
+		require.NoError(t, err)
+
+		_, err = tokenService.ParseToken(ctx, token)
+		require.ErrorIs(t, err, user.ErrInvalidToken)
+	})
+
+	t.Run("expires quickly", func(t *testing.T) {
+		jwt.Now = func() time.Time {
+			return time.Now().Add(-jwt.TwoFactorChallengeLifeTime - time.Minute)
+		}
+		defer teardownTimeNow(t)
+
+		token, err := tokenService.CreateTwoFactorChallengeToken(ctx, u)
+		require.NoError(t, err)
+
+		_, err = tokenService.ParseTwoFactorChallengeToken(ctx, token)
+		require.ErrorIs(t, err, user.ErrInvalidToken)
+	})
+}
+
+func TestTokenService_OAuthAccessToken(t *testing.T) {
+	ctx := context.Background()
+	grant := user.OAuthGrant{
+		ID:       "grant_id",
+		ClientID: "client_id",
+		UserID:   "1",
+		Scopes:   []user.Scope{user.ScopePostsRead, user.ScopePostsWrite},
+	}
+
+	t.Run("should round trip", func(t *testing.T) {
+		token, err := tokenService.CreateOAuthAccessToken(ctx, grant)
+		require.NoError(t, err)
+
+		tok, err := tokenService.ParseOAuthAccessToken(ctx, token)
+		require.NoError(t, err)
+		require.Equal(t, grant.UserID, tok.Sub)
+		require.Equal(t, grant.ClientID, tok.ClientID)
+		require.Equal(t, grant.ID, tok.GrantID)
+		require.Equal(t, grant.Scopes, tok.Scopes)
+	})
+
+	t.Run("can't be used as a session access token", func(t *testing.T) {
+		token, err := tokenService.CreateOAuthAccessToken(ctx, grant)
+		require.NoError(t, err)
+
+		_, err = tokenService.ParseToken(ctx, token)
+		require.ErrorIs(t, err, user.ErrInvalidToken)
+	})
+
+	t.Run("session access tokens aren't oauth tokens", func(t *testing.T) {
+		token, err := tokenService.CreateAccessToken(ctx, user.UserModel{ID: "1"}, "session_id")
+		require.NoError(t, err)
+
+		_, err = tokenService.ParseOAuthAccessToken(ctx, token)
+		require.ErrorIs(t, err, user.ErrInvalidOAuthToken)
+	})
+
+	t.Run("should return error when token is expired", func(t *testing.T) {
+		jwt.Now = func() time.Time {
+			return time.Now().Add(-jwt.AccessTokenLifeTime - time.Minute)
+		}
+		defer teardownTimeNow(t)
+
+		token, err := tokenService.CreateOAuthAccessToken(ctx, grant)
+		require.NoError(t, err)
+
+		_, err = tokenService.ParseOAuthAccessToken(ctx, token)
+		require.ErrorIs(t, err, user.ErrInvalidOAuthToken)
+	})
+}
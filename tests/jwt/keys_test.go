@@ -0,0 +1,200 @@
+package jwt
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"io"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/RianNegreiros/go-graphql-api/config"
+	"github.com/RianNegreiros/go-graphql-api/internal/jwt"
+	"github.com/RianNegreiros/go-graphql-api/internal/user"
+	"github.com/lestrrat-go/jwx/jwa"
+	"github.com/lestrrat-go/jwx/jwk"
+	jwtGo "github.com/lestrrat-go/jwx/jwt"
+	"github.com/stretchr/testify/require"
+)
+
+func writeKey(t *testing.T, block *pem.Block) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "key.pem")
+
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(block), 0o600))
+
+	return path
+}
+
+func rsaKey(t *testing.T) string {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	return writeKey(t, &pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+}
+
+func ecKey(t *testing.T) string {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	der, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	return writeKey(t, &pem.Block{Type: "EC PRIVATE KEY", Bytes: der})
+}
+
+func ed25519Key(t *testing.T) string {
+	t.Helper()
+
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+
+	return writeKey(t, &pem.Block{Type: "PRIVATE KEY", Bytes: der})
+}
+
+func newTokenService(t *testing.T, keyFiles ...string) *jwt.TokenService {
+	t.Helper()
+
+	conf := &config.Config{}
+	conf.JWT.Issuer = "issuer"
+	conf.JWT.PrivateKeyFiles = keyFiles
+
+	service, err := jwt.NewTokenService(conf)
+	require.NoError(t, err)
+
+	return service
+}
+
+func TestTokenService_AsymmetricKeys(t *testing.T) {
+	ctx := context.Background()
+	u := user.UserModel{ID: "1"}
+
+	testCases := []struct {
+		name string
+		key  func(t *testing.T) string
+		alg  jwa.SignatureAlgorithm
+	}{
+		{name: "RS256", key: rsaKey, alg: jwa.RS256},
+		{name: "ES256", key: ecKey, alg: jwa.ES256},
+		{name: "EdDSA", key: ed25519Key, alg: jwa.EdDSA},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			service := newTokenService(t, tc.key(t))
+
+			require.Equal(t, tc.alg, service.Keys.Algorithm())
+
+			token, err := service.CreateAccessToken(ctx, u, "session_id")
+			require.NoError(t, err)
+
+			tok, err := service.ParseToken(ctx, token)
+			require.NoError(t, err)
+			require.Equal(t, u.ID, tok.Sub)
+		})
+	}
+}
+
+func TestTokenService_KeyRotation(t *testing.T) {
+	ctx := context.Background()
+	u := user.UserModel{ID: "1"}
+
+	oldKey, newKey := rsaKey(t), ed25519Key(t)
+
+	before := newTokenService(t, oldKey)
+	during := newTokenService(t, newKey, oldKey)
+	after := newTokenService(t, newKey)
+
+	oldToken, err := before.CreateAccessToken(ctx, u, "session_id")
+	require.NoError(t, err)
+
+	_, err = during.ParseToken(ctx, oldToken)
+	require.NoError(t, err, "tokens of the previous key are still accepted")
+
+	newToken, err := during.CreateAccessToken(ctx, u, "session_id")
+	require.NoError(t, err)
+
+	_, err = after.ParseToken(ctx, newToken)
+	require.NoError(t, err)
+
+	_, err = after.ParseToken(ctx, oldToken)
+	require.ErrorIs(t, err, user.ErrInvalidToken, "tokens of a dropped key are refused")
+}
+
+func TestTokenService_JWKSHandler(t *testing.T) {
+	ctx := context.Background()
+
+	service := newTokenService(t, ecKey(t), rsaKey(t))
+
+	rec := httptest.NewRecorder()
+	service.JWKSHandler().ServeHTTP(rec, httptest.NewRequest("GET", jwt.JWKSPath, nil))
+
+	require.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+	body, err := io.ReadAll(rec.Body)
+	require.NoError(t, err)
+
+	require.NotContains(t, string(body), `"d":`, "private keys must not be published")
+
+	set, err := jwk.Parse(body)
+	require.NoError(t, err)
+	require.Equal(t, 2, set.Len())
+
+	key, ok := set.LookupKeyID(service.Keys.KeyID())
+	require.True(t, ok)
+	require.Equal(t, jwa.ES256.String(), key.Algorithm())
+
+	// Another service can verify our tokens with the published keys only.
+	token, err := service.CreateAccessToken(ctx, user.UserModel{ID: "1"}, "session_id")
+	require.NoError(t, err)
+
+	tok, err := jwtGo.Parse([]byte(token), jwtGo.WithKeySet(set), jwtGo.WithValidate(true))
+	require.NoError(t, err)
+	require.Equal(t, "1", tok.Subject())
+}
+
+func TestTokenService_JWKSHandlerWithSecret(t *testing.T) {
+	conf := &config.Config{}
+	conf.JWT.Secret = "secret"
+
+	service, err := jwt.NewTokenService(conf)
+	require.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	service.JWKSHandler().ServeHTTP(rec, httptest.NewRequest("GET", jwt.JWKSPath, nil))
+
+	require.JSONEq(t, `{"keys":[]}`, rec.Body.String())
+}
+
+func TestLoadKeys(t *testing.T) {
+	t.Run("requires a key or a secret", func(t *testing.T) {
+		_, err := jwt.LoadKeys(&config.Config{})
+		require.ErrorIs(t, err, jwt.ErrNoSigningKey)
+	})
+
+	t.Run("refuses unsupported curves", func(t *testing.T) {
+		key, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+		require.NoError(t, err)
+
+		der, err := x509.MarshalECPrivateKey(key)
+		require.NoError(t, err)
+
+		_, err = jwt.ParsePrivateKey(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der}))
+		require.Error(t, err)
+	})
+}
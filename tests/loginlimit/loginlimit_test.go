@@ -0,0 +1,190 @@
+package loginlimit
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/RianNegreiros/go-graphql-api/internal/loginlimit"
+	"github.com/RianNegreiros/go-graphql-api/internal/user"
+	"github.com/stretchr/testify/require"
+)
+
+var policy = loginlimit.Policy{
+	FreeAttempts:    2,
+	BaseDelay:       time.Second,
+	MaxDelay:        8 * time.Second,
+	LockoutAttempts: 10,
+	LockoutDuration: time.Hour,
+	Window:          24 * time.Hour,
+}
+
+func TestPolicy_Delay(t *testing.T) {
+	testCases := []struct {
+		failures int
+		delay    time.Duration
+	}{
+		{failures: 0, delay: 0},
+		{failures: 1, delay: 0},
+		{failures: 2, delay: time.Second},
+		{failures: 3, delay: 2 * time.Second},
+		{failures: 4, delay: 4 * time.Second},
+		{failures: 5, delay: 8 * time.Second},
+		{failures: 9, delay: 8 * time.Second},
+		{failures: 10, delay: time.Hour},
+	}
+
+	for _, tc := range testCases {
+		require.Equal(t, tc.delay, policy.Delay(tc.failures), "failures: %d", tc.failures)
+	}
+}
+
+func TestLimiter(t *testing.T) {
+	ctx := context.Background()
+
+	now := time.Now()
+	setNow(t, &now)
+
+	newLimiter := func() *loginlimit.Limiter {
+		l := loginlimit.New(loginlimit.NewMemory())
+		l.Account = policy
+		l.IP = policy
+		l.IP.FreeAttempts = 5
+
+		return l
+	}
+
+	t.Run("backs off after the free attempts", func(t *testing.T) {
+		l := newLimiter()
+
+		for i := 0; i < policy.FreeAttempts; i++ {
+			require.NoError(t, l.Allow(ctx, "johndoe@mail.com", ""))
+		}
+
+		err := l.Allow(ctx, "johndoe@mail.com", "")
+		require.ErrorIs(t, err, user.ErrLoginLocked)
+
+		locked, ok := err.(*user.LoginLockedError)
+		require.True(t, ok)
+		require.Equal(t, time.Second, locked.RetryAfter)
+
+		require.NoError(t, l.Allow(ctx, "other@mail.com", ""))
+
+		now = now.Add(time.Second)
+		require.NoError(t, l.Allow(ctx, "johndoe@mail.com", ""))
+	})
+
+	t.Run("parallel attempts can't get past the limit", func(t *testing.T) {
+		l := newLimiter()
+
+		var (
+			wg      sync.WaitGroup
+			allowed atomic.Int32
+		)
+
+		for i := 0; i < 20; i++ {
+			wg.Add(1)
+
+			go func() {
+				defer wg.Done()
+
+				if l.Allow(ctx, "johndoe@mail.com", "") == nil {
+					allowed.Add(1)
+				}
+			}()
+		}
+
+		wg.Wait()
+
+		require.Equal(t, int32(policy.FreeAttempts), allowed.Load())
+	})
+
+	t.Run("released attempts don't count", func(t *testing.T) {
+		l := newLimiter()
+
+		for i := 0; i < policy.FreeAttempts+2; i++ {
+			require.NoError(t, l.Allow(ctx, "johndoe@mail.com", "10.0.0.1"))
+			require.NoError(t, l.Release(ctx, "johndoe@mail.com", "10.0.0.1"))
+		}
+
+		require.NoError(t, l.Allow(ctx, "johndoe@mail.com", "10.0.0.1"))
+	})
+
+	t.Run("locks the ip across accounts", func(t *testing.T) {
+		l := newLimiter()
+
+		for i := 0; i < 5; i++ {
+			require.NoError(t, l.Allow(ctx, email(i), "10.0.0.1"))
+		}
+
+		require.ErrorIs(t, l.Allow(ctx, "johndoe@mail.com", "10.0.0.1"), user.ErrLoginLocked)
+		require.NoError(t, l.Allow(ctx, "johndoe@mail.com", "10.0.0.2"))
+	})
+
+	t.Run("a locked ip doesn't use up the account", func(t *testing.T) {
+		l := newLimiter()
+
+		for i := 0; i < 5; i++ {
+			require.NoError(t, l.Allow(ctx, email(i), "10.0.0.1"))
+		}
+
+		for i := 0; i < policy.FreeAttempts; i++ {
+			require.ErrorIs(t, l.Allow(ctx, "johndoe@mail.com", "10.0.0.1"), user.ErrLoginLocked)
+		}
+
+		require.NoError(t, l.Allow(ctx, "johndoe@mail.com", "10.0.0.2"))
+	})
+
+	t.Run("success resets the account but not the ip", func(t *testing.T) {
+		l := newLimiter()
+
+		for i := 0; i < 4; i++ {
+			now = now.Add(policy.MaxDelay)
+			require.NoError(t, l.Allow(ctx, "johndoe@mail.com", "10.0.0.1"))
+		}
+
+		now = now.Add(policy.MaxDelay)
+		require.NoError(t, l.Allow(ctx, "johndoe@mail.com", "10.0.0.1"))
+		require.NoError(t, l.Succeed(ctx, "johndoe@mail.com", "10.0.0.1"))
+
+		require.NoError(t, l.Allow(ctx, "johndoe@mail.com", "10.0.0.2"))
+		require.NoError(t, l.Allow(ctx, email(0), "10.0.0.1"))
+		require.ErrorIs(t, l.Allow(ctx, email(1), "10.0.0.1"), user.ErrLoginLocked)
+	})
+
+	t.Run("forgets failures after the window", func(t *testing.T) {
+		l := newLimiter()
+
+		for i := 0; i < policy.LockoutAttempts; i++ {
+			now = now.Add(policy.MaxDelay)
+			require.NoError(t, l.Allow(ctx, "johndoe@mail.com", ""))
+		}
+
+		require.ErrorIs(t, l.Allow(ctx, "johndoe@mail.com", ""), user.ErrLoginLocked)
+
+		now = now.Add(policy.LockoutDuration)
+		require.NoError(t, l.Allow(ctx, "johndoe@mail.com", ""))
+
+		now = now.Add(policy.Window)
+		require.NoError(t, l.Allow(ctx, "johndoe@mail.com", ""))
+		require.NoError(t, l.Allow(ctx, "johndoe@mail.com", ""))
+	})
+}
+
+func email(i int) string {
+	return string(rune('a'+i)) + "@mail.com"
+}
+
+func setNow(t *testing.T, now *time.Time) {
+	t.Helper()
+
+	loginlimit.Now = func() time.Time {
+		return *now
+	}
+
+	t.Cleanup(func() {
+		loginlimit.Now = time.Now
+	})
+}
@@ -0,0 +1,45 @@
+package mailer
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/RianNegreiros/go-graphql-api/internal/mailer"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLog_Send(t *testing.T) {
+	t.Run("writes the message", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		m := mailer.NewLog(&buf, "no-reply@mail.com")
+
+		err := m.Send(context.Background(), mailer.Message{
+			To:      "johndoe@mail.com",
+			Subject: "Hello",
+			Body:    "body",
+		})
+		require.NoError(t, err)
+
+		require.Contains(t, buf.String(), "From: no-reply@mail.com\r\n")
+		require.Contains(t, buf.String(), "To: johndoe@mail.com\r\n")
+		require.Contains(t, buf.String(), "Subject: Hello\r\n")
+		require.Contains(t, buf.String(), "\r\n\r\nbody")
+	})
+
+	t.Run("strips line breaks from headers", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		m := mailer.NewLog(&buf, "no-reply@mail.com")
+
+		err := m.Send(context.Background(), mailer.Message{
+			To:      "johndoe@mail.com\r\nBcc: evil@mail.com",
+			Subject: "Hello",
+			Body:    "body",
+		})
+		require.NoError(t, err)
+
+		require.NotContains(t, buf.String(), "\r\nBcc:")
+	})
+}
@@ -0,0 +1,276 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/lestrrat-go/jwx/jwa"
+	"github.com/stretchr/testify/require"
+
+	"github.com/RianNegreiros/go-graphql-api/internal/oidc"
+	"github.com/RianNegreiros/go-graphql-api/tests/test_helpers"
+)
+
+const redirectURL = "http://localhost:8080/auth/test/callback"
+
+func discover(t *testing.T, fake *test_helpers.OIDCProvider) *oidc.Provider {
+	p, err := oidc.Discover(context.Background(), fake.Config(redirectURL), nil)
+	require.NoError(t, err)
+
+	return p
+}
+
+func TestDiscover(t *testing.T) {
+	t.Run("reads the metadata", func(t *testing.T) {
+		fake := test_helpers.NewOIDCProvider(t)
+
+		p := discover(t, fake)
+
+		require.Equal(t, fake.Issuer(), p.Metadata.Issuer)
+		require.Equal(t, fake.Issuer()+"/token", p.Metadata.TokenEndpoint)
+	})
+
+	t.Run("issuer mismatch", func(t *testing.T) {
+		fake := test_helpers.NewOIDCProvider(t)
+
+		config := fake.Config(redirectURL)
+		config.Issuer += "/"
+
+		_, err := oidc.Discover(context.Background(), config, nil)
+		require.ErrorIs(t, err, oidc.ErrDiscovery)
+	})
+
+	t.Run("unreachable provider", func(t *testing.T) {
+		fake := test_helpers.NewOIDCProvider(t)
+		fake.Server.Close()
+
+		_, err := oidc.Discover(context.Background(), fake.Config(redirectURL), nil)
+		require.ErrorIs(t, err, oidc.ErrDiscovery)
+	})
+}
+
+func TestProvider_AuthCodeURL(t *testing.T) {
+	fake := test_helpers.NewOIDCProvider(t)
+	p := discover(t, fake)
+
+	u, err := url.Parse(p.AuthCodeURL("state", "nonce", "verifier"))
+	require.NoError(t, err)
+
+	q := u.Query()
+
+	require.Equal(t, fake.Issuer()+"/authorize", u.Scheme+"://"+u.Host+u.Path)
+	require.Equal(t, "code", q.Get("response_type"))
+	require.Equal(t, fake.ClientID, q.Get("client_id"))
+	require.Equal(t, redirectURL, q.Get("redirect_uri"))
+	require.Equal(t, "openid email profile", q.Get("scope"))
+	require.Equal(t, "state", q.Get("state"))
+	require.Equal(t, "nonce", q.Get("nonce"))
+	require.Equal(t, oidc.CodeChallenge("verifier"), q.Get("code_challenge"))
+	require.Equal(t, "S256", q.Get("code_challenge_method"))
+	require.NotContains(t, u.RawQuery, "verifier")
+}
+
+func TestProvider_Exchange(t *testing.T) {
+	t.Run("returns the claims", func(t *testing.T) {
+		fake := test_helpers.NewOIDCProvider(t)
+		fake.PreferredUsername = "johndoe"
+
+		p := discover(t, fake)
+
+		code, state := fake.Authorize(t, p.AuthCodeURL("state", "nonce", "verifier"))
+		require.Equal(t, "state", state)
+
+		claims, err := p.Exchange(context.Background(), code, "verifier", "nonce")
+		require.NoError(t, err)
+
+		require.Equal(t, oidc.Claims{
+			Subject:           fake.Subject,
+			Email:             fake.Email,
+			EmailVerified:     true,
+			PreferredUsername: "johndoe",
+		}, claims)
+	})
+
+	t.Run("wrong code verifier", func(t *testing.T) {
+		fake := test_helpers.NewOIDCProvider(t)
+		p := discover(t, fake)
+
+		code, _ := fake.Authorize(t, p.AuthCodeURL("state", "nonce", "verifier"))
+
+		_, err := p.Exchange(context.Background(), code, "other", "nonce")
+		require.ErrorIs(t, err, oidc.ErrExchange)
+	})
+
+	t.Run("code used twice", func(t *testing.T) {
+		fake := test_helpers.NewOIDCProvider(t)
+		p := discover(t, fake)
+
+		code, _ := fake.Authorize(t, p.AuthCodeURL("state", "nonce", "verifier"))
+
+		_, err := p.Exchange(context.Background(), code, "verifier", "nonce")
+		require.NoError(t, err)
+
+		_, err = p.Exchange(context.Background(), code, "verifier", "nonce")
+		require.ErrorIs(t, err, oidc.ErrExchange)
+	})
+
+	t.Run("wrong client secret", func(t *testing.T) {
+		fake := test_helpers.NewOIDCProvider(t)
+		p := discover(t, fake)
+		p.Config.ClientSecret = "wrong"
+
+		code, _ := fake.Authorize(t, p.AuthCodeURL("state", "nonce", "verifier"))
+
+		_, err := p.Exchange(context.Background(), code, "verifier", "nonce")
+		require.ErrorIs(t, err, oidc.ErrExchange)
+	})
+
+	t.Run("nonce mismatch", func(t *testing.T) {
+		fake := test_helpers.NewOIDCProvider(t)
+		p := discover(t, fake)
+
+		code, _ := fake.Authorize(t, p.AuthCodeURL("state", "nonce", "verifier"))
+
+		_, err := p.Exchange(context.Background(), code, "verifier", "other")
+		require.ErrorIs(t, err, oidc.ErrInvalidIDToken)
+	})
+}
+
+func TestProvider_VerifyIDToken(t *testing.T) {
+	fake := test_helpers.NewOIDCProvider(t)
+	p := discover(t, fake)
+
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name  string
+		token func(claims map[string]interface{}) string
+		err   error
+	}{
+		{
+			name: "valid token",
+			token: func(claims map[string]interface{}) string {
+				return fake.SignIDToken(t, claims)
+			},
+		},
+		{
+			name: "wrong audience",
+			token: func(claims map[string]interface{}) string {
+				claims["aud"] = "other_client"
+				return fake.SignIDToken(t, claims)
+			},
+			err: oidc.ErrInvalidIDToken,
+		},
+		{
+			name: "several audiences without azp",
+			token: func(claims map[string]interface{}) string {
+				claims["aud"] = []string{fake.ClientID, "other_client"}
+				return fake.SignIDToken(t, claims)
+			},
+			err: oidc.ErrInvalidIDToken,
+		},
+		{
+			name: "several audiences with azp",
+			token: func(claims map[string]interface{}) string {
+				claims["aud"] = []string{fake.ClientID, "other_client"}
+				claims["azp"] = fake.ClientID
+				return fake.SignIDToken(t, claims)
+			},
+		},
+		{
+			name: "wrong issuer",
+			token: func(claims map[string]interface{}) string {
+				claims["iss"] = "https://evil.example.com"
+				return fake.SignIDToken(t, claims)
+			},
+			err: oidc.ErrInvalidIDToken,
+		},
+		{
+			name: "expired",
+			token: func(claims map[string]interface{}) string {
+				claims["iat"] = time.Now().Add(-2 * time.Hour).Unix()
+				claims["exp"] = time.Now().Add(-time.Hour).Unix()
+				return fake.SignIDToken(t, claims)
+			},
+			err: oidc.ErrInvalidIDToken,
+		},
+		{
+			name: "missing expiry",
+			token: func(claims map[string]interface{}) string {
+				delete(claims, "exp")
+				return fake.SignIDToken(t, claims)
+			},
+			err: oidc.ErrInvalidIDToken,
+		},
+		{
+			name: "missing nonce",
+			token: func(claims map[string]interface{}) string {
+				delete(claims, "nonce")
+				return fake.SignIDToken(t, claims)
+			},
+			err: oidc.ErrInvalidIDToken,
+		},
+		{
+			name: "signed by another key",
+			token: func(claims map[string]interface{}) string {
+				return test_helpers.SignIDToken(t, jwa.RS256, otherKey, fake.KeyID(), claims)
+			},
+			err: oidc.ErrInvalidIDToken,
+		},
+		{
+			name: "signed with the client secret",
+			token: func(claims map[string]interface{}) string {
+				return test_helpers.SignIDToken(t, jwa.HS256, []byte(fake.ClientSecret), fake.KeyID(), claims)
+			},
+			err: oidc.ErrInvalidIDToken,
+		},
+		{
+			name: "unknown key",
+			token: func(claims map[string]interface{}) string {
+				return test_helpers.SignIDToken(t, jwa.RS256, otherKey, "unknown", claims)
+			},
+			err: oidc.ErrInvalidIDToken,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := p.VerifyIDToken(context.Background(), tc.token(fake.Claims("nonce")), "nonce")
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+
+	t.Run("picks up rotated keys", func(t *testing.T) {
+		fake := test_helpers.NewOIDCProvider(t)
+		p := discover(t, fake)
+
+		fake.RotateKey(t)
+
+		claims, err := p.VerifyIDToken(context.Background(), fake.SignIDToken(t, fake.Claims("nonce")), "nonce")
+		require.NoError(t, err)
+		require.Equal(t, fake.Subject, claims.Subject)
+	})
+
+	t.Run("tolerates clock skew", func(t *testing.T) {
+		defer func() { oidc.Now = time.Now }()
+
+		oidc.Now = func() time.Time {
+			return time.Now().Add(-oidc.Leeway / 2)
+		}
+
+		claims := fake.Claims("nonce")
+		claims["iat"] = time.Now().Add(oidc.Leeway / 4).Unix()
+
+		_, err := p.VerifyIDToken(context.Background(), fake.SignIDToken(t, claims), "nonce")
+		require.NoError(t, err)
+	})
+}
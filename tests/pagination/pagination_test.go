@@ -0,0 +1,141 @@
+package pagination
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/RianNegreiros/go-graphql-api/internal/pagination"
+	"github.com/stretchr/testify/require"
+)
+
+func intPtr(v int) *int {
+	return &v
+}
+
+func strPtr(v string) *string {
+	return &v
+}
+
+func TestCursor_Encode(t *testing.T) {
+	t.Run("round trips through DecodeCursor", func(t *testing.T) {
+		c := pagination.Cursor{
+			CreatedAt: time.Date(2023, 9, 5, 10, 30, 0, 123456000, time.UTC),
+			ID:        "0b5ef1c4-2f6a-4a3e-9c8f-1a2b3c4d5e6f",
+		}
+
+		decoded, err := pagination.DecodeCursor(c.Encode())
+		require.NoError(t, err)
+
+		require.True(t, c.CreatedAt.Equal(decoded.CreatedAt))
+		require.Equal(t, c.ID, decoded.ID)
+	})
+
+	t.Run("invalid cursor", func(t *testing.T) {
+		_, err := pagination.DecodeCursor("not a cursor")
+		require.ErrorIs(t, err, pagination.ErrInvalidArgs)
+	})
+
+	t.Run("id is not a uuid", func(t *testing.T) {
+		c := pagination.Cursor{CreatedAt: time.Now(), ID: "1' OR 1=1"}
+
+		_, err := pagination.DecodeCursor(c.Encode())
+		require.ErrorIs(t, err, pagination.ErrInvalidArgs)
+	})
+}
+
+func TestInput_Args(t *testing.T) {
+	cursor := pagination.Cursor{CreatedAt: time.Now(), ID: "0b5ef1c4-2f6a-4a3e-9c8f-1a2b3c4d5e6f"}.Encode()
+
+	testCases := []struct {
+		name     string
+		input    pagination.Input
+		limit    int
+		backward bool
+		err      error
+	}{
+		{
+			name:  "defaults to the first page",
+			input: pagination.Input{},
+			limit: pagination.DefaultPageSize,
+		},
+		{
+			name:  "first after",
+			input: pagination.Input{First: intPtr(5), After: strPtr(cursor)},
+			limit: 5,
+		},
+		{
+			name:     "last before",
+			input:    pagination.Input{Last: intPtr(5), Before: strPtr(cursor)},
+			limit:    5,
+			backward: true,
+		},
+		{
+			name:  "first and last",
+			input: pagination.Input{First: intPtr(5), Last: intPtr(5)},
+			err:   pagination.ErrInvalidArgs,
+		},
+		{
+			name:  "page size too big",
+			input: pagination.Input{First: intPtr(pagination.MaxPageSize + 1)},
+			err:   pagination.ErrInvalidArgs,
+		},
+		{
+			name:  "negative page size",
+			input: pagination.Input{Last: intPtr(-1)},
+			err:   pagination.ErrInvalidArgs,
+		},
+		{
+			name:  "invalid cursor",
+			input: pagination.Input{After: strPtr("invalid")},
+			err:   pagination.ErrInvalidArgs,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			args, err := tc.input.Args()
+
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.limit, args.Limit)
+			require.Equal(t, tc.backward, args.Backward)
+		})
+	}
+}
+
+func intCursor(v int) pagination.Cursor {
+	return pagination.Cursor{ID: strconv.Itoa(v)}
+}
+
+func TestNewPage(t *testing.T) {
+	t.Run("forward page with more rows", func(t *testing.T) {
+		page := pagination.NewPage([]int{1, 2, 3}, pagination.Args{Limit: 2}, intCursor)
+
+		require.Equal(t, []int{1, 2}, page.Nodes())
+		require.Equal(t, "2", page.Edges[1].Cursor.ID)
+		require.True(t, page.HasNextPage)
+		require.False(t, page.HasPreviousPage)
+	})
+
+	t.Run("forward page after a cursor", func(t *testing.T) {
+		page := pagination.NewPage([]int{3, 4}, pagination.Args{Limit: 2, After: &pagination.Cursor{}}, intCursor)
+
+		require.Equal(t, []int{3, 4}, page.Nodes())
+		require.False(t, page.HasNextPage)
+		require.True(t, page.HasPreviousPage)
+	})
+
+	t.Run("backward page is returned in display order", func(t *testing.T) {
+		page := pagination.NewPage([]int{3, 2, 1}, pagination.Args{Limit: 2, Backward: true, Before: &pagination.Cursor{}}, intCursor)
+
+		require.Equal(t, []int{2, 3}, page.Nodes())
+		require.Equal(t, "2", page.Edges[0].Cursor.ID)
+		require.True(t, page.HasNextPage)
+		require.True(t, page.HasPreviousPage)
+	})
+}
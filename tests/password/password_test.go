@@ -0,0 +1,109 @@
+package password
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/RianNegreiros/go-graphql-api/internal/password"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+var params = password.Params{
+	Memory:      64,
+	Iterations:  1,
+	Parallelism: 1,
+	SaltLength:  16,
+	KeyLength:   32,
+}
+
+func TestHasher_Hash(t *testing.T) {
+	hasher := password.New(params)
+
+	hash, err := hasher.Hash("password")
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(hash, "$argon2id$v=19$m=64,t=1,p=1$"), hash)
+
+	other, err := hasher.Hash("password")
+	require.NoError(t, err)
+	require.NotEqual(t, hash, other, "every hash gets its own salt")
+
+	ok, err := hasher.Verify(hash, "password")
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	ok, err = hasher.Verify(hash, "wrong_password")
+	require.NoError(t, err)
+	require.False(t, ok)
+}
+
+func TestHasher_Verify(t *testing.T) {
+	hasher := password.New(params)
+
+	t.Run("hashes made with other parameters", func(t *testing.T) {
+		old := params
+		old.Iterations = 2
+		old.KeyLength = 16
+
+		hash, err := password.New(old).Hash("password")
+		require.NoError(t, err)
+
+		ok, err := hasher.Verify(hash, "password")
+		require.NoError(t, err)
+		require.True(t, ok)
+	})
+
+	t.Run("legacy bcrypt hashes", func(t *testing.T) {
+		hash, err := bcrypt.GenerateFromPassword([]byte("password"), bcrypt.MinCost)
+		require.NoError(t, err)
+
+		ok, err := hasher.Verify(string(hash), "password")
+		require.NoError(t, err)
+		require.True(t, ok)
+
+		ok, err = hasher.Verify(string(hash), "wrong_password")
+		require.NoError(t, err)
+		require.False(t, ok)
+	})
+
+	t.Run("unknown format", func(t *testing.T) {
+		_, err := hasher.Verify("not_a_hash", "password")
+		require.ErrorIs(t, err, password.ErrUnknownHash)
+	})
+
+	t.Run("malformed hash", func(t *testing.T) {
+		testCases := []string{
+			"$argon2id$v=19$m=64,t=1,p=1$salt",
+			"$argon2id$v=16$m=64,t=1,p=1$c2FsdHNhbHRzYWx0c2FsdA$a2V5",
+			"$argon2id$v=19$m=0,t=1,p=1$c2FsdHNhbHRzYWx0c2FsdA$a2V5",
+			"$argon2id$v=19$m=64,t=1,p=1$!!!$a2V5",
+		}
+
+		for _, hash := range testCases {
+			_, err := hasher.Verify(hash, "password")
+			require.Error(t, err, hash)
+		}
+	})
+}
+
+func TestHasher_NeedsRehash(t *testing.T) {
+	hasher := password.New(params)
+
+	current, err := hasher.Hash("password")
+	require.NoError(t, err)
+	require.False(t, hasher.NeedsRehash(current))
+
+	stronger := params
+	stronger.Memory *= 2
+	require.True(t, password.New(stronger).NeedsRehash(current))
+
+	longer := params
+	longer.KeyLength = 64
+	require.True(t, password.New(longer).NeedsRehash(current))
+
+	legacy, err := bcrypt.GenerateFromPassword([]byte("password"), bcrypt.MinCost)
+	require.NoError(t, err)
+	require.True(t, hasher.NeedsRehash(string(legacy)))
+
+	require.True(t, hasher.NeedsRehash("not_a_hash"))
+}
@@ -1,7 +1,9 @@
 package post
 
 import (
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/RianNegreiros/go-graphql-api/internal/user"
 	"github.com/RianNegreiros/go-graphql-api/tests/faker"
@@ -65,3 +67,78 @@ func TestCreatePostInput_Validate(t *testing.T) {
 		})
 	}
 }
+
+func TestPost_CanEdit(t *testing.T) {
+	p := post.Post{
+		UserID: "123",
+	}
+
+	require.True(t, p.CanEdit(user.UserModel{ID: "123"}))
+	require.False(t, p.CanEdit(user.UserModel{ID: "456"}))
+}
+
+func TestPost_InEditWindow(t *testing.T) {
+	defer func() {
+		post.EditWindow = 0
+	}()
+
+	p := post.Post{
+		CreatedAt: time.Now().Add(-time.Hour),
+	}
+
+	post.EditWindow = 0
+	require.True(t, p.InEditWindow(time.Now()))
+
+	post.EditWindow = time.Hour * 2
+	require.True(t, p.InEditWindow(time.Now()))
+
+	post.EditWindow = time.Minute * 15
+	require.False(t, p.InEditWindow(time.Now()))
+}
+
+func TestRemovePostInput_Validate(t *testing.T) {
+	testCases := []struct {
+		name  string
+		input post.RemovePostInput
+		err   error
+	}{
+		{
+			name: "valid",
+			input: post.RemovePostInput{
+				Reason: "spam",
+			},
+			err: nil,
+		},
+		{
+			name:  "reason required",
+			input: post.RemovePostInput{},
+			err:   user.ErrValidation,
+		},
+		{
+			name: "reason too long",
+			input: post.RemovePostInput{
+				Reason: faker.RandStr(501),
+			},
+			err: user.ErrValidation,
+		},
+		{
+			name: "multibyte reason at the limit",
+			input: post.RemovePostInput{
+				Reason: strings.Repeat("é", post.RemovalReasonMaxLength),
+			},
+			err: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.input.Validate()
+
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package pubsub
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/RianNegreiros/go-graphql-api/internal/pubsub"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemory_Publish(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ps := pubsub.NewMemory()
+
+	first, err := ps.Subscribe(ctx, "topic")
+	require.NoError(t, err)
+
+	second, err := ps.Subscribe(ctx, "topic")
+	require.NoError(t, err)
+
+	other, err := ps.Subscribe(ctx, "other")
+	require.NoError(t, err)
+
+	require.NoError(t, ps.Publish(ctx, "topic", []byte("hello")))
+
+	require.Equal(t, []byte("hello"), receive(t, first))
+	require.Equal(t, []byte("hello"), receive(t, second))
+
+	select {
+	case msg := <-other:
+		t.Fatalf("unexpected message on other topic: %s", msg)
+	default:
+	}
+}
+
+func TestMemory_Subscribe(t *testing.T) {
+	t.Run("closes the channel when the context is done", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+
+		ps := pubsub.NewMemory()
+
+		messages, err := ps.Subscribe(ctx, "topic")
+		require.NoError(t, err)
+
+		cancel()
+
+		select {
+		case _, ok := <-messages:
+			require.False(t, ok)
+		case <-time.After(time.Second):
+			t.Fatal("timed out waiting for channel to close")
+		}
+
+		require.NoError(t, ps.Publish(context.Background(), "topic", []byte("hello")))
+	})
+
+	t.Run("drops messages for slow subscribers", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		ps := pubsub.NewMemory()
+
+		messages, err := ps.Subscribe(ctx, "topic")
+		require.NoError(t, err)
+
+		for i := 0; i < pubsub.SubscriptionBufferSize+1; i++ {
+			require.NoError(t, ps.Publish(ctx, "topic", []byte("hello")))
+		}
+
+		require.Len(t, messages, pubsub.SubscriptionBufferSize)
+	})
+}
+
+func receive(t *testing.T, messages <-chan []byte) []byte {
+	t.Helper()
+
+	select {
+	case msg := <-messages:
+		return msg
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for message")
+		return nil
+	}
+}
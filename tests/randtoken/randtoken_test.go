@@ -0,0 +1,21 @@
+package randtoken
+
+import (
+	"testing"
+
+	"github.com/RianNegreiros/go-graphql-api/internal/randtoken"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerate(t *testing.T) {
+	token, hash, err := randtoken.Generate()
+	require.NoError(t, err)
+
+	require.NotEmpty(t, token)
+	require.NotEqual(t, token, hash)
+	require.Equal(t, hash, randtoken.Hash(token))
+
+	other, _, err := randtoken.Generate()
+	require.NoError(t, err)
+	require.NotEqual(t, token, other)
+}
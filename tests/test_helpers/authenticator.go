@@ -0,0 +1,261 @@
+package test_helpers
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"sort"
+	"testing"
+
+	"github.com/RianNegreiros/go-graphql-api/internal/user"
+	"github.com/RianNegreiros/go-graphql-api/internal/webauthn"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	flagUserPresent            = 0x01
+	flagUserVerified           = 0x04
+	flagAttestedCredentialData = 0x40
+)
+
+// Authenticator is a software WebAuthn authenticator holding one passkey,
+// standing in for the browser and the security key in tests.
+type Authenticator struct {
+	RPID   string
+	Origin string
+	// Algorithm is webauthn.AlgES256 or webauthn.AlgEdDSA.
+	Algorithm    int
+	CredentialID []byte
+	SignCount    uint32
+	// NoCounter makes the authenticator always report a sign count of zero,
+	// like most synced passkeys do.
+	NoCounter bool
+	// SkipUserVerification clears the UV flag, as if the user wasn't asked
+	// for a PIN or biometric.
+	SkipUserVerification bool
+
+	ecKey *ecdsa.PrivateKey
+	edKey ed25519.PrivateKey
+}
+
+// NewAuthenticator returns an authenticator with a new ES256 passkey for
+// user.PasskeyRelyingParty.
+func NewAuthenticator(t *testing.T) *Authenticator {
+	return NewAuthenticatorWithAlgorithm(t, webauthn.AlgES256)
+}
+
+func NewAuthenticatorWithAlgorithm(t *testing.T, alg int) *Authenticator {
+	t.Helper()
+
+	a := &Authenticator{
+		RPID:         user.PasskeyRelyingParty.ID,
+		Origin:       user.PasskeyRelyingParty.Origins[0],
+		Algorithm:    alg,
+		CredentialID: make([]byte, 16),
+	}
+
+	_, err := rand.Read(a.CredentialID)
+	require.NoError(t, err)
+
+	switch alg {
+	case webauthn.AlgES256:
+		a.ecKey, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	case webauthn.AlgEdDSA:
+		_, a.edKey, err = ed25519.GenerateKey(rand.Reader)
+	default:
+		t.Fatalf("unsupported algorithm %d", alg)
+	}
+	require.NoError(t, err)
+
+	return a
+}
+
+// PublicKey returns the COSE_Key encoding of the passkey's public key.
+func (a *Authenticator) PublicKey() []byte {
+	if a.edKey != nil {
+		return encodeCBOR(map[int]interface{}{
+			1:  1, // OKP
+			3:  webauthn.AlgEdDSA,
+			-1: 6, // Ed25519
+			-2: []byte(a.edKey.Public().(ed25519.PublicKey)),
+		})
+	}
+
+	x := make([]byte, 32)
+	y := make([]byte, 32)
+	a.ecKey.X.FillBytes(x)
+	a.ecKey.Y.FillBytes(y)
+
+	return encodeCBOR(map[int]interface{}{
+		1:  2, // EC2
+		3:  webauthn.AlgES256,
+		-1: 1, // P-256
+		-2: x,
+		-3: y,
+	})
+}
+
+// Create answers a registration challenge, like navigator.credentials.create.
+func (a *Authenticator) Create(t *testing.T, challenge string) (clientDataJSON, attestationObject []byte) {
+	t.Helper()
+
+	clientDataJSON = a.clientData(t, webauthn.ClientDataTypeCreate, challenge)
+
+	credential := make([]byte, 16, 18+len(a.CredentialID))
+	credential = binary.BigEndian.AppendUint16(credential, uint16(len(a.CredentialID)))
+	credential = append(credential, a.CredentialID...)
+	credential = append(credential, a.PublicKey()...)
+
+	authData := a.authenticatorData(flagAttestedCredentialData, credential)
+
+	attestationObject = encodeCBOR(map[string]interface{}{
+		"fmt":      "none",
+		"attStmt":  map[string]interface{}{},
+		"authData": authData,
+	})
+
+	return clientDataJSON, attestationObject
+}
+
+// Get answers a login challenge, like navigator.credentials.get, bumping
+// the sign count.
+func (a *Authenticator) Get(t *testing.T, challenge string) (clientDataJSON, authenticatorData, signature []byte) {
+	t.Helper()
+
+	if !a.NoCounter {
+		a.SignCount++
+	}
+
+	clientDataJSON = a.clientData(t, webauthn.ClientDataTypeGet, challenge)
+	authenticatorData = a.authenticatorData(0, nil)
+
+	clientDataHash := sha256.Sum256(clientDataJSON)
+	signed := append(append([]byte{}, authenticatorData...), clientDataHash[:]...)
+
+	if a.edKey != nil {
+		return clientDataJSON, authenticatorData, ed25519.Sign(a.edKey, signed)
+	}
+
+	digest := sha256.Sum256(signed)
+
+	signature, err := ecdsa.SignASN1(rand.Reader, a.ecKey, digest[:])
+	require.NoError(t, err)
+
+	return clientDataJSON, authenticatorData, signature
+}
+
+// RegistrationInput is the FinishRegistration input for challenge.
+func (a *Authenticator) RegistrationInput(t *testing.T, name string, challenge string) user.FinishPasskeyRegistrationInput {
+	clientDataJSON, attestationObject := a.Create(t, challenge)
+
+	return user.FinishPasskeyRegistrationInput{
+		Name:              name,
+		ClientDataJSON:    base64.RawURLEncoding.EncodeToString(clientDataJSON),
+		AttestationObject: base64.RawURLEncoding.EncodeToString(attestationObject),
+	}
+}
+
+// LoginInput is the LoginWithPasskey input for challenge. userID is sent as
+// the user handle when not empty.
+func (a *Authenticator) LoginInput(t *testing.T, challenge string, userID string) user.PasskeyLoginInput {
+	clientDataJSON, authenticatorData, signature := a.Get(t, challenge)
+
+	return user.PasskeyLoginInput{
+		CredentialID:      base64.RawURLEncoding.EncodeToString(a.CredentialID),
+		ClientDataJSON:    base64.RawURLEncoding.EncodeToString(clientDataJSON),
+		AuthenticatorData: base64.RawURLEncoding.EncodeToString(authenticatorData),
+		Signature:         base64.RawURLEncoding.EncodeToString(signature),
+		UserHandle:        base64.RawURLEncoding.EncodeToString([]byte(userID)),
+	}
+}
+
+func (a *Authenticator) clientData(t *testing.T, typ string, challenge string) []byte {
+	t.Helper()
+
+	data, err := json.Marshal(webauthn.ClientData{
+		Type:      typ,
+		Challenge: challenge,
+		Origin:    a.Origin,
+	})
+	require.NoError(t, err)
+
+	return data
+}
+
+func (a *Authenticator) authenticatorData(flags byte, credential []byte) []byte {
+	rpIDHash := sha256.Sum256([]byte(a.RPID))
+
+	flags |= flagUserPresent
+	if !a.SkipUserVerification {
+		flags |= flagUserVerified
+	}
+
+	data := append(rpIDHash[:], flags)
+	data = binary.BigEndian.AppendUint32(data, a.SignCount)
+
+	return append(data, credential...)
+}
+
+// encodeCBOR encodes the few types authenticators need. Map keys are sorted
+// so the output is deterministic.
+func encodeCBOR(v interface{}) []byte {
+	switch v := v.(type) {
+	case int:
+		if v < 0 {
+			return cborHead(1, uint64(-1-v))
+		}
+		return cborHead(0, uint64(v))
+	case []byte:
+		return append(cborHead(2, uint64(len(v))), v...)
+	case string:
+		return append(cborHead(3, uint64(len(v))), v...)
+	case map[int]interface{}:
+		keys := make([]int, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Ints(keys)
+
+		out := cborHead(5, uint64(len(v)))
+		for _, k := range keys {
+			out = append(out, encodeCBOR(k)...)
+			out = append(out, encodeCBOR(v[k])...)
+		}
+
+		return out
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		out := cborHead(5, uint64(len(v)))
+		for _, k := range keys {
+			out = append(out, encodeCBOR(k)...)
+			out = append(out, encodeCBOR(v[k])...)
+		}
+
+		return out
+	default:
+		panic("encodeCBOR: unsupported type")
+	}
+}
+
+func cborHead(major byte, n uint64) []byte {
+	switch {
+	case n < 24:
+		return []byte{major<<5 | byte(n)}
+	case n <= 0xff:
+		return []byte{major<<5 | 24, byte(n)}
+	case n <= 0xffff:
+		return binary.BigEndian.AppendUint16([]byte{major<<5 | 25}, uint16(n))
+	default:
+		return binary.BigEndian.AppendUint32([]byte{major<<5 | 26}, uint32(n))
+	}
+}
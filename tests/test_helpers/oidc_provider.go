@@ -0,0 +1,326 @@
+package test_helpers
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/lestrrat-go/jwx/jwa"
+	"github.com/lestrrat-go/jwx/jwk"
+	"github.com/lestrrat-go/jwx/jws"
+	"github.com/stretchr/testify/require"
+
+	"github.com/RianNegreiros/go-graphql-api/internal/oidc"
+)
+
+// OIDCProvider is an in-process OpenID Connect provider. Logging in with it
+// always succeeds as the user described by its fields.
+type OIDCProvider struct {
+	Server       *httptest.Server
+	ClientID     string
+	ClientSecret string
+
+	Subject           string
+	Email             string
+	EmailVerified     bool
+	PreferredUsername string
+
+	mu    sync.Mutex
+	keys  []oidcKey
+	codes map[string]oidcAuthorization
+}
+
+type oidcKey struct {
+	ID  string
+	Key *rsa.PrivateKey
+}
+
+type oidcAuthorization struct {
+	RedirectURI   string
+	CodeChallenge string
+	Nonce         string
+	Claims        map[string]interface{}
+}
+
+func NewOIDCProvider(t *testing.T) *OIDCProvider {
+	t.Helper()
+
+	p := &OIDCProvider{
+		ClientID:      "client_id",
+		ClientSecret:  "client:secret",
+		Subject:       "subject",
+		Email:         "johndoe@mail.com",
+		EmailVerified: true,
+		codes:         map[string]oidcAuthorization{},
+	}
+
+	p.RotateKey(t)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", p.discovery)
+	mux.HandleFunc("/authorize", p.authorize)
+	mux.HandleFunc("/token", p.token)
+	mux.HandleFunc("/jwks", p.jwks)
+
+	p.Server = httptest.NewServer(mux)
+	t.Cleanup(p.Server.Close)
+
+	return p
+}
+
+func (p *OIDCProvider) Issuer() string {
+	return p.Server.URL
+}
+
+// Config registers a client with the provider.
+func (p *OIDCProvider) Config(redirectURL string) oidc.Config {
+	return oidc.Config{
+		Issuer:       p.Issuer(),
+		ClientID:     p.ClientID,
+		ClientSecret: p.ClientSecret,
+		RedirectURL:  redirectURL,
+		Scopes:       []string{"email", "profile"},
+	}
+}
+
+// KeyID is the ID of the key signing new ID tokens.
+func (p *OIDCProvider) KeyID() string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.keys[0].ID
+}
+
+// RotateKey signs new ID tokens with a new key. The previous keys are still
+// published.
+func (p *OIDCProvider) RotateKey(t *testing.T) {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.keys = append([]oidcKey{{ID: fmt.Sprintf("key-%d", len(p.keys)+1), Key: key}}, p.keys...)
+}
+
+// Authorize follows authURL as a logged in user consenting would, and returns
+// what the provider sends back to the redirect URL.
+func (p *OIDCProvider) Authorize(t *testing.T, authURL string) (code string, state string) {
+	t.Helper()
+
+	client := &http.Client{
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+
+	res, err := client.Get(authURL)
+	require.NoError(t, err)
+	defer res.Body.Close()
+
+	require.Equal(t, http.StatusFound, res.StatusCode)
+
+	location, err := url.Parse(res.Header.Get("Location"))
+	require.NoError(t, err)
+
+	return location.Query().Get("code"), location.Query().Get("state")
+}
+
+// Claims are the claims of an ID token issued now for nonce.
+func (p *OIDCProvider) Claims(nonce string) map[string]interface{} {
+	now := time.Now()
+
+	claims := map[string]interface{}{
+		"iss":            p.Issuer(),
+		"aud":            p.ClientID,
+		"sub":            p.Subject,
+		"iat":            now.Unix(),
+		"exp":            now.Add(time.Hour).Unix(),
+		"email":          p.Email,
+		"email_verified": p.EmailVerified,
+	}
+
+	if nonce != "" {
+		claims["nonce"] = nonce
+	}
+
+	if p.PreferredUsername != "" {
+		claims["preferred_username"] = p.PreferredUsername
+	}
+
+	return claims
+}
+
+// SignIDToken signs claims with the current key.
+func (p *OIDCProvider) SignIDToken(t *testing.T, claims map[string]interface{}) string {
+	t.Helper()
+
+	p.mu.Lock()
+	key := p.keys[0]
+	p.mu.Unlock()
+
+	return SignIDToken(t, jwa.RS256, key.Key, key.ID, claims)
+}
+
+// SignIDToken signs claims with any key, to forge ID tokens.
+func SignIDToken(t *testing.T, alg jwa.SignatureAlgorithm, key interface{}, kid string, claims map[string]interface{}) string {
+	t.Helper()
+
+	payload, err := json.Marshal(claims)
+	require.NoError(t, err)
+
+	headers := jws.NewHeaders()
+	require.NoError(t, headers.Set(jws.TypeKey, "JWT"))
+
+	if kid != "" {
+		require.NoError(t, headers.Set(jws.KeyIDKey, kid))
+	}
+
+	signed, err := jws.Sign(payload, alg, key, jws.WithHeaders(headers))
+	require.NoError(t, err)
+
+	return string(signed)
+}
+
+func (p *OIDCProvider) discovery(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{
+		"issuer":                 p.Issuer(),
+		"authorization_endpoint": p.Issuer() + "/authorize",
+		"token_endpoint":         p.Issuer() + "/token",
+		"jwks_uri":               p.Issuer() + "/jwks",
+	})
+}
+
+func (p *OIDCProvider) authorize(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	if q.Get("response_type") != "code" || q.Get("client_id") != p.ClientID ||
+		q.Get("code_challenge_method") != "S256" || q.Get("code_challenge") == "" {
+		http.Error(w, "invalid_request", http.StatusBadRequest)
+		return
+	}
+
+	code := randomString()
+
+	p.mu.Lock()
+	p.codes[code] = oidcAuthorization{
+		RedirectURI:   q.Get("redirect_uri"),
+		CodeChallenge: q.Get("code_challenge"),
+		Nonce:         q.Get("nonce"),
+		Claims:        p.Claims(q.Get("nonce")),
+	}
+	p.mu.Unlock()
+
+	redirect, err := url.Parse(q.Get("redirect_uri"))
+	if err != nil {
+		http.Error(w, "invalid_request", http.StatusBadRequest)
+		return
+	}
+
+	values := redirect.Query()
+	values.Set("code", code)
+	values.Set("state", q.Get("state"))
+	redirect.RawQuery = values.Encode()
+
+	http.Redirect(w, r, redirect.String(), http.StatusFound)
+}
+
+// token redeems codes once, checking the client and the PKCE verifier.
+func (p *OIDCProvider) token(w http.ResponseWriter, r *http.Request) {
+	clientID, clientSecret, ok := r.BasicAuth()
+	if ok {
+		clientID, _ = url.QueryUnescape(clientID)
+		clientSecret, _ = url.QueryUnescape(clientSecret)
+	}
+
+	if r.Method != http.MethodPost || !ok || clientID != p.ClientID || clientSecret != p.ClientSecret {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "invalid_client"})
+		return
+	}
+
+	if err := r.ParseForm(); err != nil || r.PostForm.Get("grant_type") != "authorization_code" {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_request"})
+		return
+	}
+
+	code := r.PostForm.Get("code")
+
+	p.mu.Lock()
+	a, found := p.codes[code]
+	delete(p.codes, code)
+	p.mu.Unlock()
+
+	if !found || a.RedirectURI != r.PostForm.Get("redirect_uri") || a.CodeChallenge != oidc.CodeChallenge(r.PostForm.Get("code_verifier")) {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant"})
+		return
+	}
+
+	p.mu.Lock()
+	key := p.keys[0]
+	p.mu.Unlock()
+
+	payload, _ := json.Marshal(a.Claims)
+
+	headers := jws.NewHeaders()
+	_ = headers.Set(jws.KeyIDKey, key.ID)
+
+	idToken, err := jws.Sign(payload, jwa.RS256, key.Key, jws.WithHeaders(headers))
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "server_error"})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"access_token": randomString(),
+		"token_type":   "Bearer",
+		"expires_in":   3600,
+		"id_token":     string(idToken),
+	})
+}
+
+func (p *OIDCProvider) jwks(w http.ResponseWriter, r *http.Request) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	set := jwk.NewSet()
+
+	for _, k := range p.keys {
+		key, err := jwk.New(&k.Key.PublicKey)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		_ = key.Set(jwk.KeyIDKey, k.ID)
+		_ = key.Set(jwk.AlgorithmKey, jwa.RS256)
+		_ = key.Set(jwk.KeyUsageKey, "sig")
+
+		set.Add(key)
+	}
+
+	writeJSON(w, http.StatusOK, set)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func randomString() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+
+	return base64.RawURLEncoding.EncodeToString(b)
+}
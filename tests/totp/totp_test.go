@@ -0,0 +1,87 @@
+package totp
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/RianNegreiros/go-graphql-api/internal/totp"
+	"github.com/stretchr/testify/require"
+)
+
+// secret is the RFC 6238 test key "12345678901234567890" in base32.
+const secret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestCode(t *testing.T) {
+	testCases := []struct {
+		unix int64
+		code string
+	}{
+		{unix: 59, code: "287082"},
+		{unix: 1111111109, code: "081804"},
+		{unix: 1234567890, code: "005924"},
+		{unix: 2000000000, code: "279037"},
+	}
+
+	for _, tc := range testCases {
+		code, err := totp.Code(secret, totp.Step(time.Unix(tc.unix, 0)))
+		require.NoError(t, err)
+		require.Equal(t, tc.code, code)
+	}
+}
+
+func TestVerify(t *testing.T) {
+	now := time.Unix(1111111109, 0)
+
+	t.Run("accepts the current and adjacent periods", func(t *testing.T) {
+		for _, offset := range []time.Duration{-totp.Period, 0, totp.Period} {
+			code, err := totp.Code(secret, totp.Step(now.Add(offset)))
+			require.NoError(t, err)
+
+			step, ok := totp.Verify(secret, code, now)
+			require.True(t, ok)
+			require.Equal(t, totp.Step(now.Add(offset)), step)
+		}
+	})
+
+	t.Run("rejects codes too far off", func(t *testing.T) {
+		code, err := totp.Code(secret, totp.Step(now.Add(2*totp.Period)))
+		require.NoError(t, err)
+
+		_, ok := totp.Verify(secret, code, now)
+		require.False(t, ok)
+	})
+
+	t.Run("rejects malformed codes and secrets", func(t *testing.T) {
+		_, ok := totp.Verify(secret, "81804", now)
+		require.False(t, ok)
+
+		_, ok = totp.Verify("not base32!", "081804", now)
+		require.False(t, ok)
+	})
+}
+
+func TestGenerateSecret(t *testing.T) {
+	first, err := totp.GenerateSecret()
+	require.NoError(t, err)
+
+	second, err := totp.GenerateSecret()
+	require.NoError(t, err)
+
+	require.Len(t, first, 32)
+	require.NotEqual(t, first, second)
+
+	_, err = totp.Code(first, 1)
+	require.NoError(t, err)
+}
+
+func TestURI(t *testing.T) {
+	u, err := url.Parse(totp.URI("Example", "johndoe@mail.com", secret))
+	require.NoError(t, err)
+
+	require.Equal(t, "otpauth", u.Scheme)
+	require.Equal(t, "totp", u.Host)
+	require.Equal(t, "/Example:johndoe@mail.com", u.Path)
+	require.Equal(t, secret, u.Query().Get("secret"))
+	require.Equal(t, "Example", u.Query().Get("issuer"))
+}
@@ -49,3 +49,47 @@ func TestPutUserIDIntoContext(t *testing.T) {
 		require.Equal(t, "123", userID)
 	})
 }
+
+func TestGetSessionIDFromContext(t *testing.T) {
+	t.Run("should return session id from context", func(t *testing.T) {
+		ctx := transport.PutSessionIDIntoContext(context.Background(), "1")
+
+		sessionID, err := transport.GetSessionIDFromContext(ctx)
+		require.NoError(t, err)
+		require.Equal(t, "1", sessionID)
+	})
+
+	t.Run("return error if no session id", func(t *testing.T) {
+		ctx := context.Background()
+
+		_, err := transport.GetSessionIDFromContext(ctx)
+		require.ErrorIs(t, err, user.ErrNoSessionIDInContext)
+	})
+}
+
+func TestGetRoleFromContext(t *testing.T) {
+	t.Run("should return role from context", func(t *testing.T) {
+		ctx := transport.PutRoleIntoContext(context.Background(), user.RoleAdmin)
+
+		require.Equal(t, user.RoleAdmin, transport.GetRoleFromContext(ctx))
+	})
+
+	t.Run("default to user role", func(t *testing.T) {
+		require.Equal(t, user.RoleUser, transport.GetRoleFromContext(context.Background()))
+	})
+}
+
+func TestGetScopesFromContext(t *testing.T) {
+	t.Run("should return scopes from context", func(t *testing.T) {
+		ctx := transport.PutScopesIntoContext(context.Background(), []user.Scope{user.ScopePostsRead})
+
+		scopes, ok := transport.GetScopesFromContext(ctx)
+		require.True(t, ok)
+		require.Equal(t, []user.Scope{user.ScopePostsRead}, scopes)
+	})
+
+	t.Run("sessions have no scopes", func(t *testing.T) {
+		_, ok := transport.GetScopesFromContext(context.Background())
+		require.False(t, ok)
+	})
+}
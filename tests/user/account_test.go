@@ -0,0 +1,64 @@
+package user
+
+import (
+	"testing"
+
+	"github.com/RianNegreiros/go-graphql-api/internal/user"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResetPasswordInput_Validate(t *testing.T) {
+	testCases := []struct {
+		name  string
+		input user.ResetPasswordInput
+		err   error
+	}{
+		{
+			name: "valid",
+			input: user.ResetPasswordInput{
+				Token:           "token",
+				Password:        "123456",
+				ConfirmPassword: "123456",
+			},
+			err: nil,
+		},
+		{
+			name: "missing token",
+			input: user.ResetPasswordInput{
+				Password:        "123456",
+				ConfirmPassword: "123456",
+			},
+			err: user.ErrInvalidResetToken,
+		},
+		{
+			name: "password not long enough",
+			input: user.ResetPasswordInput{
+				Token:           "token",
+				Password:        "12345",
+				ConfirmPassword: "12345",
+			},
+			err: user.ErrValidation,
+		},
+		{
+			name: "password and confirm password don't match",
+			input: user.ResetPasswordInput{
+				Token:           "token",
+				Password:        "123456",
+				ConfirmPassword: "1234567",
+			},
+			err: user.ErrValidation,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.input.Validate()
+
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
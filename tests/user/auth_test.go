@@ -0,0 +1,122 @@
+package user
+
+import (
+	"testing"
+
+	"github.com/RianNegreiros/go-graphql-api/internal/user"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChangePasswordInput_Validate(t *testing.T) {
+	testCases := []struct {
+		name  string
+		input user.ChangePasswordInput
+		err   error
+	}{
+		{
+			name: "valid",
+			input: user.ChangePasswordInput{
+				CurrentPassword: "password",
+				Password:        "123456",
+				ConfirmPassword: "123456",
+			},
+			err: nil,
+		},
+		{
+			name: "missing current password",
+			input: user.ChangePasswordInput{
+				Password:        "123456",
+				ConfirmPassword: "123456",
+			},
+			err: user.ErrValidation,
+		},
+		{
+			name: "password not long enough",
+			input: user.ChangePasswordInput{
+				CurrentPassword: "password",
+				Password:        "12345",
+				ConfirmPassword: "12345",
+			},
+			err: user.ErrValidation,
+		},
+		{
+			name: "password and confirm password don't match",
+			input: user.ChangePasswordInput{
+				CurrentPassword: "password",
+				Password:        "123456",
+				ConfirmPassword: "1234567",
+			},
+			err: user.ErrValidation,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.input.Validate()
+
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestChangeEmailInput_Sanitize(t *testing.T) {
+	input := user.ChangeEmailInput{
+		Email:    "  JohnDoe@Mail.com ",
+		Password: " password ",
+	}
+
+	input.Sanitize()
+
+	require.Equal(t, user.ChangeEmailInput{
+		Email:    "johndoe@mail.com",
+		Password: "password",
+	}, input)
+}
+
+func TestChangeEmailInput_Validate(t *testing.T) {
+	testCases := []struct {
+		name  string
+		input user.ChangeEmailInput
+		err   error
+	}{
+		{
+			name: "valid",
+			input: user.ChangeEmailInput{
+				Email:    "johndoe@mail.com",
+				Password: "password",
+			},
+			err: nil,
+		},
+		{
+			name: "invalid email",
+			input: user.ChangeEmailInput{
+				Email:    "johndoe",
+				Password: "password",
+			},
+			err: user.ErrValidation,
+		},
+		{
+			name: "missing password",
+			input: user.ChangeEmailInput{
+				Email: "johndoe@mail.com",
+			},
+			err: user.ErrValidation,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.input.Validate()
+
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
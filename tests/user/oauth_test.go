@@ -0,0 +1,106 @@
+package user
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/RianNegreiros/go-graphql-api/internal/user"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateOAuthClientInput_Sanitize(t *testing.T) {
+	input := user.CreateOAuthClientInput{
+		Name:         "  my app ",
+		RedirectURIs: []string{" https://app.example.com/callback "},
+		Scopes:       []user.Scope{user.ScopePostsRead, user.ScopePostsRead},
+	}
+
+	input.Sanitize()
+
+	require.Equal(t, "my app", input.Name)
+	require.Equal(t, []string{"https://app.example.com/callback"}, input.RedirectURIs)
+	require.Equal(t, []user.Scope{user.ScopePostsRead}, input.Scopes)
+}
+
+func TestCreateOAuthClientInput_Validate(t *testing.T) {
+	scopes := []user.Scope{user.ScopePostsRead}
+
+	testCases := []struct {
+		name  string
+		input user.CreateOAuthClientInput
+		err   error
+	}{
+		{
+			name:  "valid input",
+			input: user.CreateOAuthClientInput{Name: "app", RedirectURIs: []string{"https://app.example.com/callback"}, Scopes: scopes},
+		},
+		{
+			name:  "http on the loopback",
+			input: user.CreateOAuthClientInput{Name: "app", RedirectURIs: []string{"http://127.0.0.1:3000/callback"}, Scopes: scopes},
+		},
+		{
+			name:  "missing name",
+			input: user.CreateOAuthClientInput{RedirectURIs: []string{"https://app.example.com/callback"}, Scopes: scopes},
+			err:   user.ErrValidation,
+		},
+		{
+			name:  "name too long",
+			input: user.CreateOAuthClientInput{Name: strings.Repeat("a", user.OAuthClientNameMaxLength+1), RedirectURIs: []string{"https://app.example.com/callback"}, Scopes: scopes},
+			err:   user.ErrValidation,
+		},
+		{
+			name:  "no redirect uri",
+			input: user.CreateOAuthClientInput{Name: "app", Scopes: scopes},
+			err:   user.ErrValidation,
+		},
+		{
+			name:  "http redirect uri",
+			input: user.CreateOAuthClientInput{Name: "app", RedirectURIs: []string{"http://app.example.com/callback"}, Scopes: scopes},
+			err:   user.ErrValidation,
+		},
+		{
+			name:  "redirect uri with a fragment",
+			input: user.CreateOAuthClientInput{Name: "app", RedirectURIs: []string{"https://app.example.com/callback#token"}, Scopes: scopes},
+			err:   user.ErrValidation,
+		},
+		{
+			name:  "relative redirect uri",
+			input: user.CreateOAuthClientInput{Name: "app", RedirectURIs: []string{"/callback"}, Scopes: scopes},
+			err:   user.ErrValidation,
+		},
+		{
+			name:  "no scopes",
+			input: user.CreateOAuthClientInput{Name: "app", RedirectURIs: []string{"https://app.example.com/callback"}},
+			err:   user.ErrValidation,
+		},
+		{
+			name:  "unknown scope",
+			input: user.CreateOAuthClientInput{Name: "app", RedirectURIs: []string{"https://app.example.com/callback"}, Scopes: []user.Scope{"posts:delete"}},
+			err:   user.ErrValidation,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.input.Validate()
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestOAuthConsent_Covers(t *testing.T) {
+	consent := user.OAuthConsent{Scopes: []user.Scope{user.ScopePostsRead, user.ScopePostsWrite}}
+
+	require.True(t, consent.Covers([]user.Scope{user.ScopePostsRead}))
+	require.False(t, consent.Covers([]user.Scope{user.ScopePostsRead, user.ScopeUsersWrite}))
+}
+
+func TestParseScopes(t *testing.T) {
+	require.Equal(t, []user.Scope{user.ScopePostsRead, user.ScopePostsWrite}, user.ParseScopes(" posts:read  posts:write posts:read"))
+	require.Empty(t, user.ParseScopes(""))
+	require.Equal(t, "posts:read posts:write", user.FormatScopes([]user.Scope{user.ScopePostsRead, user.ScopePostsWrite}))
+}
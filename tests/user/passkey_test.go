@@ -0,0 +1,48 @@
+package user
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/RianNegreiros/go-graphql-api/internal/user"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFinishPasskeyRegistrationInput_Validate(t *testing.T) {
+	testCases := []struct {
+		name  string
+		input user.FinishPasskeyRegistrationInput
+		err   error
+	}{
+		{
+			name:  "valid input",
+			input: user.FinishPasskeyRegistrationInput{Name: "laptop", ClientDataJSON: "e30", AttestationObject: "oA"},
+		},
+		{
+			name:  "missing name",
+			input: user.FinishPasskeyRegistrationInput{ClientDataJSON: "e30", AttestationObject: "oA"},
+			err:   user.ErrValidation,
+		},
+		{
+			name:  "name too long",
+			input: user.FinishPasskeyRegistrationInput{Name: strings.Repeat("a", user.PasskeyNameMaxLength+1), ClientDataJSON: "e30", AttestationObject: "oA"},
+			err:   user.ErrValidation,
+		},
+		{
+			name:  "missing response",
+			input: user.FinishPasskeyRegistrationInput{Name: "laptop"},
+			err:   user.ErrInvalidPasskey,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.input.Validate()
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package user
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/RianNegreiros/go-graphql-api/internal/user"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreatePersonalAccessTokenInput_Sanitize(t *testing.T) {
+	input := user.CreatePersonalAccessTokenInput{
+		Name:   "  deploy bot ",
+		Scopes: []user.Scope{user.ScopePostsWrite, user.ScopePostsRead, user.ScopePostsWrite},
+	}
+
+	input.Sanitize()
+
+	require.Equal(t, "deploy bot", input.Name)
+	require.Equal(t, []user.Scope{user.ScopePostsWrite, user.ScopePostsRead}, input.Scopes)
+}
+
+func TestCreatePersonalAccessTokenInput_Validate(t *testing.T) {
+	past := time.Now().Add(-time.Minute)
+	future := time.Now().Add(time.Hour)
+
+	testCases := []struct {
+		name  string
+		input user.CreatePersonalAccessTokenInput
+		err   error
+	}{
+		{
+			name:  "valid input",
+			input: user.CreatePersonalAccessTokenInput{Name: "bot", Scopes: []user.Scope{user.ScopePostsWrite}},
+		},
+		{
+			name:  "valid input with expiration",
+			input: user.CreatePersonalAccessTokenInput{Name: "bot", Scopes: []user.Scope{user.ScopePostsWrite}, ExpiredAt: &future},
+		},
+		{
+			name:  "missing name",
+			input: user.CreatePersonalAccessTokenInput{Scopes: []user.Scope{user.ScopePostsWrite}},
+			err:   user.ErrValidation,
+		},
+		{
+			name:  "name too long",
+			input: user.CreatePersonalAccessTokenInput{Name: strings.Repeat("a", user.PersonalAccessTokenNameMaxLength+1), Scopes: []user.Scope{user.ScopePostsWrite}},
+			err:   user.ErrValidation,
+		},
+		{
+			name:  "no scopes",
+			input: user.CreatePersonalAccessTokenInput{Name: "bot"},
+			err:   user.ErrValidation,
+		},
+		{
+			name:  "unknown scope",
+			input: user.CreatePersonalAccessTokenInput{Name: "bot", Scopes: []user.Scope{"posts:delete"}},
+			err:   user.ErrValidation,
+		},
+		{
+			name:  "expired",
+			input: user.CreatePersonalAccessTokenInput{Name: "bot", Scopes: []user.Scope{user.ScopePostsWrite}, ExpiredAt: &past},
+			err:   user.ErrValidation,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.input.Validate()
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestPersonalAccessToken_HasScope(t *testing.T) {
+	token := user.PersonalAccessToken{Scopes: []user.Scope{user.ScopePostsRead}}
+
+	require.True(t, token.HasScope(user.ScopePostsRead))
+	require.False(t, token.HasScope(user.ScopePostsWrite))
+}
@@ -0,0 +1,125 @@
+package user
+
+import (
+	"testing"
+
+	"github.com/RianNegreiros/go-graphql-api/internal/user"
+	"github.com/RianNegreiros/go-graphql-api/tests/faker"
+	"github.com/stretchr/testify/require"
+)
+
+func ptr(s string) *string {
+	return &s
+}
+
+func TestUpdateProfileInput_Sanitize(t *testing.T) {
+	input := user.UpdateProfileInput{
+		DisplayName: ptr("  John Doe "),
+		Bio:         ptr(" hello "),
+	}
+
+	want := user.UpdateProfileInput{
+		DisplayName: ptr("John Doe"),
+		Bio:         ptr("hello"),
+	}
+
+	input.Sanitize()
+
+	require.Equal(t, want, input)
+}
+
+func TestUpdateProfileInput_Validate(t *testing.T) {
+	testCases := []struct {
+		name  string
+		input user.UpdateProfileInput
+		err   error
+	}{
+		{
+			name: "valid",
+			input: user.UpdateProfileInput{
+				DisplayName: ptr("John Doe"),
+				Bio:         ptr("hello"),
+				Location:    ptr("Earth"),
+				Website:     ptr("https://johndoe.com"),
+				AvatarURL:   ptr("https://johndoe.com/avatar.png"),
+			},
+			err: nil,
+		},
+		{
+			name:  "empty input",
+			input: user.UpdateProfileInput{},
+			err:   nil,
+		},
+		{
+			name: "cleared fields",
+			input: user.UpdateProfileInput{
+				Website:   ptr(""),
+				AvatarURL: ptr(""),
+			},
+			err: nil,
+		},
+		{
+			name: "display name too long",
+			input: user.UpdateProfileInput{
+				DisplayName: ptr(faker.RandStr(51)),
+			},
+			err: user.ErrValidation,
+		},
+		{
+			name: "bio too long",
+			input: user.UpdateProfileInput{
+				Bio: ptr(faker.RandStr(161)),
+			},
+			err: user.ErrValidation,
+		},
+		{
+			name: "location too long",
+			input: user.UpdateProfileInput{
+				Location: ptr(faker.RandStr(31)),
+			},
+			err: user.ErrValidation,
+		},
+		{
+			name: "website not a url",
+			input: user.UpdateProfileInput{
+				Website: ptr("johndoe"),
+			},
+			err: user.ErrValidation,
+		},
+		{
+			name: "avatar url with unsupported scheme",
+			input: user.UpdateProfileInput{
+				AvatarURL: ptr("javascript:alert(1)"),
+			},
+			err: user.ErrValidation,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.input.Validate()
+
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestUpdateProfileInput_Apply(t *testing.T) {
+	u := user.UserModel{
+		DisplayName: "John",
+		Bio:         "hello",
+	}
+
+	got := user.UpdateProfileInput{
+		DisplayName: ptr("John Doe"),
+		Location:    ptr("Earth"),
+	}.Apply(u)
+
+	require.Equal(t, "John Doe", got.DisplayName)
+	require.Equal(t, "hello", got.Bio)
+	require.Equal(t, "Earth", got.Location)
+}
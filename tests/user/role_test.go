@@ -0,0 +1,25 @@
+package user
+
+import (
+	"testing"
+
+	"github.com/RianNegreiros/go-graphql-api/internal/user"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRole_Satisfies(t *testing.T) {
+	require.True(t, user.RoleUser.Satisfies(user.RoleUser))
+	require.False(t, user.RoleUser.Satisfies(user.RoleModerator))
+	require.True(t, user.RoleModerator.Satisfies(user.RoleUser))
+	require.False(t, user.RoleModerator.Satisfies(user.RoleAdmin))
+	require.True(t, user.RoleAdmin.Satisfies(user.RoleModerator))
+	require.False(t, user.Role("root").Satisfies(user.RoleUser))
+}
+
+func TestRole_Can(t *testing.T) {
+	require.False(t, user.RoleUser.Can(user.PermissionDeleteAnyPost))
+	require.True(t, user.RoleModerator.Can(user.PermissionDeleteAnyPost))
+	require.False(t, user.RoleModerator.Can(user.PermissionManageRoles))
+	require.True(t, user.RoleAdmin.Can(user.PermissionDeleteAnyPost))
+	require.True(t, user.RoleAdmin.Can(user.PermissionManageRoles))
+}
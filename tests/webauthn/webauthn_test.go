@@ -0,0 +1,165 @@
+package webauthn
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/RianNegreiros/go-graphql-api/internal/webauthn"
+	"github.com/RianNegreiros/go-graphql-api/tests/test_helpers"
+	"github.com/stretchr/testify/require"
+)
+
+var rp = webauthn.RelyingParty{
+	ID:      "localhost",
+	Name:    "go-graphql-api",
+	Origins: []string{"http://localhost:8080"},
+}
+
+func newAuthenticator(t *testing.T, alg int) *test_helpers.Authenticator {
+	a := test_helpers.NewAuthenticatorWithAlgorithm(t, alg)
+	a.RPID = rp.ID
+	a.Origin = rp.Origins[0]
+
+	return a
+}
+
+func TestRelyingParty_VerifyRegistration(t *testing.T) {
+	for _, alg := range []int{webauthn.AlgES256, webauthn.AlgEdDSA} {
+		t.Run("can register the credential", func(t *testing.T) {
+			a := newAuthenticator(t, alg)
+
+			cd, credential, err := rp.VerifyRegistration(a.Create(t, "challenge"))
+			require.NoError(t, err)
+
+			require.Equal(t, "challenge", cd.Challenge)
+			require.Equal(t, a.CredentialID, credential.ID)
+			require.Equal(t, a.PublicKey(), credential.PublicKey)
+			require.Equal(t, uint32(0), credential.SignCount)
+
+			key, err := webauthn.ParsePublicKey(credential.PublicKey)
+			require.NoError(t, err)
+			require.Equal(t, alg, key.Algorithm)
+		})
+	}
+
+	t.Run("rejects other origins", func(t *testing.T) {
+		a := newAuthenticator(t, webauthn.AlgES256)
+		a.Origin = "https://evil.example"
+
+		_, _, err := rp.VerifyRegistration(a.Create(t, "challenge"))
+		require.ErrorIs(t, err, webauthn.ErrInvalidClientData)
+	})
+
+	t.Run("rejects credentials of other relying parties", func(t *testing.T) {
+		a := newAuthenticator(t, webauthn.AlgES256)
+		a.RPID = "evil.example"
+
+		_, _, err := rp.VerifyRegistration(a.Create(t, "challenge"))
+		require.ErrorIs(t, err, webauthn.ErrInvalidAuthenticatorData)
+	})
+
+	t.Run("requires user verification", func(t *testing.T) {
+		a := newAuthenticator(t, webauthn.AlgES256)
+		a.SkipUserVerification = true
+
+		_, _, err := rp.VerifyRegistration(a.Create(t, "challenge"))
+		require.ErrorIs(t, err, webauthn.ErrUserNotVerified)
+	})
+
+	t.Run("rejects assertions", func(t *testing.T) {
+		a := newAuthenticator(t, webauthn.AlgES256)
+
+		_, attestationObject := a.Create(t, "challenge")
+		clientDataJSON, _, _ := a.Get(t, "challenge")
+
+		_, _, err := rp.VerifyRegistration(clientDataJSON, attestationObject)
+		require.ErrorIs(t, err, webauthn.ErrInvalidClientData)
+	})
+
+	t.Run("rejects malformed attestation objects", func(t *testing.T) {
+		a := newAuthenticator(t, webauthn.AlgES256)
+
+		clientDataJSON, attestationObject := a.Create(t, "challenge")
+
+		for _, obj := range [][]byte{nil, {0xff}, attestationObject[:len(attestationObject)-1], {0xa0}} {
+			_, _, err := rp.VerifyRegistration(clientDataJSON, obj)
+			require.Error(t, err)
+		}
+	})
+}
+
+func TestRelyingParty_VerifyAssertion(t *testing.T) {
+	for _, alg := range []int{webauthn.AlgES256, webauthn.AlgEdDSA} {
+		t.Run("can verify the assertion", func(t *testing.T) {
+			a := newAuthenticator(t, alg)
+
+			clientDataJSON, authenticatorData, signature := a.Get(t, "challenge")
+
+			cd, ad, err := rp.VerifyAssertion(a.PublicKey(), clientDataJSON, authenticatorData, signature)
+			require.NoError(t, err)
+
+			require.Equal(t, "challenge", cd.Challenge)
+			require.Equal(t, uint32(1), ad.SignCount)
+			require.True(t, ad.UserVerified())
+		})
+	}
+
+	t.Run("rejects signatures of other credentials", func(t *testing.T) {
+		a := newAuthenticator(t, webauthn.AlgES256)
+		other := newAuthenticator(t, webauthn.AlgES256)
+
+		clientDataJSON, authenticatorData, signature := a.Get(t, "challenge")
+
+		_, _, err := rp.VerifyAssertion(other.PublicKey(), clientDataJSON, authenticatorData, signature)
+		require.ErrorIs(t, err, webauthn.ErrInvalidSignature)
+	})
+
+	t.Run("rejects tampered authenticator data", func(t *testing.T) {
+		a := newAuthenticator(t, webauthn.AlgES256)
+
+		clientDataJSON, authenticatorData, signature := a.Get(t, "challenge")
+		authenticatorData = bytes.Clone(authenticatorData)
+		authenticatorData[36]++
+
+		_, _, err := rp.VerifyAssertion(a.PublicKey(), clientDataJSON, authenticatorData, signature)
+		require.ErrorIs(t, err, webauthn.ErrInvalidSignature)
+	})
+
+	t.Run("rejects other origins", func(t *testing.T) {
+		a := newAuthenticator(t, webauthn.AlgES256)
+		a.Origin = "https://evil.example"
+
+		clientDataJSON, authenticatorData, signature := a.Get(t, "challenge")
+
+		_, _, err := rp.VerifyAssertion(a.PublicKey(), clientDataJSON, authenticatorData, signature)
+		require.ErrorIs(t, err, webauthn.ErrInvalidClientData)
+	})
+
+	t.Run("requires user verification", func(t *testing.T) {
+		a := newAuthenticator(t, webauthn.AlgES256)
+		a.SkipUserVerification = true
+
+		clientDataJSON, authenticatorData, signature := a.Get(t, "challenge")
+
+		_, _, err := rp.VerifyAssertion(a.PublicKey(), clientDataJSON, authenticatorData, signature)
+		require.ErrorIs(t, err, webauthn.ErrUserNotVerified)
+	})
+}
+
+func TestParsePublicKey(t *testing.T) {
+	t.Run("rejects garbage", func(t *testing.T) {
+		for _, data := range [][]byte{nil, {0x00}, {0xa1, 0x01, 0x02}, {0xbf}} {
+			_, err := webauthn.ParsePublicKey(data)
+			require.ErrorIs(t, err, webauthn.ErrUnsupportedKey)
+		}
+	})
+
+	t.Run("rejects points off the curve", func(t *testing.T) {
+		key := newAuthenticator(t, webauthn.AlgES256).PublicKey()
+		key = bytes.Clone(key)
+		key[len(key)-1]++
+
+		_, err := webauthn.ParsePublicKey(key)
+		require.ErrorIs(t, err, webauthn.ErrUnsupportedKey)
+	})
+}